@@ -41,8 +41,25 @@ func (h *Hooks) New(obj map[string]interface{}) (iface.Hook, error) {
 			GithubId: github_id,
 			Secret:   secret,
 		}, nil
+	case "gitea", "gitlab", "generic":
+		provider_id, err := maps.Int(obj, "provider_id")
+		if err != nil {
+			return nil, errors.New("Creating hook: " + err.Error())
+		}
+
+		secret, err := maps.String(obj, "secret")
+		if err != nil {
+			return nil, errors.New("Creating hook: " + err.Error())
+		}
+
+		return &iface.ProviderHook{
+			Id:         id,
+			Provider:   provider,
+			ProviderId: provider_id,
+			Secret:     secret,
+		}, nil
 	default:
-		return nil, err
+		return nil, errors.New("Creating hook: unknown provider `" + provider + "`")
 	}
 }
 
@@ -25,10 +25,14 @@ func (c *Client) Repositories() iface.Repositories {
 }
 
 func (r *Repositories) Github() iface.GithubRepositories {
-	return (*GithubRepositories)(r)
+	return r.Provider("github")
 }
 
-func (r *GithubRepositories) New(obj map[string]interface{}) (iface.GithubRepository, error) {
+func (r *Repositories) Provider(name string) iface.GitRepositories {
+	return &GitRepositories{Repositories: r, provider: name}
+}
+
+func (r *GitRepositories) New(obj map[string]interface{}) (iface.GitRepository, error) {
 	var repo GithubRepository
 	var err error
 	repo.project, _ = maps.String(obj, "project")
@@ -50,11 +54,11 @@ func (r *GithubRepositories) New(obj map[string]interface{}) (iface.GithubReposi
 	return &repo, nil
 }
 
-func (r *GithubRepositories) Get(id int) (iface.GithubRepository, error) {
-	logger.Debugf("Getting Github Repository `%d`", id)
-	defer logger.Debugf("Getting Github Repository `%d` done", id)
+func (r *GitRepositories) Get(id int) (iface.GitRepository, error) {
+	logger.Debugf("Getting %s Repository `%d`", r.provider, id)
+	defer logger.Debugf("Getting %s Repository `%d` done", r.provider, id)
 
-	response, err := r.client.Send("repositories", command.Body{"action": "get", "provider": "github", "id": id}, r.peers...)
+	response, err := r.client.Send("repositories", command.Body{"action": "get", "provider": r.provider, "id": id}, r.peers...)
 	if err != nil {
 		return nil, err
 	}
@@ -62,8 +66,8 @@ func (r *GithubRepositories) Get(id int) (iface.GithubRepository, error) {
 	return r.New(response)
 }
 
-func (r *GithubRepositories) List() ([]string, error) {
-	response, err := r.client.Send("repositories", command.Body{"action": "list"}, r.peers...)
+func (r *GitRepositories) List() ([]string, error) {
+	response, err := r.client.Send("repositories", command.Body{"action": "list", "provider": r.provider}, r.peers...)
 	if err != nil {
 		return nil, err
 	}
@@ -74,14 +78,14 @@ func (r *GithubRepositories) List() ([]string, error) {
 	return ids, nil
 }
 
-// Register registers a GitHub repository and returns the deployment key
-func (r *GithubRepositories) Register(repoID string) (string, error) {
-	logger.Debugf("Registering GitHub repository `%s`", repoID)
-	defer logger.Debugf("Registering GitHub repository `%s` done", repoID)
+// Register registers a repository with the provider and returns the deployment key
+func (r *GitRepositories) Register(repoID string) (string, error) {
+	logger.Debugf("Registering %s repository `%s`", r.provider, repoID)
+	defer logger.Debugf("Registering %s repository `%s` done", r.provider, repoID)
 
 	response, err := r.client.Send("repositories", command.Body{
 		"action":   "register",
-		"provider": "github",
+		"provider": r.provider,
 		"id":       repoID,
 	}, r.peers...)
 	if err != nil {
@@ -95,3 +99,33 @@ func (r *GithubRepositories) Register(repoID string) (string, error) {
 
 	return key, nil
 }
+
+// RegisterGeneric registers a repository on a bare git server. Nothing is
+// installed on the server; the returned key and hook are for its operator.
+func (r *Repositories) RegisterGeneric(uri string) (*iface.GenericRegistration, error) {
+	response, err := r.client.Send("repositories", command.Body{
+		"action":   "register",
+		"provider": "generic",
+		"uri":      uri,
+	}, r.peers...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register repository: %w", err)
+	}
+
+	var reg iface.GenericRegistration
+	if reg.Id, err = maps.Int(response, "id"); err != nil {
+		return nil, err
+	}
+	if reg.PublicKey, err = maps.String(response, "public_key"); err != nil {
+		return nil, err
+	}
+	if reg.HookURL, err = maps.String(response, "hook_url"); err != nil {
+		return nil, err
+	}
+	if reg.Secret, err = maps.String(response, "secret"); err != nil {
+		return nil, err
+	}
+	reg.PostReceive, _ = maps.String(response, "post_receive")
+
+	return &reg, nil
+}
@@ -22,7 +22,12 @@ type Hooks Client
 type Projects Client
 
 type Repositories Client
-type GithubRepositories Repositories
+
+// GitRepositories are the repositories registered with one git provider.
+type GitRepositories struct {
+	*Repositories
+	provider string
+}
 
 type RepositoryCommon struct {
 	project string
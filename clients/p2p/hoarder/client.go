@@ -11,7 +11,7 @@ import (
 
 func New(ctx context.Context, node peer.Node) (hoarder.Client, error) {
 	var (
-		c   Client
+		c   = Client{node: node}
 		err error
 	)
 
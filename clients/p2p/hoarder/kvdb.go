@@ -7,6 +7,9 @@ import (
 	"sync"
 	"time"
 
+	"github.com/fxamacker/cbor/v2"
+	ds "github.com/ipfs/go-datastore"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	peerCore "github.com/libp2p/go-libp2p/core/peer"
 	coreKvdb "github.com/taubyte/tau/core/kvdb"
 	hoarderIface "github.com/taubyte/tau/core/services/hoarder"
@@ -49,6 +52,8 @@ type remoteKV struct {
 
 var _ coreKvdb.KVDB = (*remoteKV)(nil)
 var _ hoarderIface.NxKVDB = (*remoteKV)(nil)
+var _ hoarderIface.CasKVDB = (*remoteKV)(nil)
+var _ hoarderIface.ConditionalBatch = (*remoteBatch)(nil)
 
 func (r *remoteKV) instanceBody() command.Body {
 	return command.Body{
@@ -238,6 +243,16 @@ func (r *remoteKV) Put(ctx context.Context, key string, v []byte) error {
 	return err
 }
 
+// PutWithTTL writes the key, expiring it after ttl (millisecond resolution).
+// The serving replica stamps the expiry, so it replicates along with the value.
+func (r *remoteKV) PutWithTTL(ctx context.Context, key string, v []byte, ttl time.Duration) error {
+	if ttl < time.Millisecond {
+		return errors.New("ttl must be at least a millisecond")
+	}
+	_, err := r.do(ctx, command.Body{hoarderSpecs.BodyKVOp: hoarderSpecs.KVPut, hoarderSpecs.BodyKey: key, hoarderSpecs.BodyValue: v, hoarderSpecs.BodyTTL: ttl.Milliseconds()})
+	return err
+}
+
 // PutNx writes the key only if absent on the serving replica, atomically
 // against concurrent writes there. Returns existed=true when the key was
 // already present (nothing written).
@@ -250,6 +265,28 @@ func (r *remoteKV) PutNx(ctx context.Context, key string, v []byte) (bool, error
 	return existed, nil
 }
 
+// CompareAndSwap writes the key only if its value on the serving replica equals
+// expected (nil means absent), atomically against concurrent writes there. On a
+// failed swap it returns the value that was found instead.
+func (r *remoteKV) CompareAndSwap(ctx context.Context, key string, expected, value []byte) (bool, []byte, error) {
+	body := command.Body{hoarderSpecs.BodyKVOp: hoarderSpecs.KVCas, hoarderSpecs.BodyKey: key, hoarderSpecs.BodyValue: value}
+	if expected != nil {
+		body[hoarderSpecs.BodyExpected] = expected
+	}
+	resp, err := r.do(ctx, body)
+	if err != nil {
+		return false, nil, err
+	}
+	if maps.TryString(resp, hoarderSpecs.BodyCode) == hoarderSpecs.CodeOverCapacity {
+		return false, nil, errors.New("cas rejected: over capacity")
+	}
+	if swapped, _ := maps.Bool(resp, hoarderSpecs.BodySwapped); swapped {
+		return true, nil, nil
+	}
+	current, _ := maps.ByteArray(resp, hoarderSpecs.BodyValue)
+	return false, current, nil
+}
+
 func (r *remoteKV) Delete(ctx context.Context, key string) error {
 	_, err := r.do(ctx, command.Body{hoarderSpecs.BodyKVOp: hoarderSpecs.KVDelete, hoarderSpecs.BodyKey: key})
 	return err
@@ -308,6 +345,69 @@ func streamKeys(ctx context.Context, keys []string) chan string {
 	return ch
 }
 
+// Watch subscribes to the changes hoarders serve for this instance. Every write
+// made through a hoarder is announced once by the hoarder that served it, from
+// the moment the subscription is up; changes that are not served writes (expiry
+// sweeps) are not seen. Events are dropped if the consumer falls behind.
+func (r *remoteKV) Watch(ctx context.Context, prefix string) (<-chan coreKvdb.Event, error) {
+	if r.client.node == nil {
+		return nil, errors.New("watching requires a node-backed client")
+	}
+	if prefix != "" {
+		prefix = ds.NewKey(prefix).String()
+	}
+
+	var (
+		lock   sync.Mutex
+		closed bool
+		ch     = make(chan coreKvdb.Event, kvdb.WatchBufferSize)
+	)
+	ctx, cancel := context.WithCancel(ctx)
+	topic := hoarderSpecs.WatchTopic(hoarderSpecs.InstanceHash(r.project, r.application, r.match))
+	err := r.client.node.PubSubSubscribeContext(ctx, topic,
+		func(msg *pubsub.Message) {
+			var events []coreKvdb.Event
+			if cbor.Unmarshal(msg.Data, &events) != nil {
+				return
+			}
+
+			lock.Lock()
+			defer lock.Unlock()
+			if closed {
+				return
+			}
+			for _, ev := range events {
+				if !coreKvdb.UnderPrefix(ev.Key, prefix) {
+					continue
+				}
+				select {
+				case ch <- ev:
+				default:
+					logger.Warnf("watcher on %s%s fell behind, dropping %s of %s", r.match, prefix, ev.Type, ev.Key)
+				}
+			}
+		},
+		func(err error) {
+			logger.Errorf("watch on %s ended with: %s", r.match, err)
+			cancel()
+		},
+	)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("subscribing to changes of %s failed with: %w", r.match, err)
+	}
+
+	go func() {
+		<-ctx.Done()
+		lock.Lock()
+		closed = true
+		close(ch)
+		lock.Unlock()
+	}()
+
+	return ch, nil
+}
+
 func (r *remoteKV) Sync(ctx context.Context, key string) error {
 	_, err := r.do(ctx, command.Body{hoarderSpecs.BodyKVOp: hoarderSpecs.KVSync, hoarderSpecs.BodyKey: key})
 	return err
@@ -352,6 +452,20 @@ func (b *remoteBatch) Delete(key string) error {
 	return nil
 }
 
+// Check guards the commit on the key's current value (nil means absent). All
+// checks are evaluated on the serving replica together with the writes.
+func (b *remoteBatch) Check(key string, expected []byte) error {
+	op := command.Body{
+		hoarderSpecs.BodyKVOp: hoarderSpecs.KVCheck,
+		hoarderSpecs.BodyKey:  key,
+	}
+	if expected != nil {
+		op[hoarderSpecs.BodyExpected] = expected
+	}
+	b.ops = append(b.ops, op)
+	return nil
+}
+
 func (b *remoteBatch) Commit() error {
 	if len(b.ops) == 0 {
 		return nil
@@ -361,6 +475,12 @@ func (b *remoteBatch) Commit() error {
 		ops[i] = map[string]interface{}(o)
 	}
 	// The Batch interface's Commit carries no ctx; the op is not caller-cancellable.
-	_, err := b.kv.do(context.Background(), command.Body{hoarderSpecs.BodyKVOp: hoarderSpecs.KVBatch, hoarderSpecs.BodyOps: ops})
-	return err
+	resp, err := b.kv.do(context.Background(), command.Body{hoarderSpecs.BodyKVOp: hoarderSpecs.KVBatch, hoarderSpecs.BodyOps: ops})
+	if err != nil {
+		return err
+	}
+	if maps.TryString(resp, hoarderSpecs.BodyCode) == hoarderSpecs.CodeConflict {
+		return fmt.Errorf("check on %q failed: %w", maps.TryString(resp, hoarderSpecs.BodyKey), hoarderIface.ErrConflict)
+	}
+	return nil
 }
@@ -8,6 +8,7 @@ import (
 func (c *Client) Peers(pids ...peerCore.ID) hoarder.Client {
 	return &Client{
 		Client: c.Client,
+		node:   c.node,
 		peers:  pids,
 	}
 }
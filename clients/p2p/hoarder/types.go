@@ -3,6 +3,7 @@ package hoarder
 import (
 	peerCore "github.com/libp2p/go-libp2p/core/peer"
 	iface "github.com/taubyte/tau/core/services/hoarder"
+	"github.com/taubyte/tau/p2p/peer"
 	client "github.com/taubyte/tau/p2p/streams/client"
 )
 
@@ -10,6 +11,7 @@ var _ iface.Client = &Client{}
 
 type Client struct {
 	*client.Client
+	node  peer.Node
 	peers []peerCore.ID
 }
 
@@ -14,7 +14,9 @@ import (
 )
 
 type Starfish struct {
-	Jobs map[string]*patrick.Job
+	Jobs      map[string]*patrick.Job
+	Policies  map[string]*patrick.RetryPolicy
+	Schedules map[string]*patrick.SchedulePolicy
 }
 
 func (s *Starfish) Close() {
@@ -111,3 +113,67 @@ func (s *Starfish) Timeout(jid string) error {
 func (s *Starfish) Cancel(jid string, cid_log map[string]string) (interface{}, error) {
 	return nil, fmt.Errorf("not implemented")
 }
+
+func (s *Starfish) RetryPolicy(projectId string) (*patrick.RetryPolicy, error) {
+	if policy, ok := s.Policies[projectId]; ok {
+		return policy, nil
+	}
+	return &patrick.RetryPolicy{}, nil
+}
+
+func (s *Starfish) SetRetryPolicy(projectId string, policy *patrick.RetryPolicy) error {
+	if s.Policies == nil {
+		s.Policies = make(map[string]*patrick.RetryPolicy)
+	}
+	if policy == nil {
+		delete(s.Policies, projectId)
+		return nil
+	}
+	s.Policies[projectId] = policy
+	return nil
+}
+
+func (s *Starfish) SchedulePolicy(projectId string) (*patrick.SchedulePolicy, error) {
+	if policy, ok := s.Schedules[projectId]; ok {
+		return policy, nil
+	}
+	return &patrick.SchedulePolicy{}, nil
+}
+
+func (s *Starfish) SetSchedulePolicy(projectId string, policy *patrick.SchedulePolicy) error {
+	if s.Schedules == nil {
+		s.Schedules = make(map[string]*patrick.SchedulePolicy)
+	}
+	if policy == nil {
+		delete(s.Schedules, projectId)
+		return nil
+	}
+	s.Schedules[projectId] = policy
+	return nil
+}
+
+// DeadLetters lists the failed jobs; the mock does not retry any.
+func (s *Starfish) DeadLetters() (ret []string, err error) {
+	for k, job := range s.Jobs {
+		if job.Status == patrick.JobStatusFailed {
+			ret = append(ret, k)
+		}
+	}
+	return
+}
+
+func (s *Starfish) Requeue(jids ...string) ([]string, error) {
+	if len(jids) == 0 {
+		jids, _ = s.DeadLetters()
+	}
+
+	for _, jid := range jids {
+		job, ok := s.Jobs[jid]
+		if !ok || job.Status != patrick.JobStatusFailed {
+			return nil, fmt.Errorf("job %s is not dead-lettered", jid)
+		}
+		job.Status = patrick.JobStatusOpen
+		job.Attempt = 0
+	}
+	return jids, nil
+}
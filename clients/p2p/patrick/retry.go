@@ -0,0 +1,85 @@
+package patrick
+
+import (
+	"fmt"
+
+	"github.com/fxamacker/cbor/v2"
+	iface "github.com/taubyte/tau/core/services/patrick"
+	"github.com/taubyte/tau/p2p/streams/command"
+	"github.com/taubyte/tau/utils/maps"
+)
+
+func (c *Client) RetryPolicy(projectId string) (*iface.RetryPolicy, error) {
+	resp, err := c.Send("patrick", command.Body{"action": "retryPolicy", "project": projectId}, c.peers...)
+	if err != nil {
+		return nil, fmt.Errorf("failed sending retryPolicy with error: %w", err)
+	}
+
+	_policy, ok := resp["policy"]
+	if !ok {
+		return nil, fmt.Errorf("no retry policy for %s", projectId)
+	}
+
+	policy_byte, err := cbor.Marshal(_policy)
+	if err != nil {
+		return nil, fmt.Errorf("failed marshal retry policy with error: %w", err)
+	}
+
+	var policy iface.RetryPolicy
+	if err = cbor.Unmarshal(policy_byte, &policy); err != nil {
+		return nil, fmt.Errorf("failed unmarshal retry policy with error: %w", err)
+	}
+
+	return &policy, nil
+}
+
+// SetRetryPolicy sets the retry policy of a project. A nil policy puts the
+// project back on the default one.
+func (c *Client) SetRetryPolicy(projectId string, policy *iface.RetryPolicy) error {
+	body := command.Body{"action": "setRetryPolicy", "project": projectId}
+	if policy != nil {
+		body["policy"] = policy
+	}
+
+	if _, err := c.Send("patrick", body, c.peers...); err != nil {
+		return fmt.Errorf("failed sending setRetryPolicy with error: %w", err)
+	}
+
+	return nil
+}
+
+// DeadLetters lists the jobs that failed all their attempts.
+func (c *Client) DeadLetters() ([]string, error) {
+	resp, err := c.Send("patrick", command.Body{"action": "deadLetters"}, c.peers...)
+	if err != nil {
+		return nil, fmt.Errorf("failed sending deadLetters with error: %w", err)
+	}
+
+	ids, err := maps.StringArray(resp, "Ids")
+	if err != nil {
+		return nil, fmt.Errorf("failed map string array with error: %w", err)
+	}
+
+	return ids, nil
+}
+
+// Requeue gives dead-lettered jobs a fresh set of attempts, all of them when
+// no jids are given. It returns the ids of the jobs requeued.
+func (c *Client) Requeue(jids ...string) ([]string, error) {
+	body := command.Body{"action": "requeue"}
+	if len(jids) > 0 {
+		body["jids"] = jids
+	}
+
+	resp, err := c.Send("patrick", body, c.peers...)
+	if err != nil {
+		return nil, fmt.Errorf("failed sending requeue with error: %w", err)
+	}
+
+	ids, err := maps.StringArray(resp, "requeued")
+	if err != nil {
+		return nil, fmt.Errorf("failed map string array with error: %w", err)
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,48 @@
+package patrick
+
+import (
+	"fmt"
+
+	"github.com/fxamacker/cbor/v2"
+	iface "github.com/taubyte/tau/core/services/patrick"
+	"github.com/taubyte/tau/p2p/streams/command"
+)
+
+func (c *Client) SchedulePolicy(projectId string) (*iface.SchedulePolicy, error) {
+	resp, err := c.Send("patrick", command.Body{"action": "schedulePolicy", "project": projectId}, c.peers...)
+	if err != nil {
+		return nil, fmt.Errorf("failed sending schedulePolicy with error: %w", err)
+	}
+
+	_policy, ok := resp["policy"]
+	if !ok {
+		return nil, fmt.Errorf("no schedule policy for %s", projectId)
+	}
+
+	policy_byte, err := cbor.Marshal(_policy)
+	if err != nil {
+		return nil, fmt.Errorf("failed marshal schedule policy with error: %w", err)
+	}
+
+	var policy iface.SchedulePolicy
+	if err = cbor.Unmarshal(policy_byte, &policy); err != nil {
+		return nil, fmt.Errorf("failed unmarshal schedule policy with error: %w", err)
+	}
+
+	return &policy, nil
+}
+
+// SetSchedulePolicy sets how many jobs of a project may build at once. A nil
+// policy leaves the project uncapped.
+func (c *Client) SetSchedulePolicy(projectId string, policy *iface.SchedulePolicy) error {
+	body := command.Body{"action": "setSchedulePolicy", "project": projectId}
+	if policy != nil {
+		body["policy"] = policy
+	}
+
+	if _, err := c.Send("patrick", body, c.peers...); err != nil {
+		return fmt.Errorf("failed sending setSchedulePolicy with error: %w", err)
+	}
+
+	return nil
+}
@@ -13,4 +13,24 @@ const (
 	BodyHost   = "host"
 	BodyPath   = "path"
 	BodyMethod = "method"
+
+	CommandCron = "cron"
+
+	BodyProject     = "project"
+	BodyApplication = "application"
+	BodyFunction    = "function"
+	BodyTick        = "tick"
+
+	CommandQueue = "queue"
+
+	BodyQueue     = "queue"
+	BodyQueueName = "queue-name"
+	BodyMessage   = "message"
+	BodyData      = "data"
+	BodyAttempt   = "attempt"
+
+	CommandDatabase = "database"
+
+	BodyKey = "key"
+	BodyOp  = "op"
 )
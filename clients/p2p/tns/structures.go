@@ -8,6 +8,7 @@ import (
 	functionSpec "github.com/taubyte/tau/pkg/specs/function"
 	librarySpec "github.com/taubyte/tau/pkg/specs/library"
 	messagingSpec "github.com/taubyte/tau/pkg/specs/messaging"
+	queueSpec "github.com/taubyte/tau/pkg/specs/queue"
 	serviceSpec "github.com/taubyte/tau/pkg/specs/service"
 	smartOpSpec "github.com/taubyte/tau/pkg/specs/smartops"
 	storageSpec "github.com/taubyte/tau/pkg/specs/storage"
@@ -39,6 +40,10 @@ func (c *Client) Messaging() tns.StructureIface[*structureSpec.Messaging] {
 	return structure.New[*structureSpec.Messaging](c, messagingSpec.PathVariable)
 }
 
+func (c *Client) Queue() tns.StructureIface[*structureSpec.Queue] {
+	return structure.New[*structureSpec.Queue](c, queueSpec.PathVariable)
+}
+
 func (c *Client) Service() tns.StructureIface[*structureSpec.Service] {
 	return structure.New[*structureSpec.Service](c, serviceSpec.PathVariable)
 }
@@ -2,6 +2,8 @@ package kvdb
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	"github.com/ipfs/go-log/v2"
 
@@ -20,6 +22,9 @@ type KVDB interface {
 	// Put will insert the data, indexed by key
 	Put(ctx context.Context, key string, v []byte) error
 
+	// PutWithTTL will insert the data like Put; the key expires after ttl
+	PutWithTTL(ctx context.Context, key string, v []byte, ttl time.Duration) error
+
 	// Delete deletes the key and index data
 	Delete(ctx context.Context, key string) error
 
@@ -38,6 +43,10 @@ type KVDB interface {
 	// Batch creates a Batch interface of the current KVDB
 	Batch(ctx context.Context) (Batch, error)
 
+	// Watch returns a channel of the changes to keys under the given prefix,
+	// as UnderPrefix matches them, closed once ctx is done or the KVDB closes
+	Watch(ctx context.Context, prefix string) (<-chan Event, error)
+
 	// Sync syncs the KVDB key values
 	Sync(ctx context.Context, key string) error
 
@@ -55,6 +64,43 @@ type Batch interface {
 	Commit() error
 }
 
+type EventType uint8
+
+const (
+	EventPut EventType = iota
+	EventDelete
+)
+
+func (t EventType) String() string {
+	switch t {
+	case EventPut:
+		return "put"
+	case EventDelete:
+		return "delete"
+	default:
+		return "unknown"
+	}
+}
+
+// Event is a change to a key. Value is empty for deletes.
+type Event struct {
+	Type  EventType `cbor:"1,keyasint"`
+	Key   string    `cbor:"2,keyasint"`
+	Value []byte    `cbor:"3,keyasint"`
+}
+
+// UnderPrefix reports whether key is prefix or a key below it. Prefixes match
+// whole path segments: "/users" covers "/users/a" but not "/usersx".
+func UnderPrefix(key, prefix string) bool {
+	prefix = strings.TrimSuffix(prefix, "/")
+	if prefix == "" {
+		return true
+	}
+
+	rest, ok := strings.CutPrefix(key, prefix)
+	return ok && (rest == "" || rest[0] == '/')
+}
+
 type Type uint
 
 const (
@@ -36,6 +36,7 @@ type Stats interface {
 type Hook interface {
 	Github() (*GithubHook, error)
 	Bitbucket() (*BitbucketHook, error)
+	ProviderHook() (*ProviderHook, error)
 }
 
 type Hooks interface {
@@ -63,15 +64,32 @@ type Project struct {
 }
 type Repositories interface {
 	Github() GithubRepositories
+	// Provider returns the repositories of the named git provider, e.g. "gitea" or "gitlab".
+	Provider(name string) GitRepositories
+	// RegisterGeneric registers a repository on a bare git server by its URI.
+	RegisterGeneric(uri string) (*GenericRegistration, error)
 }
 
-type GithubRepositories interface {
-	New(obj map[string]interface{}) (GithubRepository, error)
-	Get(id int) (GithubRepository, error)
+// GenericRegistration is what the operator of a bare git server installs to
+// connect a repository: the deploy key, and a post-receive hook that notifies
+// HookURL signed with Secret.
+type GenericRegistration struct {
+	Id          int
+	PublicKey   string
+	HookURL     string
+	Secret      string
+	PostReceive string
+}
+
+type GitRepositories interface {
+	New(obj map[string]interface{}) (GitRepository, error)
+	Get(id int) (GitRepository, error)
 	List() ([]string, error)
 	Register(repoID string) (string, error)
 }
 
+type GithubRepositories = GitRepositories
+
 type Repository interface {
 	PrivateKey() string
 	Id() int
@@ -86,12 +104,22 @@ type GithubHook struct {
 	Secret   string
 }
 
-type GithubRepository interface {
+// ProviderHook is a push hook on a git provider other than GitHub.
+type ProviderHook struct {
+	Id         string
+	Provider   string
+	ProviderId int
+	Secret     string
+}
+
+type GitRepository interface {
 	Repository
 	PrivateKey() string
 	Project() string
 }
 
+type GithubRepository = GitRepository
+
 func (h *GithubHook) Github() (*GithubHook, error) {
 	return h, nil
 }
@@ -99,3 +127,19 @@ func (h *GithubHook) Github() (*GithubHook, error) {
 func (h *GithubHook) Bitbucket() (*BitbucketHook, error) {
 	return nil, errors.New("not a Bitbucket hook")
 }
+
+func (h *GithubHook) ProviderHook() (*ProviderHook, error) {
+	return nil, errors.New("not a provider hook")
+}
+
+func (h *ProviderHook) Github() (*GithubHook, error) {
+	return nil, errors.New("not a Github hook")
+}
+
+func (h *ProviderHook) Bitbucket() (*BitbucketHook, error) {
+	return nil, errors.New("not a Bitbucket hook")
+}
+
+func (h *ProviderHook) ProviderHook() (*ProviderHook, error) {
+	return h, nil
+}
@@ -2,6 +2,7 @@ package hoarder
 
 import (
 	"context"
+	"errors"
 	"io"
 
 	peerCore "github.com/libp2p/go-libp2p/core/peer"
@@ -46,6 +47,29 @@ type NxKVDB interface {
 	PutNx(ctx context.Context, key string, value []byte) (existed bool, err error)
 }
 
+// ErrConflict is returned by a conditional batch's Commit when one of its
+// checks failed; nothing in the batch was written.
+var ErrConflict = errors.New("conditional write conflict")
+
+// CasKVDB is a KVDB handle that also supports compare-and-swap. The comparison
+// and the write are atomic on the serving replica only; the winning value then
+// replicates to the co-owners through the CRDT, so callers keep read-your-writes
+// only while they stay on the same (sticky) replica. A nil expected value means
+// the key must be absent. On a failed swap, current holds the value that
+// prevented it (nil when absent).
+type CasKVDB interface {
+	NxKVDB
+	CompareAndSwap(ctx context.Context, key string, expected, value []byte) (swapped bool, current []byte, err error)
+}
+
+// ConditionalBatch is a kvdb.Batch that can also guard its commit on the
+// current value of keys. Batches returned by a CasKVDB implement it. A nil
+// expected value means the key must be absent.
+type ConditionalBatch interface {
+	kvdb.Batch
+	Check(key string, expected []byte) error
+}
+
 // StashConfig carries push options. Target is the desired replica count; Owner
 // is the storage instance hash the blocks belong to; Fanout is whether the
 // receiving hoarder re-pushes to co-claimants (false for hoarder→hoarder
@@ -15,6 +15,12 @@ type Client interface {
 	Timeout(jid string) error
 	Cancel(jid string, cid_log map[string]string) (interface{}, error)
 	DatabaseStats() (kvdb.Stats, error)
+	RetryPolicy(projectId string) (*RetryPolicy, error)
+	SetRetryPolicy(projectId string, policy *RetryPolicy) error
+	SchedulePolicy(projectId string) (*SchedulePolicy, error)
+	SetSchedulePolicy(projectId string, policy *SchedulePolicy) error
+	DeadLetters() ([]string, error)
+	Requeue(jids ...string) ([]string, error)
 	Peers(...peerCore.ID) Client
 	Close()
 }
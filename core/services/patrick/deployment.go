@@ -0,0 +1,14 @@
+package patrick
+
+// DeploymentsTopic is the pubsub topic patrick announces deployments on.
+const DeploymentsTopic = "/tau/patrick/deployments/v1"
+
+// Deployment announces a job of Project succeeded, so what was served of the
+// project may have changed. Domains are the fqdns of the global domains of the
+// project, when they could be looked up.
+type Deployment struct {
+	Project   string   `cbor:"1,keyasint"`
+	Job       string   `cbor:"2,keyasint"`
+	Timestamp int64    `cbor:"3,keyasint"`
+	Domains   []string `cbor:"4,keyasint,omitempty"`
+}
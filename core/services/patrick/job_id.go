@@ -6,10 +6,12 @@ import (
 	"github.com/taubyte/tau/utils/id"
 )
 
-// PushEventJobID returns a stable job id for a GitHub push webhook payload.
+// PushEventJobID returns a stable job id for a push webhook payload.
 // It uses repository id, ref, after, and repository.pushed_at. When pushed_at
 // is missing (zero) or ref/after are empty, it falls back to a random id for
-// legacy payloads and backward compatibility.
+// legacy payloads and backward compatibility. Repository ids are only unique
+// within a provider, so providers other than GitHub are part of the key;
+// GitHub keeps its original key so redelivered pushes still deduplicate.
 func PushEventJobID(meta *Meta) string {
 	if meta == nil {
 		return id.Generate(0)
@@ -17,6 +19,6 @@ func PushEventJobID(meta *Meta) string {
 	if meta.Repository.PushedAt == 0 || meta.After == "" || meta.Ref == "" {
 		return id.Generate(meta.Repository.ID)
 	}
-	key := fmt.Sprintf("v1:github:%d:%s:%s:%d", meta.Repository.ID, meta.Ref, meta.After, meta.Repository.PushedAt)
+	key := fmt.Sprintf("v1:%s:%d:%s:%s:%d", meta.Repository.GitProvider(), meta.Repository.ID, meta.Ref, meta.After, meta.Repository.PushedAt)
 	return id.GenerateDeterministic(key)
 }
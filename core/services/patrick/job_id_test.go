@@ -37,3 +37,21 @@ func TestPushEventJobID_differentPushedAt_differs(t *testing.T) {
 	b := PushEventJobID(&other)
 	assert.Assert(t, a != b)
 }
+
+func TestPushEventJobID_providerDiffers(t *testing.T) {
+	base := &Meta{
+		Ref:   "refs/heads/main",
+		After: "84cac8e2c33df0ee4400aee496379745be65e8e8",
+		Repository: Repository{
+			ID:       42,
+			PushedAt: 100,
+		},
+	}
+	github := *base
+	github.Repository.Provider = "github"
+	gitea := *base
+	gitea.Repository.Provider = "gitea"
+
+	assert.Equal(t, PushEventJobID(base), PushEventJobID(&github))
+	assert.Assert(t, PushEventJobID(&github) != PushEventJobID(&gitea))
+}
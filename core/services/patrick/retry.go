@@ -0,0 +1,49 @@
+package patrick
+
+import "errors"
+
+// RetryPolicy is how the jobs of a project are retried before they are given
+// up on and dead-lettered.
+type RetryPolicy struct {
+	// MaxAttempts is how many attempts a job gets.
+	MaxAttempts int `cbor:"1,keyasint"`
+	// Backoff is the delay, in seconds, before the first retry. It doubles
+	// with every further attempt, up to MaxBackoff when that is set.
+	Backoff    int `cbor:"2,keyasint"`
+	MaxBackoff int `cbor:"3,keyasint"`
+	// TimeoutOnly retries jobs that timed out, and dead-letters failed
+	// builds straight away: a broken config fails again however often it runs.
+	TimeoutOnly bool `cbor:"4,keyasint"`
+}
+
+// maxBackoff caps the delay of a policy without MaxBackoff, so doubling stops
+// well before it overflows.
+const maxBackoff = 24 * 60 * 60
+
+func (p *RetryPolicy) Validate() error {
+	if p.MaxAttempts < 0 || p.Backoff < 0 || p.MaxBackoff < 0 {
+		return errors.New("retry policy values can not be negative")
+	}
+
+	if p.MaxBackoff > 0 && p.MaxBackoff < p.Backoff {
+		return errors.New("retry policy max backoff is less than its backoff")
+	}
+
+	return nil
+}
+
+// Delay returns how many seconds to wait before the given attempt, attempts
+// being counted from 1 for the first retry.
+func (p *RetryPolicy) Delay(attempt int) int {
+	limit := p.MaxBackoff
+	if limit == 0 || limit > maxBackoff {
+		limit = maxBackoff
+	}
+
+	delay := p.Backoff
+	for i := 1; i < attempt && delay < limit; i++ {
+		delay *= 2
+	}
+
+	return min(delay, limit)
+}
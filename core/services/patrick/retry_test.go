@@ -0,0 +1,23 @@
+package patrick
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestRetryPolicyDelay(t *testing.T) {
+	policy := &RetryPolicy{MaxAttempts: 5, Backoff: 10, MaxBackoff: 60}
+	for attempt, delay := range []int{10, 10, 20, 40, 60, 60} {
+		assert.Equal(t, policy.Delay(attempt), delay, "attempt %d", attempt)
+	}
+
+	assert.Equal(t, (&RetryPolicy{}).Delay(3), 0)
+	assert.Equal(t, (&RetryPolicy{Backoff: 1}).Delay(1000), maxBackoff)
+}
+
+func TestRetryPolicyValidate(t *testing.T) {
+	assert.NilError(t, (&RetryPolicy{MaxAttempts: 3, Backoff: 5}).Validate())
+	assert.ErrorContains(t, (&RetryPolicy{MaxAttempts: -1}).Validate(), "negative")
+	assert.ErrorContains(t, (&RetryPolicy{Backoff: 10, MaxBackoff: 5}).Validate(), "less than")
+}
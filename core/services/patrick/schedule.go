@@ -0,0 +1,28 @@
+package patrick
+
+import "errors"
+
+// Priority orders the jobs waiting for a monkey: higher priorities go first.
+type Priority int
+
+const (
+	PriorityLow Priority = iota - 1
+	PriorityNormal
+	PriorityHigh
+)
+
+// SchedulePolicy is how the jobs of a project share the monkeys with the jobs
+// of other projects.
+type SchedulePolicy struct {
+	// MaxConcurrent caps how many jobs of the project build at once. Zero
+	// leaves it uncapped.
+	MaxConcurrent int `cbor:"1,keyasint"`
+}
+
+func (p *SchedulePolicy) Validate() error {
+	if p.MaxConcurrent < 0 {
+		return errors.New("schedule policy max concurrent can not be negative")
+	}
+
+	return nil
+}
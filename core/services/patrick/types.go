@@ -17,6 +17,9 @@ type Job struct {
 	CidLock   sync.Mutex
 	AssetCid  map[string]string `cbor:"20,keyasint"` // Build Cid
 	Attempt   int               `cbor:"30,keyasint"`
+	Priority  Priority          `cbor:"31,keyasint"`
+	Project   string            `cbor:"32,keyasint"` // Set once patrick finds the project of the repository
+	NotBefore int64             `cbor:"33,keyasint"` // Unix time the job is not handed out before, while it backs off
 	Delay     *DelayConfig      `cbor:"99,keyasint"` // Inject a delay to run a job
 }
 
@@ -61,6 +64,15 @@ func (j *Job) Unmarshal(data []byte) error {
 	return nil
 }
 
+// GitProvider returns the repository's git provider. Jobs stored before other
+// providers were supported may not record one; those all came from GitHub.
+func (r Repository) GitProvider() string {
+	if r.Provider == "" {
+		return "github"
+	}
+	return r.Provider
+}
+
 // normalize promotes SSHURL to URI when URI is empty (backward compat for old webhooks and stored jobs).
 func (r *Repository) normalize() {
 	if r.URI == "" && r.SSHURL != "" {
@@ -1,13 +1,60 @@
 package database
 
-import "context"
+import (
+	"context"
+	"errors"
+	"time"
 
+	"github.com/taubyte/tau/core/kvdb"
+)
+
+// ErrConflict is returned by Batch.Commit when a check finds a value other
+// than the expected one; nothing was written.
+var ErrConflict = errors.New("database write conflict")
+
+// ErrNotSupported is returned by conditional writes on a backend that cannot
+// evaluate them atomically.
+var ErrNotSupported = errors.New("conditional writes not supported by this database backend")
+
+// KV is a database's key/value surface.
+//
+// Consistency: the database is a CRDT replicated across its hoarder replicas.
+// Plain writes are last-writer-wins and converge eventually. Conditional writes
+// (CompareAndSwap, Batch with Check) are evaluated and applied atomically on
+// the single replica serving the handle, then replicated as plain writes. They
+// are linearizable against other conditional writes that reach the same
+// replica, which is the case for a handle while its replica stays reachable;
+// after a failover, a concurrent writer on another replica may win the merge.
 type KV interface {
 	Get(ctx context.Context, key string) ([]byte, error)
 	Put(ctx context.Context, key string, v []byte) error
+	// PutWithTTL writes like Put; the key expires after ttl. Expiry is stamped
+	// when the write is served and replicates with the value, so every replica
+	// hides the key once it passes (up to clock skew) and removes it later. A
+	// plain Put on the key clears its expiry.
+	PutWithTTL(ctx context.Context, key string, v []byte, ttl time.Duration) error
 	Delete(ctx context.Context, key string) error
 	List(ctx context.Context, prefix string) ([]string, error)
+	// CompareAndSwap writes v only if the key currently holds expected; a nil
+	// expected means the key must be absent. swapped is false when the
+	// comparison failed.
+	CompareAndSwap(ctx context.Context, key string, expected, v []byte) (swapped bool, err error)
+	// Batch groups writes that commit together, optionally guarded by checks.
+	Batch(ctx context.Context) (Batch, error)
+	// Watch streams puts and deletes of keys under prefix until ctx is done.
+	// Delivery is best effort: events are dropped if the reader falls behind.
+	Watch(ctx context.Context, prefix string) (<-chan kvdb.Event, error)
 	Close()
 	UpdateSize(size uint64)
 	Size(ctx context.Context) (uint64, error)
 }
+
+// Batch buffers writes until Commit. Commit applies all of them or none: it
+// returns ErrConflict, writing nothing, when any Check fails.
+type Batch interface {
+	Put(key string, v []byte) error
+	Delete(key string) error
+	// Check guards the commit on key holding expected; nil expects absence.
+	Check(key string, expected []byte) error
+	Commit(ctx context.Context) error
+}
@@ -1,6 +1,8 @@
 package http
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"time"
 
@@ -12,6 +14,19 @@ type Service interface {
 	components.ServiceComponent
 }
 
+// ErrConnectionNotFound is returned for a websocket connection that is not
+// open, or not one of the project's.
+var ErrConnectionNotFound = errors.New("websocket connection not found")
+
+// WebSockets are the websocket connections owned by functions, addressed by
+// the connection id handed to the function with each of its events.
+type WebSockets interface {
+	// Send writes data to connection, as a binary message if binary is set.
+	Send(ctx context.Context, projectId, connection string, data []byte, binary bool) error
+	// CloseConnection closes connection with the close code and reason given.
+	CloseConnection(ctx context.Context, projectId, connection string, code int, reason string) error
+}
+
 type Serviceable interface {
 	components.Serviceable
 	Handle(w http.ResponseWriter, r *http.Request, serv components.MatchDefinition) (time.Time, error)
@@ -13,6 +13,9 @@ type Service interface {
 	Subscribe(projectId, appId, resource, channel string) error
 	Publish(ctx context.Context, projectId, appId, resource, channel string, data []byte) error
 	WebSocketURL(projectId, appId, channel string) (string, error)
+	// MQTTToken returns the password MQTT clients connect with to the
+	// channels of the project, or of its application appId, until expiry.
+	MQTTToken(projectId, appId string, expiry time.Time) (string, error)
 }
 
 type Messaging interface {
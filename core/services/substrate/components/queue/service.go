@@ -0,0 +1,23 @@
+package queue
+
+import (
+	"context"
+	"errors"
+
+	"github.com/taubyte/tau/core/services/substrate/components"
+)
+
+// ErrQueueNotFound is returned for a queue name that resolves to no queue of
+// the project, neither in the application nor globally.
+var ErrQueueNotFound = errors.New("queue not found")
+
+// Service holds the messages of the queues declared by projects. Queues are
+// addressed by name, looked up globally first then in the application, as
+// functions name them.
+type Service interface {
+	components.ServiceComponent
+	// Enqueue adds a message holding data to queue, and returns its id.
+	Enqueue(ctx context.Context, projectId, application, queue string, data []byte) (uint64, error)
+	// Ack removes message from queue, so it is not delivered again.
+	Ack(ctx context.Context, projectId, application, queue string, message uint64) error
+}
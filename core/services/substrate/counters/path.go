@@ -9,6 +9,7 @@ type Path interface {
 	FailExecutionMetricPaths() (countPath string, timePath string)
 	FailMetricPaths() (countPath string, timePath string)
 	Failed() path
+	Fuel() path
 	Join(toJoin string) path
 	Memory() path
 	SmartOp(smartOpId string) path
@@ -54,6 +55,10 @@ func (c path) Memory() path {
 	return join(c, memory)
 }
 
+func (c path) Fuel() path {
+	return join(c, fuel)
+}
+
 func (c path) Execution() path {
 	return join(c, execution)
 }
@@ -0,0 +1,32 @@
+package counters
+
+import "strings"
+
+// sensorLeaves names the leaf of the counters that measure something other
+// than a count, by the last segment of their path.
+var sensorLeaves = map[string]string{
+	time:   "time",
+	memory: "memory",
+	fuel:   "fuel",
+}
+
+// SensorName maps a counter path to the dot-separated name seer nests usage
+// custom values under. Every counter gets a leaf of its own, `count` or what
+// it measures, so that no counter is nested where another one is a value:
+// <p>/<id>/s is <p>.<id>.s.count and <p>/<id>/s/t is <p>.<id>.s.time. Dots
+// within a segment are replaced, as they would nest too.
+func SensorName(key string) string {
+	segments := strings.Split(strings.Trim(key, "/"), "/")
+	for i, segment := range segments {
+		segments[i] = strings.ReplaceAll(segment, ".", "_")
+	}
+
+	leaf := "count"
+	if last := len(segments) - 1; last > 0 {
+		if name, ok := sensorLeaves[segments[last]]; ok {
+			leaf, segments = name, segments[:last]
+		}
+	}
+
+	return SensorPrefix + "." + strings.Join(append(segments, leaf), ".")
+}
@@ -4,11 +4,21 @@ import goTime "time"
 
 var (
 	DefaultReportTime = 5 * goTime.Minute
+	// DefaultIdleTime is how long the total of a counter that stopped
+	// counting is kept before it is dropped.
+	DefaultIdleTime = 24 * goTime.Hour
+)
+
+const (
+	// SensorPrefix prefixes the counter totals a node exposes through its
+	// seer usage custom values.
+	SensorPrefix = "substrate.counters"
 )
 
 const (
 	time   = "t"
 	memory = "m"
+	fuel   = "fu"
 
 	failed  = "f"
 	success = "s"
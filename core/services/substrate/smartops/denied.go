@@ -0,0 +1,27 @@
+package smartops
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Denied is the error of a call a smartop refused, with the code it exited
+// with.
+type Denied struct {
+	Code uint32
+}
+
+func (d *Denied) Error() string {
+	return fmt.Sprintf("denied by smartop with code %d", d.Code)
+}
+
+// HTTPStatus is the status a denied request is answered with: the code
+// itself when it is an HTTP client or server error status, so a smartop can
+// answer 401 or 429, and 403 Forbidden for any other code.
+func (d *Denied) HTTPStatus() int {
+	if d.Code >= 400 && d.Code <= 599 {
+		return int(d.Code)
+	}
+
+	return http.StatusForbidden
+}
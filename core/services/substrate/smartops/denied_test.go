@@ -0,0 +1,24 @@
+package smartops
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestDeniedHTTPStatus(t *testing.T) {
+	for code, status := range map[uint32]int{
+		1:   http.StatusForbidden,
+		2:   http.StatusForbidden,
+		200: http.StatusForbidden,
+		399: http.StatusForbidden,
+		401: http.StatusUnauthorized,
+		403: http.StatusForbidden,
+		429: http.StatusTooManyRequests,
+		503: http.StatusServiceUnavailable,
+		600: http.StatusForbidden,
+	} {
+		if got := (&Denied{Code: code}).HTTPStatus(); got != status {
+			t.Errorf("code %d: got status %d, want %d", code, got, status)
+		}
+	}
+}
@@ -37,6 +37,7 @@ type Client interface {
 	Function() StructureIface[*structureSpec.Function]
 	Library() StructureIface[*structureSpec.Library]
 	Messaging() StructureIface[*structureSpec.Messaging]
+	Queue() StructureIface[*structureSpec.Queue]
 	Service() StructureIface[*structureSpec.Service]
 	SmartOp() StructureIface[*structureSpec.SmartOp]
 	Storage() StructureIface[*structureSpec.Storage]
@@ -4,6 +4,13 @@ package vm
 type Config struct {
 	MemoryLimitPages uint32 // should default to MemoryLimitPages
 	Output           OutputType
+	// Fuel is how much fuel each call may burn, roughly one unit per wasm
+	// instruction. Calls out of fuel fail with ErrFuelExhausted. Zero leaves
+	// calls unmetered.
+	Fuel uint64
+	// ExportGlobals has the runtime export the mutable globals of the modules
+	// it loads, for ModuleInstance.Globals to reach them.
+	ExportGlobals bool
 }
 
 type OutputType uint32
@@ -9,6 +9,10 @@ type HostModule interface {
 	// Builder returns the underlying wazy host-module builder.
 	Builder() wazy.HostModuleBuilder
 
+	// Group returns a builder for the same module whose functions are
+	// counted and timed under name in the runtime's stats.
+	Group(name string) wazy.HostModuleBuilder
+
 	// Compile instantiates the registered functions and returns the module.
 	Compile() (ModuleInstance, error)
 }
@@ -39,7 +39,11 @@ type Runtime interface {
 	Attach(plugin Plugin) (PluginInstance, ModuleInstance, error)
 	Stdout() io.Reader
 	Stderr() io.Reader
-	// TODO: Add Runtime Stat
+	// Fuel returns the fuel burnt by the calls made so far; always zero for
+	// runtimes without a fuel limit.
+	Fuel() uint64
+	// Stats returns what the runtime measured of itself since it was created.
+	Stats() RuntimeStats
 	Close() error
 }
 
@@ -67,6 +71,9 @@ type ModuleInstance interface {
 	// Function returns a FunctionInstance of given name from the ModuleInstance
 	Function(name string) (FunctionInstance, error)
 	Memory() Memory
+	// Globals returns the mutable globals the module defines, in order; none
+	// unless the runtime's config exports globals.
+	Globals() []MutableGlobal
 }
 
 // FunctionInstance is a callable wasm export. It stays a tau interface (rather
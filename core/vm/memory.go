@@ -5,6 +5,9 @@ import api "github.com/samyfodil/wazy/api"
 // Memory is a wazy module's memory, exposed directly (wazy is the only engine).
 type Memory = api.Memory
 
+// MutableGlobal is a wazy module's global the host may set, exposed directly.
+type MutableGlobal = api.MutableGlobal
+
 // MemorySizer applies during compilation after a module has been decoded from wasm, but before it is instantiated.
 type MemorySizer func(minPages uint32, maxPages *uint32) (min, capacity, max uint32)
 
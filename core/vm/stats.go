@@ -0,0 +1,33 @@
+package vm
+
+import "time"
+
+// RuntimeStats is what a Runtime measured of itself since it was created.
+type RuntimeStats struct {
+	// PeakMemory is the most linear memory, in bytes, the modules of the
+	// runtime held together at the end of a call.
+	PeakMemory uint64
+
+	// CompileTime and InstantiateTime are the time spent loading modules;
+	// instantiating includes running their initialization.
+	CompileTime     time.Duration
+	InstantiateTime time.Duration
+
+	// Calls is the number of calls made into the guest and WallTime the time
+	// they took, host calls included.
+	Calls    uint64
+	WallTime time.Duration
+
+	// HostCalls are the calls the guest made to host functions, keyed by the
+	// group they were registered under, e.g. "database" or "storage".
+	HostCalls map[string]HostCallStats
+
+	// Fuel is the fuel burnt by the calls; see Config.Fuel.
+	Fuel uint64
+}
+
+// HostCallStats counts and times the calls to a group of host functions.
+type HostCallStats struct {
+	Calls uint64
+	Time  time.Duration
+}
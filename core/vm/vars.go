@@ -1,10 +1,16 @@
 package vm
 
-import "time"
+import (
+	"errors"
+	"time"
+)
 
 var (
 	GetTimeout = 60 * time.Second
 
 	//TODO: Lookup should handle timeout (tns fetch may need to take context)
 	LookupTimeout = 10 * time.Second
+
+	// ErrFuelExhausted is returned by calls that burnt all the fuel they had.
+	ErrFuelExhausted = errors.New("fuel exhausted")
 )
@@ -23,7 +23,19 @@ func (b *builder) Build(ops ...ci.ContainerOption) (builders.Output, error) {
 		return out, b.Errorf("initializing image failed with: %w", err)
 	}
 
+	// A build runs without its cache rather than not at all.
+	cacheEntry, err := b.openCache()
+	if err != nil {
+		b.Errorf("opening build cache failed with: %w", err)
+	} else if cacheEntry != nil {
+		ops = append(ops, cacheEntry.Options()...)
+	}
+
 	if err = b.run(out, clientImage, environment, ops...); err != nil {
+		if cacheEntry != nil {
+			cacheEntry.Discard()
+		}
+
 		json.NewEncoder(b.output).Encode(struct {
 			Error     string `json:"error"`
 			Timestamp int64  `json:"timestamp"`
@@ -34,6 +46,12 @@ func (b *builder) Build(ops ...ci.ContainerOption) (builders.Output, error) {
 		return nil, err
 	}
 
+	if cacheEntry != nil {
+		if err = cacheEntry.Publish(); err != nil {
+			b.Error(err)
+		}
+	}
+
 	json.NewEncoder(b.output).Encode(struct {
 		Timestamp int64 `json:"timestamp"`
 		Success   bool  `json:"success"`
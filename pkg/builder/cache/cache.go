@@ -0,0 +1,461 @@
+// Package cache keeps the dependencies builds download, so that a build whose
+// lockfiles did not change does not fetch its Go modules or npm packages again.
+//
+// Caches are content addressed: the key of a build is the hash of its scope,
+// its builder image and its lockfiles. Two builds only ever share a cache when
+// all three match.
+package cache
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	ci "github.com/taubyte/tau/pkg/containers"
+)
+
+// Lockfiles are the files pinning what a build downloads, in the order they are hashed.
+var Lockfiles = []string{
+	"go.sum",
+	"package-lock.json",
+	"yarn.lock",
+	"pnpm-lock.yaml",
+	"Cargo.lock",
+}
+
+// Mount is where a cache is mounted in build containers.
+const Mount = "/cache"
+
+// Store shares caches between nodes.
+type Store interface {
+	Get(key string) (io.ReadCloser, error)
+	Put(key string, r io.Reader) error
+}
+
+type Cache struct {
+	root  string
+	store Store
+}
+
+type Option func(*Cache)
+
+// Share fetches the caches missing on this node from store, and publishes the
+// ones this node fills.
+func Share(store Store) Option {
+	return func(c *Cache) { c.store = store }
+}
+
+// New returns a cache kept under root.
+func New(root string, options ...Option) (*Cache, error) {
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return nil, fmt.Errorf("creating build cache `%s` failed with: %w", root, err)
+	}
+
+	c := &Cache{root: root}
+	for _, option := range options {
+		option(c)
+	}
+
+	return c, nil
+}
+
+// Key returns the key of a build of the sources in dir with image. Builds of
+// different scopes, such as projects, never share a cache: what a build leaves
+// in its cache is as untrusted as the build itself.
+//
+// Sources without any lockfile have nothing to key a cache on; ok is false.
+func Key(scope, image, dir string) (key string, ok bool, err error) {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00", scope, image)
+
+	for _, name := range Lockfiles {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return "", false, fmt.Errorf("reading `%s` failed with: %w", name, err)
+		}
+
+		sum := sha256.Sum256(data)
+		fmt.Fprintf(h, "%s\x00%x\x00", name, sum)
+		ok = true
+	}
+
+	if !ok {
+		return "", false, nil
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), true, nil
+}
+
+// Entry is the cache of one key.
+type Entry struct {
+	cache *Cache
+	Key   string
+	Path  string
+	// Fresh is set when the entry was neither on this node nor shared, and is
+	// filled by the build using it. Its Path is then a scratch dir, only kept
+	// as the entry once the build succeeded and Publishes it.
+	Fresh bool
+}
+
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.root, key)
+}
+
+// Open returns the entry of key, fetching it from the shared store when this
+// node does not have it.
+func (c *Cache) Open(key string) (*Entry, error) {
+	if !validKey(key) {
+		return nil, fmt.Errorf("invalid cache key `%s`", key)
+	}
+
+	e := &Entry{cache: c, Key: key, Path: c.path(key)}
+	if _, err := os.Stat(e.Path); err == nil {
+		now := time.Now()
+		return e, os.Chtimes(e.Path, now, now)
+	}
+
+	if c.store != nil {
+		if r, err := c.store.Get(key); err == nil {
+			err = c.Import(key, r)
+			r.Close()
+			if err == nil {
+				return e, nil
+			}
+		}
+	}
+
+	fill, err := os.MkdirTemp(c.root, ".fill-*")
+	if err != nil {
+		return nil, fmt.Errorf("creating cache `%s` failed with: %w", key, err)
+	}
+
+	e.Path, e.Fresh = fill, true
+
+	return e, nil
+}
+
+// Options mount the entry in a build container, and point the package managers at it.
+func (e *Entry) Options() []ci.ContainerOption {
+	return []ci.ContainerOption{
+		ci.Volume(e.Path, Mount),
+		ci.Variables(map[string]string{
+			"CACHE":      Mount,
+			"GOMODCACHE": Mount + "/go/mod",
+			"GOCACHE":    Mount + "/go/build",
+			// Go makes its module cache read-only, which would keep Clean from removing it.
+			"GOFLAGS":           "-modcacherw",
+			"npm_config_cache":  Mount + "/npm",
+			"YARN_CACHE_FOLDER": Mount + "/yarn",
+			"CARGO_HOME":        Mount + "/cargo",
+		}),
+	}
+}
+
+// Publish keeps a fresh entry once a build filled it, and shares it. Entries
+// that were not fresh are already kept and shared.
+func (e *Entry) Publish() error {
+	if !e.Fresh {
+		return nil
+	}
+
+	if path := e.cache.path(e.Key); e.Path != path {
+		ours, err := e.cache.keep(e.Key, e.Path)
+		if err != nil {
+			return err
+		}
+
+		e.Path = path
+		if !ours {
+			e.Fresh = false
+			return nil
+		}
+	}
+
+	if e.cache.store == nil {
+		e.Fresh = false
+		return nil
+	}
+
+	r, w := io.Pipe()
+	go func() {
+		w.CloseWithError(e.cache.Export(e.Key, w))
+	}()
+	defer r.Close()
+
+	if err := e.cache.store.Put(e.Key, r); err != nil {
+		return fmt.Errorf("sharing cache `%s` failed with: %w", e.Key, err)
+	}
+
+	e.Fresh = false
+	return nil
+}
+
+// Discard drops a fresh entry whose build failed, so that what the build left
+// in it never counts as a hit.
+func (e *Entry) Discard() error {
+	if !e.Fresh || e.Path == e.cache.path(e.Key) {
+		return nil
+	}
+
+	return os.RemoveAll(e.Path)
+}
+
+// Export writes the entry of key as a gzipped tarball.
+func (c *Cache) Export(key string, w io.Writer) error {
+	if !validKey(key) {
+		return fmt.Errorf("invalid cache key `%s`", key)
+	}
+
+	return tarball(c.path(key), w)
+}
+
+// Import sets the entry of key from a gzipped tarball written by Export.
+func (c *Cache) Import(key string, r io.Reader) error {
+	if !validKey(key) {
+		return fmt.Errorf("invalid cache key `%s`", key)
+	}
+
+	tmp, err := os.MkdirTemp(c.root, ".import-*")
+	if err != nil {
+		return fmt.Errorf("creating import dir failed with: %w", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	if err = untar(tmp, r); err != nil {
+		return fmt.Errorf("importing cache `%s` failed with: %w", key, err)
+	}
+
+	_, err = c.keep(key, tmp)
+	return err
+}
+
+// keep moves the dir filled for the entry of key in place, reporting whether
+// it did; when another build filled the entry first, theirs is kept.
+func (c *Cache) keep(key, filled string) (bool, error) {
+	if err := os.Rename(filled, c.path(key)); err != nil {
+		if _, statErr := os.Stat(c.path(key)); statErr == nil {
+			return false, os.RemoveAll(filled)
+		}
+		return false, fmt.Errorf("moving cache `%s` in place failed with: %w", key, err)
+	}
+
+	return true, nil
+}
+
+// Clean removes the entries no build used for maxAge.
+func (c *Cache) Clean(maxAge time.Duration) error {
+	entries, err := os.ReadDir(c.root)
+	if err != nil {
+		return fmt.Errorf("reading build cache failed with: %w", err)
+	}
+
+	for _, entry := range entries {
+		// scratch dirs left behind by builds or imports that never finished
+		scratch := strings.HasPrefix(entry.Name(), ".fill-") || strings.HasPrefix(entry.Name(), ".import-")
+		if !entry.IsDir() || !(validKey(entry.Name()) || scratch) {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil || time.Since(info.ModTime()) < maxAge {
+			continue
+		}
+
+		if err = os.RemoveAll(c.path(entry.Name())); err != nil {
+			return fmt.Errorf("removing cache `%s` failed with: %w", entry.Name(), err)
+		}
+	}
+
+	return nil
+}
+
+func validKey(key string) bool {
+	if len(key) != 2*sha256.Size {
+		return false
+	}
+
+	_, err := hex.DecodeString(key)
+	return err == nil
+}
+
+// tarball writes the files, dirs and links under src as a gzipped tarball.
+// Links are written as links, never followed.
+func tarball(src string, w io.Writer) error {
+	gzw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gzw)
+
+	err := filepath.Walk(src, func(file string, fi os.FileInfo, err error) error {
+		if err != nil || file == src {
+			return err
+		}
+
+		var link string
+		switch {
+		case fi.Mode()&os.ModeSymlink != 0:
+			if link, err = os.Readlink(file); err != nil {
+				return err
+			}
+		case !fi.Mode().IsRegular() && !fi.IsDir():
+			return nil
+		}
+
+		header, err := tar.FileInfoHeader(fi, link)
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, file)
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(rel)
+
+		if err = tw.WriteHeader(header); err != nil {
+			return err
+		}
+
+		if !fi.Mode().IsRegular() {
+			return nil
+		}
+
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = tw.Close(); err != nil {
+		return err
+	}
+
+	return gzw.Close()
+}
+
+// untar extracts the files, dirs and links of a gzipped tarball in dst. It
+// never writes through a link: the dir of each entry is resolved first, and
+// must be within dst. Links themselves must point within dst.
+func untar(dst string, r io.Reader) error {
+	root, err := filepath.EvalSymlinks(dst)
+	if err != nil {
+		return err
+	}
+
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer gzr.Close()
+
+	tr := tar.NewReader(gzr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		switch header.Typeflag {
+		case tar.TypeReg, tar.TypeDir, tar.TypeSymlink:
+		default:
+			continue
+		}
+
+		target := filepath.Join(root, header.Name)
+		if !within(root, target) || target == root {
+			return fmt.Errorf("`%s` is outside of the cache", header.Name)
+		}
+
+		dir, err := realDir(root, filepath.Dir(target))
+		if err != nil {
+			return fmt.Errorf("extracting `%s` failed with: %w", header.Name, err)
+		}
+		target = filepath.Join(dir, filepath.Base(target))
+
+		// whatever is in the way is replaced, not written through
+		if fi, err := os.Lstat(target); err == nil && !(fi.IsDir() && header.Typeflag == tar.TypeDir) {
+			if err = os.RemoveAll(target); err != nil {
+				return err
+			}
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(target, 0755)
+		case tar.TypeSymlink:
+			if filepath.IsAbs(header.Linkname) || !within(root, filepath.Join(dir, header.Linkname)) {
+				return fmt.Errorf("link `%s` points outside of the cache", header.Name)
+			}
+			err = os.Symlink(header.Linkname, target)
+		case tar.TypeReg:
+			err = writeFile(target, os.FileMode(header.Mode)&0755|0600, tr)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// within reports whether path is root or under it.
+func within(root, path string) bool {
+	return path == root || strings.HasPrefix(path, root+string(filepath.Separator))
+}
+
+// realDir returns where dir, which may not exist yet, really is once the
+// links already extracted resolve, and creates it when it must be within root.
+func realDir(root, dir string) (string, error) {
+	existing := dir
+	for {
+		if _, err := os.Lstat(existing); err == nil {
+			break
+		}
+		existing = filepath.Dir(existing)
+	}
+
+	real, err := filepath.EvalSymlinks(existing)
+	if err != nil {
+		return "", err
+	}
+
+	if !within(root, real) {
+		return "", errors.New("a link leads outside of the cache")
+	}
+
+	rest, err := filepath.Rel(existing, dir)
+	if err != nil {
+		return "", err
+	}
+
+	real = filepath.Join(real, rest)
+
+	return real, os.MkdirAll(real, 0755)
+}
+
+func writeFile(file string, mode os.FileMode, r io.Reader) error {
+	f, err := os.OpenFile(file, os.O_CREATE|os.O_EXCL|os.O_WRONLY, mode)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(f, r)
+	f.Close()
+
+	return err
+}
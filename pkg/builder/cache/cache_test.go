@@ -0,0 +1,211 @@
+package cache
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+type memStore map[string][]byte
+
+func (s memStore) Get(key string) (io.ReadCloser, error) {
+	data, ok := s[key]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s memStore) Put(key string, r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	s[key] = data
+	return nil
+}
+
+func TestKey(t *testing.T) {
+	dir := t.TempDir()
+
+	_, ok, err := Key("project", "golang:1.22", dir)
+	assert.NilError(t, err)
+	assert.Assert(t, !ok, "sources without lockfiles have no key")
+
+	assert.NilError(t, os.WriteFile(filepath.Join(dir, "go.sum"), []byte("a v1.0.0 h1:x"), 0644))
+	key, ok, err := Key("project", "golang:1.22", dir)
+	assert.NilError(t, err)
+	assert.Assert(t, ok)
+	assert.Assert(t, validKey(key))
+
+	again, _, _ := Key("project", "golang:1.22", dir)
+	assert.Equal(t, again, key)
+
+	other, _, _ := Key("other-project", "golang:1.22", dir)
+	assert.Assert(t, other != key, "scopes must not share caches")
+
+	other, _, _ = Key("project", "golang:1.23", dir)
+	assert.Assert(t, other != key, "images must not share caches")
+
+	assert.NilError(t, os.WriteFile(filepath.Join(dir, "go.sum"), []byte("a v1.0.1 h1:y"), 0644))
+	other, _, _ = Key("project", "golang:1.22", dir)
+	assert.Assert(t, other != key, "a lockfile change must change the key")
+}
+
+func TestOpenAndShare(t *testing.T) {
+	src := t.TempDir()
+	assert.NilError(t, os.WriteFile(filepath.Join(src, "package-lock.json"), []byte("{}"), 0644))
+	key, _, err := Key("project", "node:20", src)
+	assert.NilError(t, err)
+
+	store := make(memStore)
+	first, err := New(t.TempDir(), Share(store))
+	assert.NilError(t, err)
+
+	entry, err := first.Open(key)
+	assert.NilError(t, err)
+	assert.Assert(t, entry.Fresh)
+
+	// What the build leaves in the cache.
+	assert.NilError(t, os.MkdirAll(filepath.Join(entry.Path, "npm", "_cacache"), 0755))
+	assert.NilError(t, os.WriteFile(filepath.Join(entry.Path, "npm", "_cacache", "index"), []byte("left-pad"), 0644))
+	assert.NilError(t, entry.Publish())
+	assert.Assert(t, store[key] != nil)
+
+	entry, err = first.Open(key)
+	assert.NilError(t, err)
+	assert.Assert(t, !entry.Fresh)
+
+	// Another node gets it from the store.
+	second, err := New(t.TempDir(), Share(store))
+	assert.NilError(t, err)
+	entry, err = second.Open(key)
+	assert.NilError(t, err)
+	assert.Assert(t, !entry.Fresh)
+
+	data, err := os.ReadFile(filepath.Join(entry.Path, "npm", "_cacache", "index"))
+	assert.NilError(t, err)
+	assert.Equal(t, string(data), "left-pad")
+
+	_, err = second.Open("../../etc")
+	assert.ErrorContains(t, err, "invalid cache key")
+}
+
+func TestClean(t *testing.T) {
+	c, err := New(t.TempDir())
+	assert.NilError(t, err)
+
+	key := string(bytes.Repeat([]byte("a"), 64))
+	entry, err := c.Open(key)
+	assert.NilError(t, err)
+
+	assert.NilError(t, c.Clean(time.Hour))
+	_, err = os.Stat(entry.Path)
+	assert.NilError(t, err)
+
+	old := time.Now().Add(-2 * time.Hour)
+	assert.NilError(t, os.Chtimes(entry.Path, old, old))
+	assert.NilError(t, c.Clean(time.Hour))
+	_, err = os.Stat(entry.Path)
+	assert.Assert(t, os.IsNotExist(err))
+}
+
+func TestFailedBuild(t *testing.T) {
+	c, err := New(t.TempDir())
+	assert.NilError(t, err)
+
+	key := string(bytes.Repeat([]byte("b"), 64))
+	entry, err := c.Open(key)
+	assert.NilError(t, err)
+	assert.Assert(t, entry.Fresh)
+	assert.Assert(t, entry.Path != c.path(key), "a build fills a scratch dir")
+
+	// The build fails half way through filling it.
+	assert.NilError(t, os.WriteFile(filepath.Join(entry.Path, "partial"), []byte("half"), 0644))
+	assert.NilError(t, entry.Discard())
+	_, err = os.Stat(entry.Path)
+	assert.Assert(t, os.IsNotExist(err))
+
+	entry, err = c.Open(key)
+	assert.NilError(t, err)
+	assert.Assert(t, entry.Fresh, "a failed build must not leave a hit")
+
+	assert.NilError(t, entry.Publish())
+	assert.Equal(t, entry.Path, c.path(key))
+
+	entry, err = c.Open(key)
+	assert.NilError(t, err)
+	assert.Assert(t, !entry.Fresh)
+}
+
+func TestImportLinks(t *testing.T) {
+	c, err := New(t.TempDir())
+	assert.NilError(t, err)
+
+	src := t.TempDir()
+	assert.NilError(t, os.MkdirAll(filepath.Join(src, "npm", "empty"), 0755))
+	assert.NilError(t, os.MkdirAll(filepath.Join(src, "npm", "pkg"), 0755))
+	assert.NilError(t, os.WriteFile(filepath.Join(src, "npm", "pkg", "cli.js"), []byte("run"), 0755))
+	assert.NilError(t, os.MkdirAll(filepath.Join(src, "npm", ".bin"), 0755))
+	assert.NilError(t, os.Symlink("../pkg/cli.js", filepath.Join(src, "npm", ".bin", "cli")))
+
+	key := string(bytes.Repeat([]byte("c"), 64))
+	assert.NilError(t, os.Rename(src, c.path(key)))
+
+	var tarball bytes.Buffer
+	assert.NilError(t, c.Export(key, &tarball))
+
+	other, err := New(t.TempDir())
+	assert.NilError(t, err)
+	assert.NilError(t, other.Import(key, &tarball))
+
+	info, err := os.Stat(filepath.Join(other.path(key), "npm", "empty"))
+	assert.NilError(t, err)
+	assert.Assert(t, info.IsDir())
+
+	link, err := os.Readlink(filepath.Join(other.path(key), "npm", ".bin", "cli"))
+	assert.NilError(t, err)
+	assert.Equal(t, link, "../pkg/cli.js")
+
+	data, err := os.ReadFile(filepath.Join(other.path(key), "npm", ".bin", "cli"))
+	assert.NilError(t, err)
+	assert.Equal(t, string(data), "run")
+}
+
+func TestImportRejectsEscapingLinks(t *testing.T) {
+	outside := t.TempDir()
+
+	for name, entries := range map[string][]*tar.Header{
+		"absolute link": {{Name: "etc", Typeflag: tar.TypeSymlink, Linkname: outside}},
+		"relative link": {{Name: "up", Typeflag: tar.TypeSymlink, Linkname: "../../.."}},
+		"through a link": {
+			{Name: "here", Typeflag: tar.TypeSymlink, Linkname: "."},
+			{Name: "here/here/here/up", Typeflag: tar.TypeSymlink, Linkname: "../../../.."},
+		},
+	} {
+		var buf bytes.Buffer
+		gzw := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(gzw)
+		for _, header := range entries {
+			assert.NilError(t, tw.WriteHeader(header))
+		}
+		assert.NilError(t, tw.Close())
+		assert.NilError(t, gzw.Close())
+
+		c, err := New(t.TempDir())
+		assert.NilError(t, err)
+		assert.ErrorContains(t, c.Import(string(bytes.Repeat([]byte("d"), 64)), &buf), "outside of the cache", name)
+	}
+
+	entries, err := os.ReadDir(outside)
+	assert.NilError(t, err)
+	assert.Equal(t, len(entries), 0)
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/taubyte/tau/pkg/builder/cache"
 	ci "github.com/taubyte/tau/pkg/containers"
 	specs "github.com/taubyte/tau/pkg/specs/builders"
 	"github.com/taubyte/tau/utils/multihash"
@@ -30,8 +31,7 @@ func (b *builder) setTarball() error {
 // buildImage returns a container image, if tarball is set then a new image is created
 // if not image is attempted to be pulled from dockerhub
 func (b *builder) buildImage() (clientImage *ci.DockerImage, err error) {
-	environment := b.config.HandleDepreciatedEnvironment()
-	image := environment.Image
+	image := b.imageName()
 
 	// base options
 	ops := make([]ci.ImageOption, 0, 2)
@@ -39,7 +39,6 @@ func (b *builder) buildImage() (clientImage *ci.DockerImage, err error) {
 
 	// if tarball is set then a new image is created
 	if b.tarball != nil {
-		image = fmt.Sprintf("%s-%s", image, strings.ToLower(multihash.Hash(b.tarball)))
 		ops = append(ops, ci.Build(bytes.NewReader(b.tarball)))
 		if b.restrictEgress() {
 			// A repository's Dockerfile is untrusted for the same reason its
@@ -61,6 +60,48 @@ func (b *builder) buildImage() (clientImage *ci.DockerImage, err error) {
 	return b.containerClient.Image(b.context, image, ops...)
 }
 
+// imageName returns the name of the image the build runs in, which is specific
+// to the Dockerfile when the sources have one.
+func (b *builder) imageName() string {
+	image := b.config.HandleDepreciatedEnvironment().Image
+	if b.tarball != nil {
+		image = fmt.Sprintf("%s-%s", image, strings.ToLower(multihash.Hash(b.tarball)))
+	}
+
+	return image
+}
+
+// openCache returns the cache entry of the build, or nil when it has none.
+func (b *builder) openCache() (*cache.Entry, error) {
+	if b.cache == nil {
+		return nil, nil
+	}
+
+	key, ok, err := cache.Key(b.cacheScope, b.imageName(), b.wd.String())
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	entry, err := b.cache.Open(key)
+	if err != nil {
+		return nil, err
+	}
+
+	json.NewEncoder(b.output).Encode(struct {
+		Op        string `json:"op"`
+		Key       string `json:"key"`
+		Hit       bool   `json:"hit"`
+		Timestamp int64  `json:"timestamp"`
+	}{
+		Op:        "open cache",
+		Key:       key,
+		Hit:       !entry.Fresh,
+		Timestamp: time.Now().UnixNano(),
+	})
+
+	return entry, nil
+}
+
 // run will initialize and run the container with the given image
 func (b *builder) run(output *output, image *ci.DockerImage, environment specs.Environment, ops ...ci.ContainerOption) (err error) {
 	json.NewEncoder(b.output).Encode(struct {
@@ -8,6 +8,7 @@ import (
 
 	"github.com/jedib0t/go-pretty/v6/table"
 	iface "github.com/taubyte/tau/core/builders"
+	"github.com/taubyte/tau/pkg/builder/cache"
 	ci "github.com/taubyte/tau/pkg/containers"
 	"github.com/taubyte/tau/pkg/specs/builders"
 	specs "github.com/taubyte/tau/pkg/specs/builders/common"
@@ -23,6 +24,15 @@ func Dev(dev bool) Option {
 	return func(b *builder) { b.dev = dev }
 }
 
+// Cache keeps the dependencies the build downloads in c, shared only with
+// builds of the same scope.
+func Cache(c *cache.Cache, scope string) Option {
+	return func(b *builder) {
+		b.cache = c
+		b.cacheScope = scope
+	}
+}
+
 // New creates a new container Builder for the given working directory.
 func New(ctx context.Context, output io.Writer, workDir string, options ...Option) (iface.Builder, error) {
 	// create new container client
@@ -6,6 +6,7 @@ import (
 	"os"
 	"runtime"
 
+	"github.com/taubyte/tau/pkg/builder/cache"
 	ci "github.com/taubyte/tau/pkg/containers"
 	"github.com/taubyte/tau/pkg/specs/builders"
 )
@@ -19,6 +20,8 @@ type builder struct {
 	tarball         []byte
 	output          io.Writer
 	dev             bool
+	cache           *cache.Cache
+	cacheScope      string
 }
 
 // restrictEgress reports whether this build's containers should be confined to
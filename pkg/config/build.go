@@ -0,0 +1,9 @@
+package config
+
+// BuildCache configures the dependency cache of monkey builds under
+// `build-cache:`. Builds are cached on the node unless Disabled; Share also
+// stashes what a build downloaded in hoarder, for monkeys on other nodes.
+type BuildCache struct {
+	Disabled bool `yaml:"disabled,omitempty"`
+	Share    bool `yaml:"share,omitempty"`
+}
@@ -0,0 +1,30 @@
+package config
+
+// Gateway configures the gateway under `gateway:`.
+type Gateway struct {
+	Selection GatewaySelection `yaml:"selection,omitempty"`
+	Cache     GatewayCache     `yaml:"cache,omitempty"`
+}
+
+// GatewaySelection configures how the gateway picks, among the substrate nodes
+// matching a request, the one it proxies the request to.
+//
+// Policy is one of `metrics` (the default) ranking nodes by the metrics they
+// reply with, `proximity` preferring nodes close to this one, `headroom`
+// preferring nodes with cpu and memory left according to seer, or `weighted`
+// picking at random weighted by headroom. Sticky keeps a client on the same
+// node, by its ip, for as long as the nodes matching don't change.
+type GatewaySelection struct {
+	Policy string `yaml:"policy,omitempty"`
+	Sticky bool   `yaml:"sticky,omitempty"`
+}
+
+// GatewayCache configures the cache of the responses the gateway proxies.
+// Responses are cached unless Disabled, as far as their Cache-Control allows,
+// in Memory then, once evicted from memory, on Disk. Sizes read like `256MB`;
+// a Disk of `0` keeps the cache in memory only.
+type GatewayCache struct {
+	Disabled bool   `yaml:"disabled,omitempty"`
+	Memory   string `yaml:"memory,omitempty"`
+	Disk     string `yaml:"disk,omitempty"`
+}
@@ -0,0 +1,8 @@
+package config
+
+// GitProvider locates a git service tau deploys from besides GitHub, keyed by
+// provider name ("gitea", "gitlab") under `git-providers:`. URL is the
+// instance's web root; GitLab falls back to gitlab.com when it is empty.
+type GitProvider struct {
+	URL string `yaml:"url,omitempty"`
+}
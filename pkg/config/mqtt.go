@@ -0,0 +1,9 @@
+package config
+
+// MQTT configures the MQTT broker substrate nodes serve on the `mqtt` port,
+// under `mqtt:`. The broker serves TLS when Cert and Key are set, as paths
+// relative to the config directory of the PEM certificate and key to present.
+type MQTT struct {
+	Cert string `yaml:"cert,omitempty"`
+	Key  string `yaml:"key,omitempty"`
+}
@@ -71,6 +71,10 @@ type Config interface {
 	SensorsRegistry() *sensors.Registry
 	Accounts() Accounts
 	Tenancy() Tenancy
+	GitProviders() map[string]GitProvider
+	BuildCache() BuildCache
+	Gateway() Gateway
+	MQTT() MQTT
 
 	SetNode(peer.Node)
 	SetRaftCluster(raft.Cluster)
@@ -295,6 +299,38 @@ func WithTenancy(t Tenancy) Option {
 	}
 }
 
+// WithGitProviders sets the git services enabled besides GitHub.
+func WithGitProviders(p map[string]GitProvider) Option {
+	return func(c *config) error {
+		c.gitProviders = p
+		return nil
+	}
+}
+
+// WithBuildCache sets how monkey caches build dependencies.
+func WithBuildCache(b BuildCache) Option {
+	return func(c *config) error {
+		c.buildCache = b
+		return nil
+	}
+}
+
+// WithGateway sets how the gateway picks nodes.
+func WithGateway(g Gateway) Option {
+	return func(c *config) error {
+		c.gateway = g
+		return nil
+	}
+}
+
+// WithMQTT sets how substrate nodes serve MQTT.
+func WithMQTT(m MQTT) Option {
+	return func(c *config) error {
+		c.mqtt = m
+		return nil
+	}
+}
+
 // New returns a validated config. Defaults are dev-friendly; override with options.
 func New(opts ...Option) (Config, error) {
 	c := &config{
@@ -361,6 +397,10 @@ type config struct {
 	domainValidation DomainValidation
 	accounts         Accounts
 	tenancy          Tenancy
+	gitProviders     map[string]GitProvider
+	buildCache       BuildCache
+	gateway          Gateway
+	mqtt             MQTT
 	// enterprise namespaces raw config for enterprise-only services (each decoded
 	// by //go:build ee code via EnterpriseConfig); empty in community builds.
 	enterprise map[string]yaml.Node
@@ -477,6 +517,11 @@ func (c *config) DomainValidation() DomainValidation { return c.domainValidation
 func (c *config) Accounts() Accounts                 { return c.accounts }
 func (c *config) Tenancy() Tenancy                   { return c.tenancy }
 
+func (c *config) GitProviders() map[string]GitProvider { return c.gitProviders }
+func (c *config) BuildCache() BuildCache               { return c.buildCache }
+func (c *config) Gateway() Gateway                     { return c.gateway }
+func (c *config) MQTT() MQTT                           { return c.mqtt }
+
 func (c *config) SetNode(n peer.Node)            { c.node = n }
 func (c *config) SetRaftCluster(rc raft.Cluster) { c.raftCluster = rc }
 func (c *config) SetClientNode(n peer.Node)      { c.clientNode = n }
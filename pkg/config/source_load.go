@@ -72,6 +72,16 @@ func WithSource(src *Source, opts SourceOptions) Option {
 		c.acmeCAARecord = defaultCAARecord
 		c.accounts = src.Accounts
 		c.tenancy = src.Tenancy
+		c.gitProviders = src.GitProviders
+		c.buildCache = src.BuildCache
+		c.gateway = src.Gateway
+		c.mqtt = src.MQTT
+		if c.mqtt.Cert != "" {
+			c.mqtt.Cert = path.Join(configRoot, c.mqtt.Cert)
+		}
+		if c.mqtt.Key != "" {
+			c.mqtt.Key = path.Join(configRoot, c.mqtt.Key)
+		}
 		c.enterprise = src.Enterprise
 
 		if c.swarmKey, err = loadSwarmKey(swarmPath); err != nil {
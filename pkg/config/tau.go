@@ -11,6 +11,7 @@ type Ports struct {
 	Main int `yaml:"main"`
 	Lite int `yaml:"lite,omitempty"`
 	Ipfs int `yaml:"ipfs,omitempty"`
+	Mqtt int `yaml:"mqtt,omitempty"`
 }
 
 func (p Ports) ToMap() map[string]int {
@@ -18,6 +19,7 @@ func (p Ports) ToMap() map[string]int {
 		"main": p.Main,
 		"lite": p.Lite,
 		"ipfs": p.Ipfs,
+		"mqtt": p.Mqtt,
 	}
 }
 
@@ -41,6 +43,14 @@ type Source struct {
 	// is emitted to every shape, so any service can compare a repository's
 	// owner against it without a client or an API call.
 	Tenancy Tenancy `yaml:"tenancy,omitempty"`
+	// GitProviders enables self-hosted git services, keyed by provider name.
+	GitProviders map[string]GitProvider `yaml:"git-providers,omitempty"`
+	// BuildCache configures the dependency cache of monkey builds.
+	BuildCache BuildCache `yaml:"build-cache,omitempty"`
+	// Gateway configures how the gateway picks the nodes it proxies to.
+	Gateway Gateway `yaml:"gateway,omitempty"`
+	// MQTT configures the MQTT broker of substrate nodes.
+	MQTT MQTT `yaml:"mqtt,omitempty"`
 	// Enterprise namespaces raw config for enterprise-only services under
 	// `enterprise:` in the shape config. Community builds carry it opaquely;
 	// `//go:build ee` code decodes each service's entry into its own typed
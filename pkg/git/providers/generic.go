@@ -0,0 +1,151 @@
+package providers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// Generic is the provider for bare git servers (gitolite, a repository over
+// SSH) that can do no more than run a post-receive hook. It has no API: the
+// deploy key and the hook are installed by whoever runs the server, and the
+// hook posts a JSON push notification signed with the hook's secret.
+var Generic Provider = generic{}
+
+// ErrNoAPI is returned by providers that cannot be driven remotely.
+var ErrNoAPI = errors.New("provider has no API")
+
+const (
+	// GenericEventHeader names the event of a generic delivery; only "push" is sent.
+	GenericEventHeader = "X-Tau-Event"
+	// GenericSignatureHeader carries "sha256=" and the hex HMAC-SHA256 of the body.
+	GenericSignatureHeader = "X-Tau-Signature"
+)
+
+type generic struct{}
+
+// GenericPush is the notification a bare server's post-receive hook sends.
+type GenericPush struct {
+	URI    string `json:"uri"`
+	Ref    string `json:"ref"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+	// PushedAt is optional. Without it a redelivered push is not recognised
+	// as the same push and builds again.
+	PushedAt int64 `json:"pushed_at,omitempty"`
+}
+
+func (generic) Name() string { return "generic" }
+
+func (generic) NewAPI(string, string) (API, error) {
+	return nil, fmt.Errorf("generic git servers: %w", ErrNoAPI)
+}
+
+// RepositoryID derives the id a bare repository is registered under from its
+// URI. A bare server has no ids of its own, and the same URI has to land on
+// the same id both at registration and in every push. Ids are 31 bit hashes,
+// so two URIs can share one: registration has to reject the second of them.
+func RepositoryID(uri string) int {
+	h := fnv.New32a()
+	h.Write([]byte(normalizeURI(uri)))
+	return int(h.Sum32() & 0x7fffffff)
+}
+
+func normalizeURI(uri string) string {
+	return strings.TrimSuffix(strings.TrimSpace(uri), "/")
+}
+
+// GenericRepository describes the bare repository at uri.
+func GenericRepository(uri string) *Repository {
+	uri = normalizeURI(uri)
+
+	// scp-like URIs (git@host:owner/repo.git) and ssh:// ones both end in the path.
+	fullName := uri
+	if i := strings.Index(fullName, "://"); i >= 0 {
+		fullName = fullName[i+3:]
+		if j := strings.Index(fullName, "/"); j >= 0 {
+			fullName = fullName[j+1:]
+		}
+	} else if i := strings.LastIndex(fullName, ":"); i >= 0 {
+		fullName = fullName[i+1:]
+	}
+	fullName = strings.TrimSuffix(strings.TrimPrefix(fullName, "/"), ".git")
+
+	return &Repository{
+		ID:       RepositoryID(uri),
+		Name:     path.Base(fullName),
+		FullName: fullName,
+		SSHURL:   uri,
+	}
+}
+
+func (generic) VerifyHook(header http.Header, body []byte, secret string) error {
+	sig, err := hex.DecodeString(strings.TrimPrefix(header.Get(GenericSignatureHeader), "sha256="))
+	if err != nil || len(sig) == 0 || secret == "" {
+		return ErrSignature
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	if !hmac.Equal(sig, mac.Sum(nil)) {
+		return ErrSignature
+	}
+
+	return nil
+}
+
+func (generic) ParsePush(header http.Header, body []byte) (*PushEvent, error) {
+	if event := header.Get(GenericEventHeader); event != "push" {
+		return nil, fmt.Errorf("%w: got `%s`", ErrNotPush, event)
+	}
+
+	var payload GenericPush
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("decoding push payload failed with: %w", err)
+	}
+
+	if payload.URI == "" || payload.Ref == "" || payload.After == "" {
+		return nil, errors.New("push notification needs uri, ref and after")
+	}
+
+	return &PushEvent{
+		Ref:        payload.Ref,
+		Before:     payload.Before,
+		After:      payload.After,
+		HeadCommit: payload.After,
+		PushedAt:   payload.PushedAt,
+		Repository: *GenericRepository(payload.URI),
+	}, nil
+}
+
+// PostReceiveHook returns a post-receive hook that notifies hookURL of every
+// branch pushed to the repository at uri. It needs sh, openssl and curl.
+func PostReceiveHook(uri, hookURL, secret string) string {
+	// the uri goes in the body as a JSON string, escaped once for JSON and
+	// once for the shell
+	jsonURI, _ := json.Marshal(normalizeURI(uri))
+
+	return fmt.Sprintf(`#!/bin/sh
+# Notifies tau of pushes to %[1]s.
+while read before after ref; do
+	body=$(printf '{"uri":%%s,"ref":"%%s","before":"%%s","after":"%%s","pushed_at":%%s}' \
+		%[2]s "$ref" "$before" "$after" "$(date +%%s)")
+	sig=$(printf '%%s' "$body" | openssl dgst -sha256 -hmac %[4]s -r | cut -d' ' -f1)
+	curl -fsS -X POST -H 'Content-Type: application/json' \
+		-H '%[5]s: push' -H "%[6]s: sha256=$sig" \
+		--data "$body" %[3]s >/dev/null || echo "tau: notifying $ref failed" >&2
+done
+`, jsonURI, shellQuote(string(jsonURI)), shellQuote(hookURL), shellQuote(secret), GenericEventHeader, GenericSignatureHeader)
+}
+
+// shellQuote quotes s as a single shell word.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,114 @@
+package providers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestGenericRepository(t *testing.T) {
+	for uri, fullName := range map[string]string{
+		"git@git.local:acme/web.git":            "acme/web",
+		"ssh://git@git.local:2222/acme/web.git": "acme/web",
+		"ssh://git@git.local/srv/git/web.git/":  "srv/git/web",
+	} {
+		repo := GenericRepository(uri)
+		assert.Equal(t, repo.FullName, fullName, uri)
+		assert.Equal(t, repo.Name, fullName[strings.LastIndex(fullName, "/")+1:])
+		assert.Equal(t, repo.ID, RepositoryID(uri))
+		assert.Assert(t, repo.ID > 0)
+	}
+
+	// A trailing slash does not make it another repository.
+	assert.Equal(t, RepositoryID("git@git.local:acme/web.git/"), RepositoryID("git@git.local:acme/web.git"))
+	assert.Assert(t, RepositoryID("git@git.local:acme/web.git") != RepositoryID("git@git.local:acme/api.git"))
+
+	_, err := Generic.NewAPI("", "")
+	assert.Assert(t, errors.Is(err, ErrNoAPI))
+}
+
+func TestGenericHook(t *testing.T) {
+	body := []byte(`{"uri":"git@git.local:acme/web.git","ref":"refs/heads/main","before":"1111","after":"2222","pushed_at":1714557600}`)
+	mac := hmac.New(sha256.New, []byte("hooksecret"))
+	mac.Write(body)
+
+	header := http.Header{}
+	header.Set(GenericEventHeader, "push")
+	header.Set(GenericSignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+
+	assert.NilError(t, Generic.VerifyHook(header, body, "hooksecret"))
+	assert.Assert(t, errors.Is(Generic.VerifyHook(header, body, "other"), ErrSignature))
+	assert.Assert(t, errors.Is(Generic.VerifyHook(header, body, ""), ErrSignature))
+
+	ev, err := Generic.ParsePush(header, body)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, ev, &PushEvent{
+		Ref:        "refs/heads/main",
+		Before:     "1111",
+		After:      "2222",
+		HeadCommit: "2222",
+		PushedAt:   1714557600,
+		Repository: *GenericRepository("git@git.local:acme/web.git"),
+	})
+
+	_, err = Generic.ParsePush(header, []byte(`{"ref":"refs/heads/main","after":"2222"}`))
+	assert.ErrorContains(t, err, "needs uri")
+
+	header.Set(GenericEventHeader, "tag")
+	_, err = Generic.ParsePush(header, body)
+	assert.Assert(t, errors.Is(err, ErrNotPush))
+}
+
+// The generated hook has to sign exactly what the provider verifies.
+func TestPostReceiveHook(t *testing.T) {
+	for _, tool := range []string{"sh", "openssl", "curl"} {
+		if _, err := exec.LookPath(tool); err != nil {
+			t.Skipf("%s not available", tool)
+		}
+	}
+
+	for _, tc := range []struct{ uri, secret string }{
+		{"git@git.local:acme/web.git", "hooksecret"},
+		// quotes must neither break the script nor reach the shell
+		{`git@git.local:acme/it's "web".git`, `hook'secret; touch pwned`},
+	} {
+		var got *PushEvent
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			if err := Generic.VerifyHook(r.Header, body, tc.secret); err != nil {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			got, _ = Generic.ParsePush(r.Header, body)
+		}))
+
+		dir := t.TempDir()
+		cmd := exec.Command("sh", "-c", PostReceiveHook(tc.uri, srv.URL+"/generic/h1", tc.secret))
+		cmd.Dir = dir
+		cmd.Stdin = strings.NewReader("1111 2222 refs/heads/main\n")
+		out, err := cmd.CombinedOutput()
+		srv.Close()
+		assert.NilError(t, err, string(out))
+
+		assert.Assert(t, got != nil, string(out))
+		assert.Equal(t, got.Ref, "refs/heads/main")
+		assert.Equal(t, got.After, "2222")
+		assert.Equal(t, got.Repository.SSHURL, tc.uri)
+		assert.Equal(t, got.Repository.ID, RepositoryID(tc.uri))
+		assert.Assert(t, got.PushedAt > 0)
+
+		_, err = os.Stat(filepath.Join(dir, "pwned"))
+		assert.Assert(t, os.IsNotExist(err))
+	}
+}
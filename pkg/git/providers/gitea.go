@@ -0,0 +1,180 @@
+package providers
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Gitea is the Gitea provider. Forgejo speaks the same API and signs its
+// deliveries the same way.
+var Gitea Provider = gitea{}
+
+type gitea struct{}
+
+type giteaAPI struct {
+	restClient
+}
+
+type giteaRepository struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	FullName      string `json:"full_name"`
+	SSHURL        string `json:"ssh_url"`
+	DefaultBranch string `json:"default_branch"`
+	Permissions   struct {
+		Admin bool `json:"admin"`
+		Push  bool `json:"push"`
+	} `json:"permissions"`
+}
+
+func (r *giteaRepository) repository() *Repository {
+	return &Repository{
+		ID:            r.ID,
+		Name:          r.Name,
+		FullName:      r.FullName,
+		SSHURL:        r.SSHURL,
+		DefaultBranch: r.DefaultBranch,
+		CanWrite:      r.Permissions.Admin || r.Permissions.Push,
+	}
+}
+
+func (gitea) Name() string {
+	return "gitea"
+}
+
+func (gitea) NewAPI(baseURL, token string) (API, error) {
+	if baseURL == "" {
+		return nil, errors.New("gitea needs the url of its instance")
+	}
+
+	return &giteaAPI{restClient{
+		base:       strings.TrimSuffix(baseURL, "/") + "/api/v1",
+		authHeader: "Authorization",
+		authValue:  "token " + token,
+	}}, nil
+}
+
+func (a *giteaAPI) Me(ctx context.Context) (*User, error) {
+	var me struct {
+		ID    int64  `json:"id"`
+		Login string `json:"login"`
+	}
+	if err := a.do(ctx, http.MethodGet, "/user", nil, &me); err != nil {
+		return nil, err
+	}
+	return &User{ID: me.ID, Login: me.Login}, nil
+}
+
+func (a *giteaAPI) Repository(ctx context.Context, id int) (*Repository, error) {
+	var repo giteaRepository
+	if err := a.do(ctx, http.MethodGet, fmt.Sprintf("/repositories/%d", id), nil, &repo); err != nil {
+		return nil, err
+	}
+	return repo.repository(), nil
+}
+
+// repoPath is the /repos/{owner}/{name} path of a repository; Gitea addresses
+// writes by name, not id.
+func (a *giteaAPI) repoPath(repo *Repository) (string, error) {
+	owner, name, ok := strings.Cut(repo.FullName, "/")
+	if !ok || owner == "" || name == "" {
+		return "", fmt.Errorf("repository name `%s` is not owner/name", repo.FullName)
+	}
+	return "/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(name), nil
+}
+
+func (a *giteaAPI) CreateDeployKey(ctx context.Context, repo *Repository, title, key string) error {
+	path, err := a.repoPath(repo)
+	if err != nil {
+		return err
+	}
+
+	return a.do(ctx, http.MethodPost, path+"/keys", map[string]any{
+		"title":     title,
+		"key":       key,
+		"read_only": true,
+	}, nil)
+}
+
+func (a *giteaAPI) CreatePushHook(ctx context.Context, repo *Repository, hookURL, secret string) (int64, error) {
+	path, err := a.repoPath(repo)
+	if err != nil {
+		return 0, err
+	}
+
+	var hook struct {
+		ID int64 `json:"id"`
+	}
+	err = a.do(ctx, http.MethodPost, path+"/hooks", map[string]any{
+		"type": "gitea",
+		"config": map[string]string{
+			"url":          hookURL,
+			"content_type": "json",
+			"secret":       secret,
+		},
+		"events": []string{"push"},
+		"active": true,
+	}, &hook)
+	if err != nil {
+		return 0, err
+	}
+
+	return hook.ID, nil
+}
+
+// VerifyHook checks X-Gitea-Signature, the hex HMAC-SHA256 of the body.
+func (gitea) VerifyHook(header http.Header, body []byte, secret string) error {
+	sig, err := hex.DecodeString(header.Get("X-Gitea-Signature"))
+	if err != nil || len(sig) == 0 || secret == "" {
+		return ErrSignature
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	if !hmac.Equal(sig, mac.Sum(nil)) {
+		return ErrSignature
+	}
+
+	return nil
+}
+
+func (gitea) ParsePush(header http.Header, body []byte) (*PushEvent, error) {
+	if event := header.Get("X-Gitea-Event"); event != "push" {
+		return nil, fmt.Errorf("%w: got `%s`", ErrNotPush, event)
+	}
+
+	var payload struct {
+		Ref        string `json:"ref"`
+		Before     string `json:"before"`
+		After      string `json:"after"`
+		HeadCommit *struct {
+			ID        string `json:"id"`
+			Timestamp string `json:"timestamp"`
+		} `json:"head_commit"`
+		Repository giteaRepository `json:"repository"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("decoding push payload failed with: %w", err)
+	}
+
+	ev := &PushEvent{
+		Ref:        payload.Ref,
+		Before:     payload.Before,
+		After:      payload.After,
+		Repository: *payload.Repository.repository(),
+	}
+	if payload.HeadCommit != nil {
+		ev.HeadCommit = payload.HeadCommit.ID
+		ev.PushedAt = pushedAt(payload.HeadCommit.Timestamp)
+	}
+
+	return ev, nil
+}
@@ -0,0 +1,153 @@
+package providers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+// giteaStandIn serves the slice of the Gitea API the provider uses.
+func giteaStandIn(t *testing.T, token string) (*httptest.Server, map[string]map[string]any) {
+	created := make(map[string]map[string]any)
+	mux := http.NewServeMux()
+	authed := func(h http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "token "+token {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			h(w, r)
+		}
+	}
+	record := func(name string) http.HandlerFunc {
+		return authed(func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]any
+			assert.NilError(t, json.NewDecoder(r.Body).Decode(&body))
+			created[name] = body
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":77}`))
+		})
+	}
+
+	mux.HandleFunc("GET /api/v1/user", authed(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":3,"login":"alice"}`))
+	}))
+	mux.HandleFunc("GET /api/v1/repositories/42", authed(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":42,"name":"web","full_name":"acme/web","ssh_url":"git@gitea.local:acme/web.git",
+			"default_branch":"main","permissions":{"admin":false,"push":true,"pull":true}}`))
+	}))
+	mux.HandleFunc("POST /api/v1/repos/acme/web/keys", record("key"))
+	mux.HandleFunc("POST /api/v1/repos/acme/web/hooks", record("hook"))
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv, created
+}
+
+func TestGiteaAPI(t *testing.T) {
+	ctx := t.Context()
+	srv, created := giteaStandIn(t, "s3cr3t")
+
+	api, err := Gitea.NewAPI(srv.URL+"/", "s3cr3t")
+	assert.NilError(t, err)
+
+	me, err := api.Me(ctx)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, me, &User{ID: 3, Login: "alice"})
+
+	repo, err := api.Repository(ctx, 42)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, repo, &Repository{
+		ID:            42,
+		Name:          "web",
+		FullName:      "acme/web",
+		SSHURL:        "git@gitea.local:acme/web.git",
+		DefaultBranch: "main",
+		CanWrite:      true,
+	})
+
+	assert.NilError(t, api.CreateDeployKey(ctx, repo, "deploy", "ssh-ed25519 AAAA"))
+	assert.Equal(t, created["key"]["read_only"], true)
+	assert.Equal(t, created["key"]["key"], "ssh-ed25519 AAAA")
+
+	id, err := api.CreatePushHook(ctx, repo, "https://patrick.example.com/gitea/h1", "hooksecret")
+	assert.NilError(t, err)
+	assert.Equal(t, id, int64(77))
+	assert.DeepEqual(t, created["hook"]["events"], []any{"push"})
+	config := created["hook"]["config"].(map[string]any)
+	assert.Equal(t, config["url"], "https://patrick.example.com/gitea/h1")
+	assert.Equal(t, config["secret"], "hooksecret")
+
+	_, err = api.Repository(ctx, 43)
+	assert.ErrorContains(t, err, "404")
+
+	bad, err := Gitea.NewAPI(srv.URL, "wrong")
+	assert.NilError(t, err)
+	_, err = bad.Me(ctx)
+	assert.ErrorContains(t, err, "401")
+
+	_, err = Gitea.NewAPI("", "s3cr3t")
+	assert.ErrorContains(t, err, "url")
+}
+
+const giteaPush = `{
+	"ref": "refs/heads/main",
+	"before": "1111",
+	"after": "2222",
+	"head_commit": {"id": "2222", "timestamp": "2024-05-01T10:00:00Z"},
+	"repository": {"id": 42, "name": "web", "full_name": "acme/web", "ssh_url": "git@gitea.local:acme/web.git", "default_branch": "main"}
+}`
+
+func TestGiteaHook(t *testing.T) {
+	body := []byte(giteaPush)
+	mac := hmac.New(sha256.New, []byte("hooksecret"))
+	mac.Write(body)
+
+	header := http.Header{}
+	header.Set("X-Gitea-Event", "push")
+	header.Set("X-Gitea-Signature", hex.EncodeToString(mac.Sum(nil)))
+
+	assert.NilError(t, Gitea.VerifyHook(header, body, "hooksecret"))
+	assert.Assert(t, errors.Is(Gitea.VerifyHook(header, body, "other"), ErrSignature))
+	assert.Assert(t, errors.Is(Gitea.VerifyHook(header, append(body, ' '), "hooksecret"), ErrSignature))
+	assert.Assert(t, errors.Is(Gitea.VerifyHook(http.Header{}, body, "hooksecret"), ErrSignature))
+
+	ev, err := Gitea.ParsePush(header, body)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, ev, &PushEvent{
+		Ref:        "refs/heads/main",
+		Before:     "1111",
+		After:      "2222",
+		HeadCommit: "2222",
+		PushedAt:   1714557600,
+		Repository: Repository{
+			ID:            42,
+			Name:          "web",
+			FullName:      "acme/web",
+			SSHURL:        "git@gitea.local:acme/web.git",
+			DefaultBranch: "main",
+		},
+	})
+
+	header.Set("X-Gitea-Event", "issues")
+	_, err = Gitea.ParsePush(header, body)
+	assert.Assert(t, errors.Is(err, ErrNotPush))
+}
+
+func TestGet(t *testing.T) {
+	p, err := Get("GitLab")
+	assert.NilError(t, err)
+	assert.Equal(t, p, GitLab)
+
+	_, err = Get("github")
+	assert.Assert(t, errors.Is(err, ErrUnknownProvider))
+
+	assert.DeepEqual(t, Names(), []string{"generic", "gitea", "gitlab"})
+}
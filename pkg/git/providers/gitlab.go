@@ -0,0 +1,177 @@
+package providers
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// GitLab is the GitLab provider, for gitlab.com or a self-managed instance.
+var GitLab Provider = gitlab{}
+
+// GitLabURL is used when no instance url is configured.
+const GitLabURL = "https://gitlab.com"
+
+// gitlabDeveloper is the lowest access level allowed to push.
+const gitlabDeveloper = 30
+
+type gitlab struct{}
+
+type gitlabAPI struct {
+	restClient
+}
+
+func (gitlab) Name() string {
+	return "gitlab"
+}
+
+func (gitlab) NewAPI(baseURL, token string) (API, error) {
+	if baseURL == "" {
+		baseURL = GitLabURL
+	}
+
+	return &gitlabAPI{restClient{
+		base:       strings.TrimSuffix(baseURL, "/") + "/api/v4",
+		authHeader: "Authorization",
+		authValue:  "Bearer " + token,
+	}}, nil
+}
+
+func (a *gitlabAPI) Me(ctx context.Context) (*User, error) {
+	var me struct {
+		ID       int64  `json:"id"`
+		Username string `json:"username"`
+	}
+	if err := a.do(ctx, http.MethodGet, "/user", nil, &me); err != nil {
+		return nil, err
+	}
+	return &User{ID: me.ID, Login: me.Username}, nil
+}
+
+type gitlabAccess struct {
+	AccessLevel int `json:"access_level"`
+}
+
+func (a *gitlabAPI) Repository(ctx context.Context, id int) (*Repository, error) {
+	var project struct {
+		ID            int    `json:"id"`
+		Name          string `json:"name"`
+		FullName      string `json:"path_with_namespace"`
+		SSHURL        string `json:"ssh_url_to_repo"`
+		DefaultBranch string `json:"default_branch"`
+		Permissions   struct {
+			Project *gitlabAccess `json:"project_access"`
+			Group   *gitlabAccess `json:"group_access"`
+		} `json:"permissions"`
+	}
+	if err := a.do(ctx, http.MethodGet, fmt.Sprintf("/projects/%d", id), nil, &project); err != nil {
+		return nil, err
+	}
+
+	// Access is granted on the project, inherited from its group, or both;
+	// the higher of the two applies.
+	level := 0
+	for _, access := range []*gitlabAccess{project.Permissions.Project, project.Permissions.Group} {
+		if access != nil && access.AccessLevel > level {
+			level = access.AccessLevel
+		}
+	}
+
+	return &Repository{
+		ID:            project.ID,
+		Name:          project.Name,
+		FullName:      project.FullName,
+		SSHURL:        project.SSHURL,
+		DefaultBranch: project.DefaultBranch,
+		CanWrite:      level >= gitlabDeveloper,
+	}, nil
+}
+
+func (a *gitlabAPI) CreateDeployKey(ctx context.Context, repo *Repository, title, key string) error {
+	return a.do(ctx, http.MethodPost, fmt.Sprintf("/projects/%d/deploy_keys", repo.ID), map[string]any{
+		"title":    title,
+		"key":      key,
+		"can_push": false,
+	}, nil)
+}
+
+func (a *gitlabAPI) CreatePushHook(ctx context.Context, repo *Repository, hookURL, secret string) (int64, error) {
+	var hook struct {
+		ID int64 `json:"id"`
+	}
+	err := a.do(ctx, http.MethodPost, fmt.Sprintf("/projects/%d/hooks", repo.ID), map[string]any{
+		"url":                     hookURL,
+		"token":                   secret,
+		"push_events":             true,
+		"enable_ssl_verification": true,
+	}, &hook)
+	if err != nil {
+		return 0, err
+	}
+
+	return hook.ID, nil
+}
+
+// VerifyHook checks X-Gitlab-Token. GitLab does not sign deliveries; it sends
+// the hook's secret token back verbatim.
+func (gitlab) VerifyHook(header http.Header, body []byte, secret string) error {
+	token := header.Get("X-Gitlab-Token")
+	if token == "" || secret == "" || subtle.ConstantTimeCompare([]byte(token), []byte(secret)) != 1 {
+		return ErrSignature
+	}
+	return nil
+}
+
+func (gitlab) ParsePush(header http.Header, body []byte) (*PushEvent, error) {
+	if event := header.Get("X-Gitlab-Event"); event != "Push Hook" {
+		return nil, fmt.Errorf("%w: got `%s`", ErrNotPush, event)
+	}
+
+	var payload struct {
+		ObjectKind string `json:"object_kind"`
+		Ref        string `json:"ref"`
+		Before     string `json:"before"`
+		After      string `json:"after"`
+		Project    struct {
+			ID            int    `json:"id"`
+			Name          string `json:"name"`
+			FullName      string `json:"path_with_namespace"`
+			SSHURL        string `json:"git_ssh_url"`
+			DefaultBranch string `json:"default_branch"`
+		} `json:"project"`
+		Commits []struct {
+			ID        string `json:"id"`
+			Timestamp string `json:"timestamp"`
+		} `json:"commits"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("decoding push payload failed with: %w", err)
+	}
+	if payload.ObjectKind != "push" {
+		return nil, fmt.Errorf("%w: got `%s`", ErrNotPush, payload.ObjectKind)
+	}
+
+	ev := &PushEvent{
+		Ref:    payload.Ref,
+		Before: payload.Before,
+		After:  payload.After,
+		Repository: Repository{
+			ID:            payload.Project.ID,
+			Name:          payload.Project.Name,
+			FullName:      payload.Project.FullName,
+			SSHURL:        payload.Project.SSHURL,
+			DefaultBranch: payload.Project.DefaultBranch,
+		},
+	}
+	for _, commit := range payload.Commits {
+		if commit.ID == payload.After {
+			ev.HeadCommit = commit.ID
+			ev.PushedAt = pushedAt(commit.Timestamp)
+		}
+	}
+
+	return ev, nil
+}
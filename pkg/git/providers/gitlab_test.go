@@ -0,0 +1,125 @@
+package providers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func gitlabStandIn(t *testing.T, token string, access string) (*httptest.Server, map[string]map[string]any) {
+	created := make(map[string]map[string]any)
+	mux := http.NewServeMux()
+	authed := func(h http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer "+token {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			h(w, r)
+		}
+	}
+	record := func(name string) http.HandlerFunc {
+		return authed(func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]any
+			assert.NilError(t, json.NewDecoder(r.Body).Decode(&body))
+			created[name] = body
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":9}`))
+		})
+	}
+
+	mux.HandleFunc("GET /api/v4/user", authed(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":5,"username":"bob"}`))
+	}))
+	mux.HandleFunc("GET /api/v4/projects/7", authed(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":7,"name":"api","path_with_namespace":"acme/api","ssh_url_to_repo":"git@gitlab.local:acme/api.git",
+			"default_branch":"main","permissions":{"project_access":null,"group_access":` + access + `}}`))
+	}))
+	mux.HandleFunc("POST /api/v4/projects/7/deploy_keys", record("key"))
+	mux.HandleFunc("POST /api/v4/projects/7/hooks", record("hook"))
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv, created
+}
+
+func TestGitLabAPI(t *testing.T) {
+	ctx := t.Context()
+	srv, created := gitlabStandIn(t, "glpat", `{"access_level":40}`)
+
+	api, err := GitLab.NewAPI(srv.URL, "glpat")
+	assert.NilError(t, err)
+
+	me, err := api.Me(ctx)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, me, &User{ID: 5, Login: "bob"})
+
+	repo, err := api.Repository(ctx, 7)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, repo, &Repository{
+		ID:            7,
+		Name:          "api",
+		FullName:      "acme/api",
+		SSHURL:        "git@gitlab.local:acme/api.git",
+		DefaultBranch: "main",
+		CanWrite:      true,
+	})
+
+	assert.NilError(t, api.CreateDeployKey(ctx, repo, "deploy", "ssh-ed25519 AAAA"))
+	assert.Equal(t, created["key"]["can_push"], false)
+
+	id, err := api.CreatePushHook(ctx, repo, "https://patrick.example.com/gitlab/h1", "hooksecret")
+	assert.NilError(t, err)
+	assert.Equal(t, id, int64(9))
+	assert.Equal(t, created["hook"]["token"], "hooksecret")
+	assert.Equal(t, created["hook"]["push_events"], true)
+}
+
+func TestGitLabReporterCannotWrite(t *testing.T) {
+	srv, _ := gitlabStandIn(t, "glpat", `{"access_level":20}`)
+
+	api, err := GitLab.NewAPI(srv.URL, "glpat")
+	assert.NilError(t, err)
+
+	repo, err := api.Repository(t.Context(), 7)
+	assert.NilError(t, err)
+	assert.Equal(t, repo.CanWrite, false)
+}
+
+const gitlabPush = `{
+	"object_kind": "push",
+	"ref": "refs/heads/main",
+	"before": "aaaa",
+	"after": "cccc",
+	"project": {"id": 7, "name": "api", "path_with_namespace": "acme/api", "git_ssh_url": "git@gitlab.local:acme/api.git", "default_branch": "main"},
+	"commits": [
+		{"id": "bbbb", "timestamp": "2024-05-01T09:00:00+00:00"},
+		{"id": "cccc", "timestamp": "2024-05-01T12:00:00+02:00"}
+	]
+}`
+
+func TestGitLabHook(t *testing.T) {
+	body := []byte(gitlabPush)
+	header := http.Header{}
+	header.Set("X-Gitlab-Event", "Push Hook")
+	header.Set("X-Gitlab-Token", "hooksecret")
+
+	assert.NilError(t, GitLab.VerifyHook(header, body, "hooksecret"))
+	assert.Assert(t, errors.Is(GitLab.VerifyHook(header, body, "other"), ErrSignature))
+	assert.Assert(t, errors.Is(GitLab.VerifyHook(http.Header{}, body, ""), ErrSignature))
+
+	ev, err := GitLab.ParsePush(header, body)
+	assert.NilError(t, err)
+	assert.Equal(t, ev.HeadCommit, "cccc")
+	assert.Equal(t, ev.PushedAt, int64(1714557600))
+	assert.Equal(t, ev.Repository.ID, 7)
+	assert.Equal(t, ev.Repository.SSHURL, "git@gitlab.local:acme/api.git")
+
+	header.Set("X-Gitlab-Event", "Tag Push Hook")
+	_, err = GitLab.ParsePush(header, body)
+	assert.Assert(t, errors.Is(err, ErrNotPush))
+}
@@ -0,0 +1,102 @@
+// Package providers abstracts the self-hosted git services tau deploys from.
+// A Provider covers the two halves of wiring a repository: the REST API used,
+// as the caller, to inspect a repository and install a deploy key and a push
+// hook on it, and the webhook side used to verify and decode push deliveries.
+// Generic, for bare git servers, has only the webhook side.
+//
+// GitHub is not listed here: it keeps its dedicated client in the auth service
+// and its webhook handler in patrick.
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+var (
+	// ErrUnknownProvider is returned by Get for a provider that is not supported.
+	ErrUnknownProvider = errors.New("unknown git provider")
+	// ErrSignature is returned when a delivery was not signed with the hook's secret.
+	ErrSignature = errors.New("webhook signature mismatch")
+	// ErrNotPush is returned by ParsePush for deliveries of any other event.
+	ErrNotPush = errors.New("not a push event")
+)
+
+// User is the account a token belongs to.
+type User struct {
+	ID    int64
+	Login string
+}
+
+// Repository is what tau needs to know about a hosted repository.
+type Repository struct {
+	ID            int
+	Name          string
+	FullName      string // owner/name, the form tenancy ownership is checked against
+	SSHURL        string
+	DefaultBranch string
+	// CanWrite reports whether the token's owner may push to the repository.
+	// Only meaningful from API.Repository.
+	CanWrite bool
+}
+
+// PushEvent is a decoded push delivery.
+type PushEvent struct {
+	Ref        string
+	Before     string
+	After      string
+	HeadCommit string
+	// PushedAt is the head commit's time in unix seconds, 0 when unknown.
+	PushedAt   int64
+	Repository Repository
+}
+
+// API is a provider's REST surface, authenticated as the owner of a token.
+type API interface {
+	Me(ctx context.Context) (*User, error)
+	Repository(ctx context.Context, id int) (*Repository, error)
+	// CreateDeployKey installs a read-only deploy key on the repository.
+	CreateDeployKey(ctx context.Context, repo *Repository, title, key string) error
+	// CreatePushHook subscribes url to the repository's pushes and returns the
+	// provider's id for the hook.
+	CreatePushHook(ctx context.Context, repo *Repository, url, secret string) (int64, error)
+}
+
+type Provider interface {
+	Name() string
+	// NewAPI connects to the provider hosted at baseURL, which may be empty for
+	// providers with a public default.
+	NewAPI(baseURL, token string) (API, error)
+	// VerifyHook checks that a delivery was made by a hook holding secret.
+	VerifyHook(header http.Header, body []byte, secret string) error
+	// ParsePush decodes a push delivery; other events return ErrNotPush.
+	ParsePush(header http.Header, body []byte) (*PushEvent, error)
+}
+
+var registry = map[string]Provider{
+	Gitea.Name():   Gitea,
+	GitLab.Name():  GitLab,
+	Generic.Name(): Generic,
+}
+
+// Get returns the named provider; names are case-insensitive.
+func Get(name string) (Provider, error) {
+	if p, ok := registry[strings.ToLower(name)]; ok {
+		return p, nil
+	}
+	return nil, fmt.Errorf("%w `%s`", ErrUnknownProvider, name)
+}
+
+// Names lists the supported providers.
+func Names() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -6,5 +6,7 @@ var (
 		{name: "oauth", value: []byte("oauth"), length: len("oauth")},
 		{name: "github", value: []byte("github"), length: len("github")},
 		{name: "apikey", value: []byte("apikey"), length: len("apikey")},
+		{name: "gitea", value: []byte("gitea"), length: len("gitea")},
+		{name: "gitlab", value: []byte("gitlab"), length: len("gitlab")},
 	}
 )
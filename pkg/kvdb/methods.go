@@ -11,21 +11,68 @@ import (
 
 func (kvd *kvDatabase) Get(ctx context.Context, key string) ([]byte, error) {
 	k := ds.NewKey(key)
-	return kvd.datastore.Get(ctx, k)
+	v, err := kvd.datastore.Get(ctx, k)
+	if err != nil {
+		return nil, err
+	}
+
+	if kvd.expired(ctx, key) {
+		return nil, ds.ErrNotFound
+	}
+
+	return v, nil
 }
 
 func (kvd *kvDatabase) Put(ctx context.Context, key string, v []byte) error {
 	if key == "" {
 		return errors.New("key cannot be empty")
 	}
+	if isTTLKey(key) {
+		return ErrReservedKey
+	}
+
+	kvd.ttlLock.RLock()
+	defer kvd.ttlLock.RUnlock()
 
 	k := ds.NewKey(key)
-	return kvd.datastore.Put(ctx, k, v)
+	if _, ok, _ := kvd.expiry(ctx, key); !ok {
+		return kvd.datastore.Put(ctx, k, v)
+	}
+
+	// Overwriting a key with an expiry clears it in the same delta
+	b, err := kvd.datastore.Batch(ctx)
+	if err != nil {
+		return err
+	}
+	if err = b.Put(ctx, k, v); err != nil {
+		return err
+	}
+	if err = b.Delete(ctx, ttlKey(key)); err != nil {
+		return err
+	}
+	return b.Commit(ctx)
 }
 
 func (kvd *kvDatabase) Delete(ctx context.Context, key string) error {
+	kvd.ttlLock.RLock()
+	defer kvd.ttlLock.RUnlock()
+
 	k := ds.NewKey(key)
-	return kvd.datastore.Delete(ctx, k)
+	if _, ok, _ := kvd.expiry(ctx, key); !ok {
+		return kvd.datastore.Delete(ctx, k)
+	}
+
+	b, err := kvd.datastore.Batch(ctx)
+	if err != nil {
+		return err
+	}
+	if err = b.Delete(ctx, k); err != nil {
+		return err
+	}
+	if err = b.Delete(ctx, ttlKey(key)); err != nil {
+		return err
+	}
+	return b.Commit(ctx)
 }
 
 func (kvd *kvDatabase) List(ctx context.Context, prefix string) ([]string, error) {
@@ -72,6 +119,7 @@ func (kvd *kvDatabase) ListAsync(ctx context.Context, prefix string) (chan strin
 func (kvd *kvDatabase) list(ctx context.Context, prefix string) (query.Results, error) {
 	return kvd.datastore.Query(ctx, query.Query{
 		Prefix:   prefix,
+		Filters:  []query.Filter{kvd.newLiveFilter(ctx)},
 		KeysOnly: true,
 	})
 }
@@ -81,25 +129,49 @@ func (kvd *kvDatabase) Batch(ctx context.Context) (kvdb.Batch, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &batch{ctx: ctx, store: b}, nil
+	return &batch{ctx: ctx, store: b, kvd: kvd}, nil
 }
 
 type batch struct {
 	ctx   context.Context
 	store ds.Batch
+	kvd   *kvDatabase
 }
 
 func (b *batch) Put(key string, value []byte) error {
+	if isTTLKey(key) {
+		return ErrReservedKey
+	}
+
 	k := ds.NewKey(key)
-	return b.store.Put(b.ctx, k, value)
+	if err := b.store.Put(b.ctx, k, value); err != nil {
+		return err
+	}
+
+	return b.clearExpiry(key)
 }
 
 func (b *batch) Delete(key string) error {
 	k := ds.NewKey(key)
-	return b.store.Delete(b.ctx, k)
+	if err := b.store.Delete(b.ctx, k); err != nil {
+		return err
+	}
+
+	return b.clearExpiry(key)
+}
+
+func (b *batch) clearExpiry(key string) error {
+	if _, ok, _ := b.kvd.expiry(b.ctx, key); ok {
+		return b.store.Delete(b.ctx, ttlKey(key))
+	}
+
+	return nil
 }
 
 func (b *batch) Commit() error {
+	b.kvd.ttlLock.RLock()
+	defer b.kvd.ttlLock.RUnlock()
+
 	return b.store.Commit(b.ctx)
 }
 
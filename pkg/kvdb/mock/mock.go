@@ -5,6 +5,7 @@ import (
 	"errors"
 	"regexp"
 	"sync"
+	"time"
 
 	"github.com/ipfs/go-cid"
 	"github.com/ipfs/go-log/v2"
@@ -19,13 +20,15 @@ var (
 
 // KVDB implements the KVDB interface using an in-memory map
 type KVDB struct {
-	data    map[string][]byte
-	mutex   sync.RWMutex
-	logger  log.StandardLogger
-	path    string
-	closed  bool
-	factory *Factory
-	stats   *MockStats
+	data     map[string][]byte
+	expires  map[string]time.Time
+	watchers []*watcher
+	mutex    sync.RWMutex
+	logger   log.StandardLogger
+	path     string
+	closed   bool
+	factory  *Factory
+	stats    *MockStats
 }
 
 // Factory implements the Factory interface
@@ -81,6 +84,7 @@ func (f *Factory) New(logger log.StandardLogger, path string, rebroadcastInterva
 
 	db := &KVDB{
 		data:    make(map[string][]byte),
+		expires: make(map[string]time.Time),
 		logger:  logger,
 		path:    path,
 		factory: f,
@@ -100,12 +104,18 @@ func (m *KVDB) Get(ctx context.Context, key string) ([]byte, error) {
 		return nil, ErrClosed
 	}
 
-	if data, exists := m.data[key]; exists {
+	if data, exists := m.data[key]; exists && m.live(key) {
 		return data, nil
 	}
 	return nil, ErrNotFound
 }
 
+// live reports whether the key has not expired; callers hold the mutex
+func (m *KVDB) live(key string) bool {
+	t, ok := m.expires[key]
+	return !ok || time.Now().Before(t)
+}
+
 // Put inserts the data, indexed by key
 func (m *KVDB) Put(ctx context.Context, key string, v []byte) error {
 	m.mutex.Lock()
@@ -116,6 +126,23 @@ func (m *KVDB) Put(ctx context.Context, key string, v []byte) error {
 	}
 
 	m.data[key] = v
+	delete(m.expires, key)
+	m.notify(kvdb.EventPut, key, v)
+	return nil
+}
+
+// PutWithTTL inserts the data, expiring it after ttl
+func (m *KVDB) PutWithTTL(ctx context.Context, key string, v []byte, ttl time.Duration) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.closed {
+		return ErrClosed
+	}
+
+	m.data[key] = v
+	m.expires[key] = time.Now().Add(ttl)
+	m.notify(kvdb.EventPut, key, v)
 	return nil
 }
 
@@ -128,7 +155,12 @@ func (m *KVDB) Delete(ctx context.Context, key string) error {
 		return ErrClosed
 	}
 
+	_, existed := m.data[key]
 	delete(m.data, key)
+	delete(m.expires, key)
+	if existed {
+		m.notify(kvdb.EventDelete, key, nil)
+	}
 	return nil
 }
 
@@ -143,6 +175,9 @@ func (m *KVDB) List(ctx context.Context, prefix string) ([]string, error) {
 
 	var keys []string
 	for key := range m.data {
+		if !m.live(key) {
+			continue
+		}
 		if prefix == "" || (len(key) >= len(prefix) && key[:len(prefix)] == prefix) {
 			keys = append(keys, key)
 		}
@@ -172,6 +207,9 @@ func (m *KVDB) ListAsync(ctx context.Context, prefix string) (chan string, error
 		}
 
 		for key := range m.data {
+			if !m.live(key) {
+				continue
+			}
 			if prefix == "" || (len(key) >= len(prefix) && key[:len(prefix)] == prefix) {
 				select {
 				case ch <- key:
@@ -196,6 +234,10 @@ func (m *KVDB) ListRegEx(ctx context.Context, prefix string, regexs ...string) (
 
 	var keys []string
 	for key := range m.data {
+		if !m.live(key) {
+			continue
+		}
+
 		// Check prefix first
 		if prefix != "" && (len(key) < len(prefix) || key[:len(prefix)] != prefix) {
 			continue
@@ -244,6 +286,10 @@ func (m *KVDB) ListRegExAsync(ctx context.Context, prefix string, regexs ...stri
 		}
 
 		for key := range m.data {
+			if !m.live(key) {
+				continue
+			}
+
 			// Check prefix first
 			if prefix != "" && (len(key) < len(prefix) || key[:len(prefix)] != prefix) {
 				continue
@@ -280,6 +326,58 @@ func (m *KVDB) ListRegExAsync(ctx context.Context, prefix string, regexs ...stri
 	return ch, nil
 }
 
+type watcher struct {
+	ctx    context.Context
+	prefix string
+	ch     chan kvdb.Event
+}
+
+// Watch returns a channel of changes to keys under the given prefix
+func (m *KVDB) Watch(ctx context.Context, prefix string) (<-chan kvdb.Event, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.closed {
+		return nil, ErrClosed
+	}
+
+	w := &watcher{ctx: ctx, prefix: prefix, ch: make(chan kvdb.Event, 1024)}
+	m.watchers = append(m.watchers, w)
+
+	go func() {
+		<-ctx.Done()
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
+		m.dropWatcher(w)
+	}()
+
+	return w.ch, nil
+}
+
+// notify delivers an event to matching watchers; callers hold the mutex
+func (m *KVDB) notify(t kvdb.EventType, key string, value []byte) {
+	for _, w := range m.watchers {
+		if !kvdb.UnderPrefix(key, w.prefix) {
+			continue
+		}
+		select {
+		case w.ch <- kvdb.Event{Type: t, Key: key, Value: value}:
+		default:
+		}
+	}
+}
+
+// dropWatcher removes and closes a watcher; callers hold the mutex
+func (m *KVDB) dropWatcher(w *watcher) {
+	for i, cur := range m.watchers {
+		if cur == w {
+			m.watchers = append(m.watchers[:i], m.watchers[i+1:]...)
+			close(w.ch)
+			return
+		}
+	}
+}
+
 // Batch creates a Batch interface of the current KVDB
 func (m *KVDB) Batch(ctx context.Context) (kvdb.Batch, error) {
 	if m.closed {
@@ -320,6 +418,9 @@ func (m *KVDB) Close() {
 		m.closed = true
 		// Clear data
 		m.data = nil
+		for len(m.watchers) > 0 {
+			m.dropWatcher(m.watchers[0])
+		}
 	}
 }
 
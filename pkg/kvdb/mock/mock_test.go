@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/ipfs/go-log/v2"
+	"github.com/taubyte/tau/core/kvdb"
 	"gotest.tools/v3/assert"
 )
 
@@ -23,6 +24,29 @@ func TestMockKVDB(t *testing.T) {
 		defer db.Close()
 	})
 
+	t.Run("PutWithTTL", func(t *testing.T) {
+		factory := New()
+		defer factory.Close()
+
+		db, err := factory.New(logger, "test", 5)
+		assert.NilError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+		assert.NilError(t, db.PutWithTTL(ctx, "short", []byte("x"), 20*time.Millisecond))
+		assert.NilError(t, db.PutWithTTL(ctx, "cleared", []byte("y"), 20*time.Millisecond))
+		assert.NilError(t, db.Put(ctx, "cleared", []byte("z")))
+
+		time.Sleep(40 * time.Millisecond)
+
+		_, err = db.Get(ctx, "short")
+		assert.Equal(t, err, ErrNotFound)
+
+		keys, err := db.List(ctx, "")
+		assert.NilError(t, err)
+		assert.DeepEqual(t, keys, []string{"cleared"})
+	})
+
 	t.Run("Put and Get", func(t *testing.T) {
 		factory := New()
 		defer factory.Close()
@@ -373,4 +397,35 @@ func TestMockKVDB(t *testing.T) {
 	done:
 		assert.Assert(t, closed, "Channel should be closed after context cancellation")
 	})
+	t.Run("Watch", func(t *testing.T) {
+		factory := New()
+		defer factory.Close()
+
+		db, err := factory.New(logger, "watch", 5)
+		assert.NilError(t, err)
+		defer db.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		events, err := db.Watch(ctx, "users/")
+		assert.NilError(t, err)
+
+		assert.NilError(t, db.Put(ctx, "other", []byte("x")))
+		assert.NilError(t, db.Put(ctx, "users/a", []byte("1")))
+		assert.NilError(t, db.Delete(ctx, "users/missing"))
+		assert.NilError(t, db.Delete(ctx, "users/a"))
+
+		ev := <-events
+		assert.Equal(t, ev.Type, kvdb.EventPut)
+		assert.Equal(t, ev.Key, "users/a")
+		assert.Equal(t, string(ev.Value), "1")
+
+		ev = <-events
+		assert.Equal(t, ev.Type, kvdb.EventDelete)
+		assert.Equal(t, ev.Key, "users/a")
+
+		cancel()
+		for range events {
+			t.Fatal("no more events expected")
+		}
+	})
 }
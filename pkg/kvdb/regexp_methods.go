@@ -98,7 +98,7 @@ func (kvd *kvDatabase) listRegEx(ctx context.Context, prefix string, regexs ...s
 
 	return kvd.datastore.Query(ctx, query.Query{
 		Prefix:   prefix,
-		Filters:  []query.Filter{filter},
+		Filters:  []query.Filter{kvd.newLiveFilter(ctx), filter},
 		KeysOnly: true,
 	})
 }
@@ -116,10 +116,13 @@ func (f *factory) New(logger logging.StandardLogger, path string, rebroadcastInt
 	opts.RebroadcastInterval = time.Duration(rebroadcastIntervalSec * int(time.Second))
 	opts.PutHook = func(k ds.Key, v []byte) {
 		logger.Infof("Added: [%s] -> %s\n", k, string(v))
+		s.noteExpiry(k)
+		s.notify(kvdb.EventPut, k, v)
 	}
 
 	opts.DeleteHook = func(k ds.Key) {
 		logger.Infof("Removed: [%s]\n", k)
+		s.notify(kvdb.EventDelete, k, nil)
 	}
 
 	s.datastore, err = NewDatastore(f.node.Store(), ds.NewKey("crdt/"+path), f.node.DAG(), s.broadcaster, opts)
@@ -129,8 +132,15 @@ func (f *factory) New(logger logging.StandardLogger, path string, rebroadcastInt
 		return nil, err
 	}
 
+	if err = s.loadHasExpiries(s.closeCtx); err != nil {
+		slogger.Errorf("reading expiries of %s failed with %s", path, err)
+		s.hasExpiries.Store(true)
+	}
+
 	f.dbs[path] = s
 
+	go s.expiryLoop()
+
 	return s, nil
 }
 
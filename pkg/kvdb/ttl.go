@@ -0,0 +1,275 @@
+package kvdb
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	ds "github.com/ipfs/go-datastore"
+	query "github.com/ipfs/go-datastore/query"
+)
+
+// Per-key expiry.
+//
+// A key written with PutWithTTL gets a companion entry /_ttl/<key> holding its
+// absolute expiry (unix nanoseconds, big endian). The value and its expiry are
+// written in the same delta, so they replicate together and every replica
+// learns both at once. Expiry is then a pure function of that entry and the
+// local clock: reads (Get, List, ListRegEx) hide an expired key on every
+// replica without any coordination, so replicas agree on what is visible up
+// to clock skew. Reads look up the expiry of the keys they return only, and
+// not at all on a database no expiry was ever seen on.
+//
+// Physical removal is a tombstone: each replica's sweeper periodically deletes
+// expired keys together with their expiry entry. Several replicas may sweep
+// the same key; their tombstones target the same element ids and merge
+// idempotently, while a concurrent re-Put on another replica creates a new
+// element no earlier tombstone covers, so it survives the merge as any
+// add-wins write would. Once enough tombstones have accumulated the sweeper
+// compacts the DAG (see Compact), and the resulting snapshot lets the other
+// replicas reclaim the expired history (see ReclaimOnSnapshot).
+//
+// A plain Put on a key that has an expiry clears it in the same delta.
+
+// TTLNamespace is the reserved key space holding per-key expiries. Keys under
+// it cannot be written directly and are never listed.
+const TTLNamespace = "/_ttl"
+
+var (
+	// ExpirySweepInterval is how often a database deletes its expired keys.
+	ExpirySweepInterval = time.Minute
+	// ExpiryCompactThreshold is how many swept keys trigger a compaction.
+	ExpiryCompactThreshold = 1024
+)
+
+var ErrReservedKey = errors.New("key is in the reserved " + TTLNamespace + " namespace")
+
+func ttlKey(key string) ds.Key {
+	return ds.NewKey(TTLNamespace).Child(ds.NewKey(key))
+}
+
+func isTTLKey(key string) bool {
+	k := ds.NewKey(key)
+	return k.String() == TTLNamespace || strings.HasPrefix(k.String(), TTLNamespace+"/")
+}
+
+func encodeExpiry(t time.Time) []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, uint64(t.UnixNano()))
+	return buf
+}
+
+func decodeExpiry(data []byte) (time.Time, error) {
+	if len(data) != 8 {
+		return time.Time{}, fmt.Errorf("malformed expiry of %d bytes", len(data))
+	}
+	return time.Unix(0, int64(binary.BigEndian.Uint64(data))), nil
+}
+
+// PutWithTTL stores the value like Put; the key expires once ttl elapsed.
+func (kvd *kvDatabase) PutWithTTL(ctx context.Context, key string, v []byte, ttl time.Duration) error {
+	if key == "" {
+		return errors.New("key cannot be empty")
+	}
+	if isTTLKey(key) {
+		return ErrReservedKey
+	}
+	if ttl <= 0 {
+		return errors.New("ttl must be positive")
+	}
+
+	kvd.ttlLock.RLock()
+	defer kvd.ttlLock.RUnlock()
+
+	kvd.hasExpiries.Store(true)
+
+	b, err := kvd.datastore.Batch(ctx)
+	if err != nil {
+		return err
+	}
+	if err = b.Put(ctx, ds.NewKey(key), v); err != nil {
+		return err
+	}
+	if err = b.Put(ctx, ttlKey(key), encodeExpiry(time.Now().Add(ttl))); err != nil {
+		return err
+	}
+	return b.Commit(ctx)
+}
+
+// expiry returns the key's expiry; ok is false for a key without one.
+func (kvd *kvDatabase) expiry(ctx context.Context, key string) (t time.Time, ok bool, err error) {
+	if !kvd.hasExpiries.Load() {
+		return time.Time{}, false, nil
+	}
+
+	data, err := kvd.datastore.Get(ctx, ttlKey(key))
+	if errors.Is(err, ds.ErrNotFound) {
+		return time.Time{}, false, nil
+	} else if err != nil {
+		return time.Time{}, false, err
+	}
+
+	t, err = decodeExpiry(data)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	return t, true, nil
+}
+
+func (kvd *kvDatabase) expired(ctx context.Context, key string) bool {
+	t, ok, err := kvd.expiry(ctx, key)
+	return err == nil && ok && !time.Now().Before(t)
+}
+
+// expiries reads the whole expiry index.
+func (kvd *kvDatabase) expiries(ctx context.Context) (map[string]time.Time, error) {
+	result, err := kvd.datastore.Query(ctx, query.Query{Prefix: TTLNamespace})
+	if err != nil {
+		return nil, err
+	}
+	defer result.Close()
+
+	ret := make(map[string]time.Time)
+	for entry := range result.Next() {
+		if entry.Error != nil {
+			return nil, entry.Error
+		}
+		t, err := decodeExpiry(entry.Value)
+		if err != nil {
+			continue
+		}
+		ret[strings.TrimPrefix(entry.Key, TTLNamespace)] = t
+	}
+	return ret, nil
+}
+
+// noteExpiry records that the database holds expiries once an entry of the
+// index is written, locally or by another replica.
+func (kvd *kvDatabase) noteExpiry(k ds.Key) {
+	if isTTLKey(k.String()) {
+		kvd.hasExpiries.Store(true)
+	}
+}
+
+// loadHasExpiries looks for any entry of the index in what was stored before
+// the database was opened.
+func (kvd *kvDatabase) loadHasExpiries(ctx context.Context) error {
+	result, err := kvd.datastore.Query(ctx, query.Query{Prefix: TTLNamespace, KeysOnly: true, Limit: 1})
+	if err != nil {
+		return err
+	}
+	defer result.Close()
+
+	for entry := range result.Next() {
+		if entry.Error != nil {
+			return entry.Error
+		}
+		kvd.hasExpiries.Store(true)
+	}
+	return nil
+}
+
+// liveFilter hides the expiry index and every key expired as of now. It looks
+// up the expiry of each key it is given, so it only reads what a query returns.
+type liveFilter struct {
+	ctx context.Context
+	kvd *kvDatabase
+	now time.Time
+}
+
+func (f *liveFilter) Filter(e query.Entry) bool {
+	if isTTLKey(e.Key) {
+		return false
+	}
+	t, ok, err := f.kvd.expiry(f.ctx, e.Key)
+	return err != nil || !ok || f.now.Before(t)
+}
+
+func (kvd *kvDatabase) newLiveFilter(ctx context.Context) *liveFilter {
+	return &liveFilter{ctx: ctx, kvd: kvd, now: time.Now()}
+}
+
+// expiryLoop sweeps expired keys until the database is closed.
+func (kvd *kvDatabase) expiryLoop() {
+	ticker := time.NewTicker(ExpirySweepInterval)
+	defer ticker.Stop()
+
+	var swept int
+	for {
+		select {
+		case <-kvd.closeCtx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		n, err := kvd.sweepExpired(kvd.closeCtx)
+		if err != nil {
+			slogger.Errorf("sweeping expired keys of %s failed with %s", kvd.path, err)
+		}
+
+		swept += n
+		if swept >= ExpiryCompactThreshold {
+			swept = 0
+			if _, err := kvd.datastore.Compact(kvd.closeCtx, ""); err != nil {
+				slogger.Errorf("compacting %s after expiry failed with %s", kvd.path, err)
+			}
+		}
+	}
+}
+
+// sweepExpired tombstones every expired key along with its expiry entry and
+// returns how many were removed.
+func (kvd *kvDatabase) sweepExpired(ctx context.Context) (int, error) {
+	if !kvd.hasExpiries.Load() {
+		return 0, nil
+	}
+
+	expiries, err := kvd.expiries(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var swept int
+	now := time.Now()
+	for key, t := range expiries {
+		if now.Before(t) {
+			continue
+		}
+
+		ok, err := kvd.sweepKey(ctx, key)
+		if err != nil {
+			return swept, err
+		}
+		if ok {
+			swept++
+		}
+	}
+
+	return swept, nil
+}
+
+// sweepKey deletes a key if it is still expired. Writers are held off so a
+// concurrent PutWithTTL/Put can't be tombstoned along with the old value.
+func (kvd *kvDatabase) sweepKey(ctx context.Context, key string) (bool, error) {
+	kvd.ttlLock.Lock()
+	defer kvd.ttlLock.Unlock()
+
+	if !kvd.expired(ctx, key) {
+		return false, nil
+	}
+
+	b, err := kvd.datastore.Batch(ctx)
+	if err != nil {
+		return false, err
+	}
+	if err = b.Delete(ctx, ds.NewKey(key)); err != nil {
+		return false, err
+	}
+	if err = b.Delete(ctx, ttlKey(key)); err != nil {
+		return false, err
+	}
+	return true, b.Commit(ctx)
+}
@@ -0,0 +1,206 @@
+package kvdb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	ds "github.com/ipfs/go-datastore"
+	logging "github.com/ipfs/go-log/v2"
+	"github.com/taubyte/tau/p2p/peer"
+)
+
+func newTTLTestDB(t *testing.T) *kvDatabase {
+	db, err := New(peer.Mock(t.Context())).New(logging.Logger("test"), t.Name(), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(db.Close)
+	return db.(*kvDatabase)
+}
+
+// eventually polls cond until it holds or the deadline passes.
+func eventually(t *testing.T, timeout time.Duration, cond func() bool) bool {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return true
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	return cond()
+}
+
+func TestKVDatabase_PutWithTTL(t *testing.T) {
+	ctx := context.Background()
+	db := newTTLTestDB(t)
+
+	if err := db.PutWithTTL(ctx, "/session/a", []byte("a"), 200*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Put(ctx, "/session/b", []byte("b")); err != nil {
+		t.Fatal(err)
+	}
+
+	if v, err := db.Get(ctx, "/session/a"); err != nil || string(v) != "a" {
+		t.Fatalf("got %q, %v before expiry", v, err)
+	}
+	keys, _ := db.List(ctx, "/session")
+	if len(keys) != 2 {
+		t.Fatalf("listed %v before expiry, want both keys and no expiry entries", keys)
+	}
+
+	time.Sleep(250 * time.Millisecond)
+
+	if _, err := db.Get(ctx, "/session/a"); !errors.Is(err, ds.ErrNotFound) {
+		t.Fatalf("expired key still readable: %v", err)
+	}
+	keys, _ = db.List(ctx, "")
+	if len(keys) != 1 || keys[0] != "/session/b" {
+		t.Fatalf("listed %v after expiry, want [/session/b]", keys)
+	}
+	keys, _ = db.ListRegEx(ctx, "", ".*")
+	if len(keys) != 1 {
+		t.Fatalf("regex listed %v after expiry, want [/session/b]", keys)
+	}
+}
+
+func TestKVDatabase_PutClearsTTL(t *testing.T) {
+	ctx := context.Background()
+	db := newTTLTestDB(t)
+
+	if err := db.PutWithTTL(ctx, "k", []byte("1"), 100*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Put(ctx, "k", []byte("2")); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(150 * time.Millisecond)
+
+	if v, err := db.Get(ctx, "k"); err != nil || string(v) != "2" {
+		t.Fatalf("plain put did not clear the expiry: %q, %v", v, err)
+	}
+}
+
+func TestKVDatabase_TTLReserved(t *testing.T) {
+	ctx := context.Background()
+	db := newTTLTestDB(t)
+
+	if err := db.Put(ctx, TTLNamespace+"/k", []byte("x")); !errors.Is(err, ErrReservedKey) {
+		t.Fatalf("put into the expiry namespace: %v", err)
+	}
+	if err := db.PutWithTTL(ctx, "k", []byte("x"), 0); err == nil {
+		t.Fatal("zero ttl accepted")
+	}
+}
+
+func TestKVDatabase_SweepExpired(t *testing.T) {
+	ctx := context.Background()
+	db := newTTLTestDB(t)
+
+	if err := db.PutWithTTL(ctx, "old", []byte("x"), time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.PutWithTTL(ctx, "fresh", []byte("y"), time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(10 * time.Millisecond)
+
+	n, err := db.sweepExpired(ctx)
+	if err != nil || n != 1 {
+		t.Fatalf("swept %d, %v; want 1", n, err)
+	}
+
+	if _, err := db.datastore.Get(ctx, ds.NewKey("old")); !errors.Is(err, ds.ErrNotFound) {
+		t.Fatalf("expired value not tombstoned: %v", err)
+	}
+	if _, err := db.datastore.Get(ctx, ttlKey("old")); !errors.Is(err, ds.ErrNotFound) {
+		t.Fatalf("expiry entry not tombstoned: %v", err)
+	}
+	if _, err := db.Get(ctx, "fresh"); err != nil {
+		t.Fatalf("live key swept: %v", err)
+	}
+}
+
+// TestKVDatabase_TTLConvergence checks that an expiry written on one replica
+// reaches the other with the value, and that sweeping on both converges on
+// the key being gone.
+func TestKVDatabase_TTLConvergence(t *testing.T) {
+	// The replicas share their options, so both get told of every expiry
+	// entry their hooks see, as a database is.
+	r0, r1 := &kvDatabase{}, &kvDatabase{}
+	opts := DefaultOptions()
+	opts.PutHook = func(k ds.Key, _ []byte) {
+		r0.noteExpiry(k)
+		r1.noteExpiry(k)
+	}
+
+	replicas, closeReplicas := makeNReplicas(t, 2, opts)
+	defer closeReplicas()
+
+	ctx := context.Background()
+	r0.datastore, r1.datastore = replicas[0], replicas[1]
+
+	if err := r0.PutWithTTL(ctx, "k", []byte("v"), time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	if !eventually(t, 10*time.Second, func() bool {
+		_, ok, _ := r1.expiry(ctx, "k")
+		return ok
+	}) {
+		t.Fatal("expiry did not replicate")
+	}
+
+	time.Sleep(time.Second)
+	for _, r := range []*kvDatabase{r0, r1} {
+		if _, err := r.Get(ctx, "k"); !errors.Is(err, ds.ErrNotFound) {
+			t.Fatalf("expired key readable: %v", err)
+		}
+		if _, err := r.sweepExpired(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if !eventually(t, 10*time.Second, func() bool {
+		for _, r := range replicas {
+			if ok, _ := r.Has(ctx, ds.NewKey("k")); ok {
+				return false
+			}
+			if ok, _ := r.Has(ctx, ttlKey("k")); ok {
+				return false
+			}
+		}
+		return true
+	}) {
+		t.Fatal("replicas did not converge on the swept key")
+	}
+}
+
+func TestKVDatabase_NoExpiryLookups(t *testing.T) {
+	ctx := context.Background()
+	db := newTTLTestDB(t)
+
+	if err := db.Put(ctx, "/plain", []byte("v")); err != nil {
+		t.Fatal(err)
+	}
+	if db.hasExpiries.Load() {
+		t.Fatal("a database without expiries looks them up")
+	}
+
+	if err := db.PutWithTTL(ctx, "/session", []byte("s"), time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if !db.hasExpiries.Load() {
+		t.Fatal("expiries are not looked up once a key has one")
+	}
+
+	keys, err := db.List(ctx, "")
+	if err != nil || len(keys) != 2 {
+		t.Fatalf("listed %v, %v", keys, err)
+	}
+}
@@ -2,6 +2,8 @@ package kvdb
 
 import (
 	"context"
+	"sync"
+	"sync/atomic"
 
 	"github.com/ipfs/go-cid"
 )
@@ -14,6 +16,12 @@ type kvDatabase struct {
 	datastore   *(Datastore)
 	closed      bool
 	path        string
+	// ttlLock holds writers off while the expiry sweeper deletes a key
+	ttlLock sync.RWMutex
+	// hasExpiries is set once any key has had an expiry; until then reads
+	// skip looking expiries up
+	hasExpiries atomic.Bool
+	watchers    watchers
 }
 
 type stats struct {
@@ -0,0 +1,89 @@
+package kvdb
+
+import (
+	"context"
+	"sync"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/taubyte/tau/core/kvdb"
+)
+
+// WatchBufferSize is how many events a watcher may fall behind by before
+// further events to it are dropped.
+var WatchBufferSize = 1024
+
+// Watch events come from the datastore's put/delete hooks, which fire both for
+// local writes and for deltas merged from other replicas, once the change is
+// the prevalent value. As with DeleteHook, a delete may be reported for a key a
+// concurrent put kept alive. Expired keys are reported as deleted when swept,
+// not when they expire.
+
+type watcher struct {
+	ctx    context.Context
+	prefix string
+	ch     chan kvdb.Event
+}
+
+type watchers struct {
+	lock sync.RWMutex
+	next uint64
+	set  map[uint64]*watcher
+}
+
+func (kvd *kvDatabase) Watch(ctx context.Context, prefix string) (<-chan kvdb.Event, error) {
+	if prefix != "" {
+		prefix = ds.NewKey(prefix).String()
+	}
+
+	w := &watcher{
+		ctx:    ctx,
+		prefix: prefix,
+		ch:     make(chan kvdb.Event, WatchBufferSize),
+	}
+
+	kvd.watchers.lock.Lock()
+	if kvd.watchers.set == nil {
+		kvd.watchers.set = make(map[uint64]*watcher)
+	}
+	id := kvd.watchers.next
+	kvd.watchers.next++
+	kvd.watchers.set[id] = w
+	kvd.watchers.lock.Unlock()
+
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-kvd.closeCtx.Done():
+		}
+
+		kvd.watchers.lock.Lock()
+		delete(kvd.watchers.set, id)
+		kvd.watchers.lock.Unlock()
+		close(w.ch)
+	}()
+
+	return w.ch, nil
+}
+
+// notify fans a change out to the matching watchers without blocking the
+// datastore.
+func (kvd *kvDatabase) notify(t kvdb.EventType, key ds.Key, value []byte) {
+	k := key.String()
+	if isTTLKey(k) {
+		return
+	}
+
+	kvd.watchers.lock.RLock()
+	defer kvd.watchers.lock.RUnlock()
+	for _, w := range kvd.watchers.set {
+		if !kvdb.UnderPrefix(k, w.prefix) {
+			continue
+		}
+
+		select {
+		case w.ch <- kvdb.Event{Type: t, Key: k, Value: value}:
+		default:
+			slogger.Warnf("watcher on %s%s fell behind, dropping %s of %s", kvd.path, w.prefix, t, k)
+		}
+	}
+}
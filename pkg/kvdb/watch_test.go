@@ -0,0 +1,87 @@
+package kvdb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/taubyte/tau/core/kvdb"
+)
+
+func nextEvent(t *testing.T, ch <-chan kvdb.Event) kvdb.Event {
+	t.Helper()
+	select {
+	case ev, ok := <-ch:
+		if !ok {
+			t.Fatal("watch closed")
+		}
+		return ev
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event")
+	}
+	return kvdb.Event{}
+}
+
+func TestKVDatabase_Watch(t *testing.T) {
+	db := newTTLTestDB(t)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	events, err := db.Watch(ctx, "users")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := db.Put(ctx, "/other/a", []byte("x")); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Put(ctx, "/usersx/a", []byte("x")); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.PutWithTTL(ctx, "/users/a", []byte("1"), time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Delete(ctx, "/users/a"); err != nil {
+		t.Fatal(err)
+	}
+
+	ev := nextEvent(t, events)
+	if ev.Type != kvdb.EventPut || ev.Key != "/users/a" || string(ev.Value) != "1" {
+		t.Fatalf("got %s %s=%q, want put /users/a=1", ev.Type, ev.Key, ev.Value)
+	}
+	ev = nextEvent(t, events)
+	if ev.Type != kvdb.EventDelete || ev.Key != "/users/a" {
+		t.Fatalf("got %s %s, want delete /users/a", ev.Type, ev.Key)
+	}
+
+	cancel()
+	for ev := range events {
+		t.Fatalf("unexpected %s of %s", ev.Type, ev.Key)
+	}
+}
+
+func TestKVDatabase_WatchMerged(t *testing.T) {
+	replicas, closeReplicas := makeNReplicas(t, 2, nil)
+	defer closeReplicas()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	r0 := &kvDatabase{datastore: replicas[0]}
+	r1 := &kvDatabase{datastore: replicas[1], closeCtx: ctx}
+	replicas[1].opts.PutHook = func(k ds.Key, v []byte) { r1.notify(kvdb.EventPut, k, v) }
+
+	events, err := r1.Watch(ctx, "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := r0.Put(ctx, "/k", []byte("v")); err != nil {
+		t.Fatal(err)
+	}
+
+	ev := nextEvent(t, events)
+	if ev.Type != kvdb.EventPut || ev.Key != "/k" || string(ev.Value) != "v" {
+		t.Fatalf("got %s %s=%q, want merged put /k=v", ev.Type, ev.Key, ev.Value)
+	}
+}
@@ -149,6 +149,12 @@ type SnapshotStore interface {
 	Open(id string) (*raft.SnapshotMeta, io.ReadCloser, error)
 }
 
+// QueueItem is an item waiting in a Queue.
+type QueueItem struct {
+	ID   string
+	Data []byte
+}
+
 // Queue is a replicated FIFO queue built on top of a Cluster's KV primitives.
 // Each item is an (id, data) pair. Push deduplicates by id — pushing an item
 // whose id already exists in the queue is a no-op. Pop removes and returns the
@@ -157,6 +163,10 @@ type SnapshotStore interface {
 type Queue interface {
 	Push(id string, data []byte, timeout time.Duration) error
 	Pop(timeout time.Duration) (id string, data []byte, err error)
+	// PopFunc removes and returns the item pick chooses among the waiting
+	// ones, given oldest first. A negative index takes nothing, and PopFunc
+	// returns ErrQueueEmpty as it would on an empty queue.
+	PopFunc(pick func(items []QueueItem) int, timeout time.Duration) (id string, data []byte, err error)
 	Peek() (id string, data []byte, ok bool)
 	Len() int
 	Close() error
@@ -159,6 +159,59 @@ func (q *queue) Pop(timeout time.Duration) (string, []byte, error) {
 	return entry.id, entry.data, nil
 }
 
+// PopFunc reads every waiting item, so it costs more than Pop on long queues.
+func (q *queue) PopFunc(pick func(items []QueueItem) int, timeout time.Duration) (string, []byte, error) {
+	if q.closed.Load() {
+		return "", nil, ErrShutdown
+	}
+	if timeout <= 0 || timeout > MaxApplyTimeout {
+		timeout = MaxApplyTimeout
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	keys := q.sortedItemKeys()
+	if len(keys) == 0 {
+		return "", nil, ErrQueueEmpty
+	}
+
+	itemKeys := make([]string, 0, len(keys))
+	items := make([]QueueItem, 0, len(keys))
+	for _, key := range keys {
+		raw, ok := q.cluster.Get(key)
+		if !ok {
+			continue
+		}
+
+		entry, err := decodeEntry(raw)
+		if err != nil {
+			return "", nil, fmt.Errorf("corrupt queue entry: %w", err)
+		}
+
+		itemKeys = append(itemKeys, key)
+		items = append(items, QueueItem{ID: entry.id, Data: entry.data})
+	}
+
+	idx := -1
+	if len(items) > 0 {
+		idx = pick(items)
+	}
+	if idx < 0 || idx >= len(items) {
+		return "", nil, ErrQueueEmpty
+	}
+
+	item := items[idx]
+	err := q.cluster.Batch([]BatchOp{
+		{Delete: &DeleteCommand{Key: itemKeys[idx]}},
+		{Delete: &DeleteCommand{Key: q.indexKey(item.ID)}},
+	}, timeout)
+	if err != nil {
+		return "", nil, err
+	}
+	return item.ID, item.Data, nil
+}
+
 func (q *queue) Peek() (string, []byte, bool) {
 	if q.closed.Load() {
 		return "", nil, false
@@ -403,3 +403,46 @@ func TestQueue_NilData(t *testing.T) {
 		t.Errorf("data = %x, want empty", data)
 	}
 }
+
+func TestQueue_PopFunc(t *testing.T) {
+	qu := NewQueue(NewMockCluster(), "test")
+	defer qu.Close()
+
+	for i, p := range []string{"low", "high", "low"} {
+		if err := qu.Push(fmt.Sprintf("id-%d", i), []byte(p), 5*time.Second); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	high := func(items []QueueItem) int {
+		for i, item := range items {
+			if string(item.Data) == "high" {
+				return i
+			}
+		}
+		return -1
+	}
+
+	id, data, err := qu.PopFunc(high, 5*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != "id-1" || string(data) != "high" {
+		t.Errorf("PopFunc() = (%q, %q); want (id-1, high)", id, data)
+	}
+
+	if _, _, err = qu.PopFunc(high, 5*time.Second); !errors.Is(err, ErrQueueEmpty) {
+		t.Errorf("PopFunc without a pick: got %v, want ErrQueueEmpty", err)
+	}
+	if qu.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", qu.Len())
+	}
+
+	// The item popped can be pushed again, and FIFO order holds for the rest.
+	if err = qu.Push("id-1", []byte("high"), 5*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if id, _, _ = qu.Pop(5 * time.Second); id != "id-0" {
+		t.Errorf("Pop() = %q, want id-0", id)
+	}
+}
@@ -8,6 +8,7 @@ const (
 	FunctionFolder    = "functions"
 	LibraryFolder     = "libraries"
 	MessagingFolder   = "messaging"
+	QueueFolder       = "queues"
 	ServiceFolder     = "services"
 	SmartOpsFolder    = "smartops"
 	StorageFolder     = "storages"
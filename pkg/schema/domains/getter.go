@@ -41,6 +41,26 @@ func (g getter) Key() string {
 	return basic.Get[string](g, "certificate", "key")
 }
 
+func (g getter) RateLimitIP() int {
+	return basic.Get[int](g, "rate-limit", "per-ip")
+}
+
+func (g getter) RateLimitKey() int {
+	return basic.Get[int](g, "rate-limit", "per-key")
+}
+
+func (g getter) RateLimitHeader() string {
+	return basic.Get[string](g, "rate-limit", "key-header")
+}
+
+func (g getter) RateLimitBurst() int {
+	return basic.Get[int](g, "rate-limit", "burst")
+}
+
+func (g getter) Records() []string {
+	return basic.Get[[]string](g, "dns", "records")
+}
+
 func (g getter) Type() string {
 	return basic.Get[string](g, "certificate", "type")
 }
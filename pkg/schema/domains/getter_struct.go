@@ -6,12 +6,17 @@ import (
 
 func (g getter) Struct() (dom *structureSpec.Domain, err error) {
 	dom = &structureSpec.Domain{
-		Id:          g.Id(),
-		Name:        g.Name(),
-		Description: g.Description(),
-		Tags:        g.Tags(),
-		Fqdn:        g.FQDN(),
-		CertType:    g.Type(),
+		Id:              g.Id(),
+		Name:            g.Name(),
+		Description:     g.Description(),
+		Tags:            g.Tags(),
+		Fqdn:            g.FQDN(),
+		CertType:        g.Type(),
+		RateLimitIP:     g.RateLimitIP(),
+		RateLimitKey:    g.RateLimitKey(),
+		RateLimitHeader: g.RateLimitHeader(),
+		RateLimitBurst:  g.RateLimitBurst(),
+		Records:         g.Records(),
 	}
 
 	if dom.CertType == "inline" {
@@ -33,6 +33,26 @@ func Type(value string) basic.Op {
 	return basic.SetChild("certificate", "type", value)
 }
 
+func RateLimitIP(value int) basic.Op {
+	return basic.SetChild("rate-limit", "per-ip", value)
+}
+
+func RateLimitKey(value int) basic.Op {
+	return basic.SetChild("rate-limit", "per-key", value)
+}
+
+func RateLimitHeader(value string) basic.Op {
+	return basic.SetChild("rate-limit", "key-header", value)
+}
+
+func RateLimitBurst(value int) basic.Op {
+	return basic.SetChild("rate-limit", "burst", value)
+}
+
+func Records(value []string) basic.Op {
+	return basic.SetChild("dns", "records", value)
+}
+
 func SmartOps(value []string) basic.Op {
 	return basic.Set("smartops", value)
 }
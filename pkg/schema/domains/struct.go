@@ -51,6 +51,26 @@ func (d *domain) SetWithStruct(sync bool, domain *structureSpec.Domain) error {
 			}
 			return nil
 		}},
+		{"RateLimitIP", true, func() error {
+			ops = append(ops, RateLimitIP(domain.RateLimitIP))
+			return nil
+		}},
+		{"RateLimitKey", true, func() error {
+			ops = append(ops, RateLimitKey(domain.RateLimitKey))
+			return nil
+		}},
+		{"RateLimitHeader", true, func() error {
+			ops = append(ops, RateLimitHeader(domain.RateLimitHeader))
+			return nil
+		}},
+		{"RateLimitBurst", true, func() error {
+			ops = append(ops, RateLimitBurst(domain.RateLimitBurst))
+			return nil
+		}},
+		{"Records", true, func() error {
+			ops = append(ops, Records(domain.Records))
+			return nil
+		}},
 		{"SmartOps", true, func() error {
 			ops = append(ops, SmartOps(domain.SmartOps))
 			return nil
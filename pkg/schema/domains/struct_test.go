@@ -62,3 +62,51 @@ func TestStructError(t *testing.T) {
 	err = dom.SetWithStruct(true, nil)
 	assert.ErrorContains(t, err, "nil pointer")
 }
+
+func TestStructRateLimit(t *testing.T) {
+	project, err := internal.NewProjectEmpty()
+	assert.NilError(t, err)
+
+	dom, err := project.Domain("test_domain1", "")
+	assert.NilError(t, err)
+
+	err = dom.SetWithStruct(true, &structureSpec.Domain{
+		Id:              "domain1ID",
+		Fqdn:            "hal.computers.com",
+		CertType:        "auto",
+		RateLimitIP:     10,
+		RateLimitKey:    100,
+		RateLimitHeader: "X-Api-Key",
+		RateLimitBurst:  20,
+	})
+	assert.NilError(t, err)
+
+	spec, err := dom.Get().Struct()
+	assert.NilError(t, err)
+	assert.Equal(t, spec.RateLimitIP, 10)
+	assert.Equal(t, spec.RateLimitKey, 100)
+	assert.Equal(t, spec.RateLimitHeader, "X-Api-Key")
+	assert.Equal(t, spec.RateLimitBurst, 20)
+}
+
+func TestStructRecords(t *testing.T) {
+	project, err := internal.NewProjectEmpty()
+	assert.NilError(t, err)
+
+	dom, err := project.Domain("test_domain1", "")
+	assert.NilError(t, err)
+
+	records := []string{"@ MX 10 mail.computers.com.", "_dmarc TXT \"v=DMARC1; p=none\""}
+	err = dom.SetWithStruct(true, &structureSpec.Domain{
+		Id:       "domain1ID",
+		Fqdn:     "hal.computers.com",
+		CertType: "auto",
+		Records:  records,
+	})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, dom.Get().Records(), records)
+
+	spec, err := dom.Get().Struct()
+	assert.NilError(t, err)
+	assert.DeepEqual(t, spec.Records, records)
+}
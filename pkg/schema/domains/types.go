@@ -26,4 +26,9 @@ type Getter interface {
 	Type() string
 	Cert() string
 	Key() string
+	RateLimitIP() int
+	RateLimitKey() int
+	RateLimitHeader() string
+	RateLimitBurst() int
+	Records() []string
 }
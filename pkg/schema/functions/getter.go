@@ -62,6 +62,22 @@ func (g getter) Command() string {
 	return basic.Get[string](g, "trigger", "command")
 }
 
+func (g getter) Schedule() string {
+	return basic.Get[string](g, "trigger", "schedule")
+}
+
+func (g getter) Queue() string {
+	return basic.Get[string](g, "trigger", "queue")
+}
+
+func (g getter) Database() string {
+	return basic.Get[string](g, "trigger", "database")
+}
+
+func (g getter) Prefix() string {
+	return basic.Get[string](g, "trigger", "prefix")
+}
+
 func (g getter) Method() string {
 	return basic.Get[string](g, "trigger", "method")
 }
@@ -90,10 +106,46 @@ func (g getter) Memory() string {
 	return basic.Get[string](g, "execution", "memory")
 }
 
+func (g getter) Fuel() int {
+	return basic.Get[int](g, "execution", "fuel")
+}
+
 func (g getter) Call() string {
 	return basic.Get[string](g, "execution", "call")
 }
 
+func (g getter) MinIdle() int {
+	return basic.Get[int](g, "instances", "min-idle")
+}
+
+func (g getter) MaxConcurrency() int {
+	return basic.Get[int](g, "instances", "max-concurrency")
+}
+
+func (g getter) IdleTimeout() string {
+	return basic.Get[string](g, "instances", "idle-timeout")
+}
+
+func (g getter) Snapshot() bool {
+	return basic.Get[bool](g, "instances", "snapshot")
+}
+
+func (g getter) RateLimitIP() int {
+	return basic.Get[int](g, "rate-limit", "per-ip")
+}
+
+func (g getter) RateLimitKey() int {
+	return basic.Get[int](g, "rate-limit", "per-key")
+}
+
+func (g getter) RateLimitHeader() string {
+	return basic.Get[string](g, "rate-limit", "key-header")
+}
+
+func (g getter) RateLimitBurst() int {
+	return basic.Get[int](g, "rate-limit", "burst")
+}
+
 func (g getter) SmartOps() []string {
 	return basic.Get[[]string](g, "smartops")
 }
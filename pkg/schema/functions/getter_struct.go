@@ -16,26 +16,42 @@ func (g getter) Struct() (fun *structureSpec.Function, err error) {
 		return nil, err
 	}
 
+	var idleTimeout uint64
+	if _idleTimeout := g.IdleTimeout(); _idleTimeout != "" {
+		if idleTimeout, err = common.StringToTime(_idleTimeout); err != nil {
+			return nil, err
+		}
+	}
+
 	_type := g.Type()
 	fun = &structureSpec.Function{
-		Id:          g.Id(),
-		Name:        g.Name(),
-		Description: g.Description(),
-		Tags:        g.Tags(),
-		Type:        _type,
-		Timeout:     timeout,
-		Memory:      memory,
-		Call:        g.Call(),
-		Source:      g.Source(),
-		SmartOps:    g.SmartOps(),
+		Id:             g.Id(),
+		Name:           g.Name(),
+		Description:    g.Description(),
+		Tags:           g.Tags(),
+		Type:           _type,
+		Timeout:        timeout,
+		Memory:         memory,
+		Fuel:           g.Fuel(),
+		MinIdle:        g.MinIdle(),
+		MaxConcurrency: g.MaxConcurrency(),
+		IdleTimeout:    idleTimeout,
+		Snapshot:       g.Snapshot(),
+		Call:           g.Call(),
+		Source:         g.Source(),
+		SmartOps:       g.SmartOps(),
 	}
 
 	switch _type {
-	case "http", "https":
+	case "http", "https", "websocket":
 		fun.Domains = g.Domains()
 		fun.Method = g.Method()
 		fun.Paths = g.Paths()
 		fun.Secure = _type == "https"
+		fun.RateLimitIP = g.RateLimitIP()
+		fun.RateLimitKey = g.RateLimitKey()
+		fun.RateLimitHeader = g.RateLimitHeader()
+		fun.RateLimitBurst = g.RateLimitBurst()
 	case "p2p":
 		fun.Protocol = g.Protocol()
 		fun.Command = g.Command()
@@ -43,6 +59,13 @@ func (g getter) Struct() (fun *structureSpec.Function, err error) {
 	case "pubsub":
 		fun.Channel = g.Channel()
 		fun.Local = g.Local()
+	case "cron":
+		fun.Schedule = g.Schedule()
+	case "queue":
+		fun.Queue = g.Queue()
+	case "database":
+		fun.Database = g.Database()
+		fun.Prefix = g.Prefix()
 	}
 
 	return
@@ -24,7 +24,7 @@ func (f *function) Prettify(p pretty.Prettier) map[string]interface{} {
 	}
 
 	switch _type {
-	case "http", "https":
+	case "http", "https", "websocket":
 		obj["Method"] = getter.Method()
 		obj["Paths"] = getter.Paths()
 		obj["Domains"] = getter.Domains()
@@ -32,6 +32,13 @@ func (f *function) Prettify(p pretty.Prettier) map[string]interface{} {
 		obj["Command"] = getter.Command()
 		obj["Local"] = getter.Local()
 		obj["Protocol"] = getter.Protocol()
+	case "cron":
+		obj["Schedule"] = getter.Schedule()
+	case "queue":
+		obj["Queue"] = getter.Queue()
+	case "database":
+		obj["Database"] = getter.Database()
+		obj["Prefix"] = getter.Prefix()
 	default:
 		obj["Channel"] = getter.Channel()
 		obj["Local"] = getter.Local()
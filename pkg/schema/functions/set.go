@@ -42,6 +42,22 @@ func Command(value string) basic.Op {
 	return basic.SetChild("trigger", "command", value)
 }
 
+func Schedule(value string) basic.Op {
+	return basic.SetChild("trigger", "schedule", value)
+}
+
+func Queue(value string) basic.Op {
+	return basic.SetChild("trigger", "queue", value)
+}
+
+func Database(value string) basic.Op {
+	return basic.SetChild("trigger", "database", value)
+}
+
+func Prefix(value string) basic.Op {
+	return basic.SetChild("trigger", "prefix", value)
+}
+
 func Method(value string) basic.Op {
 	return basic.SetChild("trigger", "method", value)
 }
@@ -66,10 +82,46 @@ func Memory(value string) basic.Op {
 	return basic.SetChild("execution", "memory", value)
 }
 
+func Fuel(value int) basic.Op {
+	return basic.SetChild("execution", "fuel", value)
+}
+
 func Call(value string) basic.Op {
 	return basic.SetChild("execution", "call", value)
 }
 
+func MinIdle(value int) basic.Op {
+	return basic.SetChild("instances", "min-idle", value)
+}
+
+func MaxConcurrency(value int) basic.Op {
+	return basic.SetChild("instances", "max-concurrency", value)
+}
+
+func IdleTimeout(value string) basic.Op {
+	return basic.SetChild("instances", "idle-timeout", value)
+}
+
+func Snapshot(value bool) basic.Op {
+	return basic.SetChild("instances", "snapshot", value)
+}
+
+func RateLimitIP(value int) basic.Op {
+	return basic.SetChild("rate-limit", "per-ip", value)
+}
+
+func RateLimitKey(value int) basic.Op {
+	return basic.SetChild("rate-limit", "per-key", value)
+}
+
+func RateLimitHeader(value string) basic.Op {
+	return basic.SetChild("rate-limit", "key-header", value)
+}
+
+func RateLimitBurst(value int) basic.Op {
+	return basic.SetChild("rate-limit", "burst", value)
+}
+
 func SmartOps(value []string) basic.Op {
 	return basic.Set("smartops", value)
 }
@@ -55,6 +55,34 @@ func (f *function) SetWithStruct(sync bool, function *structureSpec.Function) er
 			}
 			return nil
 		}},
+		{"Schedule", true, func() error {
+			switch function.Type {
+			case "cron":
+				ops = append(ops, Schedule(function.Schedule))
+			}
+			return nil
+		}},
+		{"Queue", true, func() error {
+			switch function.Type {
+			case "queue":
+				ops = append(ops, Queue(function.Queue))
+			}
+			return nil
+		}},
+		{"Database", true, func() error {
+			switch function.Type {
+			case "database":
+				ops = append(ops, Database(function.Database))
+			}
+			return nil
+		}},
+		{"Prefix", true, func() error {
+			switch function.Type {
+			case "database":
+				ops = append(ops, Prefix(function.Prefix))
+			}
+			return nil
+		}},
 		{"Method", true, func() error {
 			switch function.Type {
 			case "pubsub", "p2p":
@@ -79,6 +107,42 @@ func (f *function) SetWithStruct(sync bool, function *structureSpec.Function) er
 			ops = append(ops, Memory(common.UnitsToString(function.Memory)))
 			return nil
 		}},
+		{"Fuel", true, func() error {
+			ops = append(ops, Fuel(function.Fuel))
+			return nil
+		}},
+		{"MinIdle", true, func() error {
+			ops = append(ops, MinIdle(function.MinIdle))
+			return nil
+		}},
+		{"MaxConcurrency", true, func() error {
+			ops = append(ops, MaxConcurrency(function.MaxConcurrency))
+			return nil
+		}},
+		{"IdleTimeout", true, func() error {
+			ops = append(ops, IdleTimeout(common.TimeToString(function.IdleTimeout)))
+			return nil
+		}},
+		{"Snapshot", true, func() error {
+			ops = append(ops, Snapshot(function.Snapshot))
+			return nil
+		}},
+		{"RateLimitIP", true, func() error {
+			ops = append(ops, RateLimitIP(function.RateLimitIP))
+			return nil
+		}},
+		{"RateLimitKey", true, func() error {
+			ops = append(ops, RateLimitKey(function.RateLimitKey))
+			return nil
+		}},
+		{"RateLimitHeader", true, func() error {
+			ops = append(ops, RateLimitHeader(function.RateLimitHeader))
+			return nil
+		}},
+		{"RateLimitBurst", true, func() error {
+			ops = append(ops, RateLimitBurst(function.RateLimitBurst))
+			return nil
+		}},
 		{"Call", true, func() error {
 			ops = append(ops, Call(function.Call))
 			return nil
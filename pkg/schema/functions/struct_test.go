@@ -98,3 +98,161 @@ func TestStructError(t *testing.T) {
 	err = fun.SetWithStruct(true, nil)
 	assert.ErrorContains(t, err, "nil pointer")
 }
+
+func TestStructFuel(t *testing.T) {
+	project, err := internal.NewProjectEmpty()
+	assert.NilError(t, err)
+
+	fun, err := project.Function("test_function1", "")
+	assert.NilError(t, err)
+
+	err = fun.SetWithStruct(true, &structureSpec.Function{
+		Id:      "function1ID",
+		Name:    "test_function1",
+		Type:    "http",
+		Timeout: uint64(20 * time.Second),
+		Memory:  uint64(32 * units.MB),
+		Fuel:    1000000,
+		Call:    "ping1",
+		Source:  ".",
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, fun.Get().Fuel(), 1000000)
+
+	spec, err := fun.Get().Struct()
+	assert.NilError(t, err)
+	assert.Equal(t, spec.Fuel, 1000000)
+}
+
+func TestStructInstances(t *testing.T) {
+	project, err := internal.NewProjectEmpty()
+	assert.NilError(t, err)
+
+	fun, err := project.Function("test_function1", "")
+	assert.NilError(t, err)
+
+	err = fun.SetWithStruct(true, &structureSpec.Function{
+		Id:             "function1ID",
+		Name:           "test_function1",
+		Type:           "http",
+		Timeout:        uint64(20 * time.Second),
+		Memory:         uint64(32 * units.MB),
+		MinIdle:        2,
+		MaxConcurrency: 8,
+		IdleTimeout:    uint64(5 * time.Minute),
+		Snapshot:       true,
+		Call:           "ping1",
+		Source:         ".",
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, fun.Get().IdleTimeout(), "5m")
+
+	spec, err := fun.Get().Struct()
+	assert.NilError(t, err)
+	assert.Equal(t, spec.MinIdle, 2)
+	assert.Equal(t, spec.MaxConcurrency, 8)
+	assert.Equal(t, spec.IdleTimeout, uint64(5*time.Minute))
+	assert.Equal(t, spec.Snapshot, true)
+}
+
+func TestStructCronAndQueue(t *testing.T) {
+	project, err := internal.NewProjectEmpty()
+	assert.NilError(t, err)
+
+	fun, err := project.Function("test_function1", "")
+	assert.NilError(t, err)
+
+	err = fun.SetWithStruct(true, &structureSpec.Function{
+		Id:       "function1ID",
+		Name:     "test_function1",
+		Type:     "cron",
+		Schedule: "@hourly",
+		Timeout:  uint64(20 * time.Second),
+		Memory:   uint64(32 * units.MB),
+		Call:     "tick",
+		Source:   ".",
+	})
+	assert.NilError(t, err)
+
+	spec, err := fun.Get().Struct()
+	assert.NilError(t, err)
+	assert.Equal(t, spec.Schedule, "@hourly")
+
+	fun, err = project.Function("test_function2", "test_app1")
+	assert.NilError(t, err)
+
+	err = fun.SetWithStruct(true, &structureSpec.Function{
+		Id:      "function2ID",
+		Name:    "test_function2",
+		Type:    "queue",
+		Queue:   "test_queue1",
+		Timeout: uint64(20 * time.Second),
+		Memory:  uint64(32 * units.MB),
+		Call:    "render",
+		Source:  ".",
+	})
+	assert.NilError(t, err)
+
+	spec, err = fun.Get().Struct()
+	assert.NilError(t, err)
+	assert.Equal(t, spec.Queue, "test_queue1")
+	assert.Equal(t, spec.Schedule, "")
+}
+
+func TestStructDatabase(t *testing.T) {
+	project, err := internal.NewProjectEmpty()
+	assert.NilError(t, err)
+
+	fun, err := project.Function("test_function1", "test_app1")
+	assert.NilError(t, err)
+
+	err = fun.SetWithStruct(true, &structureSpec.Function{
+		Id:       "function1ID",
+		Name:     "test_function1",
+		Type:     "database",
+		Database: "test_database1",
+		Prefix:   "orders/",
+		Timeout:  uint64(20 * time.Second),
+		Memory:   uint64(32 * units.MB),
+		Call:     "changed",
+		Source:   ".",
+	})
+	assert.NilError(t, err)
+
+	spec, err := fun.Get().Struct()
+	assert.NilError(t, err)
+	assert.Equal(t, spec.Database, "test_database1")
+	assert.Equal(t, spec.Prefix, "orders/")
+	assert.Equal(t, spec.Queue, "")
+}
+
+func TestStructRateLimit(t *testing.T) {
+	project, err := internal.NewProjectEmpty()
+	assert.NilError(t, err)
+
+	fun, err := project.Function("test_function1", "")
+	assert.NilError(t, err)
+
+	err = fun.SetWithStruct(true, &structureSpec.Function{
+		Id:              "function1ID",
+		Name:            "test_function1",
+		Type:            "https",
+		Timeout:         uint64(20 * time.Second),
+		Memory:          uint64(32 * units.MB),
+		RateLimitIP:     10,
+		RateLimitKey:    100,
+		RateLimitHeader: "X-Api-Key",
+		RateLimitBurst:  20,
+		Call:            "ping1",
+		Source:          ".",
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, fun.Get().RateLimitHeader(), "X-Api-Key")
+
+	spec, err := fun.Get().Struct()
+	assert.NilError(t, err)
+	assert.Equal(t, spec.RateLimitIP, 10)
+	assert.Equal(t, spec.RateLimitKey, 100)
+	assert.Equal(t, spec.RateLimitHeader, "X-Api-Key")
+	assert.Equal(t, spec.RateLimitBurst, 20)
+}
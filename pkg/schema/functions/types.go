@@ -27,10 +27,23 @@ type Getter interface {
 	Local() bool
 	Command() string
 	Channel() string
+	Schedule() string
+	Queue() string
+	Database() string
+	Prefix() string
 	Source() string
 	Domains() []string
 	Timeout() string
 	Memory() string
+	Fuel() int
+	MinIdle() int
+	MaxConcurrency() int
+	IdleTimeout() string
+	Snapshot() bool
+	RateLimitIP() int
+	RateLimitKey() int
+	RateLimitHeader() string
+	RateLimitBurst() int
 	Call() string
 	Protocol() string
 }
@@ -23,6 +23,10 @@ func (g getter) Messaging(application string) (local []string, global []string)
 	return g.list(application, common.MessagingFolder)
 }
 
+func (g getter) Queues(application string) (local []string, global []string) {
+	return g.list(application, common.QueueFolder)
+}
+
 func (g getter) Databases(application string) (local []string, global []string) {
 	return g.list(application, common.DatabaseFolder)
 }
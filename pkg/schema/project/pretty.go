@@ -37,6 +37,13 @@ func (p *project) ResourceMethods() []pretty.PrettyResourceIface {
 			},
 			List: getter.Messaging,
 		},
+		{
+			Type: "Queues",
+			Get: func(name, application string) (pretty.PrettyResource, error) {
+				return p.Queue(name, application)
+			},
+			List: getter.Queues,
+		},
 		{
 			Type: "Databases",
 			Get: func(name, application string) (pretty.PrettyResource, error) {
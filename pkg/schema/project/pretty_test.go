@@ -90,6 +90,17 @@ func TestPrettyBasic(t *testing.T) {
 				"Regex":        false,
 			},
 		},
+		"Queues": map[string]any{
+			"test_queue1": map[string]any{
+				"Id":                "queue1ID",
+				"Name":              "test_queue1",
+				"Description":       "a queue of thumbnails to render",
+				"Tags":              []string{"queue_tag_1", "queue_tag_2"},
+				"VisibilityTimeout": "45s",
+				"MaxAttempts":       5,
+				"DeadLetter":        "test_queue_dead",
+			},
+		},
 		"Storages": map[string]any{
 			"test_storage1": map[string]any{
 				"Name":        "test_storage1",
@@ -7,6 +7,7 @@ import (
 	"github.com/taubyte/tau/pkg/schema/functions"
 	"github.com/taubyte/tau/pkg/schema/libraries"
 	"github.com/taubyte/tau/pkg/schema/messaging"
+	"github.com/taubyte/tau/pkg/schema/queues"
 	"github.com/taubyte/tau/pkg/schema/services"
 	"github.com/taubyte/tau/pkg/schema/smartops"
 	"github.com/taubyte/tau/pkg/schema/storages"
@@ -37,6 +38,10 @@ func (p *project) Messaging(name string, application string) (messaging.Messagin
 	return messaging.Open(p.seer, name, application)
 }
 
+func (p *project) Queue(name string, application string) (queues.Queue, error) {
+	return queues.Open(p.seer, name, application)
+}
+
 func (p *project) Service(name string, application string) (services.Service, error) {
 	return services.Open(p.seer, name, application)
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/taubyte/tau/pkg/schema/libraries"
 	"github.com/taubyte/tau/pkg/schema/messaging"
 	"github.com/taubyte/tau/pkg/schema/pretty"
+	"github.com/taubyte/tau/pkg/schema/queues"
 	"github.com/taubyte/tau/pkg/schema/services"
 	"github.com/taubyte/tau/pkg/schema/smartops"
 	"github.com/taubyte/tau/pkg/schema/storages"
@@ -29,6 +30,7 @@ type Project interface {
 	Function(name string, application string) (functions.Function, error)
 	Library(name string, application string) (libraries.Library, error)
 	Messaging(name string, application string) (messaging.Messaging, error)
+	Queue(name string, application string) (queues.Queue, error)
 	Service(name string, application string) (services.Service, error)
 	SmartOps(name string, application string) (smartops.SmartOps, error)
 	Storage(name string, application string) (storages.Storage, error)
@@ -57,6 +59,7 @@ type Getter interface {
 	Libraries(string) (local []string, global []string)
 	Websites(string) (local []string, global []string)
 	Messaging(string) (local []string, global []string)
+	Queues(string) (local []string, global []string)
 	Databases(string) (local []string, global []string)
 	Storages(string) (local []string, global []string)
 	Domains(string) (local []string, global []string)
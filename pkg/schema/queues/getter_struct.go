@@ -0,0 +1,26 @@
+package queues
+
+import (
+	"github.com/taubyte/tau/pkg/schema/common"
+	structureSpec "github.com/taubyte/tau/pkg/specs/structure"
+)
+
+func (g getter) Struct() (q *structureSpec.Queue, err error) {
+	var visibilityTimeout uint64
+	if _visibilityTimeout := g.VisibilityTimeout(); _visibilityTimeout != "" {
+		if visibilityTimeout, err = common.StringToTime(_visibilityTimeout); err != nil {
+			return nil, err
+		}
+	}
+
+	return &structureSpec.Queue{
+		Id:                g.Id(),
+		Name:              g.Name(),
+		Description:       g.Description(),
+		Tags:              g.Tags(),
+		VisibilityTimeout: visibilityTimeout,
+		MaxAttempts:       g.MaxAttempts(),
+		DeadLetter:        g.DeadLetter(),
+		SmartOps:          g.SmartOps(),
+	}, nil
+}
@@ -0,0 +1,29 @@
+package queues_test
+
+import (
+	"testing"
+	"time"
+
+	internal "github.com/taubyte/tau/pkg/schema/internal/test"
+	"gotest.tools/v3/assert"
+)
+
+func TestGetStruct(t *testing.T) {
+	project, err := internal.NewProjectReadOnly()
+	assert.NilError(t, err)
+
+	q, err := project.Queue("test_queue1", "")
+	assert.NilError(t, err)
+
+	_struct, err := q.Get().Struct()
+	assert.NilError(t, err)
+
+	assert.Equal(t, _struct.Id, "queue1ID")
+	assert.Equal(t, _struct.Name, "test_queue1")
+	assert.Equal(t, _struct.Description, "a queue of thumbnails to render")
+	assert.DeepEqual(t, _struct.Tags, []string{"queue_tag_1", "queue_tag_2"})
+	assert.Equal(t, _struct.VisibilityTimeout, uint64(45*time.Second))
+	assert.Equal(t, _struct.MaxAttempts, 5)
+	assert.Equal(t, _struct.DeadLetter, "test_queue_dead")
+	assert.Equal(t, len(_struct.SmartOps), 0)
+}
@@ -0,0 +1,25 @@
+// Code generated by tcc-gen; DO NOT EDIT.
+// Source: pkg/tcc/taubyte/v1/schema/definition.go
+
+package queues
+
+import (
+	"github.com/taubyte/tau/pkg/schema/basic"
+	seer "github.com/taubyte/tau/pkg/yaseer"
+)
+
+func Open(seer *seer.Seer, name, application string) (Queue, error) {
+	queue := &queue{
+		seer:        seer,
+		name:        name,
+		application: application,
+	}
+
+	var err error
+	queue.Resource, err = basic.New(seer, queue, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return queue, nil
+}
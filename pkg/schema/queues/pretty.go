@@ -0,0 +1,17 @@
+package queues
+
+import "github.com/taubyte/tau/pkg/schema/pretty"
+
+func (q *queue) Prettify(pretty.Prettier) map[string]interface{} {
+	getter := q.Get()
+
+	return map[string]interface{}{
+		"Id":                getter.Id(),
+		"Name":              getter.Name(),
+		"Description":       getter.Description(),
+		"Tags":              getter.Tags(),
+		"VisibilityTimeout": getter.VisibilityTimeout(),
+		"MaxAttempts":       getter.MaxAttempts(),
+		"DeadLetter":        getter.DeadLetter(),
+	}
+}
@@ -0,0 +1,50 @@
+package queues
+
+import (
+	"fmt"
+
+	"github.com/taubyte/tau/pkg/schema/basic"
+	"github.com/taubyte/tau/pkg/schema/common"
+	structureSpec "github.com/taubyte/tau/pkg/specs/structure"
+)
+
+func (q *queue) SetWithStruct(sync bool, queue *structureSpec.Queue) error {
+	ops := make([]basic.Op, 0)
+	var opMapper = common.Mapper{
+		{"Id", false, func() error {
+			ops = append(ops, Id(queue.Id))
+			return nil
+		}},
+		{"Description", false, func() error {
+			ops = append(ops, Description(queue.Description))
+			return nil
+		}},
+		{"Tags", false, func() error {
+			ops = append(ops, Tags(queue.Tags))
+			return nil
+		}},
+		{"VisibilityTimeout", true, func() error {
+			ops = append(ops, VisibilityTimeout(common.TimeToString(queue.VisibilityTimeout)))
+			return nil
+		}},
+		{"MaxAttempts", true, func() error {
+			ops = append(ops, MaxAttempts(queue.MaxAttempts))
+			return nil
+		}},
+		{"DeadLetter", true, func() error {
+			ops = append(ops, DeadLetter(queue.DeadLetter))
+			return nil
+		}},
+		{"SmartOps", true, func() error {
+			ops = append(ops, SmartOps(queue.SmartOps))
+			return nil
+		}},
+	}
+
+	err := opMapper.Run(queue)
+	if err != nil {
+		return fmt.Errorf("mapping values failed with: %s", err)
+	}
+
+	return q.Set(sync, ops...)
+}
@@ -0,0 +1,39 @@
+package queues_test
+
+import (
+	"testing"
+	"time"
+
+	internal "github.com/taubyte/tau/pkg/schema/internal/test"
+	structureSpec "github.com/taubyte/tau/pkg/specs/structure"
+	"gotest.tools/v3/assert"
+)
+
+func TestStruct(t *testing.T) {
+	project, err := internal.NewProjectEmpty()
+	assert.NilError(t, err)
+
+	q, err := project.Queue("test_queue2", "test_app1")
+	assert.NilError(t, err)
+
+	err = q.SetWithStruct(true, &structureSpec.Queue{
+		Id:                "queue2ID",
+		Description:       "a queue of emails to send",
+		Tags:              []string{"queue_tag_3"},
+		VisibilityTimeout: uint64(2 * time.Minute),
+		MaxAttempts:       3,
+	})
+	assert.NilError(t, err)
+
+	q, err = project.Queue("test_queue2", "test_app1")
+	assert.NilError(t, err)
+
+	getter := q.Get()
+	assert.Equal(t, getter.Id(), "queue2ID")
+	assert.Equal(t, getter.Application(), "test_app1")
+	assert.Equal(t, getter.Description(), "a queue of emails to send")
+	assert.DeepEqual(t, getter.Tags(), []string{"queue_tag_3"})
+	assert.Equal(t, getter.VisibilityTimeout(), "2m")
+	assert.Equal(t, getter.MaxAttempts(), 3)
+	assert.Equal(t, getter.DeadLetter(), "")
+}
@@ -0,0 +1,28 @@
+package queues
+
+import (
+	"github.com/taubyte/tau/pkg/schema/basic"
+	"github.com/taubyte/tau/pkg/schema/common"
+	structureSpec "github.com/taubyte/tau/pkg/specs/structure"
+	seer "github.com/taubyte/tau/pkg/yaseer"
+)
+
+type Queue interface {
+	Get() Getter
+	common.Resource[*structureSpec.Queue]
+}
+
+type queue struct {
+	*basic.Resource
+	seer        *seer.Seer
+	name        string
+	application string
+}
+
+type Getter interface {
+	basic.ResourceGetter[*structureSpec.Queue]
+	VisibilityTimeout() string
+	MaxAttempts() int
+	DeadLetter() string
+	SmartOps() []string
+}
@@ -0,0 +1,21 @@
+// Code generated by tcc-gen; DO NOT EDIT.
+// Source: pkg/tcc/taubyte/v1/schema/definition.go
+
+package queues
+
+import "github.com/taubyte/tau/pkg/schema/basic"
+
+func Yaml(name, application string, yamlData []byte) (Getter, error) {
+	resource, err := basic.Yaml(yamlData)
+	if err != nil {
+		return nil, err
+	}
+
+	return getter{
+		&queue{
+			Resource:    resource,
+			name:        name,
+			application: application,
+		},
+	}, nil
+}
@@ -49,6 +49,22 @@ func (g getter) Branch() string {
 	return basic.Get[string](g, "source", "branch")
 }
 
+func (g getter) DisableSPA() bool {
+	return basic.Get[bool](g, "serving", "disable-spa")
+}
+
+func (g getter) NotFound() string {
+	return basic.Get[string](g, "serving", "not-found")
+}
+
+func (g getter) Redirects() []string {
+	return basic.Get[[]string](g, "serving", "redirects")
+}
+
+func (g getter) Headers() []string {
+	return basic.Get[[]string](g, "serving", "headers")
+}
+
 func (g getter) SmartOps() []string {
 	return basic.Get[[]string](g, "smartops")
 }
@@ -17,6 +17,10 @@ func (g getter) Struct() (web *structureSpec.Website, err error) {
 		Provider:    provider,
 		RepoID:      repoId,
 		RepoName:    fullname,
+		DisableSPA:  g.DisableSPA(),
+		NotFound:    g.NotFound(),
+		Redirects:   g.Redirects(),
+		Headers:     g.Headers(),
 		SmartOps:    g.SmartOps(),
 	}
 
@@ -29,6 +29,22 @@ func Branch(value string) basic.Op {
 	return basic.SetChild("source", "branch", value)
 }
 
+func DisableSPA(value bool) basic.Op {
+	return basic.SetChild("serving", "disable-spa", value)
+}
+
+func NotFound(value string) basic.Op {
+	return basic.SetChild("serving", "not-found", value)
+}
+
+func Redirects(value []string) basic.Op {
+	return basic.SetChild("serving", "redirects", value)
+}
+
+func Headers(value []string) basic.Op {
+	return basic.SetChild("serving", "headers", value)
+}
+
 func SmartOps(value []string) basic.Op {
 	return basic.Set("smartops", value)
 }
@@ -44,6 +44,22 @@ func (w *website) SetWithStruct(sync bool, website *structureSpec.Website) error
 			}
 			return nil
 		}},
+		{"DisableSPA", true, func() error {
+			ops = append(ops, DisableSPA(website.DisableSPA))
+			return nil
+		}},
+		{"NotFound", true, func() error {
+			ops = append(ops, NotFound(website.NotFound))
+			return nil
+		}},
+		{"Redirects", true, func() error {
+			ops = append(ops, Redirects(website.Redirects))
+			return nil
+		}},
+		{"Headers", true, func() error {
+			ops = append(ops, Headers(website.Headers))
+			return nil
+		}},
 		{"SmartOps", true, func() error {
 			ops = append(ops, SmartOps(website.SmartOps))
 			return nil
@@ -73,3 +73,32 @@ func TestStructError(t *testing.T) {
 	})
 	assert.ErrorContains(t, err, "Git provider `unsupported` not supported")
 }
+
+func TestStructServing(t *testing.T) {
+	project, err := internal.NewProjectEmpty()
+	assert.NilError(t, err)
+
+	web, err := project.Website("test_website1", "")
+	assert.NilError(t, err)
+
+	err = web.SetWithStruct(true, &structureSpec.Website{
+		Id:         "website1ID",
+		Domains:    []string{"test_domain1"},
+		Paths:      []string{"/"},
+		Provider:   "github",
+		RepoID:     "111111111",
+		RepoName:   "taubyte-test/photo_booth",
+		DisableSPA: true,
+		NotFound:   "/404.html",
+		Redirects:  []string{"/old /new 301", "/api/* /index.html 200"},
+		Headers:    []string{"/assets/* Cache-Control: max-age=31536000"},
+	})
+	assert.NilError(t, err)
+
+	spec, err := web.Get().Struct()
+	assert.NilError(t, err)
+	assert.Equal(t, spec.DisableSPA, true)
+	assert.Equal(t, spec.NotFound, "/404.html")
+	assert.DeepEqual(t, spec.Redirects, []string{"/old /new 301", "/api/* /index.html 200"})
+	assert.DeepEqual(t, spec.Headers, []string{"/assets/* Cache-Control: max-age=31536000"})
+}
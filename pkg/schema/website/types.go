@@ -25,4 +25,8 @@ type Getter interface {
 	Paths() []string
 	Branch() string
 	Git() (provider, id, fullname string)
+	DisableSPA() bool
+	NotFound() string
+	Redirects() []string
+	Headers() []string
 }
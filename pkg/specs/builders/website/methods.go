@@ -14,3 +14,14 @@ func (d Dir) BuildZip() string {
 func (d Dir) SetWorkDir() ci.ContainerOption {
 	return ci.WorkDir("/" + builders.Source)
 }
+
+// Variant returns the path of the file holding the given encoding of name.
+func Variant(name, encoding string) string {
+	for _, enc := range Encodings {
+		if enc.Name == encoding {
+			return name + enc.Ext
+		}
+	}
+
+	return name
+}
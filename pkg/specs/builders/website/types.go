@@ -3,3 +3,23 @@ package website
 import "github.com/taubyte/tau/pkg/specs/builders"
 
 type Dir struct{ builders.Dir }
+
+// Manifest describes the files of a website build, keyed by their path from
+// the root of the build, starting with "/".
+type Manifest struct {
+	Files map[string]Asset `json:"files"`
+}
+
+type Asset struct {
+	// Hash is the hex sha256 of the file's content.
+	Hash string `json:"hash"`
+	Size int64  `json:"size"`
+
+	// Encodings are the variants stored next to the file, as its path with the
+	// extension of the encoding appended.
+	Encodings []string `json:"encodings,omitempty"`
+
+	// Immutable is set for fingerprinted files, whose name changes with their
+	// content, so they may be cached forever.
+	Immutable bool `json:"immutable,omitempty"`
+}
@@ -1,3 +1,22 @@
 package website
 
 const ZipFile = "build.zip"
+
+// ManifestFile lists the content hash and precompressed variants of every file
+// of a website build; see Manifest.
+const ManifestFile = ".assets.json"
+
+const (
+	Brotli = "br"
+	Gzip   = "gzip"
+)
+
+// Encodings are the precompressed variants a build may have, in order of
+// preference, with the extension of their file.
+var Encodings = []struct {
+	Name string
+	Ext  string
+}{
+	{Brotli, ".br"},
+	{Gzip, ".gz"},
+}
@@ -3,3 +3,17 @@ package functionSpec
 import "github.com/taubyte/tau/pkg/specs/common"
 
 const PathVariable common.PathVariable = "functions"
+
+// TypeWebSocket is the type of the functions handling the connections upgraded
+// to websockets on their paths.
+const TypeWebSocket = "websocket"
+
+// TypeCron is the type of the functions run on a schedule.
+const TypeCron = "cron"
+
+// TypeQueue is the type of the functions receiving the messages of a queue.
+const TypeQueue = "queue"
+
+// TypeDatabase is the type of the functions receiving the changes to the keys
+// of a database.
+const TypeDatabase = "database"
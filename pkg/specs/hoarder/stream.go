@@ -63,6 +63,12 @@ const (
 	BodyKeys   = "keys"
 	BodySize   = "size"
 	BodyCode   = "code"
+	// BodyTTL is an optional put expiry in milliseconds (see kvdb.PutWithTTL).
+	BodyTTL = "ttl"
+	// BodyExpiry is the absolute expiry, in unix nanoseconds, the serving
+	// hoarder stamps a BodyTTL put with before replicating it, so co-owners
+	// expire the key at the same time rather than ttl after their own write.
+	BodyExpiry = "expiry"
 	// BodyServedBy is the peer ID of the hoarder that served a kvdb request, so
 	// the client can pin to it for read-your-writes (esp. after a first-touch,
 	// where the client didn't pick the peer).
@@ -86,11 +92,27 @@ const (
 	// writes on the serving replica. The response carries BodyExisted=true when
 	// the key was already present and nothing was written.
 	KVPutNx = "putnx"
+	// KVCas writes the key only if its current value on the serving replica
+	// equals BodyExpected (absent BodyExpected means "key must be absent"),
+	// atomically against concurrent writes there. The response carries
+	// BodySwapped, and BodyValue with the current value when the swap failed.
+	KVCas = "cas"
+	// KVCheck is a batch-only op: the batch commits only if the key's current
+	// value equals BodyExpected (absent BodyExpected means "key must be
+	// absent"). A failed check rejects the whole batch with CodeConflict.
+	KVCheck = "check"
 )
 
 // BodyExisted is set true on a putnx response when the key already existed.
 const BodyExisted = "existed"
 
+// Compare-and-swap fields: BodyExpected is the value a cas/check op compares
+// against; BodySwapped is set true on a cas response when the write happened.
+const (
+	BodyExpected = "expected"
+	BodySwapped  = "swapped"
+)
+
 // Typed result codes carried in the response's BodyCode field for control-flow
 // signals the client routes on (never as free text). A response with no BodyCode
 // is a success.
@@ -98,4 +120,5 @@ const (
 	CodeNotReplica   = "not-replica"   // sent to the wrong node; BodyPeers has the live replicas
 	CodeNotFound     = "not-found"     // key absent
 	CodeOverCapacity = "over-capacity" // admission rejected the write
+	CodeConflict     = "conflict"      // a batch check failed; BodyKey names the key
 )
@@ -1,6 +1,10 @@
 package hoarder
 
-import "time"
+import (
+	"time"
+
+	multihash "github.com/taubyte/tau/utils/multihash"
+)
 
 // MembersTopic carries hoarder heartbeats: the membership signal that drives
 // deterministic (HRW) placement. Separate from the reconcile topic so a burst of
@@ -12,6 +16,22 @@ var MembersTopic = "/hoarder/v1.0/members"
 // because the periodic reconcile backstop re-checks everything.
 var ReconcileTopic = "/hoarder/v1.0/reconcile"
 
+// WatchTopicPrefix carries the changes written to a database/storage instance:
+// the hoarder serving a write publishes the cbor []kvdb.Event it applied on
+// WatchTopic(hash). Replication pushes are not re-published, so each served
+// write is announced once.
+var WatchTopicPrefix = "/hoarder/v1.0/watch/"
+
+// WatchTopic is the change topic of an instance.
+func WatchTopic(hash string) string {
+	return WatchTopicPrefix + hash
+}
+
+// InstanceHash is the placement identity of a resource instance.
+func InstanceHash(project, application, match string) string {
+	return multihash.Hash(project + application + match)
+}
+
 // Tunables — exported so tests can shrink them. Defaults are production values.
 var (
 	// HeartbeatInterval is how often each hoarder announces itself on MembersTopic.
@@ -0,0 +1,22 @@
+package queueSpec
+
+import (
+	"github.com/taubyte/tau/pkg/specs/common"
+	"github.com/taubyte/tau/pkg/specs/methods"
+)
+
+func Tns() *tnsHelper {
+	return &tnsHelper{}
+}
+
+func (t *tnsHelper) BasicPath(branch, commit, projectId, appId, queueId string) (*common.TnsPath, error) {
+	return methods.GetBasicTNSKey(branch, commit, projectId, appId, queueId, PathVariable)
+}
+
+func (t *tnsHelper) IndexValue(branch, projectId, appId, queueId string) (*common.TnsPath, error) {
+	return methods.IndexValue(branch, projectId, appId, queueId, PathVariable)
+}
+
+func (t *tnsHelper) IndexPath(projectId, appId, name string) *common.TnsPath {
+	return methods.IndexPath(projectId, appId, name)
+}
@@ -0,0 +1,3 @@
+package queueSpec
+
+type tnsHelper struct{}
@@ -0,0 +1,5 @@
+package queueSpec
+
+import "github.com/taubyte/tau/pkg/specs/common"
+
+const PathVariable common.PathVariable = "queues"
@@ -12,15 +12,20 @@ import (
 )
 
 type Domain struct {
-	Id          string
-	Name        string
-	Description string
-	Tags        []string
-	Fqdn        string
-	CertFile    string `mapstructure:"cert-file"`
-	KeyFile     string `mapstructure:"key-file"`
-	CertType    string `mapstructure:"cert-type"`
-	SmartOps    []string
+	Id              string
+	Name            string
+	Description     string
+	Tags            []string
+	Fqdn            string
+	CertFile        string `mapstructure:"cert-file"`
+	KeyFile         string `mapstructure:"key-file"`
+	CertType        string `mapstructure:"cert-type"`
+	RateLimitIP     int
+	RateLimitKey    int
+	RateLimitHeader string
+	RateLimitBurst  int
+	Records         []string
+	SmartOps        []string
 
 	Indexer
 }
@@ -12,24 +12,37 @@ import (
 )
 
 type Function struct {
-	Id          string
-	Name        string
-	Description string
-	Tags        []string
-	Type        string
-	Local       bool
-	Channel     string
-	Protocol    string `mapstructure:"service"`
-	Command     string
-	Method      string
-	Domains     []string
-	Paths       []string
-	Source      string
-	Timeout     uint64
-	Memory      uint64
-	Call        string
-	Secure      bool
-	SmartOps    []string
+	Id              string
+	Name            string
+	Description     string
+	Tags            []string
+	Type            string
+	Local           bool
+	Channel         string
+	Protocol        string `mapstructure:"service"`
+	Command         string
+	Schedule        string
+	Queue           string
+	Database        string
+	Prefix          string
+	Method          string
+	Domains         []string
+	Paths           []string
+	Source          string
+	Timeout         uint64
+	Memory          uint64
+	Fuel            int
+	Call            string
+	MinIdle         int
+	MaxConcurrency  int
+	IdleTimeout     uint64
+	Snapshot        bool
+	RateLimitIP     int
+	RateLimitKey    int
+	RateLimitHeader string
+	RateLimitBurst  int
+	Secure          bool
+	SmartOps        []string
 
 	Wasm
 }
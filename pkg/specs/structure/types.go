@@ -9,6 +9,7 @@ type Structure interface {
 		*Function |
 		*Library |
 		*Messaging |
+		*Queue |
 		*Service |
 		*SmartOp |
 		*Storage |
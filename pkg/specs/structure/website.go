@@ -22,6 +22,10 @@ type Website struct {
 	Provider    string
 	RepoID      string `mapstructure:"repository-id"`
 	RepoName    string `mapstructure:"repository-name"`
+	DisableSPA  bool
+	NotFound    string
+	Redirects   []string
+	Headers     []string
 	SmartOps    []string
 
 	Basic
@@ -416,6 +416,11 @@ export class PeerUsage extends Message<PeerUsage> {
    */
   availableDisk = protoInt64.zero;
 
+  /**
+   * @generated from field: map<string, double> custom_values = 26;
+   */
+  customValues: { [key: string]: number } = {};
+
   constructor(data?: PartialMessage<PeerUsage>) {
     super();
     proto3.util.initPartial(data, this);
@@ -449,6 +454,7 @@ export class PeerUsage extends Message<PeerUsage> {
     { no: 23, name: "free_disk", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
     { no: 24, name: "used_disk", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
     { no: 25, name: "available_disk", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 26, name: "custom_values", kind: "map", K: 9 /* ScalarType.STRING */, V: {kind: "scalar", T: 1 /* ScalarType.DOUBLE */} },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): PeerUsage {
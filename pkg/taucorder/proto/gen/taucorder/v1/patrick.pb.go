@@ -74,6 +74,103 @@ func (x *GetJobRequest) GetId() string {
 	return ""
 }
 
+type RequeueRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Node  *Node                  `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	// Jobs to requeue; every dead-lettered job when empty
+	Ids           []string `protobuf:"bytes,2,rep,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequeueRequest) Reset() {
+	*x = RequeueRequest{}
+	mi := &file_taucorder_v1_patrick_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequeueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequeueRequest) ProtoMessage() {}
+
+func (x *RequeueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taucorder_v1_patrick_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequeueRequest.ProtoReflect.Descriptor instead.
+func (*RequeueRequest) Descriptor() ([]byte, []int) {
+	return file_taucorder_v1_patrick_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RequeueRequest) GetNode() *Node {
+	if x != nil {
+		return x.Node
+	}
+	return nil
+}
+
+func (x *RequeueRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type RequeueResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequeueResponse) Reset() {
+	*x = RequeueResponse{}
+	mi := &file_taucorder_v1_patrick_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequeueResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequeueResponse) ProtoMessage() {}
+
+func (x *RequeueResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_taucorder_v1_patrick_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequeueResponse.ProtoReflect.Descriptor instead.
+func (*RequeueResponse) Descriptor() ([]byte, []int) {
+	return file_taucorder_v1_patrick_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RequeueResponse) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
 var File_taucorder_v1_patrick_proto protoreflect.FileDescriptor
 
 const file_taucorder_v1_patrick_proto_rawDesc = "" +
@@ -81,12 +178,19 @@ const file_taucorder_v1_patrick_proto_rawDesc = "" +
 	"\x1ataucorder/v1/patrick.proto\x12\ftaucorder.v1\x1a\x19taucorder/v1/common.proto\"G\n" +
 	"\rGetJobRequest\x12&\n" +
 	"\x04node\x18\x01 \x01(\v2\x12.taucorder.v1.NodeR\x04node\x12\x0e\n" +
-	"\x02id\x18\x02 \x01(\tR\x02id2\x82\x02\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\"J\n" +
+	"\x0eRequeueRequest\x12&\n" +
+	"\x04node\x18\x01 \x01(\v2\x12.taucorder.v1.NodeR\x04node\x12\x10\n" +
+	"\x03ids\x18\x02 \x03(\tR\x03ids\"#\n" +
+	"\x0fRequeueResponse\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids2\x82\x03\n" +
 	"\x0ePatrickService\x12/\n" +
 	"\x04List\x12\x12.taucorder.v1.Node\x1a\x11.taucorder.v1.Job0\x01\x125\n" +
 	"\x03Get\x12\x1b.taucorder.v1.GetJobRequest\x1a\x11.taucorder.v1.Job\x12J\n" +
 	"\x05State\x12#.taucorder.v1.ConsensusStateRequest\x1a\x1c.taucorder.v1.ConsensusState\x12<\n" +
-	"\x06States\x12\x12.taucorder.v1.Node\x1a\x1c.taucorder.v1.ConsensusState0\x01B\xba\x01\n" +
+	"\x06States\x12\x12.taucorder.v1.Node\x1a\x1c.taucorder.v1.ConsensusState0\x01\x126\n" +
+	"\vDeadLetters\x12\x12.taucorder.v1.Node\x1a\x11.taucorder.v1.Job0\x01\x12F\n" +
+	"\aRequeue\x12\x1c.taucorder.v1.RequeueRequest\x1a\x1d.taucorder.v1.RequeueResponseB\xba\x01\n" +
 	"\x10com.taucorder.v1B\fPatrickProtoP\x01ZGgithub.com/taubyte/tau/pkg/taucorder/proto/gen/taucorder/v1;taucorderv1\xa2\x02\x03TXX\xaa\x02\fTaucorder.V1\xca\x02\fTaucorder\\V1\xe2\x02\x18Taucorder\\V1\\GPBMetadata\xea\x02\rTaucorder::V1b\x06proto3"
 
 var (
@@ -101,29 +205,36 @@ func file_taucorder_v1_patrick_proto_rawDescGZIP() []byte {
 	return file_taucorder_v1_patrick_proto_rawDescData
 }
 
-var file_taucorder_v1_patrick_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_taucorder_v1_patrick_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_taucorder_v1_patrick_proto_goTypes = []any{
 	(*GetJobRequest)(nil),         // 0: taucorder.v1.GetJobRequest
-	(*Node)(nil),                  // 1: taucorder.v1.Node
-	(*ConsensusStateRequest)(nil), // 2: taucorder.v1.ConsensusStateRequest
-	(*Job)(nil),                   // 3: taucorder.v1.Job
-	(*ConsensusState)(nil),        // 4: taucorder.v1.ConsensusState
+	(*RequeueRequest)(nil),        // 1: taucorder.v1.RequeueRequest
+	(*RequeueResponse)(nil),       // 2: taucorder.v1.RequeueResponse
+	(*Node)(nil),                  // 3: taucorder.v1.Node
+	(*ConsensusStateRequest)(nil), // 4: taucorder.v1.ConsensusStateRequest
+	(*Job)(nil),                   // 5: taucorder.v1.Job
+	(*ConsensusState)(nil),        // 6: taucorder.v1.ConsensusState
 }
 var file_taucorder_v1_patrick_proto_depIdxs = []int32{
-	1, // 0: taucorder.v1.GetJobRequest.node:type_name -> taucorder.v1.Node
-	1, // 1: taucorder.v1.PatrickService.List:input_type -> taucorder.v1.Node
-	0, // 2: taucorder.v1.PatrickService.Get:input_type -> taucorder.v1.GetJobRequest
-	2, // 3: taucorder.v1.PatrickService.State:input_type -> taucorder.v1.ConsensusStateRequest
-	1, // 4: taucorder.v1.PatrickService.States:input_type -> taucorder.v1.Node
-	3, // 5: taucorder.v1.PatrickService.List:output_type -> taucorder.v1.Job
-	3, // 6: taucorder.v1.PatrickService.Get:output_type -> taucorder.v1.Job
-	4, // 7: taucorder.v1.PatrickService.State:output_type -> taucorder.v1.ConsensusState
-	4, // 8: taucorder.v1.PatrickService.States:output_type -> taucorder.v1.ConsensusState
-	5, // [5:9] is the sub-list for method output_type
-	1, // [1:5] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	3, // 0: taucorder.v1.GetJobRequest.node:type_name -> taucorder.v1.Node
+	3, // 1: taucorder.v1.RequeueRequest.node:type_name -> taucorder.v1.Node
+	3, // 2: taucorder.v1.PatrickService.List:input_type -> taucorder.v1.Node
+	0, // 3: taucorder.v1.PatrickService.Get:input_type -> taucorder.v1.GetJobRequest
+	4, // 4: taucorder.v1.PatrickService.State:input_type -> taucorder.v1.ConsensusStateRequest
+	3, // 5: taucorder.v1.PatrickService.States:input_type -> taucorder.v1.Node
+	3, // 6: taucorder.v1.PatrickService.DeadLetters:input_type -> taucorder.v1.Node
+	1, // 7: taucorder.v1.PatrickService.Requeue:input_type -> taucorder.v1.RequeueRequest
+	5, // 8: taucorder.v1.PatrickService.List:output_type -> taucorder.v1.Job
+	5, // 9: taucorder.v1.PatrickService.Get:output_type -> taucorder.v1.Job
+	6, // 10: taucorder.v1.PatrickService.State:output_type -> taucorder.v1.ConsensusState
+	6, // 11: taucorder.v1.PatrickService.States:output_type -> taucorder.v1.ConsensusState
+	5, // 12: taucorder.v1.PatrickService.DeadLetters:output_type -> taucorder.v1.Job
+	2, // 13: taucorder.v1.PatrickService.Requeue:output_type -> taucorder.v1.RequeueResponse
+	8, // [8:14] is the sub-list for method output_type
+	2, // [2:8] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_taucorder_v1_patrick_proto_init() }
@@ -138,7 +249,7 @@ func file_taucorder_v1_patrick_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_taucorder_v1_patrick_proto_rawDesc), len(file_taucorder_v1_patrick_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   1,
+			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -415,6 +415,7 @@ type PeerUsage struct {
 	FreeDisk      int64                  `protobuf:"varint,23,opt,name=free_disk,json=freeDisk,proto3" json:"free_disk,omitempty"`
 	UsedDisk      int64                  `protobuf:"varint,24,opt,name=used_disk,json=usedDisk,proto3" json:"used_disk,omitempty"`
 	AvailableDisk int64                  `protobuf:"varint,25,opt,name=available_disk,json=availableDisk,proto3" json:"available_disk,omitempty"`
+	CustomValues  map[string]float64     `protobuf:"bytes,26,rep,name=custom_values,json=customValues,proto3" json:"custom_values,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -624,6 +625,13 @@ func (x *PeerUsage) GetAvailableDisk() int64 {
 	return 0
 }
 
+func (x *PeerUsage) GetCustomValues() map[string]float64 {
+	if x != nil {
+		return x.CustomValues
+	}
+	return nil
+}
+
 var File_taucorder_v1_seer_proto protoreflect.FileDescriptor
 
 const file_taucorder_v1_seer_proto_rawDesc = "" +
@@ -649,7 +657,7 @@ const file_taucorder_v1_seer_proto_rawDesc = "" +
 	"\x03all\x18\x02 \x01(\bH\x00R\x03all\x120\n" +
 	"\x04area\x18\x03 \x01(\v2\x1a.taucorder.v1.LocationAreaH\x00R\x04area\x12+\n" +
 	"\x05peers\x18\x04 \x01(\v2\x13.taucorder.v1.PeersH\x00R\x05peersB\b\n" +
-	"\x06filter\"\xf1\x05\n" +
+	"\x06filter\"\x82\a\n" +
 	"\tPeerUsage\x12&\n" +
 	"\x04peer\x18\x01 \x01(\v2\x12.taucorder.v1.PeerR\x04peer\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
@@ -680,7 +688,11 @@ const file_taucorder_v1_seer_proto_rawDesc = "" +
 	"total_disk\x18\x16 \x01(\x03R\ttotalDisk\x12\x1b\n" +
 	"\tfree_disk\x18\x17 \x01(\x03R\bfreeDisk\x12\x1b\n" +
 	"\tused_disk\x18\x18 \x01(\x03R\busedDisk\x12%\n" +
-	"\x0eavailable_disk\x18\x19 \x01(\x03R\ravailableDisk2\xd7\x01\n" +
+	"\x0eavailable_disk\x18\x19 \x01(\x03R\ravailableDisk\x12N\n" +
+	"\rcustom_values\x18\x1a \x03(\v2).taucorder.v1.PeerUsage.CustomValuesEntryR\fcustomValues\x1a?\n" +
+	"\x11CustomValuesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x012\xd7\x01\n" +
 	"\vSeerService\x12<\n" +
 	"\x04List\x12\x1e.taucorder.v1.NodesListRequest\x1a\x12.taucorder.v1.Peer0\x01\x12A\n" +
 	"\x05Usage\x12\x1f.taucorder.v1.NodesUsageRequest\x1a\x17.taucorder.v1.PeerUsage\x12G\n" +
@@ -699,7 +711,7 @@ func file_taucorder_v1_seer_proto_rawDescGZIP() []byte {
 	return file_taucorder_v1_seer_proto_rawDescData
 }
 
-var file_taucorder_v1_seer_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_taucorder_v1_seer_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_taucorder_v1_seer_proto_goTypes = []any{
 	(*NodesListRequest)(nil),  // 0: taucorder.v1.NodesListRequest
 	(*NodesUsageRequest)(nil), // 1: taucorder.v1.NodesUsageRequest
@@ -708,31 +720,33 @@ var file_taucorder_v1_seer_proto_goTypes = []any{
 	(*PeerLocation)(nil),      // 4: taucorder.v1.PeerLocation
 	(*LocationRequest)(nil),   // 5: taucorder.v1.LocationRequest
 	(*PeerUsage)(nil),         // 6: taucorder.v1.PeerUsage
-	(*Node)(nil),              // 7: taucorder.v1.Node
-	(*Peer)(nil),              // 8: taucorder.v1.Peer
-	(*Peers)(nil),             // 9: taucorder.v1.Peers
+	nil,                       // 7: taucorder.v1.PeerUsage.CustomValuesEntry
+	(*Node)(nil),              // 8: taucorder.v1.Node
+	(*Peer)(nil),              // 9: taucorder.v1.Peer
+	(*Peers)(nil),             // 10: taucorder.v1.Peers
 }
 var file_taucorder_v1_seer_proto_depIdxs = []int32{
-	7,  // 0: taucorder.v1.NodesListRequest.node:type_name -> taucorder.v1.Node
-	7,  // 1: taucorder.v1.NodesUsageRequest.node:type_name -> taucorder.v1.Node
+	8,  // 0: taucorder.v1.NodesListRequest.node:type_name -> taucorder.v1.Node
+	8,  // 1: taucorder.v1.NodesUsageRequest.node:type_name -> taucorder.v1.Node
 	3,  // 2: taucorder.v1.LocationArea.location:type_name -> taucorder.v1.Location
-	8,  // 3: taucorder.v1.PeerLocation.peer:type_name -> taucorder.v1.Peer
+	9,  // 3: taucorder.v1.PeerLocation.peer:type_name -> taucorder.v1.Peer
 	3,  // 4: taucorder.v1.PeerLocation.location:type_name -> taucorder.v1.Location
-	7,  // 5: taucorder.v1.LocationRequest.node:type_name -> taucorder.v1.Node
+	8,  // 5: taucorder.v1.LocationRequest.node:type_name -> taucorder.v1.Node
 	2,  // 6: taucorder.v1.LocationRequest.area:type_name -> taucorder.v1.LocationArea
-	9,  // 7: taucorder.v1.LocationRequest.peers:type_name -> taucorder.v1.Peers
-	8,  // 8: taucorder.v1.PeerUsage.peer:type_name -> taucorder.v1.Peer
-	0,  // 9: taucorder.v1.SeerService.List:input_type -> taucorder.v1.NodesListRequest
-	1,  // 10: taucorder.v1.SeerService.Usage:input_type -> taucorder.v1.NodesUsageRequest
-	5,  // 11: taucorder.v1.SeerService.Location:input_type -> taucorder.v1.LocationRequest
-	8,  // 12: taucorder.v1.SeerService.List:output_type -> taucorder.v1.Peer
-	6,  // 13: taucorder.v1.SeerService.Usage:output_type -> taucorder.v1.PeerUsage
-	4,  // 14: taucorder.v1.SeerService.Location:output_type -> taucorder.v1.PeerLocation
-	12, // [12:15] is the sub-list for method output_type
-	9,  // [9:12] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	10, // 7: taucorder.v1.LocationRequest.peers:type_name -> taucorder.v1.Peers
+	9,  // 8: taucorder.v1.PeerUsage.peer:type_name -> taucorder.v1.Peer
+	7,  // 9: taucorder.v1.PeerUsage.custom_values:type_name -> taucorder.v1.PeerUsage.CustomValuesEntry
+	0,  // 10: taucorder.v1.SeerService.List:input_type -> taucorder.v1.NodesListRequest
+	1,  // 11: taucorder.v1.SeerService.Usage:input_type -> taucorder.v1.NodesUsageRequest
+	5,  // 12: taucorder.v1.SeerService.Location:input_type -> taucorder.v1.LocationRequest
+	9,  // 13: taucorder.v1.SeerService.List:output_type -> taucorder.v1.Peer
+	6,  // 14: taucorder.v1.SeerService.Usage:output_type -> taucorder.v1.PeerUsage
+	4,  // 15: taucorder.v1.SeerService.Location:output_type -> taucorder.v1.PeerLocation
+	13, // [13:16] is the sub-list for method output_type
+	10, // [10:13] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_taucorder_v1_seer_proto_init() }
@@ -752,7 +766,7 @@ func file_taucorder_v1_seer_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_taucorder_v1_seer_proto_rawDesc), len(file_taucorder_v1_seer_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -41,6 +41,11 @@ const (
 	PatrickServiceStateProcedure = "/taucorder.v1.PatrickService/State"
 	// PatrickServiceStatesProcedure is the fully-qualified name of the PatrickService's States RPC.
 	PatrickServiceStatesProcedure = "/taucorder.v1.PatrickService/States"
+	// PatrickServiceDeadLettersProcedure is the fully-qualified name of the PatrickService's
+	// DeadLetters RPC.
+	PatrickServiceDeadLettersProcedure = "/taucorder.v1.PatrickService/DeadLetters"
+	// PatrickServiceRequeueProcedure is the fully-qualified name of the PatrickService's Requeue RPC.
+	PatrickServiceRequeueProcedure = "/taucorder.v1.PatrickService/Requeue"
 )
 
 // PatrickServiceClient is a client for the taucorder.v1.PatrickService service.
@@ -49,6 +54,8 @@ type PatrickServiceClient interface {
 	Get(context.Context, *connect.Request[v1.GetJobRequest]) (*connect.Response[v1.Job], error)
 	State(context.Context, *connect.Request[v1.ConsensusStateRequest]) (*connect.Response[v1.ConsensusState], error)
 	States(context.Context, *connect.Request[v1.Node]) (*connect.ServerStreamForClient[v1.ConsensusState], error)
+	DeadLetters(context.Context, *connect.Request[v1.Node]) (*connect.ServerStreamForClient[v1.Job], error)
+	Requeue(context.Context, *connect.Request[v1.RequeueRequest]) (*connect.Response[v1.RequeueResponse], error)
 }
 
 // NewPatrickServiceClient constructs a client for the taucorder.v1.PatrickService service. By
@@ -86,15 +93,29 @@ func NewPatrickServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(patrickServiceMethods.ByName("States")),
 			connect.WithClientOptions(opts...),
 		),
+		deadLetters: connect.NewClient[v1.Node, v1.Job](
+			httpClient,
+			baseURL+PatrickServiceDeadLettersProcedure,
+			connect.WithSchema(patrickServiceMethods.ByName("DeadLetters")),
+			connect.WithClientOptions(opts...),
+		),
+		requeue: connect.NewClient[v1.RequeueRequest, v1.RequeueResponse](
+			httpClient,
+			baseURL+PatrickServiceRequeueProcedure,
+			connect.WithSchema(patrickServiceMethods.ByName("Requeue")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // patrickServiceClient implements PatrickServiceClient.
 type patrickServiceClient struct {
-	list   *connect.Client[v1.Node, v1.Job]
-	get    *connect.Client[v1.GetJobRequest, v1.Job]
-	state  *connect.Client[v1.ConsensusStateRequest, v1.ConsensusState]
-	states *connect.Client[v1.Node, v1.ConsensusState]
+	list        *connect.Client[v1.Node, v1.Job]
+	get         *connect.Client[v1.GetJobRequest, v1.Job]
+	state       *connect.Client[v1.ConsensusStateRequest, v1.ConsensusState]
+	states      *connect.Client[v1.Node, v1.ConsensusState]
+	deadLetters *connect.Client[v1.Node, v1.Job]
+	requeue     *connect.Client[v1.RequeueRequest, v1.RequeueResponse]
 }
 
 // List calls taucorder.v1.PatrickService.List.
@@ -117,12 +138,24 @@ func (c *patrickServiceClient) States(ctx context.Context, req *connect.Request[
 	return c.states.CallServerStream(ctx, req)
 }
 
+// DeadLetters calls taucorder.v1.PatrickService.DeadLetters.
+func (c *patrickServiceClient) DeadLetters(ctx context.Context, req *connect.Request[v1.Node]) (*connect.ServerStreamForClient[v1.Job], error) {
+	return c.deadLetters.CallServerStream(ctx, req)
+}
+
+// Requeue calls taucorder.v1.PatrickService.Requeue.
+func (c *patrickServiceClient) Requeue(ctx context.Context, req *connect.Request[v1.RequeueRequest]) (*connect.Response[v1.RequeueResponse], error) {
+	return c.requeue.CallUnary(ctx, req)
+}
+
 // PatrickServiceHandler is an implementation of the taucorder.v1.PatrickService service.
 type PatrickServiceHandler interface {
 	List(context.Context, *connect.Request[v1.Node], *connect.ServerStream[v1.Job]) error
 	Get(context.Context, *connect.Request[v1.GetJobRequest]) (*connect.Response[v1.Job], error)
 	State(context.Context, *connect.Request[v1.ConsensusStateRequest]) (*connect.Response[v1.ConsensusState], error)
 	States(context.Context, *connect.Request[v1.Node], *connect.ServerStream[v1.ConsensusState]) error
+	DeadLetters(context.Context, *connect.Request[v1.Node], *connect.ServerStream[v1.Job]) error
+	Requeue(context.Context, *connect.Request[v1.RequeueRequest]) (*connect.Response[v1.RequeueResponse], error)
 }
 
 // NewPatrickServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -156,6 +189,18 @@ func NewPatrickServiceHandler(svc PatrickServiceHandler, opts ...connect.Handler
 		connect.WithSchema(patrickServiceMethods.ByName("States")),
 		connect.WithHandlerOptions(opts...),
 	)
+	patrickServiceDeadLettersHandler := connect.NewServerStreamHandler(
+		PatrickServiceDeadLettersProcedure,
+		svc.DeadLetters,
+		connect.WithSchema(patrickServiceMethods.ByName("DeadLetters")),
+		connect.WithHandlerOptions(opts...),
+	)
+	patrickServiceRequeueHandler := connect.NewUnaryHandler(
+		PatrickServiceRequeueProcedure,
+		svc.Requeue,
+		connect.WithSchema(patrickServiceMethods.ByName("Requeue")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/taucorder.v1.PatrickService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case PatrickServiceListProcedure:
@@ -166,6 +211,10 @@ func NewPatrickServiceHandler(svc PatrickServiceHandler, opts ...connect.Handler
 			patrickServiceStateHandler.ServeHTTP(w, r)
 		case PatrickServiceStatesProcedure:
 			patrickServiceStatesHandler.ServeHTTP(w, r)
+		case PatrickServiceDeadLettersProcedure:
+			patrickServiceDeadLettersHandler.ServeHTTP(w, r)
+		case PatrickServiceRequeueProcedure:
+			patrickServiceRequeueHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -190,3 +239,11 @@ func (UnimplementedPatrickServiceHandler) State(context.Context, *connect.Reques
 func (UnimplementedPatrickServiceHandler) States(context.Context, *connect.Request[v1.Node], *connect.ServerStream[v1.ConsensusState]) error {
 	return connect.NewError(connect.CodeUnimplemented, errors.New("taucorder.v1.PatrickService.States is not implemented"))
 }
+
+func (UnimplementedPatrickServiceHandler) DeadLetters(context.Context, *connect.Request[v1.Node], *connect.ServerStream[v1.Job]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("taucorder.v1.PatrickService.DeadLetters is not implemented"))
+}
+
+func (UnimplementedPatrickServiceHandler) Requeue(context.Context, *connect.Request[v1.RequeueRequest]) (*connect.Response[v1.RequeueResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("taucorder.v1.PatrickService.Requeue is not implemented"))
+}
@@ -167,6 +167,43 @@ func (ps *patrickService) States(ctx context.Context, req *connect.Request[pb.No
 	return nil
 }
 
+func (ps *patrickService) DeadLetters(ctx context.Context, req *connect.Request[pb.Node], stream *connect.ServerStream[pb.Job]) error {
+	ni, err := ps.getNodeById(req.Msg.GetId())
+	if err != nil {
+		return err
+	}
+
+	jids, err := ni.patrickClient.DeadLetters()
+	if err != nil {
+		return fmt.Errorf("list dead letters failed: %w", err)
+	}
+
+	for _, jid := range jids {
+		err = stream.Send(&pb.Job{
+			Id: jid,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (ps *patrickService) Requeue(ctx context.Context, req *connect.Request[pb.RequeueRequest]) (*connect.Response[pb.RequeueResponse], error) {
+	ni, err := ps.getNode(req.Msg)
+	if err != nil {
+		return nil, err
+	}
+
+	jids, err := ni.patrickClient.Requeue(req.Msg.GetIds()...)
+	if err != nil {
+		return nil, fmt.Errorf("requeue failed: %w", err)
+	}
+
+	return connect.NewResponse(&pb.RequeueResponse{Ids: jids}), nil
+}
+
 // GetRepositoryURI returns the repository URI from a JobRepository, preferring uri and falling back to the deprecated ssh_url for backward compat.
 func GetRepositoryURI(repo *pb.JobRepository) string {
 	if repo == nil {
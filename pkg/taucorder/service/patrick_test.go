@@ -166,4 +166,24 @@ func TestPatrick_Dreaming(t *testing.T) {
 		assert.Equal(t, cstate.Msg.GetMember().GetId(), ninst.ID().String())
 		assert.Equal(t, len(cstate.Msg.GetCrdt().GetHeads()), 0) // should be empty
 	})
+
+	t.Run("DeadLettersAndRequeue", func(t *testing.T) {
+		fjob2.Status = patrick.JobStatusFailed
+
+		c := pbconnect.NewPatrickServiceClient(http.DefaultClient, "http://"+listener.Addr().String())
+		stream, err := c.DeadLetters(ctx, connect.NewRequest(ni.Msg))
+		assert.NilError(t, err)
+
+		dead := make([]string, 0)
+		for stream.Receive() {
+			dead = append(dead, stream.Msg().GetId())
+		}
+		assert.NilError(t, stream.Err())
+		assert.DeepEqual(t, dead, []string{fjob2.Id})
+
+		resp, err := c.Requeue(ctx, connect.NewRequest(&pb.RequeueRequest{Node: ni.Msg}))
+		assert.NilError(t, err)
+		assert.DeepEqual(t, resp.Msg.GetIds(), []string{fjob2.Id})
+		assert.Equal(t, fjob2.Status, patrick.JobStatusOpen)
+	})
 }
@@ -138,5 +138,6 @@ func (ss *seerService) Usage(ctx context.Context, req *connect.Request[pb.NodesU
 		FreeDisk:      int64(usage.FreeDisk),
 		UsedDisk:      int64(usage.UsedDisk),
 		AvailableDisk: int64(usage.AvailableDisk),
+		CustomValues:  usage.CustomValues,
 	}), nil
 }
@@ -15,7 +15,7 @@ import type { AsyncFs } from "../fs.js";
 
 export type DatabaseNetwork = "all" | "subnet" | "host";
 export type DomainCertType = "inline" | "auto";
-export type FunctionType = "http" | "https" | "pubsub" | "p2p";
+export type FunctionType = "http" | "https" | "pubsub" | "p2p" | "websocket" | "cron" | "queue" | "database";
 export type FunctionMethod = "GET" | "HEAD" | "POST" | "PUT" | "DELETE" | "CONNECT" | "OPTIONS" | "TRACE" | "PATCH";
 export type LibraryProvider = "github";
 export type StorageType = "object" | "streaming";
@@ -63,6 +63,12 @@ export class Session {
   messagingNames(app?: string): Promise<string[]> {
     return this.binding.list(this.handle, app ? ["applications", app, "messaging"] : ["messaging"]);
   }
+  queue(name: string, app?: string): QueueConfig {
+    return new QueueConfig(this, name, app);
+  }
+  queueNames(app?: string): Promise<string[]> {
+    return this.binding.list(this.handle, app ? ["applications", app, "queues"] : ["queues"]);
+  }
   service(name: string, app?: string): ServiceConfig {
     return new ServiceConfig(this, name, app);
   }
@@ -396,6 +402,56 @@ export class DomainConfig extends ResourceConfig {
   unsetCertType(): Promise<void> {
     return this.s.binding.delete(this.s.handle, this.res, ["certificate", "type"]);
   }
+
+  async rateLimitIP(): Promise<number | undefined> {
+    return (await this.s.binding.get(this.s.handle, this.res, ["rate-limit", "per-ip"])) as number | undefined;
+  }
+  setRateLimitIP(v: number): Promise<void> {
+    return this.s.binding.set(this.s.handle, this.res, ["rate-limit", "per-ip"], v);
+  }
+  unsetRateLimitIP(): Promise<void> {
+    return this.s.binding.delete(this.s.handle, this.res, ["rate-limit", "per-ip"]);
+  }
+
+  async rateLimitKey(): Promise<number | undefined> {
+    return (await this.s.binding.get(this.s.handle, this.res, ["rate-limit", "per-key"])) as number | undefined;
+  }
+  setRateLimitKey(v: number): Promise<void> {
+    return this.s.binding.set(this.s.handle, this.res, ["rate-limit", "per-key"], v);
+  }
+  unsetRateLimitKey(): Promise<void> {
+    return this.s.binding.delete(this.s.handle, this.res, ["rate-limit", "per-key"]);
+  }
+
+  async rateLimitHeader(): Promise<string | undefined> {
+    return (await this.s.binding.get(this.s.handle, this.res, ["rate-limit", "key-header"])) as string | undefined;
+  }
+  setRateLimitHeader(v: string): Promise<void> {
+    return this.s.binding.set(this.s.handle, this.res, ["rate-limit", "key-header"], v);
+  }
+  unsetRateLimitHeader(): Promise<void> {
+    return this.s.binding.delete(this.s.handle, this.res, ["rate-limit", "key-header"]);
+  }
+
+  async rateLimitBurst(): Promise<number | undefined> {
+    return (await this.s.binding.get(this.s.handle, this.res, ["rate-limit", "burst"])) as number | undefined;
+  }
+  setRateLimitBurst(v: number): Promise<void> {
+    return this.s.binding.set(this.s.handle, this.res, ["rate-limit", "burst"], v);
+  }
+  unsetRateLimitBurst(): Promise<void> {
+    return this.s.binding.delete(this.s.handle, this.res, ["rate-limit", "burst"]);
+  }
+
+  async records(): Promise<string[] | undefined> {
+    return (await this.s.binding.get(this.s.handle, this.res, ["dns", "records"])) as string[] | undefined;
+  }
+  setRecords(v: string[]): Promise<void> {
+    return this.s.binding.set(this.s.handle, this.res, ["dns", "records"], v);
+  }
+  unsetRecords(): Promise<void> {
+    return this.s.binding.delete(this.s.handle, this.res, ["dns", "records"]);
+  }
 }
 
 /** Typed accessors for a function's config. */
@@ -495,6 +551,46 @@ export class FunctionConfig extends ResourceConfig {
     return this.s.binding.delete(this.s.handle, this.res, ["trigger", "command"]);
   }
 
+  async schedule(): Promise<string | undefined> {
+    return (await this.s.binding.get(this.s.handle, this.res, ["trigger", "schedule"])) as string | undefined;
+  }
+  setSchedule(v: string): Promise<void> {
+    return this.s.binding.set(this.s.handle, this.res, ["trigger", "schedule"], v);
+  }
+  unsetSchedule(): Promise<void> {
+    return this.s.binding.delete(this.s.handle, this.res, ["trigger", "schedule"]);
+  }
+
+  async queue(): Promise<string | undefined> {
+    return (await this.s.binding.get(this.s.handle, this.res, ["trigger", "queue"])) as string | undefined;
+  }
+  setQueue(v: string): Promise<void> {
+    return this.s.binding.set(this.s.handle, this.res, ["trigger", "queue"], v);
+  }
+  unsetQueue(): Promise<void> {
+    return this.s.binding.delete(this.s.handle, this.res, ["trigger", "queue"]);
+  }
+
+  async database(): Promise<string | undefined> {
+    return (await this.s.binding.get(this.s.handle, this.res, ["trigger", "database"])) as string | undefined;
+  }
+  setDatabase(v: string): Promise<void> {
+    return this.s.binding.set(this.s.handle, this.res, ["trigger", "database"], v);
+  }
+  unsetDatabase(): Promise<void> {
+    return this.s.binding.delete(this.s.handle, this.res, ["trigger", "database"]);
+  }
+
+  async prefix(): Promise<string | undefined> {
+    return (await this.s.binding.get(this.s.handle, this.res, ["trigger", "prefix"])) as string | undefined;
+  }
+  setPrefix(v: string): Promise<void> {
+    return this.s.binding.set(this.s.handle, this.res, ["trigger", "prefix"], v);
+  }
+  unsetPrefix(): Promise<void> {
+    return this.s.binding.delete(this.s.handle, this.res, ["trigger", "prefix"]);
+  }
+
   async method(): Promise<FunctionMethod | undefined> {
     return (await this.s.binding.get(this.s.handle, this.res, ["trigger", "method"])) as FunctionMethod | undefined;
   }
@@ -556,6 +652,16 @@ export class FunctionConfig extends ResourceConfig {
     return this.s.binding.delete(this.s.handle, this.res, ["execution", "memory"]);
   }
 
+  async fuel(): Promise<number | undefined> {
+    return (await this.s.binding.get(this.s.handle, this.res, ["execution", "fuel"])) as number | undefined;
+  }
+  setFuel(v: number): Promise<void> {
+    return this.s.binding.set(this.s.handle, this.res, ["execution", "fuel"], v);
+  }
+  unsetFuel(): Promise<void> {
+    return this.s.binding.delete(this.s.handle, this.res, ["execution", "fuel"]);
+  }
+
   async call(): Promise<string | undefined> {
     return (await this.s.binding.get(this.s.handle, this.res, ["execution", "call"])) as string | undefined;
   }
@@ -565,6 +671,86 @@ export class FunctionConfig extends ResourceConfig {
   unsetCall(): Promise<void> {
     return this.s.binding.delete(this.s.handle, this.res, ["execution", "call"]);
   }
+
+  async minIdle(): Promise<number | undefined> {
+    return (await this.s.binding.get(this.s.handle, this.res, ["instances", "min-idle"])) as number | undefined;
+  }
+  setMinIdle(v: number): Promise<void> {
+    return this.s.binding.set(this.s.handle, this.res, ["instances", "min-idle"], v);
+  }
+  unsetMinIdle(): Promise<void> {
+    return this.s.binding.delete(this.s.handle, this.res, ["instances", "min-idle"]);
+  }
+
+  async maxConcurrency(): Promise<number | undefined> {
+    return (await this.s.binding.get(this.s.handle, this.res, ["instances", "max-concurrency"])) as number | undefined;
+  }
+  setMaxConcurrency(v: number): Promise<void> {
+    return this.s.binding.set(this.s.handle, this.res, ["instances", "max-concurrency"], v);
+  }
+  unsetMaxConcurrency(): Promise<void> {
+    return this.s.binding.delete(this.s.handle, this.res, ["instances", "max-concurrency"]);
+  }
+
+  async idleTimeout(): Promise<string | undefined> {
+    return (await this.s.binding.get(this.s.handle, this.res, ["instances", "idle-timeout"])) as string | undefined;
+  }
+  setIdleTimeout(v: string): Promise<void> {
+    return this.s.binding.set(this.s.handle, this.res, ["instances", "idle-timeout"], v);
+  }
+  unsetIdleTimeout(): Promise<void> {
+    return this.s.binding.delete(this.s.handle, this.res, ["instances", "idle-timeout"]);
+  }
+
+  async snapshot(): Promise<boolean | undefined> {
+    return (await this.s.binding.get(this.s.handle, this.res, ["instances", "snapshot"])) as boolean | undefined;
+  }
+  setSnapshot(v: boolean): Promise<void> {
+    return this.s.binding.set(this.s.handle, this.res, ["instances", "snapshot"], v);
+  }
+  unsetSnapshot(): Promise<void> {
+    return this.s.binding.delete(this.s.handle, this.res, ["instances", "snapshot"]);
+  }
+
+  async rateLimitIP(): Promise<number | undefined> {
+    return (await this.s.binding.get(this.s.handle, this.res, ["rate-limit", "per-ip"])) as number | undefined;
+  }
+  setRateLimitIP(v: number): Promise<void> {
+    return this.s.binding.set(this.s.handle, this.res, ["rate-limit", "per-ip"], v);
+  }
+  unsetRateLimitIP(): Promise<void> {
+    return this.s.binding.delete(this.s.handle, this.res, ["rate-limit", "per-ip"]);
+  }
+
+  async rateLimitKey(): Promise<number | undefined> {
+    return (await this.s.binding.get(this.s.handle, this.res, ["rate-limit", "per-key"])) as number | undefined;
+  }
+  setRateLimitKey(v: number): Promise<void> {
+    return this.s.binding.set(this.s.handle, this.res, ["rate-limit", "per-key"], v);
+  }
+  unsetRateLimitKey(): Promise<void> {
+    return this.s.binding.delete(this.s.handle, this.res, ["rate-limit", "per-key"]);
+  }
+
+  async rateLimitHeader(): Promise<string | undefined> {
+    return (await this.s.binding.get(this.s.handle, this.res, ["rate-limit", "key-header"])) as string | undefined;
+  }
+  setRateLimitHeader(v: string): Promise<void> {
+    return this.s.binding.set(this.s.handle, this.res, ["rate-limit", "key-header"], v);
+  }
+  unsetRateLimitHeader(): Promise<void> {
+    return this.s.binding.delete(this.s.handle, this.res, ["rate-limit", "key-header"]);
+  }
+
+  async rateLimitBurst(): Promise<number | undefined> {
+    return (await this.s.binding.get(this.s.handle, this.res, ["rate-limit", "burst"])) as number | undefined;
+  }
+  setRateLimitBurst(v: number): Promise<void> {
+    return this.s.binding.set(this.s.handle, this.res, ["rate-limit", "burst"], v);
+  }
+  unsetRateLimitBurst(): Promise<void> {
+    return this.s.binding.delete(this.s.handle, this.res, ["rate-limit", "burst"]);
+  }
 }
 
 /** Typed accessors for a library's config. */
@@ -759,6 +945,83 @@ export class MessagingConfig extends ResourceConfig {
   }
 }
 
+/** Typed accessors for a queue's config. */
+export class QueueConfig extends ResourceConfig {
+  constructor(s: Session, name: string, app?: string) {
+    super(s, app ? ["applications", app, "queues", name] : ["queues", name]);
+  }
+
+  async id(): Promise<string | undefined> {
+    return (await this.s.binding.get(this.s.handle, this.res, ["id"])) as string | undefined;
+  }
+  setId(v: string): Promise<void> {
+    return this.s.binding.set(this.s.handle, this.res, ["id"], v);
+  }
+  unsetId(): Promise<void> {
+    return this.s.binding.delete(this.s.handle, this.res, ["id"]);
+  }
+
+  async name(): Promise<string | undefined> {
+    return (await this.s.binding.get(this.s.handle, this.res, ["name"])) as string | undefined;
+  }
+  setName(v: string): Promise<void> {
+    return this.s.binding.set(this.s.handle, this.res, ["name"], v);
+  }
+  unsetName(): Promise<void> {
+    return this.s.binding.delete(this.s.handle, this.res, ["name"]);
+  }
+
+  async description(): Promise<string | undefined> {
+    return (await this.s.binding.get(this.s.handle, this.res, ["description"])) as string | undefined;
+  }
+  setDescription(v: string): Promise<void> {
+    return this.s.binding.set(this.s.handle, this.res, ["description"], v);
+  }
+  unsetDescription(): Promise<void> {
+    return this.s.binding.delete(this.s.handle, this.res, ["description"]);
+  }
+
+  async tags(): Promise<string[] | undefined> {
+    return (await this.s.binding.get(this.s.handle, this.res, ["tags"])) as string[] | undefined;
+  }
+  setTags(v: string[]): Promise<void> {
+    return this.s.binding.set(this.s.handle, this.res, ["tags"], v);
+  }
+  unsetTags(): Promise<void> {
+    return this.s.binding.delete(this.s.handle, this.res, ["tags"]);
+  }
+
+  async visibilityTimeout(): Promise<string | undefined> {
+    return (await this.s.binding.get(this.s.handle, this.res, ["delivery", "visibility-timeout"])) as string | undefined;
+  }
+  setVisibilityTimeout(v: string): Promise<void> {
+    return this.s.binding.set(this.s.handle, this.res, ["delivery", "visibility-timeout"], v);
+  }
+  unsetVisibilityTimeout(): Promise<void> {
+    return this.s.binding.delete(this.s.handle, this.res, ["delivery", "visibility-timeout"]);
+  }
+
+  async maxAttempts(): Promise<number | undefined> {
+    return (await this.s.binding.get(this.s.handle, this.res, ["delivery", "max-attempts"])) as number | undefined;
+  }
+  setMaxAttempts(v: number): Promise<void> {
+    return this.s.binding.set(this.s.handle, this.res, ["delivery", "max-attempts"], v);
+  }
+  unsetMaxAttempts(): Promise<void> {
+    return this.s.binding.delete(this.s.handle, this.res, ["delivery", "max-attempts"]);
+  }
+
+  async deadLetter(): Promise<string | undefined> {
+    return (await this.s.binding.get(this.s.handle, this.res, ["dead-letter", "queue"])) as string | undefined;
+  }
+  setDeadLetter(v: string): Promise<void> {
+    return this.s.binding.set(this.s.handle, this.res, ["dead-letter", "queue"], v);
+  }
+  unsetDeadLetter(): Promise<void> {
+    return this.s.binding.delete(this.s.handle, this.res, ["dead-letter", "queue"]);
+  }
+}
+
 /** Typed accessors for a service's config. */
 export class ServiceConfig extends ResourceConfig {
   constructor(s: Session, name: string, app?: string) {
@@ -1124,6 +1387,46 @@ export class WebsiteConfig extends ResourceConfig {
   unsetRepoName(): Promise<void> {
     return this.s.binding.delete(this.s.handle, this.res, ["source", "github", "fullname"]);
   }
+
+  async disableSPA(): Promise<boolean | undefined> {
+    return (await this.s.binding.get(this.s.handle, this.res, ["serving", "disable-spa"])) as boolean | undefined;
+  }
+  setDisableSPA(v: boolean): Promise<void> {
+    return this.s.binding.set(this.s.handle, this.res, ["serving", "disable-spa"], v);
+  }
+  unsetDisableSPA(): Promise<void> {
+    return this.s.binding.delete(this.s.handle, this.res, ["serving", "disable-spa"]);
+  }
+
+  async notFound(): Promise<string | undefined> {
+    return (await this.s.binding.get(this.s.handle, this.res, ["serving", "not-found"])) as string | undefined;
+  }
+  setNotFound(v: string): Promise<void> {
+    return this.s.binding.set(this.s.handle, this.res, ["serving", "not-found"], v);
+  }
+  unsetNotFound(): Promise<void> {
+    return this.s.binding.delete(this.s.handle, this.res, ["serving", "not-found"]);
+  }
+
+  async redirects(): Promise<string[] | undefined> {
+    return (await this.s.binding.get(this.s.handle, this.res, ["serving", "redirects"])) as string[] | undefined;
+  }
+  setRedirects(v: string[]): Promise<void> {
+    return this.s.binding.set(this.s.handle, this.res, ["serving", "redirects"], v);
+  }
+  unsetRedirects(): Promise<void> {
+    return this.s.binding.delete(this.s.handle, this.res, ["serving", "redirects"]);
+  }
+
+  async headers(): Promise<string[] | undefined> {
+    return (await this.s.binding.get(this.s.handle, this.res, ["serving", "headers"])) as string[] | undefined;
+  }
+  setHeaders(v: string[]): Promise<void> {
+    return this.s.binding.set(this.s.handle, this.res, ["serving", "headers"], v);
+  }
+  unsetHeaders(): Promise<void> {
+    return this.s.binding.delete(this.s.handle, this.res, ["serving", "headers"]);
+  }
 }
 
 /** Typed accessors for a application's config. */
@@ -1256,6 +1559,11 @@ export interface Domain {
   "cert-file"?: string;
   "key-file"?: string;
   "cert-type"?: DomainCertType;
+  ratelimitip?: number;
+  ratelimitkey?: number;
+  ratelimitheader?: string;
+  ratelimitburst?: number;
+  records?: string[];
   smartops?: string[];
 }
 
@@ -1270,13 +1578,26 @@ export interface Function {
   channel?: string;
   service?: string;
   command?: string;
+  schedule?: string;
+  queue?: string;
+  database?: string;
+  prefix?: string;
   method?: FunctionMethod;
   domains?: string[];
   paths?: string[];
   source?: string;
   timeout?: number;
   memory?: number;
+  fuel?: number;
   call?: string;
+  minidle?: number;
+  maxconcurrency?: number;
+  idletimeout?: number;
+  snapshot?: boolean;
+  ratelimitip?: number;
+  ratelimitkey?: number;
+  ratelimitheader?: string;
+  ratelimitburst?: number;
   secure?: boolean;
   smartops?: string[];
 }
@@ -1309,6 +1630,18 @@ export interface Messaging {
   smartops?: string[];
 }
 
+/** Queue as decoded from the compiled config object. */
+export interface Queue {
+  id?: string;
+  name?: string;
+  description?: string;
+  tags?: string[];
+  visibilitytimeout?: number;
+  maxattempts?: number;
+  deadletter?: string;
+  smartops?: string[];
+}
+
 /** Service as decoded from the compiled config object. */
 export interface Service {
   id?: string;
@@ -1360,5 +1693,9 @@ export interface Website {
   provider?: string;
   "repository-id"?: string;
   "repository-name"?: string;
+  disablespa?: boolean;
+  notfound?: string;
+  redirects?: string[];
+  headers?: string[];
   smartops?: string[];
 }
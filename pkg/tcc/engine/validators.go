@@ -9,6 +9,8 @@ import (
 	"strings"
 
 	"github.com/ipfs/go-cid"
+	"github.com/taubyte/tau/utils/cron"
+	"github.com/taubyte/tau/utils/records"
 )
 
 // NextValidation represents a validation that needs to be performed externally.
@@ -175,6 +177,32 @@ func IsHttpMethod() Option {
 	}
 }
 
+// IsCronSchedule validates a cron expression (see cron.Parse).
+func IsCronSchedule() Option {
+	return func(a *Attribute) {
+		Validator(func(s string) error {
+			if _, err := cron.Parse(s); err != nil {
+				return fmt.Errorf("invalid cron schedule: %w", err)
+			}
+			return nil
+		})(a)
+	}
+}
+
+// IsDnsRecords validates a list of zone file records (see records.Validate).
+func IsDnsRecords() Option {
+	return func(a *Attribute) {
+		Validator(func(rs []string) error {
+			for _, r := range rs {
+				if err := records.Validate(r); err != nil {
+					return fmt.Errorf("invalid dns record `%s`: %w", r, err)
+				}
+			}
+			return nil
+		})(a)
+	}
+}
+
 // MinInt returns an Option that validates an Int attribute is >= min.
 func MinInt(min int) Option {
 	return func(a *Attribute) {
@@ -227,3 +227,29 @@ func TestIsDomainName(t *testing.T) {
 		}
 	}
 }
+
+func TestIsDnsRecords(t *testing.T) {
+	attr := &Attribute{}
+	option := IsDnsRecords()
+	option(attr)
+
+	tests := []struct {
+		val     []string
+		isValid bool
+	}{
+		{[]string{"@ MX 10 mail.example.com.", "_dmarc TXT \"v=DMARC1; p=none\""}, true},
+		{[]string{}, true},
+		{[]string{"www CNAME example.net.", "@ A 1.2.3.4"}, false},
+		{[]string{"not a record"}, false},
+	}
+
+	for _, test := range tests {
+		err := attr.Validator(test.val)
+		if test.isValid && err != nil {
+			t.Errorf("Expected records %v to be valid, but got error: %v", test.val, err)
+		}
+		if !test.isValid && err == nil {
+			t.Errorf("Expected records %v to be invalid, but got no error", test.val)
+		}
+	}
+}
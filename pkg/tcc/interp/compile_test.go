@@ -3,6 +3,7 @@ package interp_test
 import (
 	"context"
 	"regexp"
+	"strings"
 	"testing"
 
 	schema "github.com/taubyte/tau/pkg/tcc/taubyte/v1/schema"
@@ -52,6 +53,10 @@ func TestCompile(t *testing.T) {
 	newObj := obj.Flat()["object"].(map[string]interface{})
 	oldObj := oldCompiler.Object()
 
+	// older compiler predates cron functions, compare everything else
+	delete(newObj["functions"].(map[string]interface{}), "QmYb8cpTBs4uE4TmPzVGn7YNbgF7drwPnZq8dXJuCVyR3c")
+	delete(oldObj["functions"].(map[string]interface{}), "QmYb8cpTBs4uE4TmPzVGn7YNbgF7drwPnZq8dXJuCVyR3c")
+
 	assert.Assert(t, cmp.Equal(newObj, oldObj), cmp.Diff(oldObj, newObj))
 
 	indexes := obj.Flat()["indexes"].(map[string]interface{})
@@ -60,6 +65,14 @@ func TestCompile(t *testing.T) {
 	// delete it to make the deep equal works
 	delete(indexes, "p2p/pubsub/QmUgRE95oaisf5cK1DNaKizPQS7mqtd3zZ68wuUEKfoWoB")
 
+	// older compiler only reserved the fqdn of a domain, it now links to it
+	assert.DeepEqual(t, indexes["domains/com/computers/hal/links"], []string{"branches/master/projects/QmTz6X9hTn18fpKxrnbE3BvmkZHy3r1mRyHzfXK3gVZLxR/domains/QmUcVJtgGZYkqFr2J9t2jV2fJJWZBvD7FJ6RyXzJY2kAj1"})
+	for key := range indexes {
+		if strings.HasPrefix(key, "domains/") && strings.HasSuffix(key, "/links") {
+			indexes[key] = nil
+		}
+	}
+
 	assert.Assert(t, cmp.Equal(indexes, oldCompiler.Indexes()), cmp.Diff(oldCompiler.Indexes(), indexes))
 
 	// Verify validations are returned
@@ -26,6 +26,7 @@ var decompileOrder = []string{
 	"libraries",
 	"messaging",
 	"services",
+	"queues",
 }
 
 // NewDecompileDriver returns the mechanical inverse of CompileDriver+ResolveRefs,
@@ -305,7 +306,7 @@ func applyInstanceInverse(sel object.Selector[object.Refrence], iter *engine.Nod
 	}
 
 	// 6. id->name key swap (inverse of RenameById): every keyed resource — i.e.
-	//    every DefineIter carrying an `id` attr — which is all nine here.
+	//    every DefineIter carrying an `id` attr — which is all ten here.
 	if hasAttr(iter, "id") {
 		if _, err := utils.RenameByName(sel); err != nil {
 			return fmt.Errorf("renaming by name failed with %w", err)
@@ -21,8 +21,7 @@ type IndexCtx struct {
 	Branch, Project, App, Id, Name string
 	// IndexValue is the resource's IndexValue(branch, proj, app, id) — the value
 	// appended into every link bucket an annotation names. nil is never produced for
-	// the eight indexed groups (all declare HasIndex); groups whose footprint does
-	// not read it (domains) simply ignore it.
+	// the indexed groups (all declare HasIndex).
 	IndexValue *common.TnsPath
 	// Obj is the compiled instance (wire keys, refs already resolved).
 	Obj object.Object[object.Refrence]
@@ -79,12 +78,13 @@ func IndexByScope(cap engine.Capability) engine.NodeOption {
 	return engine.GroupAnnotate("indexByScope", cap)
 }
 
-// IndexPlaceholder declares a nil-placeholder link keyed by keyField's value: the
-// driver reverses the fqdn at keyField into the group's basic path and, only when
-// the key's Links() bucket is currently absent, Set()s it to nil. Replaces
-// domains' basic-path nil IndexSet closure.
-func IndexPlaceholder(keyField string) engine.NodeOption {
-	return engine.GroupAnnotate("indexPlaceholder", keyField)
+// IndexByFqdn declares the fqdn link a domain contributes: the driver reverses the
+// fqdn at keyField into the group's basic path and appends this resource's
+// IndexValue at its Links() bucket, so the domain's config can be reached from the
+// fqdn alone (seer serves its records by it). Replaces domains' basic-path nil
+// IndexSet closure, which only reserved the bucket.
+func IndexByFqdn(keyField string) engine.NodeOption {
+	return engine.GroupAnnotate("indexByFqdn", keyField)
 }
 
 // IndexByName declares the mechanical "keyed by Name" index link most resources
@@ -188,10 +188,9 @@ func indexByScope(ic *IndexCtx, index object.Object[object.Refrence], cap engine
 	return nil
 }
 
-// indexPlaceholder reproduces the old domain nil-placeholder IndexSet: it reverses
-// the fqdn at keyField into the group's basic path and, only when the key's Links()
-// bucket is currently absent, Set()s it to nil.
-func indexPlaceholder(ic *IndexCtx, index object.Object[object.Refrence], groupKey, keyField string) error {
+// indexByFqdn reverses the fqdn at keyField into the group's basic path and
+// appends the instance's IndexValue at its Links() bucket.
+func indexByFqdn(ic *IndexCtx, index object.Object[object.Refrence], groupKey, keyField string) error {
 	fqdn, err := ic.Obj.GetString(keyField)
 	if err != nil {
 		return fmt.Errorf("domain %s is not a string: %w", keyField, err)
@@ -200,10 +199,7 @@ func indexPlaceholder(ic *IndexCtx, index object.Object[object.Refrence], groupK
 	if err != nil {
 		return fmt.Errorf("getting basic path for domain failed with %w", err)
 	}
-	key := p.Versioning().Links().String()
-	if index.Get(key) == nil {
-		index.Set(key, nil)
-	}
+	appendLink(index, p.Versioning().Links().String(), ic.IndexValue.String())
 	return nil
 }
 
@@ -220,6 +216,7 @@ var indexOrder = []string{
 	"messaging",
 	"smartops",
 	"domains",
+	"queues",
 }
 
 // IndexDriver replaces the whole pass4 layer with one generic transform. It
@@ -329,7 +326,7 @@ func (gi *groupIndexer) Process(ct transform.Context[object.Refrence], config ob
 	repoType, hasRepo := gi.iter.Meta["indexRepo"].(repositorytype.Type)
 	_, hasName := gi.iter.Meta["indexName"].(bool)
 	scopeCap, _ := gi.iter.Meta["indexByScope"].(engine.Capability)
-	placeholderField, hasPlaceholder := gi.iter.Meta["indexPlaceholder"].(string)
+	fqdnField, hasFqdn := gi.iter.Meta["indexByFqdn"].(string)
 
 	lookup := makeLookup(config, configRoot)
 
@@ -387,9 +384,9 @@ func (gi *groupIndexer) Process(ct transform.Context[object.Refrence], config ob
 			}
 		}
 
-		if hasPlaceholder {
-			if err := indexPlaceholder(ic, index, gi.groupKey, placeholderField); err != nil {
-				return nil, fmt.Errorf("index placeholder for %s %s failed with %w", gi.groupKey, id, err)
+		if hasFqdn {
+			if err := indexByFqdn(ic, index, gi.groupKey, fqdnField); err != nil {
+				return nil, fmt.Errorf("index by fqdn for %s %s failed with %w", gi.groupKey, id, err)
 			}
 		}
 
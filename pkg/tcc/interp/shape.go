@@ -67,7 +67,7 @@ var indexMetaKeys = []string{
 	"indexRepo",
 	"indexName",
 	"indexByScope",
-	"indexPlaceholder",
+	"indexByFqdn",
 }
 
 // UsesIndexing reports whether any group iterator declares an index footprint. It
@@ -72,41 +72,66 @@ var TaubyteRessources = []*Node{
 				String("certificate-data", Path("certificate", "cert"), RequiredWhen("certificate-type", "inline"), Field("CertFile"), Tag("cert-file"), InSection("tls"), ShowWhen("certificate-type", "inline"), Doc("Certificate", "PEM-encoded TLS certificate for the domain (inline certificate-type).")),
 				String("certificate-key", Path("certificate", "key"), RequiredWhen("certificate-type", "inline"), Field("KeyFile"), Tag("key-file"), InSection("tls"), ShowWhen("certificate-type", "inline"), Doc("Certificate Key", "PEM-encoded private key for the certificate (inline certificate-type).")),
 				String("certificate-type", Path("certificate", "type"), InSet("inline", "auto"), Default("auto"), Field("CertType"), Tag("cert-type"), InSection("tls"), Doc("Certificate Type", "How the TLS certificate is provisioned: inline (supplied here) or auto (managed).")),
+				Int("rateLimitIp", Path("rate-limit", "per-ip"), Field("RateLimitIP"), Accessor("RateLimitIP"), InSection("rate-limit"), Doc("Requests per IP", "Requests per second a client ip may make; 0 or unset leaves it unlimited.")),
+				Int("rateLimitKey", Path("rate-limit", "per-key"), Field("RateLimitKey"), Accessor("RateLimitKey"), InSection("rate-limit"), Doc("Requests per Key", "Requests per second an API key may make, read from the key header; 0 or unset leaves it unlimited.")),
+				String("rateLimitHeader", Path("rate-limit", "key-header"), Field("RateLimitHeader"), Accessor("RateLimitHeader"), InSection("rate-limit"), Doc("Key Header", "Request header carrying the API key requests are limited by.")),
+				Int("rateLimitBurst", Path("rate-limit", "burst"), Field("RateLimitBurst"), Accessor("RateLimitBurst"), InSection("rate-limit"), Doc("Burst", "Requests a client may make at once above its rate; 0 or unset allows as many as its rate.")),
+				StringSlice("records", Path("dns", "records"), IsDnsRecords(), InSection("dns"), Doc("DNS Records", "MX, TXT, CNAME and CAA records served for the domain, one per entry as a zone file line \"<name> [ttl] <type> <data>\"; names are relative to the domain, \"@\" being the domain itself.")),
 			),
 			GroupDoc("A DNS domain and its TLS configuration, referenced by functions and websites."), Icon("link"),
 			secIdentity,
 			Section("tls", "TLS", "Certificate configuration."),
+			Section("rate-limit", "Rate Limit", "How many requests clients may make to the domain; past it they are answered 429 Too Many Requests."),
+			Section("dns", "DNS", "Records served for the domain alongside the addresses of the nodes serving it."),
 			// domain's BasicPath is bespoke (fqdn-reversed), so it's not tagged here.
 			Addressing(HasIndex),
 			Embeds("Indexer"),
 			Resource("domains", "Domain", "Domain", "domain"),
-			interp.IndexPlaceholder("fqdn"),
+			interp.IndexByFqdn("fqdn"),
 		)),
 	DefineGroup("functions",
 		DefineIter(
 			TaubyteAttributes(
-				String("type", Path("trigger", "type"), Required(), InSet("http", "https", "pubsub", "p2p"), DerivedBool("Secure", map[string]bool{"http": false, "https": true}, map[bool]string{false: "http", true: "https"}), InSection("trigger"), Doc("Trigger Type", "Trigger that invokes the function: http, https, pubsub, or p2p.")),
+				String("type", Path("trigger", "type"), Required(), InSet("http", "https", "pubsub", "p2p", "websocket", "cron", "queue", "database"), DerivedBool("Secure", map[string]bool{"http": false, "https": true}, map[bool]string{false: "http", true: "https"}), InSection("trigger"), Doc("Trigger Type", "Trigger that invokes the function: http, https, pubsub, p2p, websocket, cron, queue, or database.")),
 				Bool("local", Path("trigger", "local"), InSection("trigger"), Doc("Local", "Restrict the trigger to the local node / project scope.")),
 				String("pubsub-channel", Path("trigger", "channel"), RequiredWhen("type", "pubsub"), Tag("channel"), InSection("pubsub"), Doc("PubSub Channel", "PubSub channel the function subscribes to (pubsub trigger).")),
 				String("p2p-protocol", Path("trigger", "protocol"), Compat("trigger", "service"), RequiredWhen("type", "p2p"), Tag("service"), OnlyWhen("type", "p2p"), Default(""), InSection("p2p"), Doc("P2P Protocol", "libp2p protocol the function serves (p2p trigger).")),
 				String("p2p-command", Path("trigger", "command"), RequiredWhen("type", "p2p"), Tag("command"), InSection("p2p"), Doc("P2P Command", "Command name within the p2p protocol this function handles.")),
+				String("cron-schedule", Path("trigger", "schedule"), IsCronSchedule(), RequiredWhen("type", "cron"), Tag("schedule"), InSection("cron"), Doc("Schedule", "Cron expression the function runs on, in UTC (cron trigger): five fields, a macro like \"@hourly\", or \"@every <duration>\".")),
+				String("queue", Path("trigger", "queue"), RequiredWhen("type", "queue"), InSection("queue"), Doc("Queue", "Name of the queue whose messages the function receives (queue trigger).")),
+				String("database", Path("trigger", "database"), RequiredWhen("type", "database"), InSection("database"), Doc("Database", "Name, as functions open it, of the database whose changes the function receives (database trigger).")),
+				String("prefix", Path("trigger", "prefix"), InSection("database"), Doc("Key Prefix", "Only changes to keys under this prefix trigger the function; when unset, every change does.")),
 				String("http-method", Path("trigger", "method"), IsHttpMethod(), RequiredWhen("type", "http", "https"), Tag("method"), InSection("http"), Doc("HTTP Method", "HTTP method the function handles (http/https trigger).")),
 				StringSlice("http-methods", Path("trigger", "methods"), Tag("methods"), NoAccessors(), NoStructField()), // TO IMPLEMENT
-				StringSlice("http-domains", Path("trigger", "domains"), Compat("domains"), RequiredWhen("type", "http", "https"), Tag("domains"), Ref("domains"), InSection("http"), Doc("Domains", "Domains that route to this function. Each must name a defined domain.")),
-				StringSlice("http-paths", Path("trigger", "paths"), RequiredWhen("type", "http", "https"), Tag("paths"), InSection("http"), Doc("Paths", "URL path patterns that route to this function (http/https trigger).")),
+				StringSlice("http-domains", Path("trigger", "domains"), Compat("domains"), RequiredWhen("type", "http", "https", "websocket"), Tag("domains"), Ref("domains"), InSection("http"), Doc("Domains", "Domains that route to this function. Each must name a defined domain.")),
+				StringSlice("http-paths", Path("trigger", "paths"), RequiredWhen("type", "http", "https", "websocket"), Tag("paths"), InSection("http"), Doc("Paths", "URL path patterns that route to this function (http/https/websocket trigger).")),
 				String("source", Required(), Ref("libraries", Prefix("libraries/")), sourceShape, InSection("code"), Doc("Source", "Code source: \".\" for inline code, or \"libraries/<name>\" to build from a defined library.")),
 				Duration("timeout", Path("execution", "timeout"), Required(), InSection("limits"), Doc("Timeout", "Maximum execution time, as a human string (e.g. \"30s\").")),
 				Bytes("memory", Path("execution", "memory"), Required(), InSection("limits"), Doc("Memory", "Maximum memory the function may use, as a human string (e.g. \"32MB\").")),
+				Int("fuel", Path("execution", "fuel"), InSection("limits"), Doc("Fuel", "Maximum fuel a call may burn, about one unit per WASM instruction. Calls out of fuel fail; 0 or unset leaves calls unmetered.")),
 				String("call", Path("execution", "call"), Required(), InSection("code"), Doc("Entrypoint", "Exported entrypoint symbol invoked in the WASM module.")),
+				Int("minIdle", Path("instances", "min-idle"), Field("MinIdle"), Accessor("MinIdle"), InSection("instances"), Doc("Min Idle", "Instances kept warm and idle, ready for calls; created when the function is provisioned.")),
+				Int("maxConcurrency", Path("instances", "max-concurrency"), Field("MaxConcurrency"), Accessor("MaxConcurrency"), InSection("instances"), Doc("Max Concurrency", "Maximum instances of the function a node runs at once; calls past it wait for an instance to free. 0 or unset leaves it unbounded.")),
+				Duration("idleTimeout", Path("instances", "idle-timeout"), Field("IdleTimeout"), Accessor("IdleTimeout"), InSection("instances"), Doc("Idle Timeout", "How long an instance past min-idle may sit idle before it is evicted, as a human string (e.g. \"5m\").")),
+				Bool("snapshot", Path("instances", "snapshot"), InSection("instances"), Doc("Snapshot", "Reset the memory of instances to a snapshot taken after initialization between calls, so no call sees what a previous one left.")),
+				Int("rateLimitIp", Path("rate-limit", "per-ip"), Field("RateLimitIP"), Accessor("RateLimitIP"), InSection("rate-limit"), Doc("Requests per IP", "Requests per second a client ip may make; 0 or unset leaves it unlimited.")),
+				Int("rateLimitKey", Path("rate-limit", "per-key"), Field("RateLimitKey"), Accessor("RateLimitKey"), InSection("rate-limit"), Doc("Requests per Key", "Requests per second an API key may make, read from the key header; 0 or unset leaves it unlimited.")),
+				String("rateLimitHeader", Path("rate-limit", "key-header"), Field("RateLimitHeader"), Accessor("RateLimitHeader"), InSection("rate-limit"), Doc("Key Header", "Request header carrying the API key requests are limited by.")),
+				Int("rateLimitBurst", Path("rate-limit", "burst"), Field("RateLimitBurst"), Accessor("RateLimitBurst"), InSection("rate-limit"), Doc("Burst", "Requests a client may make at once above its rate; 0 or unset allows as many as its rate.")),
 			),
-			GroupDoc("A serverless function triggered over HTTP(S), PubSub, or p2p."), Icon("bolt"),
+			GroupDoc("A serverless function triggered over HTTP(S), PubSub, p2p, websockets, a queue, database changes, or on a schedule."), Icon("bolt"),
 			secIdentity,
 			Section("trigger", "Trigger", "How the function is invoked."),
-			SectionWhen("http", "HTTP", "HTTP(S) routing.", "type", "http", "https"),
+			SectionWhen("http", "HTTP", "HTTP(S) and websocket routing.", "type", "http", "https", "websocket"),
 			SectionWhen("pubsub", "PubSub", "PubSub subscription.", "type", "pubsub"),
 			SectionWhen("p2p", "P2P", "libp2p protocol handling.", "type", "p2p"),
+			SectionWhen("cron", "Cron", "Time-based schedule.", "type", "cron"),
+			SectionWhen("queue", "Queue", "Queue the function consumes.", "type", "queue"),
+			SectionWhen("database", "Database", "Database changes the function receives.", "type", "database"),
 			Section("code", "Code", "The function's code source and entrypoint."),
 			Section("limits", "Limits", "Runtime resource limits."),
+			Section("instances", "Instances", "How instances of the function are kept warm and reused."),
+			SectionWhen("rate-limit", "Rate Limit", "How many requests clients may make to the function; past it they are answered 429 Too Many Requests.", "type", "http", "https", "websocket"),
 			Addressing(HasBasicPath, HasIndex, HasHttp, HasWasmModule, HasServices),
 			Embeds("Wasm"),
 			Resource("functions", "Function", "Function", "function"),
@@ -152,6 +177,22 @@ var TaubyteRessources = []*Node{
 			Resource("messaging", "Messaging", "Messaging", "messaging"),
 			interp.IndexByScope(HasWebSocket),
 		)),
+	DefineGroup("queues",
+		DefineIter(
+			TaubyteAttributes(
+				Duration("visibilityTimeout", Path("delivery", "visibility-timeout"), Field("VisibilityTimeout"), Accessor("VisibilityTimeout"), InSection("delivery"), Doc("Visibility Timeout", "How long a delivered message waits for its ack before it is delivered again, as a human string (e.g. \"30s\"). 0 or unset uses 30s.")),
+				Int("maxAttempts", Path("delivery", "max-attempts"), Field("MaxAttempts"), Accessor("MaxAttempts"), InSection("delivery"), Doc("Max Attempts", "Deliveries of a message before it is dead-lettered. 0 or unset delivers it until it is acked.")),
+				String("deadLetter", Path("dead-letter", "queue"), Field("DeadLetter"), Accessor("DeadLetter"), InSection("dead-letter"), Doc("Dead Letter Queue", "Name of the queue dead-lettered messages move to. When unset, they are kept aside in this queue.")),
+			),
+			GroupDoc("A durable task queue, delivering each message at least once to the functions it triggers."), Icon("inbox"),
+			secIdentity,
+			Section("delivery", "Delivery", "Acknowledgement deadline and retries."),
+			Section("dead-letter", "Dead Letter", "Where messages that exhaust their attempts go."),
+			Addressing(HasBasicPath, HasIndex, HasIndexPath),
+			Embeds("Basic", "Indexer"),
+			Resource("queues", "Queue", "Queue", "queue"),
+			interp.IndexByName(HasIndexPath),
+		)),
 	DefineGroup("services",
 		DefineIter(
 			TaubyteAttributes(
@@ -219,6 +260,10 @@ var TaubyteRessources = []*Node{
 				String("git-provider", Path("source", Either("github")), Key(), Field("Provider"), Tag("provider"), InSection("source"), Doc("Provider", "Source-control provider hosting the repository (the key selects the provider block).")),
 				String("github-id", Path("source", "github", "id"), Required(), Field("RepoID"), Tag("repository-id"), NoAccessors(), InSection("source"), Doc("Repository ID", "GitHub repository numeric id.")),
 				String("github-fullname", Path("source", "github", "fullname"), Required(), RepoName(), Field("RepoName"), Tag("repository-name"), NoAccessors(), InSection("source"), Doc("Repository", "GitHub repository full name (owner/repo).")),
+				Bool("disableSpa", Path("serving", "disable-spa"), Field("DisableSPA"), Accessor("DisableSPA"), InSection("serving"), Doc("Disable SPA", "Answer paths that match no file with a 404 instead of falling back to index.html.")),
+				String("notFound", Path("serving", "not-found"), Field("NotFound"), Accessor("NotFound"), InSection("serving"), Doc("Not Found Page", "Page served, with a 404 status, for paths that match no file (e.g. \"/404.html\"). Takes precedence over the SPA fallback.")),
+				StringSlice("redirects", Path("serving", "redirects"), InSection("serving"), Doc("Redirects", "Redirect and rewrite rules, one per entry as \"<from> <to> [status]\", like a _redirects file. A 200 status rewrites; from may end in \"/*\" and to use \":splat\".")),
+				StringSlice("headers", Path("serving", "headers"), InSection("serving"), Doc("Headers", "Response headers per path, one per entry as \"<path> <Name>: <value>\", like a _headers file; path may end in \"/*\".")),
 			),
 			GroupDoc("A static website built from a git repository and served over one or more domains."), Icon("globe"),
 			secIdentity,
@@ -270,7 +315,7 @@ var taubyteRoot = Root(
 var TaubyteProject = SchemaDefinition(taubyteRoot)
 
 // GenerationRoot is the node list tcc-gen walks: the real project root's groups
-// (the 9 resources + applications + clouds), so no curated list can drift from
+// (the 10 resources + applications + clouds), so no curated list can drift from
 // the schema. Every generator/test uses this one accessor.
 func GenerationRoot() []*Node { return taubyteRoot.Children }
 
@@ -38,10 +38,13 @@ var (
 	Generated        = engine.Generated
 	GroupDoc         = engine.GroupDoc
 	Icon             = engine.Icon
+	Int              = engine.Int
 	IsCID            = engine.IsCID
 	IsEmail          = engine.IsEmail
 	IsFqdn           = engine.IsFqdn
 	IsHttpMethod     = engine.IsHttpMethod
+	IsCronSchedule   = engine.IsCronSchedule
+	IsDnsRecords     = engine.IsDnsRecords
 	IsVariableName   = engine.IsVariableName
 	Key              = engine.Key
 	NoAccessors      = engine.NoAccessors
@@ -301,7 +301,7 @@ func TestSession(t *testing.T) {
 		for _, k := range kinds {
 			assert.Assert(t, k.Name != "", "every kind the DSL reports is named: %+v", k)
 		}
-		assert.Equal(t, len(kinds), 10, "9 resources + the application container")
+		assert.Equal(t, len(kinds), 11, "10 resources + the application container")
 
 		addr := func(kind, name, app string) []string {
 			t.Helper()
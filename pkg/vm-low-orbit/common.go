@@ -7,8 +7,10 @@ import (
 	"sync"
 
 	"github.com/taubyte/tau/core/services/substrate/components/database"
+	"github.com/taubyte/tau/core/services/substrate/components/http"
 	"github.com/taubyte/tau/core/services/substrate/components/p2p"
 	"github.com/taubyte/tau/core/services/substrate/components/pubsub"
+	"github.com/taubyte/tau/core/services/substrate/components/queue"
 	"github.com/taubyte/tau/core/services/substrate/components/storage"
 	"github.com/taubyte/tau/core/vm"
 )
@@ -76,6 +78,34 @@ func P2PNode(node p2p.Service) Option {
 	}
 }
 
+func WebSocketNode(node http.WebSockets) Option {
+	return func() (err error) {
+		if _plugin == nil {
+			return errNilPlugin
+		}
+
+		if err = _plugin.setNode(node); err != nil {
+			return fmt.Errorf("setting websocket node failed with: %w", err)
+		}
+
+		return
+	}
+}
+
+func QueueNode(node queue.Service) Option {
+	return func() (err error) {
+		if _plugin == nil {
+			return errNilPlugin
+		}
+
+		if err = _plugin.setNode(node); err != nil {
+			return fmt.Errorf("setting queue node failed with: %w", err)
+		}
+
+		return
+	}
+}
+
 func (p *plugin) Name() string {
 	return "taubyte/sdk"
 }
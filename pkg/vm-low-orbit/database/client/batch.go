@@ -0,0 +1,48 @@
+package client
+
+import (
+	"github.com/taubyte/go-sdk/errno"
+	"github.com/taubyte/tau/core/services/substrate/components/database"
+)
+
+func (f *Factory) createBatchPointer(databaseId uint32, batch database.Batch) *Batch {
+	f.batchLock.Lock()
+	defer f.batchLock.Unlock()
+
+	b := &Batch{
+		Batch:      batch,
+		Id:         f.batchIdToGrab,
+		DatabaseId: databaseId,
+	}
+	f.batchIdToGrab += 1
+	f.batches[b.Id] = b
+
+	return b
+}
+
+func (f *Factory) getBatch(batchId uint32) (*Batch, errno.Error) {
+	f.batchLock.Lock()
+	defer f.batchLock.Unlock()
+	if b, exists := f.batches[batchId]; exists {
+		return b, 0
+	}
+
+	return nil, errno.ErrorDatabaseNotFound
+}
+
+func (f *Factory) deleteBatch(batchId uint32) {
+	f.batchLock.Lock()
+	defer f.batchLock.Unlock()
+	delete(f.batches, batchId)
+}
+
+// dropBatches forgets the uncommitted batches of a closed database.
+func (f *Factory) dropBatches(databaseId uint32) {
+	f.batchLock.Lock()
+	defer f.batchLock.Unlock()
+	for id, b := range f.batches {
+		if b.DatabaseId == databaseId {
+			delete(f.batches, id)
+		}
+	}
+}
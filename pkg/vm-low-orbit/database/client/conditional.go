@@ -0,0 +1,195 @@
+package client
+
+import (
+	"context"
+	"errors"
+
+	"github.com/taubyte/go-sdk/errno"
+	dbIface "github.com/taubyte/tau/core/services/substrate/components/database"
+	common "github.com/taubyte/tau/core/vm"
+)
+
+// readExpected reads the expected value of a conditional write; hasExpected == 0
+// means the key is expected to be absent.
+func (f *Factory) readExpected(module common.Module, expectedPtr, expectedLen, hasExpected uint32) ([]byte, errno.Error) {
+	if hasExpected == 0 {
+		return nil, 0
+	}
+
+	expected, err := f.ReadBytes(module, expectedPtr, expectedLen)
+	if err != 0 {
+		return nil, err
+	}
+	if expected == nil {
+		expected = []byte{}
+	}
+
+	return expected, 0
+}
+
+func (f *Factory) databaseCompareAndSwap(ctx context.Context, module common.Module,
+	databaseId,
+	keyPtr, keyLen,
+	expectedPtr, expectedLen, hasExpected,
+	bufPtr, bufSize,
+	swappedPtr uint32,
+) uint32 {
+
+	database, err := f.getDatabase(databaseId)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	key, err := f.ReadString(module, keyPtr, keyLen)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	expected, err := f.readExpected(module, expectedPtr, expectedLen, hasExpected)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	data, err := f.ReadBytes(module, bufPtr, bufSize)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	swapped, err0 := database.KV().CompareAndSwap(ctx, key, expected, data)
+	if err0 != nil {
+		return uint32(errno.ErrorDatabasePutFailed)
+	}
+
+	return uint32(f.WriteBool(module, swappedPtr, swapped))
+}
+
+func (f *Factory) databaseNewBatch(ctx context.Context, module common.Module,
+	databaseId,
+	idPtr uint32,
+) uint32 {
+
+	database, err := f.getDatabase(databaseId)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	batch, err0 := database.KV().Batch(ctx)
+	if err0 != nil {
+		return uint32(errno.ErrorDatabaseCreateFailed)
+	}
+
+	b := f.createBatchPointer(databaseId, batch)
+
+	return uint32(f.WriteUint32Le(module, idPtr, b.Id))
+}
+
+func (f *Factory) databaseBatchPut(ctx context.Context, module common.Module,
+	batchId,
+	keyPtr, keyLen,
+	bufPtr, bufSize uint32,
+) uint32 {
+
+	batch, err := f.getBatch(batchId)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	key, err := f.ReadString(module, keyPtr, keyLen)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	data, err := f.ReadBytes(module, bufPtr, bufSize)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	if batch.Put(key, data) != nil {
+		return uint32(errno.ErrorDatabasePutFailed)
+	}
+
+	return 0
+}
+
+func (f *Factory) databaseBatchDelete(ctx context.Context, module common.Module,
+	batchId,
+	keyPtr, keyLen uint32,
+) uint32 {
+
+	batch, err := f.getBatch(batchId)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	key, err := f.ReadString(module, keyPtr, keyLen)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	if batch.Delete(key) != nil {
+		return uint32(errno.ErrorDatabaseDeleteFailed)
+	}
+
+	return 0
+}
+
+func (f *Factory) databaseBatchCheck(ctx context.Context, module common.Module,
+	batchId,
+	keyPtr, keyLen,
+	expectedPtr, expectedLen, hasExpected uint32,
+) uint32 {
+
+	batch, err := f.getBatch(batchId)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	key, err := f.ReadString(module, keyPtr, keyLen)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	expected, err := f.readExpected(module, expectedPtr, expectedLen, hasExpected)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	if batch.Check(key, expected) != nil {
+		return uint32(errno.ErrorDatabasePutFailed)
+	}
+
+	return 0
+}
+
+// databaseBatchCommit commits and releases the batch. A failed check is not an
+// error: committedPtr is set false and nothing was written.
+func (f *Factory) databaseBatchCommit(ctx context.Context, module common.Module,
+	batchId,
+	committedPtr uint32,
+) uint32 {
+
+	batch, err := f.getBatch(batchId)
+	if err != 0 {
+		return uint32(err)
+	}
+	f.deleteBatch(batchId)
+
+	err0 := batch.Commit(ctx)
+	if err0 != nil && !errors.Is(err0, dbIface.ErrConflict) {
+		return uint32(errno.ErrorDatabasePutFailed)
+	}
+
+	return uint32(f.WriteBool(module, committedPtr, err0 == nil))
+}
+
+func (f *Factory) databaseBatchDiscard(ctx context.Context, module common.Module,
+	batchId uint32,
+) uint32 {
+
+	if _, err := f.getBatch(batchId); err != 0 {
+		return uint32(err)
+	}
+	f.deleteBatch(batchId)
+
+	return 0
+}
@@ -8,9 +8,17 @@ func (f *Factory) RegisterHostFunctions(b wazy.HostModuleBuilder) {
 	wazy.HostFunc4(b.NewFunctionBuilder(), f.databaseGet).Export("databaseGet")
 	wazy.HostFunc4(b.NewFunctionBuilder(), f.databaseGetSize).Export("databaseGetSize")
 	wazy.HostFunc5(b.NewFunctionBuilder(), f.databasePut).Export("databasePut")
+	wazy.HostFunc6(b.NewFunctionBuilder(), f.databasePutWithTTL).Export("databasePutWithTTL")
 	wazy.HostFunc1(b.NewFunctionBuilder(), f.databaseClose).Export("databaseClose")
 	wazy.HostFunc3(b.NewFunctionBuilder(), f.databaseDelete).Export("databaseDelete")
 	wazy.HostFunc4(b.NewFunctionBuilder(), f.databaseList).Export("databaseList")
 	wazy.HostFunc4(b.NewFunctionBuilder(), f.databaseListSize).Export("databaseListSize")
 	wazy.HostFunc3(b.NewFunctionBuilder(), f.newDatabase).Export("newDatabase")
+	wazy.HostFunc9(b.NewFunctionBuilder(), f.databaseCompareAndSwap).Export("databaseCompareAndSwap")
+	wazy.HostFunc2(b.NewFunctionBuilder(), f.databaseNewBatch).Export("databaseNewBatch")
+	wazy.HostFunc5(b.NewFunctionBuilder(), f.databaseBatchPut).Export("databaseBatchPut")
+	wazy.HostFunc3(b.NewFunctionBuilder(), f.databaseBatchDelete).Export("databaseBatchDelete")
+	wazy.HostFunc6(b.NewFunctionBuilder(), f.databaseBatchCheck).Export("databaseBatchCheck")
+	wazy.HostFunc2(b.NewFunctionBuilder(), f.databaseBatchCommit).Export("databaseBatchCommit")
+	wazy.HostFunc1(b.NewFunctionBuilder(), f.databaseBatchDiscard).Export("databaseBatchDiscard")
 }
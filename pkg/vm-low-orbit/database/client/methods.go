@@ -2,7 +2,9 @@ package client
 
 import (
 	"context"
+	"math"
 	"strings"
+	"time"
 
 	"github.com/ipfs/go-datastore"
 	"github.com/taubyte/go-sdk/errno"
@@ -100,6 +102,42 @@ func (f *Factory) databasePut(ctx context.Context, module common.Module,
 	return 0
 }
 
+// databasePutWithTTL writes like databasePut; the key expires after ttl
+// milliseconds, the resolution expiries have across the network.
+func (f *Factory) databasePutWithTTL(ctx context.Context, module common.Module,
+	databaseId,
+	keyPtr, keyLen,
+	bufPtr, bufSize uint32,
+	ttl uint64,
+) uint32 {
+
+	database, err := f.getDatabase(databaseId)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	_key, err := f.ReadString(module, keyPtr, keyLen)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	data, err := f.ReadBytes(module, bufPtr, bufSize)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	if ttl == 0 || ttl > uint64(math.MaxInt64/int64(time.Millisecond)) {
+		return uint32(errno.ErrorDatabasePutFailed)
+	}
+
+	_err := database.KV().PutWithTTL(ctx, _key, data, time.Duration(ttl)*time.Millisecond)
+	if _err != nil {
+		return uint32(errno.ErrorDatabasePutFailed)
+	}
+
+	return 0
+}
+
 func (f *Factory) databaseClose(ctx context.Context, module common.Module,
 	databaseId uint32,
 ) uint32 {
@@ -111,6 +149,7 @@ func (f *Factory) databaseClose(ctx context.Context, module common.Module,
 	f.databaseLock.Lock()
 	defer f.databaseLock.Unlock()
 	delete(f.database, databaseId)
+	f.dropBatches(databaseId)
 
 	database.Close()
 	return 0
@@ -18,6 +18,9 @@ type Factory struct {
 	databaseLock     sync.RWMutex
 	databaseIdToGrab uint32
 	database         map[uint32]*Database
+	batchLock        sync.Mutex
+	batchIdToGrab    uint32
+	batches          map[uint32]*Batch
 }
 
 var _ vm.Factory = &Factory{}
@@ -26,3 +29,9 @@ type Database struct {
 	dbIface.Database
 	Id uint32
 }
+
+type Batch struct {
+	dbIface.Batch
+	Id         uint32
+	DatabaseId uint32
+}
@@ -0,0 +1,77 @@
+package event
+
+import (
+	"context"
+	"time"
+
+	sdkCommon "github.com/taubyte/go-sdk/common"
+	"github.com/taubyte/go-sdk/errno"
+	common "github.com/taubyte/tau/core/vm"
+)
+
+// EventTypeCron is the type of the events of cron functions, next to the ones
+// known to the sdk.
+const EventTypeCron = sdkCommon.EventTypeP2P + 2
+
+type CronData struct {
+	schedule string
+	tick     time.Time
+}
+
+// CreateCronEvent creates the event of the tick of schedule.
+func (f *Factory) CreateCronEvent(schedule string, tick time.Time) *Event {
+	e := &Event{
+		Id:   f.generateEventId(),
+		Type: EventTypeCron,
+		cron: &CronData{
+			schedule: schedule,
+			tick:     tick,
+		},
+	}
+
+	f.eventsLock.Lock()
+	defer f.eventsLock.Unlock()
+	f.events[e.Id] = e
+	return e
+}
+
+func (f *Factory) getCronEvent(eventId uint32) (*CronData, errno.Error) {
+	e, err := f.getEvent(eventId)
+	if err != 0 {
+		return nil, err
+	}
+
+	if e.cron == nil {
+		return nil, errno.ErrorNilAddress
+	}
+
+	return e.cron, 0
+}
+
+func (f *Factory) getCronEventScheduleSize(ctx context.Context, module common.Module, eventId, sizePtr uint32) uint32 {
+	data, err := f.getCronEvent(eventId)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	return uint32(f.WriteStringSize(module, sizePtr, data.schedule))
+}
+
+func (f *Factory) getCronEventSchedule(ctx context.Context, module common.Module, eventId, schedulePtr uint32) uint32 {
+	data, err := f.getCronEvent(eventId)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	return uint32(f.WriteString(module, schedulePtr, data.schedule))
+}
+
+// getCronEventTime writes the time of the tick, in seconds since the epoch.
+func (f *Factory) getCronEventTime(ctx context.Context, module common.Module, eventId, timePtr uint32) uint32 {
+	data, err := f.getCronEvent(eventId)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	return uint32(f.WriteUint64Le(module, timePtr, uint64(data.tick.Unix())))
+}
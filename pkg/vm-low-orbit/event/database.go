@@ -0,0 +1,113 @@
+package event
+
+import (
+	"context"
+
+	sdkCommon "github.com/taubyte/go-sdk/common"
+	"github.com/taubyte/go-sdk/errno"
+	"github.com/taubyte/tau/core/kvdb"
+	common "github.com/taubyte/tau/core/vm"
+)
+
+// EventTypeDatabase is the type of the events of database functions, next to
+// the ones known to the sdk.
+const EventTypeDatabase = sdkCommon.EventTypeP2P + 4
+
+type DatabaseData struct {
+	database string
+	change   kvdb.Event
+}
+
+// CreateDatabaseEvent creates the event of change, made to a key of database.
+func (f *Factory) CreateDatabaseEvent(database string, change kvdb.Event) *Event {
+	e := &Event{
+		Id:   f.generateEventId(),
+		Type: EventTypeDatabase,
+		database: &DatabaseData{
+			database: database,
+			change:   change,
+		},
+	}
+
+	f.eventsLock.Lock()
+	defer f.eventsLock.Unlock()
+	f.events[e.Id] = e
+	return e
+}
+
+func (f *Factory) getDatabaseEvent(eventId uint32) (*DatabaseData, errno.Error) {
+	e, err := f.getEvent(eventId)
+	if err != 0 {
+		return nil, err
+	}
+
+	if e.database == nil {
+		return nil, errno.ErrorNilAddress
+	}
+
+	return e.database, 0
+}
+
+func (f *Factory) getDatabaseEventDatabaseSize(ctx context.Context, module common.Module, eventId, sizePtr uint32) uint32 {
+	data, err := f.getDatabaseEvent(eventId)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	return uint32(f.WriteStringSize(module, sizePtr, data.database))
+}
+
+func (f *Factory) getDatabaseEventDatabase(ctx context.Context, module common.Module, eventId, databasePtr uint32) uint32 {
+	data, err := f.getDatabaseEvent(eventId)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	return uint32(f.WriteString(module, databasePtr, data.database))
+}
+
+// getDatabaseEventOp writes 0 for a put of the key, 1 for its deletion.
+func (f *Factory) getDatabaseEventOp(ctx context.Context, module common.Module, eventId, opPtr uint32) uint32 {
+	data, err := f.getDatabaseEvent(eventId)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	return uint32(f.WriteUint32Le(module, opPtr, uint32(data.change.Type)))
+}
+
+func (f *Factory) getDatabaseEventKeySize(ctx context.Context, module common.Module, eventId, sizePtr uint32) uint32 {
+	data, err := f.getDatabaseEvent(eventId)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	return uint32(f.WriteStringSize(module, sizePtr, data.change.Key))
+}
+
+func (f *Factory) getDatabaseEventKey(ctx context.Context, module common.Module, eventId, keyPtr uint32) uint32 {
+	data, err := f.getDatabaseEvent(eventId)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	return uint32(f.WriteString(module, keyPtr, data.change.Key))
+}
+
+func (f *Factory) getDatabaseEventValueSize(ctx context.Context, module common.Module, eventId, sizePtr uint32) uint32 {
+	data, err := f.getDatabaseEvent(eventId)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	return uint32(f.WriteBytesSize(module, sizePtr, data.change.Value))
+}
+
+func (f *Factory) getDatabaseEventValue(ctx context.Context, module common.Module, eventId, valuePtr uint32) uint32 {
+	data, err := f.getDatabaseEvent(eventId)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	return uint32(f.WriteBytes(module, valuePtr, data.change.Value))
+}
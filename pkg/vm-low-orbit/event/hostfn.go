@@ -42,4 +42,26 @@ func (f *Factory) RegisterHostFunctions(b wazy.HostModuleBuilder) {
 	wazy.HostFunc2(b.NewFunctionBuilder(), f.getHttpEventRequestQueryKeysSize).Export("getHttpEventRequestQueryKeysSize")
 	wazy.HostFunc2(b.NewFunctionBuilder(), f.getHttpEventRequestQueryKeys).Export("getHttpEventRequestQueryKeys")
 	wazy.HostFunc4(b.NewFunctionBuilder(), f.eventHttpRedirect).Export("eventHttpRedirect")
+	wazy.HostFunc2(b.NewFunctionBuilder(), f.getWebSocketEventConnectionSize).Export("getWebSocketEventConnectionSize")
+	wazy.HostFunc2(b.NewFunctionBuilder(), f.getWebSocketEventConnection).Export("getWebSocketEventConnection")
+	wazy.HostFunc2(b.NewFunctionBuilder(), f.getWebSocketEventKind).Export("getWebSocketEventKind")
+	wazy.HostFunc2(b.NewFunctionBuilder(), f.getWebSocketEventBinary).Export("getWebSocketEventBinary")
+	wazy.HostFunc2(b.NewFunctionBuilder(), f.getWebSocketEventDataSize).Export("getWebSocketEventDataSize")
+	wazy.HostFunc2(b.NewFunctionBuilder(), f.getWebSocketEventData).Export("getWebSocketEventData")
+	wazy.HostFunc2(b.NewFunctionBuilder(), f.getCronEventScheduleSize).Export("getCronEventScheduleSize")
+	wazy.HostFunc2(b.NewFunctionBuilder(), f.getCronEventSchedule).Export("getCronEventSchedule")
+	wazy.HostFunc2(b.NewFunctionBuilder(), f.getCronEventTime).Export("getCronEventTime")
+	wazy.HostFunc2(b.NewFunctionBuilder(), f.getQueueEventQueueSize).Export("getQueueEventQueueSize")
+	wazy.HostFunc2(b.NewFunctionBuilder(), f.getQueueEventQueue).Export("getQueueEventQueue")
+	wazy.HostFunc2(b.NewFunctionBuilder(), f.getQueueEventMessageId).Export("getQueueEventMessageId")
+	wazy.HostFunc2(b.NewFunctionBuilder(), f.getQueueEventAttempt).Export("getQueueEventAttempt")
+	wazy.HostFunc2(b.NewFunctionBuilder(), f.getQueueEventDataSize).Export("getQueueEventDataSize")
+	wazy.HostFunc2(b.NewFunctionBuilder(), f.getQueueEventData).Export("getQueueEventData")
+	wazy.HostFunc2(b.NewFunctionBuilder(), f.getDatabaseEventDatabaseSize).Export("getDatabaseEventDatabaseSize")
+	wazy.HostFunc2(b.NewFunctionBuilder(), f.getDatabaseEventDatabase).Export("getDatabaseEventDatabase")
+	wazy.HostFunc2(b.NewFunctionBuilder(), f.getDatabaseEventOp).Export("getDatabaseEventOp")
+	wazy.HostFunc2(b.NewFunctionBuilder(), f.getDatabaseEventKeySize).Export("getDatabaseEventKeySize")
+	wazy.HostFunc2(b.NewFunctionBuilder(), f.getDatabaseEventKey).Export("getDatabaseEventKey")
+	wazy.HostFunc2(b.NewFunctionBuilder(), f.getDatabaseEventValueSize).Export("getDatabaseEventValueSize")
+	wazy.HostFunc2(b.NewFunctionBuilder(), f.getDatabaseEventValue).Export("getDatabaseEventValue")
 }
@@ -0,0 +1,107 @@
+package event
+
+import (
+	"context"
+
+	sdkCommon "github.com/taubyte/go-sdk/common"
+	"github.com/taubyte/go-sdk/errno"
+	common "github.com/taubyte/tau/core/vm"
+)
+
+// EventTypeQueue is the type of the events of queue functions, next to the
+// ones known to the sdk.
+const EventTypeQueue = sdkCommon.EventTypeP2P + 3
+
+type QueueData struct {
+	queue   string
+	message uint64
+	data    []byte
+	attempt uint32
+}
+
+// CreateQueueEvent creates the event of the delivery of message, holding data,
+// from queue. attempt counts the deliveries of the message, this one included.
+func (f *Factory) CreateQueueEvent(queue string, message uint64, data []byte, attempt uint32) *Event {
+	e := &Event{
+		Id:   f.generateEventId(),
+		Type: EventTypeQueue,
+		queue: &QueueData{
+			queue:   queue,
+			message: message,
+			data:    data,
+			attempt: attempt,
+		},
+	}
+
+	f.eventsLock.Lock()
+	defer f.eventsLock.Unlock()
+	f.events[e.Id] = e
+	return e
+}
+
+func (f *Factory) getQueueEvent(eventId uint32) (*QueueData, errno.Error) {
+	e, err := f.getEvent(eventId)
+	if err != 0 {
+		return nil, err
+	}
+
+	if e.queue == nil {
+		return nil, errno.ErrorNilAddress
+	}
+
+	return e.queue, 0
+}
+
+func (f *Factory) getQueueEventQueueSize(ctx context.Context, module common.Module, eventId, sizePtr uint32) uint32 {
+	data, err := f.getQueueEvent(eventId)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	return uint32(f.WriteStringSize(module, sizePtr, data.queue))
+}
+
+func (f *Factory) getQueueEventQueue(ctx context.Context, module common.Module, eventId, queuePtr uint32) uint32 {
+	data, err := f.getQueueEvent(eventId)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	return uint32(f.WriteString(module, queuePtr, data.queue))
+}
+
+func (f *Factory) getQueueEventMessageId(ctx context.Context, module common.Module, eventId, idPtr uint32) uint32 {
+	data, err := f.getQueueEvent(eventId)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	return uint32(f.WriteUint64Le(module, idPtr, data.message))
+}
+
+func (f *Factory) getQueueEventAttempt(ctx context.Context, module common.Module, eventId, attemptPtr uint32) uint32 {
+	data, err := f.getQueueEvent(eventId)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	return uint32(f.WriteUint32Le(module, attemptPtr, data.attempt))
+}
+
+func (f *Factory) getQueueEventDataSize(ctx context.Context, module common.Module, eventId, sizePtr uint32) uint32 {
+	data, err := f.getQueueEvent(eventId)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	return uint32(f.WriteBytesSize(module, sizePtr, data.data))
+}
+
+func (f *Factory) getQueueEventData(ctx context.Context, module common.Module, eventId, dataPtr uint32) uint32 {
+	data, err := f.getQueueEvent(eventId)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	return uint32(f.WriteBytes(module, dataPtr, data.data))
+}
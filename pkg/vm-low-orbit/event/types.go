@@ -23,11 +23,15 @@ type Factory struct {
 var _ vm.Factory = &Factory{}
 
 type Event struct {
-	Id     uint32
-	Type   common.EventType
-	http   *httpEventAttributes
-	pubsub pubsubIface.Message
-	p2p    *P2PData
+	Id        uint32
+	Type      common.EventType
+	http      *httpEventAttributes
+	pubsub    pubsubIface.Message
+	p2p       *P2PData
+	websocket *WebSocketData
+	cron      *CronData
+	queue     *QueueData
+	database  *DatabaseData
 }
 
 type httpEventAttributes struct {
@@ -0,0 +1,116 @@
+package event
+
+import (
+	"context"
+
+	sdkCommon "github.com/taubyte/go-sdk/common"
+	"github.com/taubyte/go-sdk/errno"
+	common "github.com/taubyte/tau/core/vm"
+)
+
+// EventTypeWebSocket is the type of the events of websocket functions, next to
+// the ones known to the sdk.
+const EventTypeWebSocket = sdkCommon.EventTypeP2P + 1
+
+// WebSocketEventKind is what happened on the connection of a websocket event.
+type WebSocketEventKind uint32
+
+const (
+	WebSocketConnect WebSocketEventKind = iota + 1
+	WebSocketMessage
+	WebSocketClose
+)
+
+type WebSocketData struct {
+	connection string
+	kind       WebSocketEventKind
+	data       []byte
+	binary     bool
+}
+
+// CreateWebSocketEvent creates the event of kind on connection; data and binary
+// are those of the message received, for WebSocketMessage.
+func (f *Factory) CreateWebSocketEvent(connection string, kind WebSocketEventKind, data []byte, binary bool) *Event {
+	e := &Event{
+		Id:   f.generateEventId(),
+		Type: EventTypeWebSocket,
+		websocket: &WebSocketData{
+			connection: connection,
+			kind:       kind,
+			data:       data,
+			binary:     binary,
+		},
+	}
+
+	f.eventsLock.Lock()
+	defer f.eventsLock.Unlock()
+	f.events[e.Id] = e
+	return e
+}
+
+func (f *Factory) getWebSocketEvent(eventId uint32) (*WebSocketData, errno.Error) {
+	e, err := f.getEvent(eventId)
+	if err != 0 {
+		return nil, err
+	}
+
+	if e.websocket == nil {
+		return nil, errno.ErrorNilAddress
+	}
+
+	return e.websocket, 0
+}
+
+func (f *Factory) getWebSocketEventConnectionSize(ctx context.Context, module common.Module, eventId, sizePtr uint32) uint32 {
+	data, err := f.getWebSocketEvent(eventId)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	return uint32(f.WriteStringSize(module, sizePtr, data.connection))
+}
+
+func (f *Factory) getWebSocketEventConnection(ctx context.Context, module common.Module, eventId, connectionPtr uint32) uint32 {
+	data, err := f.getWebSocketEvent(eventId)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	return uint32(f.WriteString(module, connectionPtr, data.connection))
+}
+
+func (f *Factory) getWebSocketEventKind(ctx context.Context, module common.Module, eventId, kindPtr uint32) uint32 {
+	data, err := f.getWebSocketEvent(eventId)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	return uint32(f.WriteUint32Le(module, kindPtr, uint32(data.kind)))
+}
+
+func (f *Factory) getWebSocketEventBinary(ctx context.Context, module common.Module, eventId, binaryPtr uint32) uint32 {
+	data, err := f.getWebSocketEvent(eventId)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	return uint32(f.WriteBool(module, binaryPtr, data.binary))
+}
+
+func (f *Factory) getWebSocketEventDataSize(ctx context.Context, module common.Module, eventId, sizePtr uint32) uint32 {
+	data, err := f.getWebSocketEvent(eventId)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	return uint32(f.WriteBytesSize(module, sizePtr, data.data))
+}
+
+func (f *Factory) getWebSocketEventData(ctx context.Context, module common.Module, eventId, bufPtr uint32) uint32 {
+	data, err := f.getWebSocketEvent(eventId)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	return uint32(f.WriteBytes(module, bufPtr, data.data))
+}
@@ -2,6 +2,10 @@ package client
 
 import (
 	"context"
+	"io"
+	"net/http"
+
+	"github.com/taubyte/go-sdk/errno"
 
 	common "github.com/taubyte/tau/core/vm"
 	"github.com/taubyte/tau/pkg/vm-low-orbit/memory"
@@ -20,3 +24,104 @@ func (f *Factory) setHttpRequestBody(ctx context.Context, module common.Module,
 
 	return uint32(0)
 }
+
+// openBody starts sending req with a body streamed through the returned
+// bodyStream; the request must be complete but for its body.
+func (client *Client) openBody(ctx context.Context, req *Request) *bodyStream {
+	pr, pw := io.Pipe()
+	req.Body = pr
+	req.ContentLength = -1
+
+	s := &bodyStream{w: pw, done: make(chan struct{})}
+	req.body = s
+
+	go func() {
+		defer close(s.done)
+		s.resp, s.err = client.do(ctx, req)
+		if s.err != nil {
+			// unblock a guest writing to a request that failed
+			pr.CloseWithError(s.err)
+		}
+	}()
+
+	return s
+}
+
+// write blocks until the transport has sent p, or the request failed.
+func (s *bodyStream) write(p []byte) (int, error) {
+	return s.w.Write(p)
+}
+
+// wait ends the body, if the guest did not, and returns the response.
+func (s *bodyStream) wait() (*http.Response, error) {
+	s.w.Close()
+	<-s.done
+	return s.resp, s.err
+}
+
+func (f *Factory) openHttpRequestBody(ctx context.Context, module common.Module,
+	clientId, requestId uint32,
+) uint32 {
+	client, req, err := f.getClientAndRequest(clientId, requestId)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	if req.body != nil {
+		return uint32(errno.ErrorHttpWriteBodyFailed)
+	}
+
+	// The host-call ctx ends with the function's call, cancelling the request.
+	client.openBody(ctx, req)
+
+	return 0
+}
+
+func (f *Factory) writeHttpRequestBody(ctx context.Context, module common.Module,
+	clientId, requestId,
+	bufPtr, bufSize,
+	wroteNPtr uint32,
+) uint32 {
+	_, req, err := f.getClientAndRequest(clientId, requestId)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	if req.body == nil {
+		return uint32(errno.ErrorHttpWriteBodyFailed)
+	}
+
+	// The chunk is sent straight from guest memory: write returns only once
+	// the transport is done with it.
+	buf, err := f.ReadBytes(module, bufPtr, bufSize)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	n, err0 := req.body.write(buf)
+	if err0 != nil {
+		f.WriteUint32Le(module, wroteNPtr, uint32(n))
+		return uint32(errno.ErrorHttpWriteBodyFailed)
+	}
+
+	return uint32(f.WriteUint32Le(module, wroteNPtr, uint32(n)))
+}
+
+func (f *Factory) closeHttpRequestBody(ctx context.Context, module common.Module,
+	clientId, requestId uint32,
+) uint32 {
+	_, req, err := f.getClientAndRequest(clientId, requestId)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	if req.body == nil {
+		return uint32(errno.ErrorHttpWriteBodyFailed)
+	}
+
+	if req.body.w.Close() != nil {
+		return uint32(errno.ErrorCloseBody)
+	}
+
+	return 0
+}
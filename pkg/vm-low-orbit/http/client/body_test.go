@@ -0,0 +1,129 @@
+package client
+
+import (
+	"context"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// testClient is a guest client without the egress guard, which denies the
+// loopback httptest binds.
+func testClient() *Client {
+	return &Client{Client: &http.Client{Transport: &http.Transport{}}, reqs: make(map[uint32]*Request)}
+}
+
+func TestStreamedRequestBody(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength != -1 {
+			t.Errorf("expected a chunked body, got content length %d", r.ContentLength)
+		}
+		io.Copy(w, r.Body)
+	}))
+	defer srv.Close()
+
+	client := testClient()
+	_r, err := http.NewRequest(http.MethodPost, srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := &Request{Request: _r}
+
+	body := client.openBody(context.Background(), req)
+	for _, chunk := range []string{"hello ", "streamed ", "world"} {
+		if _, err = body.write([]byte(chunk)); err != nil {
+			t.Fatalf("writing chunk failed with: %s", err)
+		}
+	}
+
+	resp, err := body.wait()
+	if err != nil {
+		t.Fatalf("request failed with: %s", err)
+	}
+	defer resp.Body.Close()
+
+	data, _ := io.ReadAll(resp.Body)
+	if string(data) != "hello streamed world" {
+		t.Fatalf("server got %q", data)
+	}
+}
+
+// A failed request must not leave the guest blocked writing its body.
+func TestStreamedRequestBodyFailure(t *testing.T) {
+	client := restrictedHTTPClient()
+	_r, err := http.NewRequest(http.MethodPost, "http://127.0.0.1:1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := &Request{Request: _r}
+
+	body := (&Client{Client: client}).openBody(context.Background(), req)
+	if _, err = body.write([]byte("data")); err == nil {
+		t.Fatal("write to a denied upstream succeeded")
+	}
+
+	if _, err = body.wait(); err == nil {
+		t.Fatal("request to a denied upstream succeeded")
+	}
+}
+
+func TestRequestRedirectPolicy(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/to", http.StatusFound)
+	}))
+	defer srv.Close()
+
+	client := testClient()
+	newRequest := func(redirects int) *Request {
+		_r, err := http.NewRequest(http.MethodGet, srv.URL+"/from", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &Request{Request: _r, redirects: &redirects}
+	}
+
+	// without redirects, the guest gets the redirect itself
+	resp, err := client.do(context.Background(), newRequest(0))
+	if err != nil {
+		t.Fatalf("request failed with: %s", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusFound {
+		t.Fatalf("expected the redirect, got status %d", resp.StatusCode)
+	}
+
+	// followed redirects are still subject to the egress policy
+	_, err = client.do(context.Background(), newRequest(1))
+	if err == nil || !strings.Contains(err.Error(), "not permitted") {
+		t.Fatalf("expected a netguard rejection of the redirect, got: %v", err)
+	}
+}
+
+// Clients share the transport, and its keep-alive connections, of their TLS
+// identity.
+func TestSharedTransports(t *testing.T) {
+	if restrictedHTTPClient().Transport != restrictedHTTPClient().Transport {
+		t.Fatal("clients without a TLS identity don't share a transport")
+	}
+
+	if _, err := transportFor(&tlsIdentity{cert: []byte("not a certificate")}); err == nil {
+		t.Fatal("invalid certificate accepted")
+	}
+
+	srv := httptest.NewTLSServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	t1, err := transportFor(&tlsIdentity{rootCAs: ca})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t2, _ := transportFor(&tlsIdentity{rootCAs: ca})
+	if t1 != t2 || t1 == restrictedHTTPClient().Transport {
+		t.Fatal("transports not keyed by TLS identity")
+	}
+}
@@ -1,11 +1,11 @@
 package client
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"net"
 	"net/http"
-	"time"
 
 	"github.com/taubyte/go-sdk/errno"
 	common "github.com/taubyte/tau/core/vm"
@@ -13,34 +13,39 @@ import (
 )
 
 // restrictedHTTPClient builds the http.Client handed to a guest function. Its
-// dialer enforces the egress policy (netguard) on every connection — including
-// redirects, which re-dial through the same transport — so a function cannot
-// reach node-local services or the cloud metadata endpoint. The timeout bounds
-// a hung request so it can't pin the pooled instance (which is freed only after
-// the synchronous call returns).
+// transport is shared (see transportFor) and its dialer enforces the egress
+// policy (netguard) on every connection — including redirects, which re-dial
+// through the same transport — so a function cannot reach node-local services
+// or the cloud metadata endpoint. The timeout bounds a hung request so it can't
+// pin the pooled instance (which is freed only after the synchronous call
+// returns).
 func restrictedHTTPClient() *http.Client {
+	transport, _ := transportFor(nil)
 	return &http.Client{
-		Timeout: 30 * time.Second,
-		Transport: &http.Transport{
-			DialContext:           netguard.RestrictedDialer(10 * time.Second).DialContext,
-			ForceAttemptHTTP2:     true,
-			MaxIdleConns:          8,
-			IdleConnTimeout:       30 * time.Second,
-			TLSHandshakeTimeout:   10 * time.Second,
-			ExpectContinueTimeout: time.Second,
-		},
+		Timeout:       DefaultTimeout,
+		Transport:     transport,
+		CheckRedirect: checkRedirect(MaxRedirects),
+	}
+}
+
+// checkRedirect stops following redirects once max requests were made, like
+// net/http's default policy; with max of zero, the redirect response itself
+// is returned to the guest.
+func checkRedirect(max int) func(req *http.Request, via []*http.Request) error {
+	return func(req *http.Request, via []*http.Request) error {
+		if max == 0 {
+			return http.ErrUseLastResponse
+		}
+		if len(via) >= max {
+			return fmt.Errorf("stopped after %d redirects", max)
+		}
 		// The dial guard already blocks a redirect to a denied IP (each redirect
 		// re-dials through the transport). This is belt-and-suspenders on the
-		// literal target host, and caps redirect chains.
-		CheckRedirect: func(req *http.Request, via []*http.Request) error {
-			if len(via) >= 10 {
-				return fmt.Errorf("stopped after 10 redirects")
-			}
-			if ip := net.ParseIP(req.URL.Hostname()); ip != nil && netguard.IsDenied(ip) {
-				return fmt.Errorf("netguard: redirect to %s not permitted", req.URL.Host)
-			}
-			return nil
-		},
+		// literal target host.
+		if ip := net.ParseIP(req.URL.Hostname()); ip != nil && netguard.IsDenied(ip) {
+			return fmt.Errorf("netguard: redirect to %s not permitted", req.URL.Host)
+		}
+		return nil
 	}
 }
 
@@ -69,3 +74,69 @@ func (f *Factory) newHttpClient(ctx context.Context, module common.Module,
 
 	return uint32(f.WriteUint32Le(module, clientIdPtr, c.Id))
 }
+
+// setTLS switches the client to the shared transport of its TLS identity once
+// set is applied to it.
+func (client *Client) setTLS(set func(id *tlsIdentity)) errno.Error {
+	client.tlsLock.Lock()
+	defer client.tlsLock.Unlock()
+
+	id := client.tls
+	set(&id)
+
+	transport, err := transportFor(&id)
+	if err != nil {
+		return ErrorTLSRejected
+	}
+
+	client.tls = id
+	client.Transport = transport
+
+	return 0
+}
+
+func (f *Factory) setHttpClientCertificate(ctx context.Context, module common.Module,
+	clientId,
+	certPtr, certLen,
+	keyPtr, keyLen uint32,
+) uint32 {
+	client, err := f.getClient(clientId)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	cert, err := f.ReadBytes(module, certPtr, certLen)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	key, err := f.ReadBytes(module, keyPtr, keyLen)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	// ReadBytes aliases guest memory, which the guest is free to reuse.
+	return uint32(client.setTLS(func(id *tlsIdentity) {
+		id.cert = bytes.Clone(cert)
+		id.key = bytes.Clone(key)
+	}))
+}
+
+func (f *Factory) setHttpClientRootCAs(ctx context.Context, module common.Module,
+	clientId,
+	caPtr, caLen uint32,
+) uint32 {
+	client, err := f.getClient(clientId)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	ca, err := f.ReadBytes(module, caPtr, caLen)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	return uint32(client.setTLS(func(id *tlsIdentity) {
+		id.rootCAs = bytes.Clone(ca)
+	}))
+}
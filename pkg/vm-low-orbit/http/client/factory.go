@@ -1,6 +1,8 @@
 package client
 
 import (
+	"io"
+
 	"github.com/taubyte/tau/core/vm"
 	"github.com/taubyte/tau/pkg/vm-low-orbit/helpers"
 )
@@ -14,6 +16,21 @@ func (f *Factory) Name() string {
 }
 
 func (f *Factory) Close() error {
+	f.clientsLock.Lock()
+	defer f.clientsLock.Unlock()
+
+	// end the bodies still streaming; connections stay with the shared
+	// transports for the next invocations
+	for _, client := range f.clients {
+		client.reqLock.RLock()
+		for _, req := range client.reqs {
+			if req.body != nil {
+				req.body.w.CloseWithError(io.ErrClosedPipe)
+			}
+		}
+		client.reqLock.RUnlock()
+	}
+
 	f.clients = nil
 	return nil
 }
@@ -25,4 +25,11 @@ func (f *Factory) RegisterHostFunctions(b wazy.HostModuleBuilder) {
 	wazy.HostFunc4(b.NewFunctionBuilder(), f.getHttpResponseHeaderKeys).Export("getHttpResponseHeaderKeys")
 	wazy.HostFunc3(b.NewFunctionBuilder(), f.setHttpRequestMethod).Export("setHttpRequestMethod")
 	wazy.HostFunc3(b.NewFunctionBuilder(), f.getHttpRequestMethod).Export("getHttpRequestMethod")
+	wazy.HostFunc2(b.NewFunctionBuilder(), f.openHttpRequestBody).Export("openHttpRequestBody")
+	wazy.HostFunc5(b.NewFunctionBuilder(), f.writeHttpRequestBody).Export("writeHttpRequestBody")
+	wazy.HostFunc2(b.NewFunctionBuilder(), f.closeHttpRequestBody).Export("closeHttpRequestBody")
+	wazy.HostFunc3(b.NewFunctionBuilder(), f.setHttpRequestTimeout).Export("setHttpRequestTimeout")
+	wazy.HostFunc3(b.NewFunctionBuilder(), f.setHttpRequestRedirects).Export("setHttpRequestRedirects")
+	wazy.HostFunc5(b.NewFunctionBuilder(), f.setHttpClientCertificate).Export("setHttpClientCertificate")
+	wazy.HostFunc3(b.NewFunctionBuilder(), f.setHttpClientRootCAs).Export("setHttpClientRootCAs")
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	urlpkg "net/url"
+	"time"
 
 	"github.com/taubyte/go-sdk/errno"
 	common "github.com/taubyte/tau/core/vm"
@@ -94,8 +95,15 @@ func (f *Factory) doHttpRequest(ctx context.Context, module common.Module,
 	// Thread the host-call ctx so the guest's outbound request is cancelled when
 	// the function's deadline passes — otherwise a slow/blackhole endpoint pins
 	// the pooled instance, which is freed only after this synchronous call.
-	var _err error
-	resp, _err := client.Do(req.Request.WithContext(ctx))
+	var (
+		resp *http.Response
+		_err error
+	)
+	if req.body != nil {
+		resp, _err = req.body.wait()
+	} else {
+		resp, _err = client.do(ctx, req)
+	}
 	if _err != nil {
 		return uint32(errno.ErrorHttpRequestFailed)
 	}
@@ -103,3 +111,52 @@ func (f *Factory) doHttpRequest(ctx context.Context, module common.Module,
 	req.Response = resp
 	return uint32(client.setRequest(req))
 }
+
+// do sends req with the client, under the request's own timeout and redirect
+// policy when it has one.
+func (client *Client) do(ctx context.Context, req *Request) (*http.Response, error) {
+	client.tlsLock.RLock()
+	c := *client.Client
+	client.tlsLock.RUnlock()
+
+	if req.timeout > 0 {
+		c.Timeout = req.timeout
+	}
+
+	if req.redirects != nil {
+		c.CheckRedirect = checkRedirect(*req.redirects)
+	}
+
+	return c.Do(req.Request.WithContext(ctx))
+}
+
+func (f *Factory) setHttpRequestTimeout(ctx context.Context, module common.Module,
+	clientId,
+	requestId,
+	timeoutMs uint32,
+) uint32 {
+	_, req, err := f.getClientAndRequest(clientId, requestId)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	req.timeout = min(time.Duration(timeoutMs)*time.Millisecond, MaxTimeout)
+
+	return 0
+}
+
+func (f *Factory) setHttpRequestRedirects(ctx context.Context, module common.Module,
+	clientId,
+	requestId,
+	maxRedirects uint32,
+) uint32 {
+	_, req, err := f.getClientAndRequest(clientId, requestId)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	redirects := min(int(maxRedirects), MaxRedirects)
+	req.redirects = &redirects
+
+	return 0
+}
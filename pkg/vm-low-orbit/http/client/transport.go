@@ -0,0 +1,138 @@
+package client
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/taubyte/tau/pkg/netguard"
+)
+
+// transports are shared by the guest clients of every instance, keyed by their
+// TLS identity, so that keep-alive connections outlive a single invocation
+// instead of being dialed anew for each. Every one of them dials through the
+// egress policy.
+var transports = struct {
+	lock sync.Mutex
+	m    map[string]*sharedTransport
+}{m: make(map[string]*sharedTransport)}
+
+type sharedTransport struct {
+	*http.Transport
+	lastUsed time.Time
+}
+
+// tlsIdentity is the client certificate and trusted roots a guest client
+// presents to mTLS upstreams, as PEM.
+type tlsIdentity struct {
+	cert, key []byte
+	rootCAs   []byte
+}
+
+func (id *tlsIdentity) isZero() bool {
+	return id == nil || (len(id.cert) == 0 && len(id.rootCAs) == 0)
+}
+
+func (id *tlsIdentity) hash() string {
+	if id.isZero() {
+		return ""
+	}
+
+	h := sha256.New()
+	for _, b := range [][]byte{id.cert, id.key, id.rootCAs} {
+		h.Write(b)
+		h.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (id *tlsIdentity) config() (*tls.Config, error) {
+	if id.isZero() {
+		return nil, nil
+	}
+
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if len(id.cert) > 0 {
+		cert, err := tls.X509KeyPair(id.cert, id.key)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	if len(id.rootCAs) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(id.rootCAs) {
+			return nil, errors.New("no certificate found in root CAs")
+		}
+		cfg.RootCAs = pool
+	}
+
+	return cfg, nil
+}
+
+func newTransport(tlsConfig *tls.Config) *http.Transport {
+	return &http.Transport{
+		DialContext:           netguard.RestrictedDialer(10 * time.Second).DialContext,
+		TLSClientConfig:       tlsConfig,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          MaxIdleConns,
+		MaxIdleConnsPerHost:   MaxIdleConnsPerHost,
+		IdleConnTimeout:       IdleConnTimeout,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: time.Second,
+	}
+}
+
+// transportFor returns the shared transport of id, creating it if needed. When
+// there are more than MaxTransports, the least recently used one is dropped.
+func transportFor(id *tlsIdentity) (*http.Transport, error) {
+	key := id.hash()
+
+	transports.lock.Lock()
+	defer transports.lock.Unlock()
+
+	if t, ok := transports.m[key]; ok {
+		t.lastUsed = time.Now()
+		return t.Transport, nil
+	}
+
+	tlsConfig, err := id.config()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(transports.m) >= MaxTransports {
+		evictTransport()
+	}
+
+	t := &sharedTransport{Transport: newTransport(tlsConfig), lastUsed: time.Now()}
+	transports.m[key] = t
+
+	return t.Transport, nil
+}
+
+// evictTransport drops the least recently used transport; its connections
+// close once idle. The transport lock must be held.
+func evictTransport() {
+	var (
+		oldest string
+		t      *sharedTransport
+	)
+	for key, st := range transports.m {
+		if t == nil || st.lastUsed.Before(t.lastUsed) {
+			oldest, t = key, st
+		}
+	}
+
+	if t != nil {
+		delete(transports.m, oldest)
+		t.CloseIdleConnections()
+	}
+}
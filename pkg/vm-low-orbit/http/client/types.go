@@ -2,8 +2,10 @@ package client
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/taubyte/tau/core/vm"
 	"github.com/taubyte/tau/pkg/vm-low-orbit/helpers"
@@ -26,9 +28,27 @@ type Client struct {
 	reqLock     sync.RWMutex
 	reqIdToGrab uint32
 	reqs        map[uint32]*Request
+	tlsLock     sync.RWMutex
+	tls         tlsIdentity
 }
 
 type Request struct {
 	*http.Request
 	Id uint32
+
+	// timeout and redirects override the client's policy when set.
+	timeout   time.Duration
+	redirects *int
+
+	// body streams the request body while it is being sent.
+	body *bodyStream
+}
+
+// bodyStream is a request body the guest writes in chunks. The request is sent
+// as the body is opened, and its response is ready once done is closed.
+type bodyStream struct {
+	w    *io.PipeWriter
+	done chan struct{}
+	resp *http.Response
+	err  error
 }
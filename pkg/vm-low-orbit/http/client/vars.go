@@ -0,0 +1,29 @@
+package client
+
+import (
+	"time"
+
+	"github.com/taubyte/go-sdk/errno"
+)
+
+// ErrorTLSRejected is returned when the certificate, key or root CAs set on a
+// client do not load. The sdk has no errno for TLS material, so it shares the
+// one of a request that could not be created: none can be with it.
+const ErrorTLSRejected = errno.ErrorNewRequestFailed
+
+var (
+	// DefaultTimeout bounds a request unless the guest sets its own timeout,
+	// which is capped at MaxTimeout.
+	DefaultTimeout = 30 * time.Second
+	MaxTimeout     = 5 * time.Minute
+
+	// MaxRedirects caps the redirects a request follows, whatever its policy.
+	MaxRedirects = 10
+
+	// MaxTransports caps the shared transports, one per TLS identity.
+	MaxTransports = 64
+
+	MaxIdleConns        = 64
+	MaxIdleConnsPerHost = 8
+	IdleConnTimeout     = 90 * time.Second
+)
@@ -5,8 +5,10 @@ import (
 	"errors"
 
 	"github.com/taubyte/tau/core/services/substrate/components/database"
+	"github.com/taubyte/tau/core/services/substrate/components/http"
 	"github.com/taubyte/tau/core/services/substrate/components/p2p"
 	"github.com/taubyte/tau/core/services/substrate/components/pubsub"
+	"github.com/taubyte/tau/core/services/substrate/components/queue"
 	"github.com/taubyte/tau/core/services/substrate/components/storage"
 	"github.com/taubyte/tau/core/vm"
 	"github.com/taubyte/tau/pkg/vm-low-orbit/crypto/rand"
@@ -21,7 +23,9 @@ import (
 	"github.com/taubyte/tau/pkg/vm-low-orbit/self"
 
 	vmpubsub "github.com/taubyte/tau/pkg/vm-low-orbit/pubsub"
+	vmqueue "github.com/taubyte/tau/pkg/vm-low-orbit/queue"
 	vmstorage "github.com/taubyte/tau/pkg/vm-low-orbit/storage"
+	vmwebsocket "github.com/taubyte/tau/pkg/vm-low-orbit/websocket"
 
 	kvdb "github.com/taubyte/tau/pkg/vm-low-orbit/database/client"
 )
@@ -33,6 +37,8 @@ type plugin struct {
 	databaseNode database.Service
 	storageNode  storage.Service
 	p2pNode      p2p.Service
+	socketsNode  http.WebSockets
+	queueNode    queue.Service
 }
 
 func (p *plugin) setNode(nodeService interface{}) error {
@@ -49,6 +55,10 @@ func (p *plugin) setNode(nodeService interface{}) error {
 		p.storageNode = service
 	case p2p.Service:
 		p.p2pNode = service
+	case http.WebSockets:
+		p.socketsNode = service
+	case queue.Service:
+		p.queueNode = service
 	default:
 		return errors.New("not a valid node service")
 	}
@@ -73,6 +83,8 @@ func (p *plugin) New(instance vm.Instance) (vm.PluginInstance, error) {
 			vmstorage.New(instance, p.storageNode, helperMethods),
 			kvdb.New(instance, p.databaseNode, helperMethods),
 			p2pClient.New(instance, p.p2pNode, helperMethods),
+			vmwebsocket.New(instance, p.socketsNode, helperMethods),
+			vmqueue.New(instance, p.queueNode, helperMethods),
 			dns.New(instance, helperMethods),
 			self.New(instance, helperMethods),
 			globals.New(instance, p.databaseNode, helperMethods),
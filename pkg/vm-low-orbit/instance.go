@@ -14,13 +14,14 @@ type pluginInstance struct {
 }
 
 // LoadFactory registers a factory's host functions onto the host-module
-// builder. Every factory in the plugin implements vm.HostFunctionProvider.
+// builder, grouped under the factory name in the runtime stats. Every factory
+// in the plugin implements vm.HostFunctionProvider.
 func (i *pluginInstance) LoadFactory(factory vm.Factory, hm vm.HostModule) error {
 	provider, ok := factory.(vm.HostFunctionProvider)
 	if !ok {
 		return fmt.Errorf("factory %q (%T) does not provide host functions", factory.Name(), factory)
 	}
-	provider.RegisterHostFunctions(hm.Builder())
+	provider.RegisterHostFunctions(hm.Group(factory.Name()))
 	return nil
 }
 func (i *pluginInstance) Load(hm vm.HostModule) (moduleInstance vm.ModuleInstance, err error) {
@@ -33,6 +33,7 @@ func (f *plainFactory) Name() string { return "plainFactory" }
 type mockHostModule struct{ builder wazy.HostModuleBuilder }
 
 func (m *mockHostModule) Builder() wazy.HostModuleBuilder     { return m.builder }
+func (m *mockHostModule) Group(string) wazy.HostModuleBuilder { return m.builder }
 func (m *mockHostModule) Compile() (vm.ModuleInstance, error) { return nil, nil }
 
 func newMockHostModule() *mockHostModule {
@@ -5,10 +5,12 @@ import (
 	"fmt"
 	"net/http"
 	"runtime/debug"
+	"time"
 
 	"github.com/taubyte/tau/p2p/streams/command"
 	res "github.com/taubyte/tau/p2p/streams/command/response"
 
+	"github.com/taubyte/tau/core/kvdb"
 	pubsubIface "github.com/taubyte/tau/core/services/substrate/components/pubsub"
 	"github.com/taubyte/tau/core/vm"
 	"github.com/taubyte/tau/pkg/vm-low-orbit/event"
@@ -24,6 +26,10 @@ type eventApi interface {
 	CreateHttpEvent(w http.ResponseWriter, r *http.Request) *event.Event
 	CreatePubsubEvent(msg pubsubIface.Message) *event.Event
 	CreateP2PEvent(cmd *command.Command, response res.Response) *event.Event
+	CreateWebSocketEvent(connection string, kind event.WebSocketEventKind, data []byte, binary bool) *event.Event
+	CreateCronEvent(schedule string, tick time.Time) *event.Event
+	CreateQueueEvent(queue string, message uint64, data []byte, attempt uint32) *event.Event
+	CreateDatabaseEvent(database string, change kvdb.Event) *event.Event
 }
 
 var With = func(pi vm.PluginInstance) (Instance, error) {
@@ -9,4 +9,6 @@ func (f *Factory) RegisterHostFunctions(b wazy.HostModuleBuilder) {
 	wazy.HostFunc2(b.NewFunctionBuilder(), f.setSubscriptionChannel).Export("setSubscriptionChannel")
 	wazy.HostFunc3(b.NewFunctionBuilder(), f.getWebSocketURLSize).Export("getWebSocketURLSize")
 	wazy.HostFunc3(b.NewFunctionBuilder(), f.getWebSocketURL).Export("getWebSocketURL")
+	wazy.HostFunc2(b.NewFunctionBuilder(), f.getMQTTTokenSize).Export("getMQTTTokenSize")
+	wazy.HostFunc2(b.NewFunctionBuilder(), f.getMQTTToken).Export("getMQTTToken")
 }
@@ -0,0 +1,49 @@
+package pubsub
+
+import (
+	"context"
+	"time"
+
+	"github.com/taubyte/go-sdk/errno"
+	common "github.com/taubyte/tau/core/vm"
+)
+
+func (f *Factory) getToken(expiry uint32) (token string, err errno.Error) {
+	_ctx := f.parent.Context()
+	token, err0 := f.pubsubNode.MQTTToken(_ctx.Project(), _ctx.Application(), time.Unix(int64(expiry), 0))
+	if err0 != nil {
+		// the sdk has no errno of its own for mqtt
+		return "", errno.ErrorSubscribeFailed
+	}
+
+	return token, 0
+}
+
+// getMQTTTokenSize writes the size of the token MQTT clients connect with to
+// the channels of the function, valid until expiry in unix seconds. Nodes
+// refuse expiries more than a day away, so clients renew their tokens.
+func (f *Factory) getMQTTTokenSize(ctx context.Context, module common.Module,
+	expiry,
+	sizePtr uint32,
+) uint32 {
+
+	token, err := f.getToken(expiry)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	return uint32(f.WriteStringSize(module, sizePtr, token))
+}
+
+func (f *Factory) getMQTTToken(ctx context.Context, module common.Module,
+	expiry,
+	tokenPtr uint32,
+) uint32 {
+
+	token, err := f.getToken(expiry)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	return uint32(f.WriteString(module, tokenPtr, token))
+}
@@ -0,0 +1,19 @@
+package queue
+
+import (
+	queueIface "github.com/taubyte/tau/core/services/substrate/components/queue"
+	"github.com/taubyte/tau/core/vm"
+	"github.com/taubyte/tau/pkg/vm-low-orbit/helpers"
+)
+
+func New(i vm.Instance, queueNode queueIface.Service, helper helpers.Methods) *Factory {
+	return &Factory{parent: i, ctx: i.Context().Context(), queueNode: queueNode, Methods: helper}
+}
+
+func (f *Factory) Name() string {
+	return "queue"
+}
+
+func (f *Factory) Close() error {
+	return nil
+}
@@ -0,0 +1,10 @@
+package queue
+
+import wazy "github.com/samyfodil/wazy"
+
+// RegisterHostFunctions registers this factory's host functions on the wasm
+// host-module builder.
+func (f *Factory) RegisterHostFunctions(b wazy.HostModuleBuilder) {
+	wazy.HostFunc5(b.NewFunctionBuilder(), f.queueEnqueue).Export("queueEnqueue")
+	wazy.HostFunc3(b.NewFunctionBuilder(), f.queueAck).Export("queueAck")
+}
@@ -0,0 +1,72 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/taubyte/go-sdk/errno"
+	queueIface "github.com/taubyte/tau/core/services/substrate/components/queue"
+	common "github.com/taubyte/tau/core/vm"
+	"github.com/taubyte/tau/pkg/vm-low-orbit/memory"
+)
+
+// queueEnqueue adds the body as a message of the queue named, and writes the
+// id of the message at idPtr.
+func (f *Factory) queueEnqueue(ctx context.Context, module common.Module,
+	queuePtr, queueLen,
+	bodyPtr, bodySize,
+	idPtr uint32,
+) uint32 {
+	queue, err := f.ReadString(module, queuePtr, queueLen)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	readCloser := memory.New(f.ctx, module.Memory(), bodyPtr, bodySize)
+	defer readCloser.Close()
+	data, err0 := io.ReadAll(readCloser)
+	if err0 != nil {
+		return uint32(errno.ErrorEOF)
+	}
+
+	instanceCtx := f.parent.Context()
+	id, err0 := f.queueNode.Enqueue(ctx, instanceCtx.Project(), instanceCtx.Application(), queue, data)
+	if err0 != nil {
+		return uint32(queueErrno(err0, errno.ErrorDatabasePutFailed))
+	}
+
+	return uint32(f.WriteUint64Le(module, idPtr, id))
+}
+
+// queueAck acks the message whose id is at idPtr, so the queue named does not
+// deliver it again.
+func (f *Factory) queueAck(ctx context.Context, module common.Module,
+	queuePtr, queueLen,
+	idPtr uint32,
+) uint32 {
+	queue, err := f.ReadString(module, queuePtr, queueLen)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	id, err := f.ReadUint64Le(module, idPtr)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	instanceCtx := f.parent.Context()
+	if err0 := f.queueNode.Ack(ctx, instanceCtx.Project(), instanceCtx.Application(), queue, id); err0 != nil {
+		return uint32(queueErrno(err0, errno.ErrorDatabaseDeleteFailed))
+	}
+
+	return 0
+}
+
+func queueErrno(err error, fallback errno.Error) errno.Error {
+	if errors.Is(err, queueIface.ErrQueueNotFound) {
+		return errno.ErrorChannelNotFound
+	}
+
+	return fallback
+}
@@ -0,0 +1,21 @@
+package queue
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/taubyte/go-sdk/errno"
+	queueIface "github.com/taubyte/tau/core/services/substrate/components/queue"
+)
+
+func TestQueueErrno(t *testing.T) {
+	for err, expected := range map[error]errno.Error{
+		fmt.Errorf("resolving: %w", queueIface.ErrQueueNotFound): errno.ErrorChannelNotFound,
+		errors.New("no leader"):                                  errno.ErrorDatabasePutFailed,
+	} {
+		if got := queueErrno(err, errno.ErrorDatabasePutFailed); got != expected {
+			t.Errorf("queueErrno(%q) = %d, want %d", err, got, expected)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package queue
+
+import (
+	"context"
+
+	queueIface "github.com/taubyte/tau/core/services/substrate/components/queue"
+	"github.com/taubyte/tau/core/vm"
+	"github.com/taubyte/tau/pkg/vm-low-orbit/helpers"
+)
+
+type Factory struct {
+	helpers.Methods
+	queueNode queueIface.Service
+	parent    vm.Instance
+	ctx       context.Context
+}
+
+var _ vm.Factory = &Factory{}
@@ -0,0 +1,19 @@
+package websocket
+
+import (
+	httpIface "github.com/taubyte/tau/core/services/substrate/components/http"
+	"github.com/taubyte/tau/core/vm"
+	"github.com/taubyte/tau/pkg/vm-low-orbit/helpers"
+)
+
+func New(i vm.Instance, socketsNode httpIface.WebSockets, helper helpers.Methods) *Factory {
+	return &Factory{parent: i, ctx: i.Context().Context(), socketsNode: socketsNode, Methods: helper}
+}
+
+func (f *Factory) Name() string {
+	return "websocket"
+}
+
+func (f *Factory) Close() error {
+	return nil
+}
@@ -0,0 +1,10 @@
+package websocket
+
+import wazy "github.com/samyfodil/wazy"
+
+// RegisterHostFunctions registers this factory's host functions on the wasm
+// host-module builder.
+func (f *Factory) RegisterHostFunctions(b wazy.HostModuleBuilder) {
+	wazy.HostFunc5(b.NewFunctionBuilder(), f.websocketSend).Export("websocketSend")
+	wazy.HostFunc5(b.NewFunctionBuilder(), f.websocketClose).Export("websocketClose")
+}
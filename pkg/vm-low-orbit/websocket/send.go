@@ -0,0 +1,95 @@
+package websocket
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/taubyte/go-sdk/errno"
+	httpIface "github.com/taubyte/tau/core/services/substrate/components/http"
+	common "github.com/taubyte/tau/core/vm"
+	"github.com/taubyte/tau/pkg/vm-low-orbit/memory"
+)
+
+// maxCloseReason is the longest reason a close frame has room for.
+const maxCloseReason = 123
+
+func (f *Factory) websocketSend(ctx context.Context, module common.Module,
+	connectionPtr, connectionLen,
+	bodyPtr, bodySize,
+	binary uint32,
+) uint32 {
+	connection, err := f.ReadString(module, connectionPtr, connectionLen)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	_binary, err := f.ReadBool(module, binary)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	readCloser := memory.New(f.ctx, module.Memory(), bodyPtr, bodySize)
+	defer readCloser.Close()
+	data, err0 := io.ReadAll(readCloser)
+	if err0 != nil {
+		return uint32(errno.ErrorEOF)
+	}
+
+	err0 = f.socketsNode.Send(ctx, f.parent.Context().Project(), connection, data, _binary)
+	if err0 != nil {
+		return uint32(socketErrno(err0))
+	}
+
+	return 0
+}
+
+func (f *Factory) websocketClose(ctx context.Context, module common.Module,
+	connectionPtr, connectionLen,
+	code,
+	reasonPtr, reasonLen uint32,
+) uint32 {
+	if !validCloseCode(code) || reasonLen > maxCloseReason {
+		return uint32(errno.ErrorCap)
+	}
+
+	connection, err := f.ReadString(module, connectionPtr, connectionLen)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	reason, err := f.ReadString(module, reasonPtr, reasonLen)
+	if err != 0 {
+		return uint32(err)
+	}
+
+	err0 := f.socketsNode.CloseConnection(ctx, f.parent.Context().Project(), connection, int(code), reason)
+	if err0 != nil {
+		return uint32(socketErrno(err0))
+	}
+
+	return 0
+}
+
+// validCloseCode reports whether code can be sent in a close frame; 0 stands
+// for a normal closure. The codes reserved to report failures locally are not.
+func validCloseCode(code uint32) bool {
+	switch {
+	case code == 0:
+		return true
+	case code < 1000 || code > 4999:
+		return false
+	case code == 1004, code == 1005, code == 1006, code == 1015:
+		return false
+	default:
+		return true
+	}
+}
+
+func socketErrno(err error) errno.Error {
+	if errors.Is(err, httpIface.ErrConnectionNotFound) {
+		return errno.ErrorClientNotFound
+	}
+
+	return errno.ErrorHttpWrite
+}
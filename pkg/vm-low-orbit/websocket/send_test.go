@@ -0,0 +1,14 @@
+package websocket
+
+import "testing"
+
+func TestValidCloseCode(t *testing.T) {
+	for code, valid := range map[uint32]bool{
+		0: true, 1000: true, 1001: true, 1011: true, 3000: true, 4999: true,
+		999: false, 1004: false, 1005: false, 1006: false, 1015: false, 5000: false,
+	} {
+		if got := validCloseCode(code); got != valid {
+			t.Errorf("validCloseCode(%d) = %v, want %v", code, got, valid)
+		}
+	}
+}
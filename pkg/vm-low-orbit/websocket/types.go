@@ -0,0 +1,18 @@
+package websocket
+
+import (
+	"context"
+
+	httpIface "github.com/taubyte/tau/core/services/substrate/components/http"
+	"github.com/taubyte/tau/core/vm"
+	"github.com/taubyte/tau/pkg/vm-low-orbit/helpers"
+)
+
+type Factory struct {
+	helpers.Methods
+	socketsNode httpIface.WebSockets
+	parent      vm.Instance
+	ctx         context.Context
+}
+
+var _ vm.Factory = &Factory{}
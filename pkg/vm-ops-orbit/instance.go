@@ -40,7 +40,7 @@ func (i *pluginInstance) LoadFactory(factory vm.Factory, hm vm.HostModule) error
 	if !ok {
 		return fmt.Errorf("factory %q (%T) does not provide host functions", factory.Name(), factory)
 	}
-	provider.RegisterHostFunctions(hm.Builder())
+	provider.RegisterHostFunctions(hm.Group(factory.Name()))
 	return nil
 }
 func (i *pluginInstance) Load(hm vm.HostModule) (modInstance vm.ModuleInstance, err error) {
@@ -15,6 +15,7 @@ type Instance interface {
 
 type resourceApi interface {
 	CreateSmartOp(caller smartops.EventCaller) *common.Resource
+	ReleaseSmartOp(resource *common.Resource)
 }
 
 var With = func(pi vm.PluginInstance) (Instance, error) {
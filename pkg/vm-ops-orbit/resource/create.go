@@ -16,3 +16,11 @@ func (f *Factory) CreateSmartOp(caller smartops.EventCaller) *common.Resource {
 	f.resources[r.Id] = r
 	return r
 }
+
+// ReleaseSmartOp drops a resource once the smartop call it was created for
+// has returned.
+func (f *Factory) ReleaseSmartOp(r *common.Resource) {
+	f.resourceLock.Lock()
+	defer f.resourceLock.Unlock()
+	delete(f.resources, r.Id)
+}
@@ -0,0 +1,130 @@
+package fuel
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// GlobalPrefix prefixes the names ExportGlobals exports globals under; the
+// name of each is the prefix followed by its ordinal, from 0.
+const GlobalPrefix = "taubyte/global/"
+
+// ExportGlobals returns module with every mutable number global it defines
+// exported, so a host can save and reset the state the module keeps outside
+// its linear memory. It goes with metering as both rewrite the binary, and
+// runs before Meter, which then keeps the exports on the right globals.
+func ExportGlobals(module []byte) ([]byte, error) {
+	if !bytes.HasPrefix(module, magic) {
+		return nil, errors.New("not a wasm binary module")
+	}
+
+	sections, err := readSections(module[len(magic):])
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		imported uint32
+		defined  []uint32
+		exports  = -1
+	)
+	for i, s := range sections {
+		switch s.id {
+		case sectionImport:
+			imported, err = countGlobalImports(s.content)
+		case sectionGlobal:
+			defined, err = mutableGlobals(s.content)
+		case sectionExport:
+			exports = i
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("reading section %d failed with: %w", s.id, err)
+		}
+	}
+
+	if len(defined) == 0 {
+		return module, nil
+	}
+
+	if exports < 0 {
+		exports = 0
+		for exports < len(sections) && !followsExports(sections[exports].id) {
+			exports++
+		}
+		sections = append(sections[:exports], append([]section{{id: sectionExport, content: []byte{0x00}}}, sections[exports:]...)...)
+	}
+
+	// the globals a module defines are indexed after those it imports
+	for i := range defined {
+		defined[i] += imported
+	}
+
+	if sections[exports].content, err = addGlobalExports(sections[exports].content, defined); err != nil {
+		return nil, fmt.Errorf("exporting globals failed with: %w", err)
+	}
+
+	return writeSections(sections), nil
+}
+
+// followsExports reports whether a section of given id comes after the
+// export section in a module.
+func followsExports(id byte) bool {
+	switch id {
+	case sectionStart, sectionElem, sectionDataCount, sectionCode, sectionData:
+		return true
+	default:
+		return false
+	}
+}
+
+// mutableGlobals returns the position, in the global section, of the mutable
+// number globals it defines.
+func mutableGlobals(content []byte) ([]uint32, error) {
+	var (
+		mutable []uint32
+		i       uint32
+	)
+	_, err := vector(content, func(r reader, out []byte) ([]byte, error) {
+		globalType, err := r.bytes(2)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err = constExpr(r, func(i uint32) uint32 { return i }); err != nil {
+			return nil, err
+		}
+
+		switch globalType[0] {
+		case valueI32, valueI64, valueF32, valueF64:
+			if globalType[1] == 0x01 {
+				mutable = append(mutable, i)
+			}
+		}
+		i++
+
+		return out, nil
+	})
+
+	return mutable, err
+}
+
+func addGlobalExports(content []byte, globals []uint32) ([]byte, error) {
+	r := reader{bytes.NewReader(content)}
+
+	n, err := r.u32()
+	if err != nil {
+		return nil, err
+	}
+
+	out := appendU32(nil, n+uint32(len(globals)))
+	out = append(out, content[len(content)-r.Len():]...)
+	for i, idx := range globals {
+		out = appendName(out, GlobalPrefix+strconv.Itoa(i))
+		out = appendU32(append(out, externGlobal), idx)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,44 @@
+package fuel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/samyfodil/wazy"
+	"github.com/samyfodil/wazy/api"
+	"gotest.tools/v3/assert"
+)
+
+func TestExportGlobals(t *testing.T) {
+	ctx := context.Background()
+	rt := wazy.NewRuntime(ctx)
+	t.Cleanup(func() { rt.Close(ctx) })
+
+	_, err := rt.InstantiateWithConfig(ctx, Module, wazy.NewModuleConfig().WithName(ModuleName))
+	assert.NilError(t, err)
+
+	exported, err := ExportGlobals(spinModule)
+	assert.NilError(t, err)
+
+	metered, err := Meter(exported)
+	assert.NilError(t, err)
+
+	for name, module := range map[string][]byte{"exported": exported, "metered": metered} {
+		mod, err := rt.InstantiateWithConfig(ctx, module, wazy.NewModuleConfig().WithName(name))
+		assert.NilError(t, err)
+
+		g, ok := mod.ExportedGlobal(GlobalPrefix + "0").(api.MutableGlobal)
+		assert.Assert(t, ok)
+		assert.Equal(t, g.Get(), uint64(7))
+		assert.Assert(t, mod.ExportedGlobal(GlobalPrefix+"1") == nil)
+
+		// setting the export sets the global the module reads
+		g.Set(9)
+		assert.Equal(t, mod.ExportedGlobal("g").Get(), uint64(9))
+	}
+
+	// a module without mutable globals is left as is
+	unchanged, err := ExportGlobals(Module[:8])
+	assert.NilError(t, err)
+	assert.DeepEqual(t, unchanged, Module[:8])
+}
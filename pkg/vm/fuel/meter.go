@@ -0,0 +1,529 @@
+// Package fuel meters the CPU a WebAssembly module uses, deterministically.
+//
+// Meter rewrites a module so that every straight-line block of code charges
+// its instruction count against a global before it runs, and traps once the
+// global runs out. The global is imported from the counter module, Module,
+// which a runtime instantiates once so every metered module it runs charges
+// the same counter.
+//
+// The same module called with the same input always burns the same fuel,
+// whatever the load of the node it runs on.
+package fuel
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+const (
+	// ModuleName is the name the counter module must be instantiated under.
+	ModuleName = "taubyte/fuel"
+	// GlobalName is the counter global the counter module exports.
+	GlobalName = "fuel"
+
+	// Exhausted is the value the counter is set to when a module runs out
+	// of fuel, right before it traps.
+	Exhausted = math.MaxUint64
+)
+
+// Module is the counter module: a single mutable i64 global, exported.
+var Module = []byte{
+	0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00,
+	// global section: (global (mut i64) (i64.const 0))
+	sectionGlobal, 0x06, 0x01, valueI64, 0x01, opI64Const, 0x00, opEnd,
+	// export section: (export "fuel" (global 0))
+	sectionExport, 0x08, 0x01, 0x04, 'f', 'u', 'e', 'l', externGlobal, 0x00,
+}
+
+type section struct {
+	id      byte
+	content []byte
+}
+
+// Meter returns module metered against the counter of Module.
+func Meter(module []byte) ([]byte, error) {
+	if !bytes.HasPrefix(module, magic) {
+		return nil, errors.New("not a wasm binary module")
+	}
+
+	sections, err := readSections(module[len(magic):])
+	if err != nil {
+		return nil, err
+	}
+
+	// The counter is imported after every other global import, which makes
+	// its index the number of those; the globals the module defines shift by one.
+	var (
+		counter uint32
+		idx     = -1
+	)
+	for i, s := range sections {
+		if s.id == sectionImport {
+			if counter, err = countGlobalImports(s.content); err != nil {
+				return nil, fmt.Errorf("reading imports failed with: %w", err)
+			}
+			idx = i
+		}
+	}
+
+	if idx < 0 {
+		idx = 0
+		for idx < len(sections) && (sections[idx].id == sectionCustom || sections[idx].id == sectionType) {
+			idx++
+		}
+		sections = append(sections[:idx], append([]section{{id: sectionImport, content: []byte{0x00}}}, sections[idx:]...)...)
+	}
+
+	remap := func(i uint32) uint32 {
+		if i >= counter {
+			return i + 1
+		}
+		return i
+	}
+
+	for i := range sections {
+		s := &sections[i]
+
+		switch s.id {
+		case sectionImport:
+			s.content, err = addCounterImport(s.content)
+		case sectionGlobal:
+			s.content, err = rewriteGlobals(s.content, remap)
+		case sectionExport:
+			s.content, err = rewriteExports(s.content, remap)
+		case sectionElem:
+			s.content, err = rewriteElems(s.content, remap)
+		case sectionData:
+			s.content, err = rewriteData(s.content, remap)
+		case sectionCode:
+			s.content, err = meterCode(s.content, remap, counter)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("metering section %d failed with: %w", s.id, err)
+		}
+	}
+
+	return writeSections(sections), nil
+}
+
+func readSections(data []byte) ([]section, error) {
+	r := reader{bytes.NewReader(data)}
+
+	var sections []section
+	for r.Len() > 0 {
+		id, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+
+		size, err := r.u32()
+		if err != nil {
+			return nil, err
+		}
+
+		content, err := r.bytes(size)
+		if err != nil {
+			return nil, fmt.Errorf("reading section %d failed with: %w", id, err)
+		}
+
+		sections = append(sections, section{id: id, content: content})
+	}
+
+	return sections, nil
+}
+
+func writeSections(sections []section) []byte {
+	out := append([]byte{}, magic...)
+	for _, s := range sections {
+		out = append(out, s.id)
+		out = appendU32(out, uint32(len(s.content)))
+		out = append(out, s.content...)
+	}
+
+	return out
+}
+
+func skipName(r reader) error {
+	n, err := r.u32()
+	if err != nil {
+		return err
+	}
+	return r.skip(int(n))
+}
+
+func skipLimits(r reader) error {
+	flags, err := r.ReadByte()
+	if err != nil {
+		return err
+	}
+	if err = r.skipLEB(); err != nil {
+		return err
+	}
+	if flags&0x01 != 0 {
+		return r.skipLEB()
+	}
+	return nil
+}
+
+func countGlobalImports(content []byte) (uint32, error) {
+	r := reader{bytes.NewReader(content)}
+
+	n, err := r.u32()
+	if err != nil {
+		return 0, err
+	}
+
+	var globals uint32
+	for i := uint32(0); i < n; i++ {
+		if err = skipName(r); err != nil {
+			return 0, err
+		}
+		if err = skipName(r); err != nil {
+			return 0, err
+		}
+
+		kind, err := r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+
+		switch kind {
+		case 0x00: // function
+			_, err = r.u32()
+		case 0x01: // table
+			if _, err = r.ReadByte(); err == nil {
+				err = skipLimits(r)
+			}
+		case 0x02: // memory
+			err = skipLimits(r)
+		case externGlobal:
+			err = r.skip(2)
+			globals++
+		case 0x04: // tag
+			if _, err = r.ReadByte(); err == nil {
+				_, err = r.u32()
+			}
+		default:
+			err = fmt.Errorf("unknown import kind 0x%02x", kind)
+		}
+
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return globals, nil
+}
+
+func addCounterImport(content []byte) ([]byte, error) {
+	r := reader{bytes.NewReader(content)}
+
+	n, err := r.u32()
+	if err != nil {
+		return nil, err
+	}
+
+	out := appendU32(nil, n+1)
+	out = append(out, content[len(content)-r.Len():]...)
+	out = appendName(out, ModuleName)
+	out = appendName(out, GlobalName)
+
+	return append(out, externGlobal, valueI64, 0x01), nil
+}
+
+// vector rewrites a vector of count entries, each through entry.
+func vector(content []byte, entry func(r reader, out []byte) ([]byte, error)) ([]byte, error) {
+	r := reader{bytes.NewReader(content)}
+
+	n, err := r.u32()
+	if err != nil {
+		return nil, err
+	}
+
+	out := appendU32(nil, n)
+	for i := uint32(0); i < n; i++ {
+		if out, err = entry(r, out); err != nil {
+			return nil, err
+		}
+	}
+
+	if r.Len() != 0 {
+		return nil, errors.New("unexpected bytes after the last entry")
+	}
+
+	return out, nil
+}
+
+func rewriteGlobals(content []byte, remap func(uint32) uint32) ([]byte, error) {
+	return vector(content, func(r reader, out []byte) ([]byte, error) {
+		globalType, err := r.bytes(2)
+		if err != nil {
+			return nil, err
+		}
+
+		init, err := constExpr(r, remap)
+		if err != nil {
+			return nil, err
+		}
+
+		return append(append(out, globalType...), init...), nil
+	})
+}
+
+func rewriteExports(content []byte, remap func(uint32) uint32) ([]byte, error) {
+	return vector(content, func(r reader, out []byte) ([]byte, error) {
+		n, err := r.u32()
+		if err != nil {
+			return nil, err
+		}
+
+		name, err := r.bytes(n)
+		if err != nil {
+			return nil, err
+		}
+
+		kind, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+
+		idx, err := r.u32()
+		if err != nil {
+			return nil, err
+		}
+
+		if kind == externGlobal {
+			idx = remap(idx)
+		}
+
+		out = append(appendU32(out, n), name...)
+		return appendU32(append(out, kind), idx), nil
+	})
+}
+
+// copyU32s copies a vector of u32 indexes.
+func copyU32s(r reader, out []byte) ([]byte, error) {
+	n, err := r.u32()
+	if err != nil {
+		return nil, err
+	}
+
+	out = appendU32(out, n)
+	for i := uint32(0); i < n; i++ {
+		v, err := r.u32()
+		if err != nil {
+			return nil, err
+		}
+		out = appendU32(out, v)
+	}
+
+	return out, nil
+}
+
+// copyExprs copies a vector of constant expressions.
+func copyExprs(r reader, out []byte, remap func(uint32) uint32) ([]byte, error) {
+	n, err := r.u32()
+	if err != nil {
+		return nil, err
+	}
+
+	out = appendU32(out, n)
+	for i := uint32(0); i < n; i++ {
+		expr, err := constExpr(r, remap)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, expr...)
+	}
+
+	return out, nil
+}
+
+func copyByte(r reader, out []byte) ([]byte, error) {
+	b, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	return append(out, b), nil
+}
+
+func rewriteElems(content []byte, remap func(uint32) uint32) ([]byte, error) {
+	return vector(content, func(r reader, out []byte) ([]byte, error) {
+		flags, err := r.u32()
+		if err != nil {
+			return nil, err
+		}
+		out = appendU32(out, flags)
+
+		// Active segments with an explicit table carry its index.
+		if flags&0x03 == 0x02 {
+			table, err := r.u32()
+			if err != nil {
+				return nil, err
+			}
+			out = appendU32(out, table)
+		}
+
+		// Active segments carry their offset.
+		if flags&0x01 == 0 {
+			offset, err := constExpr(r, remap)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, offset...)
+		}
+
+		// Only the legacy form, flags 0, goes without an element kind or type.
+		if flags != 0 && flags != 0x04 {
+			if out, err = copyByte(r, out); err != nil {
+				return nil, err
+			}
+		}
+
+		if flags&0x04 != 0 {
+			return copyExprs(r, out, remap)
+		}
+		return copyU32s(r, out)
+	})
+}
+
+func rewriteData(content []byte, remap func(uint32) uint32) ([]byte, error) {
+	return vector(content, func(r reader, out []byte) ([]byte, error) {
+		flags, err := r.u32()
+		if err != nil {
+			return nil, err
+		}
+		out = appendU32(out, flags)
+
+		if flags == 0x02 {
+			memory, err := r.u32()
+			if err != nil {
+				return nil, err
+			}
+			out = appendU32(out, memory)
+		}
+
+		if flags != 0x01 {
+			offset, err := constExpr(r, remap)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, offset...)
+		}
+
+		n, err := r.u32()
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := r.bytes(n)
+		if err != nil {
+			return nil, err
+		}
+
+		return append(appendU32(out, n), data...), nil
+	})
+}
+
+func meterCode(content []byte, remap func(uint32) uint32, counter uint32) ([]byte, error) {
+	return vector(content, func(r reader, out []byte) ([]byte, error) {
+		size, err := r.u32()
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := r.bytes(size)
+		if err != nil {
+			return nil, err
+		}
+
+		metered, err := meterBody(body, remap, counter)
+		if err != nil {
+			return nil, err
+		}
+
+		return append(appendU32(out, uint32(len(metered))), metered...), nil
+	})
+}
+
+// meterBody charges each block of a function body its cost up front. A block
+// runs from the start of the body, or from any instruction control can land
+// on, to the next instruction that can move control elsewhere.
+func meterBody(body []byte, remap func(uint32) uint32, counter uint32) ([]byte, error) {
+	r := reader{bytes.NewReader(body)}
+
+	// Locals are copied as they are.
+	n, err := r.u32()
+	if err != nil {
+		return nil, err
+	}
+	for i := uint32(0); i < n; i++ {
+		if _, err = r.u32(); err != nil {
+			return nil, err
+		}
+		if _, err = r.ReadByte(); err != nil {
+			return nil, err
+		}
+	}
+
+	out := append([]byte{}, body[:len(body)-r.Len()]...)
+
+	var (
+		block []byte
+		cost  int64
+	)
+	for r.Len() > 0 {
+		op, raw, err := instruction(r, remap)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		block = append(block, raw...)
+		switch op {
+		case opEnd, opElse:
+			// Structure, not work.
+		default:
+			cost++
+		}
+
+		switch op {
+		case opBlock, opLoop, opIf, opElse, opEnd, opBr, opBrIf, opBrTable,
+			opReturn, opUnreachable, opReturnCall, opReturnCallI:
+			if cost > 0 {
+				out = charge(out, counter, cost)
+			}
+			out = append(out, block...)
+			block, cost = block[:0], 0
+		}
+	}
+
+	if len(block) > 0 {
+		return nil, errors.New("function body does not end with `end`")
+	}
+
+	return out, nil
+}
+
+// charge appends the code charging cost to the counter, which traps once the
+// counter can not cover it.
+func charge(out []byte, counter uint32, cost int64) []byte {
+	// if counter < cost { counter = Exhausted; unreachable }
+	out = appendU32(append(out, opGlobalGet), counter)
+	out = appendS64(append(out, opI64Const), cost)
+	out = append(out, opI64LtU, opIf, 0x40)
+	out = appendS64(append(out, opI64Const), -1)
+	out = appendU32(append(out, opGlobalSet), counter)
+	out = append(out, opUnreachable, opEnd)
+
+	// counter -= cost
+	out = appendU32(append(out, opGlobalGet), counter)
+	out = appendS64(append(out, opI64Const), cost)
+	out = append(out, opI64Sub)
+	return appendU32(append(out, opGlobalSet), counter)
+}
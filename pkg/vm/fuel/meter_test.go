@@ -0,0 +1,82 @@
+package fuel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/samyfodil/wazy"
+	"github.com/samyfodil/wazy/api"
+	"gotest.tools/v3/assert"
+)
+
+// spinModule counts its parameter down to zero in a loop, then returns the
+// global it defines and exports, 7.
+var spinModule = []byte{
+	0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00,
+	// type: (func (param i32) (result i32))
+	0x01, 0x06, 0x01, 0x60, 0x01, 0x7f, 0x01, 0x7f,
+	// function
+	0x03, 0x02, 0x01, 0x00,
+	// global: (global $g (mut i32) (i32.const 7))
+	0x06, 0x06, 0x01, 0x7f, 0x01, 0x41, 0x07, 0x0b,
+	// export: "spin" func 0, "g" global 0
+	0x07, 0x0c, 0x02, 0x04, 's', 'p', 'i', 'n', 0x00, 0x00, 0x01, 'g', 0x03, 0x00,
+	// code: loop (local.get 0; i32.const 1; i32.sub; local.tee 0; br_if 0) end; global.get 0
+	0x0a, 0x12, 0x01, 0x10, 0x00,
+	0x03, 0x40, 0x20, 0x00, 0x41, 0x01, 0x6b, 0x22, 0x00, 0x0d, 0x00, 0x0b,
+	0x23, 0x00, 0x0b,
+}
+
+func instantiate(t *testing.T) (api.Function, api.MutableGlobal) {
+	ctx := context.Background()
+	rt := wazy.NewRuntime(ctx)
+	t.Cleanup(func() { rt.Close(ctx) })
+
+	counter, err := rt.InstantiateWithConfig(ctx, Module, wazy.NewModuleConfig().WithName(ModuleName))
+	assert.NilError(t, err)
+
+	metered, err := Meter(spinModule)
+	assert.NilError(t, err)
+
+	mod, err := rt.InstantiateWithConfig(ctx, metered, wazy.NewModuleConfig().WithName("spin"))
+	assert.NilError(t, err)
+
+	// The module's own global moved past the imported counter, and still reads right.
+	assert.Equal(t, mod.ExportedGlobal("g").Get(), uint64(7))
+
+	return mod.ExportedFunction("spin"), counter.ExportedGlobal(GlobalName).(api.MutableGlobal)
+}
+
+func TestMeter(t *testing.T) {
+	spin, counter := instantiate(t)
+
+	for _, n := range []uint64{1, 10, 100} {
+		counter.Set(1000)
+		ret, err := spin.Call(context.Background(), n)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, ret, []uint64{7})
+
+		// loop, 5 instructions an iteration, and global.get.
+		assert.Equal(t, 1000-counter.Get(), 5*n+2)
+	}
+}
+
+func TestMeterExhausted(t *testing.T) {
+	spin, counter := instantiate(t)
+
+	counter.Set(50)
+	_, err := spin.Call(context.Background(), 100)
+	assert.ErrorContains(t, err, "unreachable")
+	assert.Equal(t, counter.Get(), uint64(Exhausted))
+}
+
+func TestMeterRejects(t *testing.T) {
+	_, err := Meter([]byte("not wasm"))
+	assert.ErrorContains(t, err, "not a wasm binary module")
+
+	// An exception handling `try` is not something we know how to meter.
+	module := append([]byte{}, spinModule[:len(spinModule)-20]...)
+	module = append(module, 0x0a, 0x06, 0x01, 0x04, 0x00, 0x06, 0x40, 0x0b)
+	_, err = Meter(module)
+	assert.ErrorContains(t, err, "unsupported instruction")
+}
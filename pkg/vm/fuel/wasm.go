@@ -0,0 +1,345 @@
+package fuel
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+)
+
+// Binary format bits the metering rewrites; see
+// https://webassembly.github.io/spec/core/binary/index.html
+
+var magic = []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+
+const (
+	sectionCustom    = 0
+	sectionType      = 1
+	sectionImport    = 2
+	sectionGlobal    = 6
+	sectionExport    = 7
+	sectionStart     = 8
+	sectionElem      = 9
+	sectionCode      = 10
+	sectionData      = 11
+	sectionDataCount = 12
+
+	externGlobal = 0x03
+
+	valueI32 = 0x7f
+	valueI64 = 0x7e
+	valueF32 = 0x7d
+	valueF64 = 0x7c
+)
+
+const (
+	opUnreachable = 0x00
+	opBlock       = 0x02
+	opLoop        = 0x03
+	opIf          = 0x04
+	opElse        = 0x05
+	opEnd         = 0x0b
+	opBr          = 0x0c
+	opBrIf        = 0x0d
+	opBrTable     = 0x0e
+	opReturn      = 0x0f
+	opReturnCall  = 0x12
+	opReturnCallI = 0x13
+	opGlobalGet   = 0x23
+	opGlobalSet   = 0x24
+	opI64Const    = 0x42
+	opI64LtU      = 0x54
+	opI64Sub      = 0x7d
+)
+
+var errUnsupported = errors.New("unsupported instruction")
+
+type reader struct {
+	*bytes.Reader
+}
+
+func (r reader) u32() (uint32, error) {
+	var (
+		v     uint32
+		shift uint
+	)
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		v |= uint32(b&0x7f) << shift
+		if b&0x80 == 0 {
+			return v, nil
+		}
+		if shift += 7; shift >= 35 {
+			return 0, errors.New("u32 overflows")
+		}
+	}
+}
+
+// skipLEB skips a signed or unsigned LEB128 value.
+func (r reader) skipLEB() error {
+	for i := 0; i < 10; i++ {
+		b, err := r.ReadByte()
+		if err != nil {
+			return err
+		}
+		if b&0x80 == 0 {
+			return nil
+		}
+	}
+	return errors.New("LEB128 too long")
+}
+
+func (r reader) skip(n int) error {
+	if n > r.Len() {
+		return io.ErrUnexpectedEOF
+	}
+	_, err := r.Seek(int64(n), io.SeekCurrent)
+	return err
+}
+
+func (r reader) bytes(n uint32) ([]byte, error) {
+	if uint64(n) > uint64(r.Len()) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	b := make([]byte, n)
+	_, err := io.ReadFull(r, b)
+	return b, err
+}
+
+func appendU32(b []byte, v uint32) []byte {
+	for {
+		c := byte(v & 0x7f)
+		v >>= 7
+		if v != 0 {
+			b = append(b, c|0x80)
+			continue
+		}
+		return append(b, c)
+	}
+}
+
+func appendS64(b []byte, v int64) []byte {
+	for {
+		c := byte(v & 0x7f)
+		v >>= 7
+		if (v == 0 && c&0x40 == 0) || (v == -1 && c&0x40 != 0) {
+			return append(b, c)
+		}
+		b = append(b, c|0x80)
+	}
+}
+
+func appendName(b []byte, name string) []byte {
+	return append(appendU32(b, uint32(len(name))), name...)
+}
+
+// instruction reads the instruction at r, returning its opcode and its raw
+// bytes, with global indexes passed through remap.
+func instruction(r reader, remap func(uint32) uint32) (byte, []byte, error) {
+	start := r.Size() - int64(r.Len())
+
+	op, err := r.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if op == opGlobalGet || op == opGlobalSet {
+		idx, err := r.u32()
+		if err != nil {
+			return 0, nil, err
+		}
+		return op, appendU32([]byte{op}, remap(idx)), nil
+	}
+
+	if err = skipImmediates(r, op); err != nil {
+		return 0, nil, fmt.Errorf("opcode 0x%02x: %w", op, err)
+	}
+
+	end := r.Size() - int64(r.Len())
+	raw := make([]byte, end-start)
+	if _, err = r.ReadAt(raw, start); err != nil {
+		return 0, nil, err
+	}
+
+	return op, raw, nil
+}
+
+func skipImmediates(r reader, op byte) error {
+	switch {
+	case op == 0x00, op == 0x01, op == opElse, op == opEnd, op == opReturn,
+		op == 0x1a, op == 0x1b, op == 0xd1,
+		op >= 0x45 && op <= 0xc4:
+		return nil
+	case op == opBlock, op == opLoop, op == opIf:
+		return skipBlockType(r)
+	case op == opBr, op == opBrIf, op == 0x10, op == opReturnCall,
+		op >= 0x20 && op <= 0x22, op == 0x25, op == 0x26,
+		op == 0x3f, op == 0x40, op == 0xd2:
+		_, err := r.u32()
+		return err
+	case op == 0x11, op == opReturnCallI:
+		if _, err := r.u32(); err != nil {
+			return err
+		}
+		_, err := r.u32()
+		return err
+	case op == opBrTable:
+		n, err := r.u32()
+		if err != nil {
+			return err
+		}
+		for i := uint32(0); i <= n; i++ {
+			if _, err = r.u32(); err != nil {
+				return err
+			}
+		}
+		return nil
+	case op == 0x1c:
+		n, err := r.u32()
+		if err != nil {
+			return err
+		}
+		return r.skip(int(n))
+	case op >= 0x28 && op <= 0x3e:
+		return skipMemArg(r)
+	case op == 0x41, op == opI64Const:
+		return r.skipLEB()
+	case op == 0x43:
+		return r.skip(4)
+	case op == 0x44:
+		return r.skip(8)
+	case op == 0xd0:
+		return r.skip(1)
+	case op == 0xfc:
+		return skipMisc(r)
+	case op == 0xfd:
+		return skipVector(r)
+	case op == 0xfe:
+		return skipAtomic(r)
+	default:
+		return errUnsupported
+	}
+}
+
+func skipBlockType(r reader) error {
+	b, err := r.ReadByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 0x40, 0x7f, 0x7e, 0x7d, 0x7c, 0x7b, 0x70, 0x6f:
+		return nil
+	}
+
+	// A type index, as a signed LEB128 we already read the first byte of.
+	if b&0x80 == 0 {
+		return nil
+	}
+	return r.skipLEB()
+}
+
+func skipMemArg(r reader) error {
+	if _, err := r.u32(); err != nil {
+		return err
+	}
+	_, err := r.u32()
+	return err
+}
+
+func skipU32s(r reader, n int) error {
+	for i := 0; i < n; i++ {
+		if _, err := r.u32(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func skipMisc(r reader) error {
+	sub, err := r.u32()
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case sub <= 7:
+		return nil
+	case sub == 8, sub == 10, sub == 12, sub == 14:
+		return skipU32s(r, 2)
+	case sub <= 17:
+		return skipU32s(r, 1)
+	default:
+		return errUnsupported
+	}
+}
+
+// vectorReserved are the opcodes left unassigned among the SIMD instructions
+// without immediates.
+var vectorReserved = []uint32{154, 162, 165, 166, 175, 176, 178, 179, 180, 187, 194, 197, 198, 207, 208, 210, 211, 212, 226, 238}
+
+func skipVector(r reader) error {
+	sub, err := r.u32()
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case sub <= 11, sub == 92, sub == 93:
+		return skipMemArg(r)
+	case sub == 12, sub == 13:
+		return r.skip(16)
+	case sub >= 21 && sub <= 34:
+		return r.skip(1)
+	case sub >= 84 && sub <= 91:
+		if err = skipMemArg(r); err != nil {
+			return err
+		}
+		return r.skip(1)
+	case slices.Contains(vectorReserved, sub):
+		return errUnsupported
+	case sub >= 14 && sub <= 20, // swizzle and splats
+		sub >= 35 && sub <= 83,   // comparisons and bitwise
+		sub >= 94 && sub <= 255,  // arithmetic and conversions
+		sub >= 256 && sub <= 275: // relaxed SIMD
+		return nil
+	default:
+		return errUnsupported
+	}
+}
+
+func skipAtomic(r reader) error {
+	sub, err := r.u32()
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case sub == 0x03:
+		return r.skip(1)
+	case sub <= 0x02, sub >= 0x10 && sub <= 0x4e:
+		return skipMemArg(r)
+	default:
+		return errUnsupported
+	}
+}
+
+// constExpr copies the constant expression at r, with its global indexes
+// passed through remap.
+func constExpr(r reader, remap func(uint32) uint32) ([]byte, error) {
+	var out []byte
+	for {
+		op, raw, err := instruction(r, remap)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, raw...)
+		if op == opEnd {
+			return out, nil
+		}
+	}
+}
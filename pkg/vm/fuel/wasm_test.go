@@ -0,0 +1,201 @@
+package fuel
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+var v128 = make([]byte, 16)
+
+// instructions covers every class of immediates the decoder knows. want is
+// what the rewriter makes of the instruction, when it differs from it.
+var instructions = []struct {
+	name string
+	code []byte
+	want []byte
+}{
+	{name: "i32.add", code: []byte{0x6a}},
+	{name: "drop", code: []byte{0x1a}},
+	{name: "local.get", code: []byte{0x20, 0x05}},
+	{name: "global.get", code: []byte{0x23, 0x00}, want: []byte{0x23, 0x01}},
+	{name: "global.set", code: []byte{0x24, 0x80, 0x01}, want: []byte{0x24, 0x81, 0x01}},
+	{name: "call", code: []byte{0x10, 0x02}},
+	{name: "call_indirect", code: []byte{0x11, 0x01, 0x00}},
+	{name: "i32.load", code: []byte{0x28, 0x02, 0x80, 0x01}},
+	{name: "i64.store32", code: []byte{0x3e, 0x02, 0x00}},
+	{name: "memory.size", code: []byte{0x3f, 0x00}},
+	{name: "i32.const", code: []byte{0x41, 0x7f}},
+	{name: "i64.const", code: []byte{0x42, 0x80, 0x80, 0x04}},
+	{name: "f32.const", code: []byte{0x43, 0x00, 0x00, 0x80, 0x3f}},
+	{name: "f64.const", code: []byte{0x44, 0, 0, 0, 0, 0, 0, 0xf0, 0x3f}},
+	{name: "select", code: []byte{0x1b}},
+	{name: "select t", code: []byte{0x1c, 0x01, 0x7f}},
+	{name: "table.get", code: []byte{0x25, 0x00}},
+	{name: "table.set", code: []byte{0x26, 0x01}},
+	{name: "ref.null", code: []byte{0xd0, 0x70}},
+	{name: "ref.is_null", code: []byte{0xd1}},
+	{name: "ref.func", code: []byte{0xd2, 0x03}},
+
+	{name: "i32.trunc_sat_f32_s", code: []byte{0xfc, 0x00}},
+	{name: "memory.init", code: []byte{0xfc, 0x08, 0x01, 0x00}},
+	{name: "data.drop", code: []byte{0xfc, 0x09, 0x01}},
+	{name: "memory.copy", code: []byte{0xfc, 0x0a, 0x00, 0x00}},
+	{name: "memory.fill", code: []byte{0xfc, 0x0b, 0x00}},
+	{name: "table.init", code: []byte{0xfc, 0x0c, 0x01, 0x00}},
+	{name: "elem.drop", code: []byte{0xfc, 0x0d, 0x00}},
+	{name: "table.copy", code: []byte{0xfc, 0x0e, 0x00, 0x01}},
+	{name: "table.grow", code: []byte{0xfc, 0x0f, 0x00}},
+	{name: "table.size", code: []byte{0xfc, 0x10, 0x00}},
+	{name: "table.fill", code: []byte{0xfc, 0x11, 0x00}},
+
+	{name: "memory.atomic.notify", code: []byte{0xfe, 0x00, 0x02, 0x00}},
+	{name: "memory.atomic.wait64", code: []byte{0xfe, 0x02, 0x03, 0x00}},
+	{name: "atomic.fence", code: []byte{0xfe, 0x03, 0x00}},
+	{name: "i32.atomic.rmw.add", code: []byte{0xfe, 0x1e, 0x02, 0x08}},
+	{name: "i64.atomic.rmw32.cmpxchg_u", code: []byte{0xfe, 0x4e, 0x02, 0x00}},
+
+	{name: "v128.load", code: []byte{0xfd, 0x00, 0x04, 0x00}},
+	{name: "v128.store", code: []byte{0xfd, 0x0b, 0x04, 0x10}},
+	{name: "v128.const", code: append([]byte{0xfd, 0x0c}, v128...)},
+	{name: "i8x16.shuffle", code: append([]byte{0xfd, 0x0d}, v128...)},
+	{name: "i8x16.swizzle", code: []byte{0xfd, 0x0e}},
+	{name: "f64x2.splat", code: []byte{0xfd, 0x14}},
+	{name: "i8x16.extract_lane_s", code: []byte{0xfd, 0x15, 0x0f}},
+	{name: "f64x2.replace_lane", code: []byte{0xfd, 0x22, 0x01}},
+	{name: "i8x16.eq", code: []byte{0xfd, 0x23}},
+	{name: "v128.any_true", code: []byte{0xfd, 0x53}},
+	{name: "v128.load8_lane", code: []byte{0xfd, 0x54, 0x00, 0x00, 0x0f}},
+	{name: "v128.store64_lane", code: []byte{0xfd, 0x5b, 0x03, 0x08, 0x01}},
+	{name: "v128.load32_zero", code: []byte{0xfd, 0x5c, 0x02, 0x00}},
+	{name: "f32x4.demote_f64x2_zero", code: []byte{0xfd, 0x5e}},
+	{name: "i32x4.add", code: []byte{0xfd, 0xae, 0x01}},
+	{name: "f64x2.convert_low_i32x4_u", code: []byte{0xfd, 0xff, 0x01}},
+	{name: "i8x16.relaxed_laneselect", code: []byte{0xfd, 0x89, 0x02}},
+}
+
+func remapGlobals(i uint32) uint32 { return i + 1 }
+
+func TestInstruction(t *testing.T) {
+	for _, tc := range instructions {
+		t.Run(tc.name, func(t *testing.T) {
+			// trailing bytes must be left to the next instruction
+			r := reader{bytes.NewReader(append(append([]byte{}, tc.code...), opEnd))}
+
+			op, raw, err := instruction(r, remapGlobals)
+			assert.NilError(t, err)
+			assert.Equal(t, op, tc.code[0])
+
+			want := tc.want
+			if want == nil {
+				want = tc.code
+			}
+			assert.DeepEqual(t, raw, want)
+			assert.Equal(t, r.Len(), 1)
+		})
+	}
+}
+
+func TestInstructionUnsupported(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		code []byte
+	}{
+		{"try", []byte{0x06, 0x40}},
+		{"misc 18", []byte{0xfc, 0x12}},
+		{"atomic 0x04", []byte{0xfe, 0x04, 0x02, 0x00}},
+		{"atomic 0x4f", []byte{0xfe, 0x4f, 0x02, 0x00}},
+		{"reserved SIMD 154", []byte{0xfd, 0x9a, 0x01}},
+		{"reserved SIMD 238", []byte{0xfd, 0xee, 0x01}},
+		{"SIMD 276", []byte{0xfd, 0x94, 0x02}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := instruction(reader{bytes.NewReader(tc.code)}, remapGlobals)
+			assert.Assert(t, errors.Is(err, errUnsupported), "got %v", err)
+		})
+	}
+}
+
+// codeModule is a module with a single function of body, no locals.
+func codeModule(body []byte) []byte {
+	fn := append([]byte{0x00}, body...)
+	code := appendU32(appendU32(nil, 1), uint32(len(fn)))
+	code = append(code, fn...)
+
+	module := append([]byte{}, magic...)
+	module = append(module, sectionCode)
+	module = appendU32(module, uint32(len(code)))
+	return append(module, code...)
+}
+
+// meteredBody returns the body of the single function of module, metered.
+func meteredBody(t *testing.T, module []byte) []byte {
+	t.Helper()
+
+	metered, err := Meter(module)
+	assert.NilError(t, err)
+
+	sections, err := readSections(metered[len(magic):])
+	assert.NilError(t, err)
+
+	for _, s := range sections {
+		if s.id != sectionCode {
+			continue
+		}
+
+		r := reader{bytes.NewReader(s.content)}
+		n, err := r.u32()
+		assert.NilError(t, err)
+		assert.Equal(t, n, uint32(1))
+
+		size, err := r.u32()
+		assert.NilError(t, err)
+
+		body, err := r.bytes(size)
+		assert.NilError(t, err)
+		assert.Equal(t, r.Len(), 0)
+		return body
+	}
+
+	t.Fatal("metered module has no code section")
+	return nil
+}
+
+func TestMeterRoundTrip(t *testing.T) {
+	for _, tc := range instructions {
+		t.Run(tc.name, func(t *testing.T) {
+			body := meteredBody(t, codeModule(append(append([]byte{}, tc.code...), opEnd)))
+
+			want := tc.want
+			if want == nil {
+				want = tc.code
+			}
+
+			// the counter is the only global import, ahead of the module's own
+			expected := charge([]byte{0x00}, 0, 1)
+			expected = append(append(expected, want...), opEnd)
+			assert.DeepEqual(t, body, expected)
+		})
+	}
+}
+
+func TestMeterRoundTripBlock(t *testing.T) {
+	var code, want []byte
+	for _, tc := range instructions {
+		code = append(code, tc.code...)
+		if tc.want != nil {
+			want = append(want, tc.want...)
+		} else {
+			want = append(want, tc.code...)
+		}
+	}
+
+	body := meteredBody(t, codeModule(append(code, opEnd)))
+
+	// straight-line code is a single block, charged once
+	expected := charge([]byte{0x00}, 0, int64(len(instructions)))
+	expected = append(append(expected, want...), opEnd)
+	assert.DeepEqual(t, body, expected)
+}
@@ -2,12 +2,27 @@ package vm
 
 import (
 	"context"
+	"time"
 
 	"github.com/taubyte/tau/core/vm"
 )
 
 var _ vm.FunctionInstance = &funcInstance{}
 
-func (f *funcInstance) RawCall(ctx context.Context, args ...uint64) ([]uint64, error) {
-	return f.function.Call(ctx, args...)
+func (f *funcInstance) RawCall(ctx context.Context, args ...uint64) (ret []uint64, err error) {
+	r := f.module.parent
+
+	defer r.leave()
+	if r.enter() {
+		return f.function.Call(ctx, args...)
+	}
+
+	start := time.Now()
+	err = r.metered(func() error {
+		ret, err = f.function.Call(ctx, args...)
+		return err
+	})
+	r.stats.called(time.Since(start), r.memorySize())
+
+	return
 }
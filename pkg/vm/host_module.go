@@ -1,7 +1,12 @@
 package vm
 
 import (
+	"context"
+	"reflect"
+	"time"
+
 	wazy "github.com/samyfodil/wazy"
+	"github.com/samyfodil/wazy/api"
 	"github.com/taubyte/tau/core/vm"
 )
 
@@ -11,6 +16,17 @@ func (hm *hostModule) Builder() wazy.HostModuleBuilder {
 	return hm.builder
 }
 
+func (hm *hostModule) Group(name string) wazy.HostModuleBuilder {
+	if hm.stats == nil {
+		return hm.builder
+	}
+
+	return &groupBuilder{
+		HostModuleBuilder: hm.builder,
+		calls:             hm.stats.group(name),
+	}
+}
+
 func (hm *hostModule) Compile() (vm.ModuleInstance, error) {
 	cm, err := hm.builder.Instantiate(hm.ctx.Context())
 	if err != nil {
@@ -21,3 +37,72 @@ func (hm *hostModule) Compile() (vm.ModuleInstance, error) {
 		ctx:    hm.ctx.Context(),
 	}, nil
 }
+
+// groupBuilder builds host functions whose calls are tracked in calls.
+type groupBuilder struct {
+	wazy.HostModuleBuilder
+	calls *hostCalls
+}
+
+func (b *groupBuilder) NewFunctionBuilder() wazy.HostFunctionBuilder {
+	return &groupFunctionBuilder{
+		HostFunctionBuilder: b.HostModuleBuilder.NewFunctionBuilder(),
+		module:              b,
+	}
+}
+
+type groupFunctionBuilder struct {
+	wazy.HostFunctionBuilder
+	module *groupBuilder
+}
+
+func (b *groupFunctionBuilder) WithGoFunction(fn api.GoFunction, params, results []api.ValueType) wazy.HostFunctionBuilder {
+	calls := b.module.calls
+	b.HostFunctionBuilder = b.HostFunctionBuilder.WithGoFunction(api.GoFunc(func(ctx context.Context, stack []uint64) {
+		defer calls.track(time.Now())
+		fn.Call(ctx, stack)
+	}), params, results)
+	return b
+}
+
+func (b *groupFunctionBuilder) WithGoModuleFunction(fn api.GoModuleFunction, params, results []api.ValueType) wazy.HostFunctionBuilder {
+	calls := b.module.calls
+	b.HostFunctionBuilder = b.HostFunctionBuilder.WithGoModuleFunction(api.GoModuleFunc(func(ctx context.Context, mod api.Module, stack []uint64) {
+		defer calls.track(time.Now())
+		fn.Call(ctx, mod, stack)
+	}), params, results)
+	return b
+}
+
+func (b *groupFunctionBuilder) WithFunc(fn interface{}) wazy.HostFunctionBuilder {
+	if v := reflect.ValueOf(fn); v.Kind() == reflect.Func {
+		calls := b.module.calls
+		fn = reflect.MakeFunc(v.Type(), func(args []reflect.Value) []reflect.Value {
+			defer calls.track(time.Now())
+			return v.Call(args)
+		}).Interface()
+	}
+
+	b.HostFunctionBuilder = b.HostFunctionBuilder.WithFunc(fn)
+	return b
+}
+
+func (b *groupFunctionBuilder) WithName(name string) wazy.HostFunctionBuilder {
+	b.HostFunctionBuilder = b.HostFunctionBuilder.WithName(name)
+	return b
+}
+
+func (b *groupFunctionBuilder) WithParameterNames(names ...string) wazy.HostFunctionBuilder {
+	b.HostFunctionBuilder = b.HostFunctionBuilder.WithParameterNames(names...)
+	return b
+}
+
+func (b *groupFunctionBuilder) WithResultNames(names ...string) wazy.HostFunctionBuilder {
+	b.HostFunctionBuilder = b.HostFunctionBuilder.WithResultNames(names...)
+	return b
+}
+
+func (b *groupFunctionBuilder) Export(name string) wazy.HostModuleBuilder {
+	b.HostFunctionBuilder.Export(name)
+	return b.module
+}
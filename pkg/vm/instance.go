@@ -40,6 +40,10 @@ func (i *instance) Runtime(register ...func(wazy.HostModuleBuilder)) (vm.Runtime
 		return nil, fmt.Errorf("instantiating host module failed with: %s", err)
 	}
 
+	if err = r.meter(); err != nil {
+		return nil, err
+	}
+
 	return r, nil
 }
 
@@ -0,0 +1,59 @@
+package vm
+
+import (
+	"fmt"
+
+	"github.com/samyfodil/wazy"
+	api "github.com/samyfodil/wazy/api"
+	"github.com/taubyte/tau/core/vm"
+	"github.com/taubyte/tau/pkg/vm/fuel"
+)
+
+// meter sets up the fuel counter of the runtime when its calls are limited.
+// Modules loaded afterwards are metered against it.
+func (r *runtime) meter() error {
+	if r.instance.config == nil || r.instance.config.Fuel == 0 {
+		return nil
+	}
+
+	ctx := r.instance.ctx.Context()
+	m, err := r.runtime.InstantiateWithConfig(ctx, fuel.Module, wazy.NewModuleConfig().WithName(fuel.ModuleName))
+	if err != nil {
+		return fmt.Errorf("instantiating fuel counter failed with: %w", err)
+	}
+
+	counter, ok := m.ExportedGlobal(fuel.GlobalName).(api.MutableGlobal)
+	if !ok {
+		return fmt.Errorf("fuel counter is not a mutable global")
+	}
+
+	r.fuel = counter
+	r.fuelLimit = r.instance.config.Fuel
+
+	return nil
+}
+
+// metered runs call on a full tank and accounts for the fuel it burnt. It is
+// only for outer calls; see enter.
+func (r *runtime) metered(call func() error) error {
+	if r.fuel == nil {
+		return call()
+	}
+
+	r.fuel.Set(r.fuelLimit)
+	err := call()
+
+	left := r.fuel.Get()
+	if left == fuel.Exhausted {
+		r.fuelUsed.Add(r.fuelLimit)
+		return vm.ErrFuelExhausted
+	}
+
+	r.fuelUsed.Add(r.fuelLimit - left)
+
+	return err
+}
+
+func (r *runtime) Fuel() uint64 {
+	return r.fuelUsed.Load()
+}
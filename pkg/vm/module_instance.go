@@ -2,8 +2,11 @@ package vm
 
 import (
 	"fmt"
+	"strconv"
 
+	"github.com/samyfodil/wazy/api"
 	"github.com/taubyte/tau/core/vm"
+	"github.com/taubyte/tau/pkg/vm/fuel"
 )
 
 var _ vm.ModuleInstance = &moduleInstance{}
@@ -25,3 +28,14 @@ func (m *moduleInstance) Function(name string) (vm.FunctionInstance, error) {
 func (m *moduleInstance) Memory() vm.Memory {
 	return m.module.Memory()
 }
+
+func (m *moduleInstance) Globals() []vm.MutableGlobal {
+	var globals []vm.MutableGlobal
+	for i := 0; ; i++ {
+		global, ok := m.module.ExportedGlobal(fuel.GlobalPrefix + strconv.Itoa(i)).(api.MutableGlobal)
+		if !ok {
+			return globals
+		}
+		globals = append(globals, global)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	api "github.com/samyfodil/wazy/api"
 	"github.com/spf13/afero"
 	"github.com/taubyte/tau/core/vm"
+	"github.com/taubyte/tau/pkg/vm/fuel"
 
 	crand "crypto/rand"
 )
@@ -28,6 +29,7 @@ func (r *runtime) Expose(name string) (vm.HostModule, error) {
 	return &hostModule{
 		ctx:     r.instance.ctx,
 		builder: r.runtime.NewHostModuleBuilder(name),
+		stats:   &r.stats,
 	}, nil
 }
 
@@ -52,6 +54,7 @@ func (r *runtime) Attach(plugin vm.Plugin) (vm.PluginInstance, vm.ModuleInstance
 	hm := &hostModule{
 		ctx:     r.instance.ctx,
 		builder: r.runtime.NewHostModuleBuilder(plugin.Name()),
+		stats:   &r.stats,
 	}
 
 	minst, err := pi.Load(hm)
@@ -82,10 +85,24 @@ func (r *runtime) module(name string) (vm.ModuleInstance, error) {
 			return nil, fmt.Errorf("loading module `%s` failed with: %s", name, err)
 		}
 
+		if r.instance.config != nil && r.instance.config.ExportGlobals {
+			if module, err = fuel.ExportGlobals(module); err != nil {
+				return nil, fmt.Errorf("exporting globals of module `%s` failed with: %w", name, err)
+			}
+		}
+
+		if r.fuel != nil {
+			if module, err = fuel.Meter(module); err != nil {
+				return nil, fmt.Errorf("metering module `%s` failed with: %w", name, err)
+			}
+		}
+
+		start := time.Now()
 		compiled, err := r.runtime.CompileModule(r.instance.ctx.Context(), module)
 		if err != nil {
 			return nil, fmt.Errorf("getting compiled module failed with: %s", err)
 		}
+		r.stats.compiled(time.Since(start))
 
 		deps := make(map[string]struct{})
 		hasReady := false
@@ -141,31 +158,51 @@ func (r *runtime) instantiate(name string, compiled wazy.CompiledModule, hasRead
 		WithRandSource(crand.Reader)
 
 	ctx := r.instance.ctx.Context()
-	m, err := r.runtime.InstantiateModule(ctx, compiled, config)
-	if err != nil {
-		return nil, fmt.Errorf("instantiating compiled module `%s` failed with: %s", name, err)
-	}
-
-	// reactor modules export _initialize to set up globals (e.g. os.Stdout).
-	// Must be called before any other exported function.
-	if _initialize := m.ExportedFunction("_initialize"); _initialize != nil {
-		if _, err := _initialize.Call(ctx); err != nil {
-			return nil, fmt.Errorf("calling _initialize for module `%s`: %w", name, err)
+	var m api.Module
+	run := func() (err error) {
+		if m, err = r.runtime.InstantiateModule(ctx, compiled, config); err != nil {
+			return fmt.Errorf("instantiating compiled module `%s` failed with: %s", name, err)
 		}
-		// TODO: this should be deleted later as we should only support reactor modules
-	} else if _start := m.ExportedFunction("_start"); _start != nil {
-		if hasReady {
-			go func() {
-				_start.Call(ctx)
-			}()
 
-			select {
-			case <-ctx.Done():
-			case <-r.wasiStartDone:
+		// reactor modules export _initialize to set up globals (e.g. os.Stdout).
+		// Must be called before any other exported function.
+		if _initialize := m.ExportedFunction("_initialize"); _initialize != nil {
+			if _, err := _initialize.Call(ctx); err != nil {
+				return fmt.Errorf("calling _initialize for module `%s`: %w", name, err)
+			}
+			// TODO: this should be deleted later as we should only support reactor modules
+		} else if _start := m.ExportedFunction("_start"); _start != nil {
+			if hasReady {
+				go func() {
+					_start.Call(ctx)
+				}()
+
+				select {
+				case <-ctx.Done():
+				case <-r.wasiStartDone:
+				}
+			} else {
+				_start.Call(ctx)
 			}
-		} else {
-			_start.Call(ctx)
 		}
+
+		return nil
+	}
+
+	// Modules can be loaded from within a call, which meters them already.
+	defer r.leave()
+	nested := r.enter()
+
+	start := time.Now()
+	var err error
+	if nested {
+		err = run()
+	} else {
+		err = r.metered(run)
+	}
+	r.stats.instantiated(time.Since(start))
+	if err != nil {
+		return nil, err
 	}
 
 	r.modules[name] = m
@@ -0,0 +1,117 @@
+package vm
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/taubyte/tau/core/vm"
+)
+
+// stats is what a runtime measures of itself; see vm.RuntimeStats.
+type stats struct {
+	lock sync.Mutex
+
+	peakMemory  uint64
+	compile     time.Duration
+	instantiate time.Duration
+	calls       uint64
+	wall        time.Duration
+
+	groups map[string]*hostCalls
+}
+
+// hostCalls counts and times the calls to a group of host functions. It is
+// updated from the guest's goroutine, so without holding the stats lock.
+type hostCalls struct {
+	calls atomic.Uint64
+	time  atomic.Int64
+}
+
+func (c *hostCalls) track(start time.Time) {
+	c.calls.Add(1)
+	c.time.Add(int64(time.Since(start)))
+}
+
+func (s *stats) group(name string) *hostCalls {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.groups == nil {
+		s.groups = make(map[string]*hostCalls)
+	}
+
+	c, ok := s.groups[name]
+	if !ok {
+		c = new(hostCalls)
+		s.groups[name] = c
+	}
+
+	return c
+}
+
+func (s *stats) compiled(d time.Duration) {
+	s.lock.Lock()
+	s.compile += d
+	s.lock.Unlock()
+}
+
+func (s *stats) instantiated(d time.Duration) {
+	s.lock.Lock()
+	s.instantiate += d
+	s.lock.Unlock()
+}
+
+func (s *stats) called(d time.Duration, memory uint64) {
+	s.lock.Lock()
+	s.calls++
+	s.wall += d
+	s.peakMemory = max(s.peakMemory, memory)
+	s.lock.Unlock()
+}
+
+// memorySize is the linear memory held by the modules of the runtime.
+func (r *runtime) memorySize() (size uint64) {
+	for _, m := range r.modules {
+		if mem := m.Memory(); mem != nil {
+			size += uint64(mem.Size())
+		}
+	}
+	return
+}
+
+// enter marks the start of a call into the guest, reporting whether it is
+// nested in another, such as a host function calling back into the guest.
+// Nested calls are accounted for, and metered, as part of the outer call.
+func (r *runtime) enter() (nested bool) {
+	return r.depth.Add(1) > 1
+}
+
+func (r *runtime) leave() {
+	r.depth.Add(-1)
+}
+
+func (r *runtime) Stats() vm.RuntimeStats {
+	s := &r.stats
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	stats := vm.RuntimeStats{
+		PeakMemory:      s.peakMemory,
+		CompileTime:     s.compile,
+		InstantiateTime: s.instantiate,
+		Calls:           s.calls,
+		WallTime:        s.wall,
+		HostCalls:       make(map[string]vm.HostCallStats, len(s.groups)),
+		Fuel:            r.Fuel(),
+	}
+
+	for name, c := range s.groups {
+		stats.HostCalls[name] = vm.HostCallStats{
+			Calls: c.calls.Load(),
+			Time:  time.Duration(c.time.Load()),
+		}
+	}
+
+	return stats
+}
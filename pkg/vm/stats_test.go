@@ -0,0 +1,66 @@
+package vm
+
+import (
+	"context"
+	"testing"
+
+	wazy "github.com/samyfodil/wazy"
+	wazyapi "github.com/samyfodil/wazy/api"
+	"gotest.tools/v3/assert"
+)
+
+// hostCallsModule exports run, which returns untracked(inc(double(x))) of its
+// parameter x, all three imported from the "host" module.
+var hostCallsModule = []byte{
+	0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00,
+	// type: (func (param i32) (result i32))
+	0x01, 0x06, 0x01, 0x60, 0x01, 0x7f, 0x01, 0x7f,
+	// import: host.double, host.inc, host.untracked
+	0x02, 0x2b, 0x03,
+	0x04, 'h', 'o', 's', 't', 0x06, 'd', 'o', 'u', 'b', 'l', 'e', 0x00, 0x00,
+	0x04, 'h', 'o', 's', 't', 0x03, 'i', 'n', 'c', 0x00, 0x00,
+	0x04, 'h', 'o', 's', 't', 0x09, 'u', 'n', 't', 'r', 'a', 'c', 'k', 'e', 'd', 0x00, 0x00,
+	// function
+	0x03, 0x02, 0x01, 0x00,
+	// export: "run" func 3
+	0x07, 0x07, 0x01, 0x03, 'r', 'u', 'n', 0x00, 0x03,
+	// code: local.get 0; call 0; call 1; call 2
+	0x0a, 0x0c, 0x01, 0x0a, 0x00, 0x20, 0x00, 0x10, 0x00, 0x10, 0x01, 0x10, 0x02, 0x0b,
+}
+
+func TestHostModuleGroup(t *testing.T) {
+	ctx := context.Background()
+	rt := wazy.NewRuntime(ctx)
+	defer rt.Close(ctx)
+
+	r := &runtime{}
+	hm := &hostModule{builder: rt.NewHostModuleBuilder("host"), stats: &r.stats}
+
+	i32 := []wazyapi.ValueType{wazyapi.ValueTypeI32}
+	hm.Group("math").NewFunctionBuilder().
+		WithGoModuleFunction(wazyapi.GoModuleFunc(func(ctx context.Context, m wazyapi.Module, stack []uint64) {
+			stack[0] *= 2
+		}), i32, i32).
+		Export("double").
+		NewFunctionBuilder().
+		WithFunc(func(v uint32) uint32 { return v + 1 }).
+		Export("inc")
+	hm.Builder().NewFunctionBuilder().WithFunc(func(v uint32) uint32 { return v }).Export("untracked")
+
+	_, err := hm.builder.Instantiate(ctx)
+	assert.NilError(t, err)
+
+	mod, err := rt.Instantiate(ctx, hostCallsModule)
+	assert.NilError(t, err)
+
+	for range 3 {
+		ret, err := mod.ExportedFunction("run").Call(ctx, 20)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, ret, []uint64{41})
+	}
+
+	stats := r.Stats()
+	assert.Equal(t, len(stats.HostCalls), 1)
+	assert.Equal(t, stats.HostCalls["math"].Calls, uint64(6))
+	assert.Assert(t, stats.HostCalls["math"].Time > 0)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"io"
 	"sync"
+	"sync/atomic"
 
 	"github.com/samyfodil/wazy"
 	"github.com/samyfodil/wazy/api"
@@ -23,6 +24,7 @@ type funcInstance struct {
 type hostModule struct {
 	ctx     vm.Context
 	builder wazy.HostModuleBuilder
+	stats   *stats
 }
 
 /*************** Instance ***************/
@@ -54,6 +56,15 @@ type runtime struct {
 	runtime wazy.Runtime
 
 	wasiStartDone chan bool
+
+	// depth is how deep in calls into the guest the runtime is.
+	depth atomic.Int32
+	stats stats
+
+	// fuel is the counter metered modules charge; nil when calls are not limited.
+	fuel      api.MutableGlobal
+	fuelLimit uint64
+	fuelUsed  atomic.Uint64
 }
 
 /*************** Service ***************/
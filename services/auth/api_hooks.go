@@ -9,6 +9,7 @@ import (
 	"github.com/taubyte/tau/p2p/streams"
 	"github.com/taubyte/tau/p2p/streams/command"
 	cr "github.com/taubyte/tau/p2p/streams/command/response"
+	"github.com/taubyte/tau/pkg/git/providers"
 	"github.com/taubyte/tau/services/auth/hooks"
 	"github.com/taubyte/tau/services/auth/projects"
 	"github.com/taubyte/tau/services/auth/repositories"
@@ -58,8 +59,8 @@ func (srv *AuthService) apiHookServiceHandler(ctx context.Context, st streams.Co
 }
 
 /******* REPOS ********/
-func (srv *AuthService) getGithubRepositoryByID(ctx context.Context, id int) (cr.Response, error) {
-	repo, err := repositories.Fetch(ctx, srv.db, fmt.Sprintf("%d", id))
+func (srv *AuthService) getRepositoryByID(ctx context.Context, provider string, id int) (cr.Response, error) {
+	repo, err := repositories.FetchOn(ctx, srv.db, provider, fmt.Sprintf("%d", id))
 	if err != nil {
 		return nil, err
 	}
@@ -67,8 +68,8 @@ func (srv *AuthService) getGithubRepositoryByID(ctx context.Context, id int) (cr
 	return cr.Response(repo.Serialize()), nil
 }
 
-func (srv *AuthService) listRepo(ctx context.Context) (cr.Response, error) {
-	repoList, err := srv.db.List(ctx, "/repositories/github/")
+func (srv *AuthService) listRepo(ctx context.Context, provider string) (cr.Response, error) {
+	repoList, err := srv.db.List(ctx, "/repositories/"+provider+"/")
 	if err != nil {
 		return nil, fmt.Errorf("failed gettting repo with error: %w", err)
 	}
@@ -90,18 +91,24 @@ func (srv *AuthService) apiGitRepositoryServiceHandler(ctx context.Context, st s
 		if err != nil {
 			return nil, err
 		}
-		switch provider {
-		case "github":
-			hook_id, err := maps.Int(body, "id")
-			if err != nil {
-				return nil, err
-			}
-			return srv.getGithubRepositoryByID(ctx, hook_id)
-		default:
+		if !repositories.IsGitProvider(provider) {
 			return nil, errors.New("Repository provider `" + provider + "` not supported.")
 		}
+		repo_id, err := maps.Int(body, "id")
+		if err != nil {
+			return nil, err
+		}
+		return srv.getRepositoryByID(ctx, provider, repo_id)
 	case "list":
-		return srv.listRepo(ctx)
+		// Older clients do not send a provider; they only knew GitHub.
+		provider, err := maps.String(body, "provider")
+		if err != nil {
+			provider = "github"
+		}
+		if !repositories.IsGitProvider(provider) {
+			return nil, errors.New("Repository provider `" + provider + "` not supported.")
+		}
+		return srv.listRepo(ctx, provider)
 	case "register":
 		return srv.registerRepositoryStream(ctx, body)
 	case "unregister":
@@ -150,6 +157,14 @@ func (srv *AuthService) createProjectStream(ctx context.Context, body command.Bo
 		return nil, fmt.Errorf("missing code repository ID parameter: %w", err)
 	}
 
+	provider, err := maps.String(body, "provider")
+	if err != nil {
+		provider = "github"
+	}
+	if !repositories.IsGitProvider(provider) {
+		return nil, fmt.Errorf("provider `%s` is not supported", provider)
+	}
+
 	// Generate a new project ID
 	projectID := common.GetNewProjectID()
 
@@ -157,7 +172,7 @@ func (srv *AuthService) createProjectStream(ctx context.Context, body command.Bo
 	project, err := projects.New(srv.KV(), projects.Data{
 		"id":       projectID,
 		"name":     name,
-		"provider": "github",
+		"provider": provider,
 		"config":   configRepoID,
 		"code":     codeRepoID,
 	})
@@ -171,12 +186,12 @@ func (srv *AuthService) createProjectStream(ctx context.Context, body command.Bo
 	}
 
 	// Link repositories to project
-	repo_key := fmt.Sprintf("/repositories/github/%s", configRepoID)
+	repo_key := fmt.Sprintf("/repositories/%s/%s", provider, configRepoID)
 	if err = srv.db.Put(ctx, repo_key+"/project", []byte(projectID)); err != nil {
 		return nil, err
 	}
 
-	repo_key = fmt.Sprintf("/repositories/github/%s", codeRepoID)
+	repo_key = fmt.Sprintf("/repositories/%s/%s", provider, codeRepoID)
 	if err = srv.db.Put(ctx, repo_key+"/project", []byte(projectID)); err != nil {
 		return nil, err
 	}
@@ -216,10 +231,19 @@ func (srv *AuthService) registerRepositoryStream(ctx context.Context, body comma
 		return nil, fmt.Errorf("missing provider parameter: %w", err)
 	}
 
-	if provider != "github" {
+	if !repositories.IsGitProvider(provider) {
 		return nil, fmt.Errorf("provider `%s` is not supported", provider)
 	}
 
+	// A bare git server is named by its URI; its id is derived from it.
+	if provider == providers.Generic.Name() {
+		uri, err := maps.String(body, "uri")
+		if err != nil {
+			return nil, fmt.Errorf("missing repository uri parameter: %w", err)
+		}
+		return srv.registerGenericRepository(ctx, uri)
+	}
+
 	repoID, err := maps.String(body, "id")
 	if err != nil {
 		return nil, fmt.Errorf("missing repository ID parameter: %w", err)
@@ -228,7 +252,12 @@ func (srv *AuthService) registerRepositoryStream(ctx context.Context, body comma
 	// For p2p streams (internal service communication), we can register repositories
 	// without external GitHub API calls since services trust each other
 	// Use the main function with nil client to skip GitHub API verification
-	response, err := srv.registerGitHubRepository(ctx, nil, repoID)
+	var response *RepositoryRegistrationResponse
+	if provider == "github" {
+		response, err = srv.registerGitHubRepository(ctx, nil, repoID)
+	} else {
+		response, err = srv.registerProviderRepository(ctx, nil, provider, repoID)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("repository registration failed: %w", err)
 	}
@@ -245,7 +274,7 @@ func (srv *AuthService) unregisterRepositoryStream(ctx context.Context, body com
 		return nil, fmt.Errorf("missing provider parameter: %w", err)
 	}
 
-	if provider != "github" {
+	if !repositories.IsGitProvider(provider) {
 		return nil, fmt.Errorf("provider `%s` is not supported", provider)
 	}
 
@@ -257,7 +286,11 @@ func (srv *AuthService) unregisterRepositoryStream(ctx context.Context, body com
 	// For p2p streams (internal service communication), we can unregister repositories
 	// without external GitHub API calls
 	// Use the main function with nil client to skip GitHub API verification
-	err = srv.unregisterGitHubRepository(ctx, nil, repoID)
+	if provider == "github" {
+		err = srv.unregisterGitHubRepository(ctx, nil, repoID)
+	} else {
+		err = srv.unregisterProviderRepository(ctx, nil, provider, repoID)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("repository unregistration failed: %w", err)
 	}
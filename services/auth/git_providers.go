@@ -0,0 +1,522 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	cr "github.com/taubyte/tau/p2p/streams/command/response"
+	"github.com/taubyte/tau/pkg/git/providers"
+	http "github.com/taubyte/tau/pkg/http"
+	httpAuth "github.com/taubyte/tau/pkg/http/auth"
+	cu "github.com/taubyte/tau/services/auth/crypto"
+	"github.com/taubyte/tau/services/auth/hooks"
+	"github.com/taubyte/tau/services/auth/projects"
+	"github.com/taubyte/tau/services/auth/repositories"
+	protocolCommon "github.com/taubyte/tau/services/common"
+	"github.com/taubyte/tau/utils/id"
+	"github.com/taubyte/tau/utils/maps"
+	"golang.org/x/crypto/ssh"
+)
+
+// defaultProviderAPI connects to a git provider other than GitHub, at the URL
+// configured for it under `git-providers`.
+func (srv *AuthService) defaultProviderAPI(name, token string) (providers.API, error) {
+	p, err := providers.Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var baseURL string
+	if srv.config != nil {
+		baseURL = srv.config.GitProviders()[p.Name()].URL
+	}
+
+	return p.NewAPI(baseURL, token)
+}
+
+// GitTokenHTTPAuth is GitHubTokenHTTPAuth for routes that also serve the other
+// git providers. The token type names the provider, so a `gitea` or `gitlab`
+// token is checked against that provider and anything else falls through to
+// GitHub unchanged.
+func (srv *AuthService) GitTokenHTTPAuth(ctx http.Context) (interface{}, error) {
+	auth := httpAuth.GetAuthorization(ctx)
+	if auth == nil {
+		return srv.GitHubTokenHTTPAuth(ctx)
+	}
+
+	if _, err := providers.Get(auth.Type); err != nil {
+		return srv.GitHubTokenHTTPAuth(ctx)
+	}
+
+	return srv.providerTokenHTTPAuth(ctx, auth.Type, auth.Token)
+}
+
+// providerTokenHTTPAuth validates a provider token by asking who it belongs to.
+//
+// There is no membership verifier for these providers, so the identity gate is
+// narrower than GitHub's: outside dev mode the cloud's tenancy must name this
+// provider, and every repository touched is then checked to be owned by the
+// tenancy's namespace.
+func (srv *AuthService) providerTokenHTTPAuth(ctx http.Context, provider, token string) (interface{}, error) {
+	if !srv.devMode {
+		if !srv.tenancy.Configured() {
+			return nil, ErrNoTenancy
+		}
+		if !strings.EqualFold(srv.tenancy.Provider, provider) {
+			return nil, fmt.Errorf("this cloud does not accept `%s` tokens", provider)
+		}
+	}
+
+	rctx, rctx_cancel := context.WithTimeout(srv.ctx, time.Duration(30)*time.Second)
+
+	api, err := srv.newProviderAPI(provider, token)
+	if err != nil {
+		rctx_cancel()
+		return nil, err
+	}
+
+	if _, err = api.Me(rctx); err != nil {
+		rctx_cancel()
+		return nil, fmt.Errorf("invalid %s token", provider)
+	}
+
+	ctx.SetVariable("GitProvider", provider)
+	ctx.SetVariable("GitProviderAPI", api)
+	ctx.SetVariable("GitProviderDone", rctx_cancel)
+
+	return nil, nil
+}
+
+func (srv *AuthService) GitTokenHTTPAuthCleanup(ctx http.Context) (interface{}, error) {
+	if done, k := ctx.Variables()["GitProviderDone"]; k && done != nil {
+		done.(context.CancelFunc)()
+	}
+	return srv.GitHubTokenHTTPAuthCleanup(ctx)
+}
+
+func getProviderAPIFromContext(ctx http.Context) (string, providers.API, bool) {
+	ctxVars := ctx.Variables()
+	api, k := ctxVars["GitProviderAPI"].(providers.API)
+	if !k {
+		return "", nil, false
+	}
+
+	provider, _ := ctxVars["GitProvider"].(string)
+	return provider, api, true
+}
+
+// byProvider serves a request with onProvider when it was authenticated with a
+// non-GitHub token, and with onGitHub otherwise.
+func byProvider(onGitHub, onProvider http.Handler) http.Handler {
+	return func(ctx http.Context) (interface{}, error) {
+		if _, _, ok := getProviderAPIFromContext(ctx); ok {
+			return onProvider(ctx)
+		}
+		return onGitHub(ctx)
+	}
+}
+
+// providerRepository fetches a repository as the caller and refuses it unless
+// the caller can push to it and the tenancy owns it.
+func (srv *AuthService) providerRepository(ctx context.Context, api providers.API, repoID string) (*providers.Repository, error) {
+	_id, err := strconv.Atoi(repoID)
+	if err != nil {
+		return nil, fmt.Errorf("parse repoId failed with %s", err)
+	}
+
+	repo, err := api.Repository(ctx, _id)
+	if err != nil || !repo.CanWrite {
+		return nil, fmt.Errorf("no access to repository `%s`", repoID)
+	}
+
+	if !srv.devMode && !srv.tenancy.Owns(repo.FullName) {
+		return nil, fmt.Errorf("repository `%s` is not owned by `%s`", repo.FullName, srv.tenancy.Owner)
+	}
+
+	return repo, nil
+}
+
+// registerProviderRepository is registerGitHubRepository for the other git
+// providers. A nil api comes from p2p callers, which are trusted and skip the
+// provider entirely.
+func (srv *AuthService) registerProviderRepository(ctx context.Context, api providers.API, provider, repoID string) (*RepositoryRegistrationResponse, error) {
+	var repo *providers.Repository
+	if api != nil {
+		var err error
+		if repo, err = srv.providerRepository(ctx, api, repoID); err != nil {
+			return nil, err
+		}
+	}
+
+	_repo_id, err := strconv.ParseInt(repoID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("parse repoId failed with %s", err)
+	}
+
+	repoKey := fmt.Sprintf("/repositories/%s/%s/key", provider, repoID)
+
+	hook_id := id.Generate(repoKey)
+	hookUrl := srv.webHookUrl + "/" + provider + "/" + hook_id
+
+	var (
+		hook_providerid int64
+		secret          string
+	)
+	if !srv.devMode && api != nil {
+		if secret, err = cu.GenerateSecretString(); err != nil {
+			return nil, fmt.Errorf("generate hook secret failed with: %s", err)
+		}
+
+		hook_providerid, err = api.CreatePushHook(ctx, repo, hookUrl, secret)
+		if err != nil {
+			return nil, fmt.Errorf("create push hook failed with: %s", err)
+		}
+	} else {
+		hook_providerid = 12345
+		secret = "mock-secret"
+	}
+
+	kname, kpub, kpriv, err := generateKey()
+	if err != nil {
+		return nil, fmt.Errorf("generate key failed with %s", err)
+	}
+
+	if api != nil {
+		if err = api.CreateDeployKey(ctx, repo, kname, kpub); err != nil {
+			return nil, fmt.Errorf("create deploy key failed with: %s", err)
+		}
+	}
+
+	if err = srv.saveProviderRepository(ctx, provider, _repo_id, repo, hook_id, hook_providerid, secret, kpriv); err != nil {
+		return nil, err
+	}
+
+	return &RepositoryRegistrationResponse{
+		Key: repoKey,
+	}, nil
+}
+
+// saveProviderRepository records a registered repository with its deploy key
+// and push hook, and publishes where it is fetched from when repo is known.
+func (srv *AuthService) saveProviderRepository(ctx context.Context, provider string, _repo_id int64, repo *providers.Repository, hook_id string, hook_providerid int64, secret, kpriv string) error {
+	_repo, err := repositories.New(srv.KV(), repositories.Data{
+		"id":       _repo_id,
+		"provider": provider,
+		"key":      kpriv,
+	})
+	if err != nil {
+		return fmt.Errorf("new repository failed with %s", err)
+	}
+
+	if err = _repo.Register(ctx); err != nil {
+		return err
+	}
+
+	hook, err := hooks.New(srv.KV(), hooks.Data{
+		"id":          hook_id,
+		"provider":    provider,
+		"provider_id": hook_providerid,
+		"repository":  _repo_id,
+		"secret":      secret,
+	})
+	if err != nil {
+		return fmt.Errorf("hooks new failed with %s", err)
+	}
+
+	if err = hook.Register(ctx); err != nil {
+		return fmt.Errorf("hooks register failed with %s", err)
+	}
+
+	repoID := strconv.FormatInt(_repo_id, 10)
+	repoInfo := make(map[string]string, 2)
+	if repo != nil {
+		repoInfo["ssh"] = repo.SSHURL
+		repoInfo["fullname"] = repo.FullName
+	}
+
+	err = srv.tnsClient.Push([]string{"resolve", "repo", provider, repoID}, repoInfo)
+	if err != nil {
+		return fmt.Errorf("failed registering new job repo %s into tns with error: %v", repoID, err)
+	}
+
+	return nil
+}
+
+// genericURIKey is where the uri a bare repository was registered with is
+// kept. Its id is a hash of the uri, so this tells registering the same
+// repository again apart from another uri colliding with it.
+func genericURIKey(repoID int) string {
+	return fmt.Sprintf("/repositories/%s/%d/uri", providers.Generic.Name(), repoID)
+}
+
+// registerGenericRepository registers a repository on a bare git server. There
+// is no API to install anything through, so the deploy key and a signed
+// post-receive hook are returned for the server's operator to install.
+// Registering a repository again hands back the same key and hook, so the
+// ones already installed keep working.
+func (srv *AuthService) registerGenericRepository(ctx context.Context, uri string) (cr.Response, error) {
+	if uri == "" {
+		return nil, errors.New("repository uri is required")
+	}
+
+	repo := providers.GenericRepository(uri)
+	repoKey := fmt.Sprintf("/repositories/%s/%d/key", providers.Generic.Name(), repo.ID)
+
+	if registered, err := srv.KV().Get(ctx, genericURIKey(repo.ID)); err == nil && registered != nil {
+		if string(registered) != repo.SSHURL {
+			return nil, fmt.Errorf("repository `%s` collides with `%s`, already registered under id %d", repo.SSHURL, registered, repo.ID)
+		}
+
+		resp, err := srv.registeredGenericRepository(ctx, repo, repoKey)
+		if err == nil {
+			return resp, nil
+		}
+
+		// a registration that did not complete is done over
+		logger.Warnf("registering generic repository %d again: %s", repo.ID, err)
+	}
+
+	hook_id := id.Generate(repoKey)
+	hookUrl := srv.webHookUrl + "/" + providers.Generic.Name() + "/" + hook_id
+
+	// The secret is real even in dev mode: unlike the mock hooks of the other
+	// providers, this one is installed by hand and actually signs deliveries.
+	secret, err := cu.GenerateSecretString()
+	if err != nil {
+		return nil, fmt.Errorf("generate hook secret failed with: %s", err)
+	}
+
+	_, kpub, kpriv, err := generateKey()
+	if err != nil {
+		return nil, fmt.Errorf("generate key failed with %s", err)
+	}
+
+	if err = srv.saveProviderRepository(ctx, providers.Generic.Name(), int64(repo.ID), repo, hook_id, 0, secret, kpriv); err != nil {
+		return nil, err
+	}
+
+	if err = srv.KV().Put(ctx, genericURIKey(repo.ID), []byte(repo.SSHURL)); err != nil {
+		return nil, fmt.Errorf("saving repository uri failed with: %w", err)
+	}
+
+	return genericRepositoryResponse(repo, repoKey, kpub, hookUrl, secret), nil
+}
+
+// registeredGenericRepository rebuilds the response of an earlier
+// registration of repo from what it stored.
+func (srv *AuthService) registeredGenericRepository(ctx context.Context, repo *providers.Repository, repoKey string) (cr.Response, error) {
+	stored, err := repositories.FetchOn(ctx, srv.KV(), providers.Generic.Name(), strconv.Itoa(repo.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	hks := stored.Hooks(ctx)
+	if len(hks) == 0 {
+		return nil, errors.New("repository has no hook")
+	}
+
+	secret, _ := hks[0].Serialize()["secret"].(string)
+	kpriv, _ := stored.Serialize()["key"].(string)
+	signer, err := ssh.ParsePrivateKey([]byte(kpriv))
+	if err != nil {
+		return nil, fmt.Errorf("parsing deploy key failed with: %w", err)
+	}
+
+	hookUrl := srv.webHookUrl + "/" + providers.Generic.Name() + "/" + hks[0].ID()
+	return genericRepositoryResponse(repo, repoKey, string(ssh.MarshalAuthorizedKey(signer.PublicKey())), hookUrl, secret), nil
+}
+
+func genericRepositoryResponse(repo *providers.Repository, repoKey, kpub, hookUrl, secret string) cr.Response {
+	return cr.Response{
+		"id":           repo.ID,
+		"key":          repoKey,
+		"public_key":   kpub,
+		"hook_url":     hookUrl,
+		"secret":       secret,
+		"post_receive": providers.PostReceiveHook(repo.SSHURL, hookUrl, secret),
+	}
+}
+
+func (srv *AuthService) unregisterProviderRepository(ctx context.Context, api providers.API, provider, repoID string) error {
+	if api != nil {
+		if _, err := srv.providerRepository(ctx, api, repoID); err != nil {
+			return err
+		}
+	}
+
+	repo, err := repositories.FetchOn(ctx, srv.db, provider, repoID)
+	if err != nil {
+		return fmt.Errorf("repository `%s` not registered! err = %w", repoID, err)
+	}
+
+	if err = repo.Delete(ctx); err != nil {
+		return err
+	}
+
+	if provider == providers.Generic.Name() {
+		if _id, err := strconv.Atoi(repoID); err == nil {
+			return srv.db.Delete(ctx, genericURIKey(_id))
+		}
+	}
+
+	return nil
+}
+
+func (srv *AuthService) newProviderProject(ctx context.Context, api providers.API, provider, projectID, projectName, configID, codeID string) (*ProjectCreateResponse, error) {
+	if !srv.devMode {
+		for _, repoID := range []string{configID, codeID} {
+			if _, err := srv.providerRepository(ctx, api, repoID); err != nil {
+				return nil, err
+			}
+		}
+
+		// Same as newGitHubProject: an import must not take over a project
+		// the caller cannot already reach.
+		if existing, err := projects.Fetch(ctx, srv.KV(), projectID); err == nil {
+			if existing.Provider() != provider {
+				return nil, errors.New("project not found")
+			}
+			if _, err := srv.providerRepository(ctx, api, existing.Config()); err != nil {
+				if _, err := srv.providerRepository(ctx, api, existing.Code()); err != nil {
+					return nil, errors.New("project not found")
+				}
+			}
+		}
+	}
+
+	user, err := api.Me(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	project, err := projects.New(srv.KV(), projects.Data{
+		"id":       projectID,
+		"name":     projectName,
+		"provider": provider,
+		"config":   configID,
+		"code":     codeID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create project object: %w", err)
+	}
+
+	if err = project.Register(); err != nil {
+		return nil, fmt.Errorf("failed to register project: %w", err)
+	}
+
+	err = srv.db.Put(ctx, "/projects/"+projectID+"/owners/"+fmt.Sprintf("%d", user.ID), []byte(user.Login))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, repoID := range []string{configID, codeID} {
+		if err = srv.db.Put(ctx, fmt.Sprintf("/repositories/%s/%s/project", provider, repoID), []byte(projectID)); err != nil {
+			return nil, err
+		}
+	}
+
+	return &ProjectCreateResponse{
+		Project: ProjectInfo{
+			ID:   projectID,
+			Name: projectName,
+		},
+	}, nil
+}
+
+// providerRouteVariables returns the provider and repository id of a
+// /repository/{provider}/{id} request, refusing a provider other than the one
+// the token was checked against.
+func providerRouteVariables(ctx http.Context) (string, providers.API, string, error) {
+	provider, api, _ := getProviderAPIFromContext(ctx)
+
+	ctxVars := ctx.Variables()
+	routeProvider, err := maps.String(ctxVars, "provider")
+	if err != nil {
+		return "", nil, "", err
+	}
+	if routeProvider != provider {
+		return "", nil, "", fmt.Errorf("a `%s` token cannot act on `%s` repositories", provider, routeProvider)
+	}
+
+	repoId, err := maps.String(ctxVars, "id")
+	if err != nil {
+		return "", nil, "", fmt.Errorf("parsing %s repository ID failed with %w", provider, err)
+	}
+
+	return provider, api, repoId, nil
+}
+
+func (srv *AuthService) registerProviderRepositoryHTTPHandler(ctx http.Context) (interface{}, error) {
+	provider, api, repoId, err := providerRouteVariables(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return srv.registerProviderRepository(ctx.Request().Context(), api, provider, repoId)
+}
+
+func (srv *AuthService) unregisterProviderRepositoryHTTPHandler(ctx http.Context) (interface{}, error) {
+	provider, api, repoId, err := providerRouteVariables(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, srv.unregisterProviderRepository(ctx.Request().Context(), api, provider, repoId)
+}
+
+func (srv *AuthService) getProviderRepositoryHTTPHandler(ctx http.Context) (interface{}, error) {
+	provider, api, repoId, err := providerRouteVariables(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	requestCtx := ctx.Request().Context()
+	if _, err := srv.providerRepository(requestCtx, api, repoId); err != nil {
+		return nil, fmt.Errorf("repository %s not found", repoId)
+	}
+
+	repo, err := repositories.FetchOn(requestCtx, srv.db, provider, repoId)
+	if err != nil {
+		return nil, fmt.Errorf("repository %s not found", repoId)
+	}
+
+	hks := make([]string, 0)
+	for _, h := range repo.Hooks(requestCtx) {
+		hks = append(hks, h.ProviderID())
+	}
+
+	return map[string]interface{}{"hooks": hks}, nil
+}
+
+func (srv *AuthService) newProviderProjectHTTPHandler(ctx http.Context) (interface{}, error) {
+	provider, api, _ := getProviderAPIFromContext(ctx)
+
+	configID, codeID, projectName, err := extractProjectVariables(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	projectID := protocolCommon.GetNewProjectID(projectName, time.Now().Unix(), rand.Intn(1000000000))
+	return srv.newProviderProject(ctx.Request().Context(), api, provider, projectID, projectName, configID, codeID)
+}
+
+func (srv *AuthService) importProviderProjectHTTPHandler(ctx http.Context) (interface{}, error) {
+	provider, api, _ := getProviderAPIFromContext(ctx)
+
+	configID, codeID, projectName, err := extractProjectVariables(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	projectID, err := maps.String(ctx.Variables(), "project-id")
+	if err != nil {
+		return nil, err
+	}
+
+	return srv.newProviderProject(ctx.Request().Context(), api, provider, projectID, projectName, configID, codeID)
+}
@@ -0,0 +1,140 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/taubyte/tau/pkg/config"
+	"github.com/taubyte/tau/pkg/git/providers"
+	"github.com/taubyte/tau/services/auth/hooks"
+	"github.com/taubyte/tau/services/auth/repositories"
+	"gotest.tools/v3/assert"
+)
+
+// giteaStandIn serves two repositories: 42 belongs to the tenancy, 43 does not.
+// It records the body of every hook it is asked to create.
+func giteaStandIn(t *testing.T) (*httptest.Server, *[]map[string]any) {
+	var hooksCreated []map[string]any
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/user", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":3,"login":"alice"}`))
+	})
+	mux.HandleFunc("GET /api/v1/repositories/42", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":42,"name":"web","full_name":"acme/web","ssh_url":"git@gitea.local:acme/web.git","permissions":{"push":true}}`))
+	})
+	mux.HandleFunc("GET /api/v1/repositories/43", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":43,"name":"web","full_name":"other/web","ssh_url":"git@gitea.local:other/web.git","permissions":{"push":true}}`))
+	})
+	mux.HandleFunc("POST /api/v1/repos/acme/web/keys", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":1}`))
+	})
+	mux.HandleFunc("POST /api/v1/repos/acme/web/hooks", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		assert.NilError(t, json.NewDecoder(r.Body).Decode(&body))
+		hooksCreated = append(hooksCreated, body)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":77}`))
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv, &hooksCreated
+}
+
+func TestRegisterProviderRepository(t *testing.T) {
+	ctx := t.Context()
+	svc := newAuthzService(t, 13517)
+	svc.tnsClient = &mockTNSClient{}
+	svc.tenancy = config.Tenancy{Provider: "gitea", Owner: "acme"}
+
+	gitea, created := giteaStandIn(t)
+	api, err := providers.Gitea.NewAPI(gitea.URL, "token")
+	assert.NilError(t, err)
+
+	_, err = svc.registerProviderRepository(ctx, api, "gitea", "43")
+	assert.Error(t, err, "repository `other/web` is not owned by `acme`")
+
+	resp, err := svc.registerProviderRepository(ctx, api, "gitea", "42")
+	assert.NilError(t, err)
+	assert.Equal(t, resp.Key, "/repositories/gitea/42/key")
+
+	// The hook points patrick at the provider's own route, signed with a
+	// generated secret that auth keeps for verifying deliveries.
+	assert.Equal(t, len(*created), 1)
+	hookConfig := (*created)[0]["config"].(map[string]any)
+
+	repo, err := repositories.FetchOn(ctx, svc.db, "gitea", "42")
+	assert.NilError(t, err)
+	hks := repo.Hooks(ctx)
+	assert.Equal(t, len(hks), 1)
+	assert.Equal(t, hookConfig["url"], svc.webHookUrl+"/gitea/"+hks[0].ID())
+
+	hook, err := hooks.Fetch(ctx, svc.db, hks[0].ID())
+	assert.NilError(t, err)
+	data := hook.Serialize()
+	assert.Equal(t, data["provider"], "gitea")
+	assert.Equal(t, data["provider_id"], 77)
+	assert.Equal(t, data["secret"], hookConfig["secret"])
+	assert.Assert(t, data["secret"] != "")
+
+	assert.NilError(t, svc.unregisterProviderRepository(ctx, api, "gitea", "42"))
+	assert.Assert(t, !repositories.ExistOn(ctx, svc.db, "gitea", "42"))
+}
+
+func TestProviderTokenRequiresTenancyProvider(t *testing.T) {
+	svc := newAuthzService(t, 13518)
+	svc.tenancy = config.Tenancy{Provider: "github", Owner: "acme"}
+
+	_, err := svc.providerTokenHTTPAuth(nil, "gitea", "token")
+	assert.Error(t, err, "this cloud does not accept `gitea` tokens")
+}
+
+func TestRegisterGenericRepository(t *testing.T) {
+	ctx := t.Context()
+	svc := newAuthzService(t, 13519)
+	svc.tnsClient = &mockTNSClient{}
+
+	resp, err := svc.registerRepositoryStream(ctx, map[string]interface{}{
+		"provider": "generic",
+		"uri":      "git@git.local:acme/web.git",
+	})
+	assert.NilError(t, err)
+
+	repoID := providers.RepositoryID("git@git.local:acme/web.git")
+	assert.Equal(t, resp["id"], repoID)
+	assert.Assert(t, strings.HasPrefix(resp["public_key"].(string), "ecdsa-sha2-nistp256 "))
+
+	// The hook handed back is the one patrick will verify against.
+	repo, err := repositories.FetchOn(ctx, svc.db, "generic", strconv.Itoa(repoID))
+	assert.NilError(t, err)
+	hks := repo.Hooks(ctx)
+	assert.Equal(t, len(hks), 1)
+	assert.Equal(t, resp["hook_url"], svc.webHookUrl+"/generic/"+hks[0].ID())
+
+	hook, err := hooks.Fetch(ctx, svc.db, hks[0].ID())
+	assert.NilError(t, err)
+	assert.Equal(t, hook.Serialize()["secret"], resp["secret"])
+	assert.Assert(t, strings.Contains(resp["post_receive"].(string), resp["hook_url"].(string)))
+
+	// Registering again keeps the key and hook already installed.
+	again, err := svc.registerRepositoryStream(ctx, map[string]interface{}{
+		"provider": "generic",
+		"uri":      "git@git.local:acme/web.git/",
+	})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, again, resp)
+	assert.Equal(t, len(repo.Hooks(ctx)), 1)
+
+	// Another uri landing on the same id is rejected.
+	assert.NilError(t, svc.db.Put(ctx, genericURIKey(repoID), []byte("git@git.local:acme/other.git")))
+	_, err = svc.registerRepositoryStream(ctx, map[string]interface{}{
+		"provider": "generic",
+		"uri":      "git@git.local:acme/web.git",
+	})
+	assert.ErrorContains(t, err, "collides")
+}
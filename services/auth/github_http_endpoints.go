@@ -241,10 +241,10 @@ func (srv *AuthService) setupGitHubHTTPRoutes() {
 		},
 		Scope: []string{"projects/new"},
 		Auth: http.RouteAuthHandler{
-			Validator: srv.GitHubTokenHTTPAuth,
-			GC:        srv.GitHubTokenHTTPAuthCleanup,
+			Validator: srv.GitTokenHTTPAuth,
+			GC:        srv.GitTokenHTTPAuthCleanup,
 		},
-		Handler: srv.newGitHubProjectHTTPHandler,
+		Handler: byProvider(srv.newGitHubProjectHTTPHandler, srv.newProviderProjectHTTPHandler),
 	})
 
 	srv.http.POST(&http.RouteDefinition{
@@ -255,10 +255,10 @@ func (srv *AuthService) setupGitHubHTTPRoutes() {
 		},
 		Scope: []string{"projects/import"},
 		Auth: http.RouteAuthHandler{
-			Validator: srv.GitHubTokenHTTPAuth,
-			GC:        srv.GitHubTokenHTTPAuthCleanup,
+			Validator: srv.GitTokenHTTPAuth,
+			GC:        srv.GitTokenHTTPAuthCleanup,
 		},
-		Handler: srv.importGitHubProjectHTTPHandler,
+		Handler: byProvider(srv.importGitHubProjectHTTPHandler, srv.importProviderProjectHTTPHandler),
 	})
 
 	srv.http.PUT(&http.RouteDefinition{
@@ -269,10 +269,10 @@ func (srv *AuthService) setupGitHubHTTPRoutes() {
 		},
 		Scope: []string{"repositories/write"},
 		Auth: http.RouteAuthHandler{
-			Validator: srv.GitHubTokenHTTPAuth,
-			GC:        srv.GitHubTokenHTTPAuthCleanup,
+			Validator: srv.GitTokenHTTPAuth,
+			GC:        srv.GitTokenHTTPAuthCleanup,
 		},
-		Handler: srv.registerGitHubUserRepositoryHTTPHandler,
+		Handler: byProvider(srv.registerGitHubUserRepositoryHTTPHandler, srv.registerProviderRepositoryHTTPHandler),
 	})
 
 	srv.http.DELETE(&http.RouteDefinition{
@@ -283,10 +283,10 @@ func (srv *AuthService) setupGitHubHTTPRoutes() {
 		},
 		Scope: []string{"repositories/write"},
 		Auth: http.RouteAuthHandler{
-			Validator: srv.GitHubTokenHTTPAuth,
-			GC:        srv.GitHubTokenHTTPAuthCleanup,
+			Validator: srv.GitTokenHTTPAuth,
+			GC:        srv.GitTokenHTTPAuthCleanup,
 		},
-		Handler: srv.unregisterGitHubUserRepositoryHTTPHandler,
+		Handler: byProvider(srv.unregisterGitHubUserRepositoryHTTPHandler, srv.unregisterProviderRepositoryHTTPHandler),
 	})
 
 	srv.http.GET(&http.RouteDefinition{
@@ -297,10 +297,10 @@ func (srv *AuthService) setupGitHubHTTPRoutes() {
 		},
 		Scope: []string{"repositories/read"},
 		Auth: http.RouteAuthHandler{
-			Validator: srv.GitHubTokenHTTPAuth,
-			GC:        srv.GitHubTokenHTTPAuthCleanup,
+			Validator: srv.GitTokenHTTPAuth,
+			GC:        srv.GitTokenHTTPAuthCleanup,
 		},
-		Handler: srv.getGitHubUserRepositoryHTTPHandler,
+		Handler: byProvider(srv.getGitHubUserRepositoryHTTPHandler, srv.getProviderRepositoryHTTPHandler),
 	})
 
 	srv.http.GET(&http.RouteDefinition{
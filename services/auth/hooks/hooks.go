@@ -8,6 +8,7 @@ import (
 
 	"github.com/ipfs/go-log/v2"
 	"github.com/taubyte/tau/core/kvdb"
+	"github.com/taubyte/tau/pkg/git/providers"
 	"github.com/taubyte/tau/utils/maps"
 	"github.com/taubyte/tau/utils/network"
 )
@@ -64,7 +65,46 @@ func (h *GithubHook) ProviderID() string {
 }
 
 func (h *GithubHook) Delete(ctx context.Context) error {
-	err := h.HookCommon.Delete(ctx)
+	return h.deleteEntries(ctx, h.Repository)
+}
+
+func (h *GithubHook) Register(ctx context.Context) error {
+	return h.registerEntries(ctx, h.GithubId, h.Secret, h.Repository)
+}
+
+// ProviderHook is a push hook on one of the git providers besides GitHub. It
+// is stored like a GithubHook, under the provider's name.
+type ProviderHook struct {
+	HookCommon
+	ProviderId int
+	Secret     string
+	Repository int
+}
+
+func (h *ProviderHook) Serialize() Data {
+	return Data{
+		"id":          h.Id,
+		"provider":    h.Provider,
+		"provider_id": h.ProviderId,
+		"secret":      h.Secret,
+		"repository":  h.Repository,
+	}
+}
+
+func (h *ProviderHook) ProviderID() string {
+	return strconv.Itoa(h.ProviderId)
+}
+
+func (h *ProviderHook) Delete(ctx context.Context) error {
+	return h.deleteEntries(ctx, h.Repository)
+}
+
+func (h *ProviderHook) Register(ctx context.Context) error {
+	return h.registerEntries(ctx, h.ProviderId, h.Secret, h.Repository)
+}
+
+func (h *HookCommon) deleteEntries(ctx context.Context, repository int) error {
+	err := h.Delete(ctx)
 	if err != nil {
 		return err
 	}
@@ -74,24 +114,24 @@ func (h *GithubHook) Delete(ctx context.Context) error {
 		return fmt.Errorf("failed to create batch: %w", err)
 	}
 
-	root := "/hooks/" + h.Id
+	root := "/hooks/" + h.Id + "/" + h.Provider
 
-	err = batch.Delete(root + "/github/id")
+	err = batch.Delete(root + "/id")
 	if err != nil {
 		return fmt.Errorf("failed to batch delete hook ID: %w", err)
 	}
 
-	err = batch.Delete(root + "/github/secret")
+	err = batch.Delete(root + "/secret")
 	if err != nil {
 		return fmt.Errorf("failed to batch delete hook secret: %w", err)
 	}
 
-	err = batch.Delete(root + "/github/repository")
+	err = batch.Delete(root + "/repository")
 	if err != nil {
 		return fmt.Errorf("failed to batch delete hook repository: %w", err)
 	}
 
-	err = batch.Delete(fmt.Sprintf("/repositories/github/%d/hooks/%s", h.Repository, h.Id))
+	err = batch.Delete(fmt.Sprintf("/repositories/%s/%d/hooks/%s", h.Provider, repository, h.Id))
 	if err != nil {
 		return fmt.Errorf("failed to batch delete repository hook reference: %w", err)
 	}
@@ -104,47 +144,47 @@ func (h *GithubHook) Delete(ctx context.Context) error {
 	return nil
 }
 
-func (h *GithubHook) Register(ctx context.Context) error {
-	err := h.HookCommon.Register(ctx)
+func (h *HookCommon) registerEntries(ctx context.Context, providerId int, secret string, repository int) error {
+	err := h.Register(ctx)
 	if err != nil {
 		return err
 	}
 
 	batch, err := h.KV.Batch(ctx)
 	if err != nil {
-		h.Delete(ctx)
+		h.deleteEntries(ctx, repository)
 		return fmt.Errorf("failed to create batch: %w", err)
 	}
 
-	root := "/hooks/" + h.Id
+	root := "/hooks/" + h.Id + "/" + h.Provider
 
-	err = batch.Put(fmt.Sprintf("/repositories/github/%d/hooks/%s", h.Repository, h.Id), nil)
+	err = batch.Put(fmt.Sprintf("/repositories/%s/%d/hooks/%s", h.Provider, repository, h.Id), nil)
 	if err != nil {
-		h.Delete(ctx)
+		h.deleteEntries(ctx, repository)
 		return fmt.Errorf("failed to batch repository hook reference: %w", err)
 	}
 
-	err = batch.Put(root+"/github/id", network.UInt64ToBytes(uint64(h.GithubId)))
+	err = batch.Put(root+"/id", network.UInt64ToBytes(uint64(providerId)))
 	if err != nil {
-		h.Delete(ctx)
+		h.deleteEntries(ctx, repository)
 		return fmt.Errorf("failed to batch hook ID: %w", err)
 	}
 
-	err = batch.Put(root+"/github/secret", []byte(h.Secret))
+	err = batch.Put(root+"/secret", []byte(secret))
 	if err != nil {
-		h.Delete(ctx)
+		h.deleteEntries(ctx, repository)
 		return fmt.Errorf("failed to batch hook secret: %w", err)
 	}
 
-	err = batch.Put(root+"/github/repository", network.UInt64ToBytes(uint64(h.Repository)))
+	err = batch.Put(root+"/repository", network.UInt64ToBytes(uint64(repository)))
 	if err != nil {
-		h.Delete(ctx)
+		h.deleteEntries(ctx, repository)
 		return fmt.Errorf("failed to batch hook repository: %w", err)
 	}
 
 	err = batch.Commit()
 	if err != nil {
-		h.Delete(ctx)
+		h.deleteEntries(ctx, repository)
 		return fmt.Errorf("failed to commit hook registration batch: %w", err)
 	}
 
@@ -170,39 +210,44 @@ func Fetch(ctx context.Context, kv kvdb.KVDB, hook_id string) (Hook, error) {
 		"id":       hook_id,
 		"provider": provider,
 	}
-	switch provider {
-	case "github":
-		_id, err := kv.Get(ctx, "/hooks/"+hook_id+"/github/id")
-		if err != nil {
-			return nil, err
-		}
-		id, err := network.BytesToUInt64(_id)
-		if err != nil {
-			return nil, errors.New("Repository ID for Hook `" + hook_id + "` is not an `int`")
+	idKey := "github_id"
+	if provider != "github" {
+		if !isProvider(provider) {
+			return nil, errors.New("unknown/unsupported git provider " + provider)
 		}
+		idKey = "provider_id"
+	}
 
-		data["github_id"] = int(id)
+	root := "/hooks/" + hook_id + "/" + provider
 
-		_secret, err := kv.Get(ctx, "/hooks/"+hook_id+"/github/secret")
-		if err != nil {
-			return nil, err
-		}
-		data["secret"] = string(_secret)
+	_id, err := kv.Get(ctx, root+"/id")
+	if err != nil {
+		return nil, err
+	}
+	id, err := network.BytesToUInt64(_id)
+	if err != nil {
+		return nil, errors.New("Repository ID for Hook `" + hook_id + "` is not an `int`")
+	}
 
-		_repository, err := kv.Get(ctx, "/hooks/"+hook_id+"/github/repository")
-		if err != nil {
-			return nil, err
-		}
-		repository, err := network.BytesToUInt64(_repository)
-		if err != nil {
-			return nil, errors.New("Repository ID for Hook `" + hook_id + "` is not an `int`")
-		}
+	data[idKey] = int(id)
 
-		data["repository"] = int(repository)
-	default:
-		return nil, errors.New("unknown/unsupported git provider " + provider)
+	_secret, err := kv.Get(ctx, root+"/secret")
+	if err != nil {
+		return nil, err
+	}
+	data["secret"] = string(_secret)
+
+	_repository, err := kv.Get(ctx, root+"/repository")
+	if err != nil {
+		return nil, err
+	}
+	repository, err := network.BytesToUInt64(_repository)
+	if err != nil {
+		return nil, errors.New("Repository ID for Hook `" + hook_id + "` is not an `int`")
 	}
 
+	data["repository"] = int(repository)
+
 	return New(kv, data)
 }
 
@@ -217,8 +262,8 @@ func New(kv kvdb.KVDB, data Data) (Hook, error) {
 		return nil, err
 	}
 
-	switch provider {
-	case "github":
+	switch {
+	case provider == "github":
 		github_id, err := maps.Int(data, "github_id")
 		if err != nil {
 			return nil, err
@@ -242,7 +287,36 @@ func New(kv kvdb.KVDB, data Data) (Hook, error) {
 			Secret:     secret,
 			Repository: repository,
 		}, nil
+	case isProvider(provider):
+		provider_id, err := maps.Int(data, "provider_id")
+		if err != nil {
+			return nil, err
+		}
+		repository, err := maps.Int(data, "repository")
+		if err != nil {
+			return nil, err
+		}
+		secret, err := maps.String(data, "secret")
+		if err != nil {
+			return nil, err
+		}
+
+		return &ProviderHook{
+			HookCommon: HookCommon{
+				KV:       kv,
+				Id:       id,
+				Provider: provider,
+			},
+			ProviderId: provider_id,
+			Secret:     secret,
+			Repository: repository,
+		}, nil
 	default:
 		return nil, fmt.Errorf("unknown hook type `%s` ", provider)
 	}
 }
+
+func isProvider(name string) bool {
+	_, err := providers.Get(name)
+	return err == nil
+}
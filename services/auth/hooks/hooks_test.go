@@ -325,3 +325,39 @@ func TestNew_WithInvalidProvider(t *testing.T) {
 	assert.Assert(t, err != nil)
 	assert.Assert(t, err.Error() != "")
 }
+
+func TestProviderHook_RoundTrip(t *testing.T) {
+	mockKV := mock.New()
+	defer mockKV.Close()
+
+	ctx := context.Background()
+	db, err := mockKV.New(nil, "test", 5)
+	assert.NilError(t, err)
+	defer db.Close()
+
+	hook, err := New(db, Data{
+		"id":          "hook-gitea",
+		"provider":    "gitea",
+		"provider_id": 77,
+		"secret":      "s3cr3t",
+		"repository":  42,
+	})
+	assert.NilError(t, err)
+	assert.NilError(t, hook.Register(ctx))
+
+	_, err = db.Get(ctx, "/repositories/gitea/42/hooks/hook-gitea")
+	assert.NilError(t, err)
+
+	fetched, err := Fetch(ctx, db, "hook-gitea")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, fetched.Serialize(), hook.Serialize())
+	assert.Equal(t, fetched.ProviderID(), "77")
+
+	assert.NilError(t, fetched.Delete(ctx))
+	assert.Assert(t, !Exist(ctx, db, "hook-gitea"))
+	_, err = db.Get(ctx, "/hooks/hook-gitea/gitea/secret")
+	assert.Assert(t, err != nil)
+
+	_, err = New(db, Data{"id": "x", "provider": "bitbucket"})
+	assert.ErrorContains(t, err, "unknown hook type")
+}
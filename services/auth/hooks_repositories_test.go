@@ -60,7 +60,7 @@ func TestRepositoriesEndpointsWithFixtures(t *testing.T) {
 
 	// Test sequence: setup test data then test repository endpoints
 	// 1. Test repository listing workflow
-	repoListResp, err := svc.listRepo(ctx)
+	repoListResp, err := svc.listRepo(ctx, "github")
 	assert.NilError(t, err)
 	assert.Assert(t, repoListResp != nil)
 	assert.Assert(t, repoListResp["ids"] != nil)
@@ -111,8 +111,8 @@ func TestRepositoryHookErrorHandling(t *testing.T) {
 	_, err = svc.getRepositoryHookByID(ctx, "non-existent-hook")
 	assert.Assert(t, err != nil, "Expected error for non-existent hook")
 
-	// Test getGithubRepositoryByID with non-existent repo
-	_, err = svc.getGithubRepositoryByID(ctx, 999)
+	// Test getRepositoryByID with non-existent repo
+	_, err = svc.getRepositoryByID(ctx, "github", 999)
 	assert.Assert(t, err != nil, "Expected error for non-existent repository")
 
 	// Test apiHookServiceHandler with get action
@@ -140,8 +140,8 @@ func TestRepositoryHookErrorHandlingForNonExistentEntities(t *testing.T) {
 	_, err = svc.getRepositoryHookByID(ctx, "non-existent-hook")
 	assert.Assert(t, err != nil, "Expected error for non-existent hook")
 
-	// Test getGithubRepositoryByID with non-existent repo
-	_, err = svc.getGithubRepositoryByID(ctx, 999)
+	// Test getRepositoryByID with non-existent repo
+	_, err = svc.getRepositoryByID(ctx, "github", 999)
 	assert.Assert(t, err != nil, "Expected error for non-existent repository")
 }
 
@@ -159,7 +159,7 @@ func TestHookAndRepositoryListOperationsAndInvalidActions(t *testing.T) {
 	assert.Assert(t, hooksResp != nil)
 
 	// Test listRepo
-	repoResp, err := svc.listRepo(ctx)
+	repoResp, err := svc.listRepo(ctx, "github")
 	assert.NilError(t, err)
 	assert.Assert(t, repoResp != nil)
 
@@ -40,7 +40,7 @@ func TestHTTPRouteSetupWorkflowWithFixtures(t *testing.T) {
 	assert.Assert(t, statsResp != nil)
 
 	// Test list operations
-	repoResp, err := svc.listRepo(ctx)
+	repoResp, err := svc.listRepo(ctx, "github")
 	assert.NilError(t, err)
 	assert.Assert(t, repoResp != nil)
 
@@ -146,15 +146,15 @@ func TestProjectAndRepositoryAPIOperationsWithSubTests(t *testing.T) {
 		assert.Assert(t, err != nil, "Expected error for invalid action")
 	})
 
-	// Test getGithubRepositoryByID with non-existent repo
+	// Test getRepositoryByID with non-existent repo
 	t.Run("getGithubRepositoryByID", func(t *testing.T) {
-		_, err := svc.getGithubRepositoryByID(ctx, 999)
+		_, err := svc.getRepositoryByID(ctx, "github", 999)
 		assert.Assert(t, err != nil, "Expected error for non-existent repository")
 	})
 
 	// Test listRepo
 	t.Run("listRepo", func(t *testing.T) {
-		repos, err := svc.listRepo(ctx)
+		repos, err := svc.listRepo(ctx, "github")
 		assert.NilError(t, err)
 		assert.Assert(t, repos != nil)
 	})
@@ -209,7 +209,7 @@ func TestRepositoryProjectWorkflowsWithFixtures(t *testing.T) {
 
 	// Test sequence: setup test data then test workflows
 	// 1. Test repository listing workflow
-	repoListResp, err := svc.listRepo(ctx)
+	repoListResp, err := svc.listRepo(ctx, "github")
 	assert.NilError(t, err)
 	assert.Assert(t, repoListResp != nil)
 
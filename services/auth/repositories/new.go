@@ -15,8 +15,8 @@ func New(kv kvdb.KVDB, data Data) (Repository, error) {
 	}
 
 	project, _ := maps.String(data, "project")
-	switch provider {
-	case "github":
+	switch {
+	case IsGitProvider(provider):
 		id, err := maps.Int(data, "id")
 		if err != nil {
 			return nil, err
@@ -27,7 +27,7 @@ func New(kv kvdb.KVDB, data Data) (Repository, error) {
 			return nil, err
 		}
 
-		return &githubRepository{
+		return &gitRepository{
 			repositoryCommon: repositoryCommon{
 				kv:       kv,
 				provider: provider,
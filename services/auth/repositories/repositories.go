@@ -9,15 +9,26 @@ import (
 
 	"github.com/ipfs/go-log/v2"
 	"github.com/taubyte/tau/core/kvdb"
+	"github.com/taubyte/tau/pkg/git/providers"
 	"github.com/taubyte/tau/services/auth/hooks"
 )
 
 var (
-	GitProviders = []string{"github"}
+	GitProviders = append([]string{"github"}, providers.Names()...)
 	logger       = log.Logger("tau.auth.service.api.repositories")
 )
 
-func (r *githubRepository) Serialize() Data {
+// IsGitProvider reports whether repositories can be registered with provider.
+func IsGitProvider(provider string) bool {
+	for _, p := range GitProviders {
+		if p == provider {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *gitRepository) Serialize() Data {
 	return Data{
 		"id":       r.id,
 		"provider": r.provider,
@@ -26,7 +37,7 @@ func (r *githubRepository) Serialize() Data {
 	}
 }
 
-func (r *githubRepository) Delete(ctx context.Context) (err error) {
+func (r *gitRepository) Delete(ctx context.Context) (err error) {
 	for _, h := range r.Hooks(ctx) {
 		err = h.Delete(ctx)
 		if err != nil {
@@ -39,7 +50,7 @@ func (r *githubRepository) Delete(ctx context.Context) (err error) {
 		return fmt.Errorf("failed to create batch: %w", err)
 	}
 
-	repo_key := fmt.Sprintf("/repositories/github/%d/key", r.id)
+	repo_key := fmt.Sprintf("/repositories/%s/%d/key", r.provider, r.id)
 	err = batch.Delete(repo_key)
 	if err != nil {
 		return fmt.Errorf("failed to batch delete repository key: %w", err)
@@ -53,13 +64,13 @@ func (r *githubRepository) Delete(ctx context.Context) (err error) {
 	return nil
 }
 
-func (r *githubRepository) Register(ctx context.Context) (err error) {
+func (r *gitRepository) Register(ctx context.Context) (err error) {
 	batch, err := r.kv.Batch(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to create batch: %w", err)
 	}
 
-	repo_key := fmt.Sprintf("/repositories/github/%d/key", r.id)
+	repo_key := fmt.Sprintf("/repositories/%s/%d/key", r.provider, r.id)
 	err = batch.Put(repo_key, []byte(r.key))
 	if err != nil {
 		return fmt.Errorf("failed to batch repository key: %w", err)
@@ -74,8 +85,8 @@ func (r *githubRepository) Register(ctx context.Context) (err error) {
 	return nil
 }
 
-func (r *githubRepository) Hooks(ctx context.Context) []hooks.Hook {
-	keys, err := r.kv.List(ctx, fmt.Sprintf("/repositories/github/%d/hooks/", r.id))
+func (r *gitRepository) Hooks(ctx context.Context) []hooks.Hook {
+	keys, err := r.kv.List(ctx, fmt.Sprintf("/repositories/%s/%d/hooks/", r.provider, r.id))
 	if err != nil {
 		return nil
 	}
@@ -125,8 +136,8 @@ func Provider(ctx context.Context, kv kvdb.KVDB, id string) (string, error) {
 	return "", fmt.Errorf("Repository with ID = `%s` does not exist! error: %w", id, err)
 }
 
-func fetchGithub(ctx context.Context, kv kvdb.KVDB, id int) (Repository, error) {
-	repo_key := fmt.Sprintf("/repositories/github/%d", id)
+func fetchOn(ctx context.Context, kv kvdb.KVDB, provider string, id int) (Repository, error) {
+	repo_key := fmt.Sprintf("/repositories/%s/%d", provider, id)
 
 	key, err := kv.Get(ctx, repo_key+"/key")
 	if err != nil {
@@ -136,7 +147,7 @@ func fetchGithub(ctx context.Context, kv kvdb.KVDB, id int) (Repository, error)
 	projectId, _ := kv.Get(ctx, repo_key+"/project")
 	return New(kv, Data{
 		"id":       id,
-		"provider": "github",
+		"provider": provider,
 		"project":  string(projectId),
 		"key":      string(key),
 	})
@@ -149,17 +160,25 @@ func Fetch(ctx context.Context, kv kvdb.KVDB, id string) (Repository, error) {
 		return nil, err
 	}
 
-	switch provider {
-	case "github":
-		_id, err := strconv.Atoi(id)
-		if err != nil {
-			return nil, errors.New("Github repository id must be an int. Parsing returned: " + err.Error())
-		}
-		repo, err := fetchGithub(ctx, kv, _id)
-		if err != nil {
-			return nil, errors.New("Failed fetching github with: " + err.Error())
-		}
-		return repo, nil
+	return FetchOn(ctx, kv, provider, id)
+}
+
+// FetchOn fetches a repository of a given provider. Ids are only unique within
+// a provider, so this is the lookup to use whenever the provider is known.
+func FetchOn(ctx context.Context, kv kvdb.KVDB, provider, id string) (Repository, error) {
+	if !IsGitProvider(provider) {
+		return nil, errors.New("unknown/unsupported git provider " + provider)
+	}
+
+	_id, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, fmt.Errorf("%s repository id must be an int. Parsing returned: %w", provider, err)
 	}
-	return nil, errors.New("unknown/unsupported git provider " + provider)
+
+	repo, err := fetchOn(ctx, kv, provider, _id)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching %s repository with: %w", provider, err)
+	}
+
+	return repo, nil
 }
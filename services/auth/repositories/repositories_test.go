@@ -9,7 +9,7 @@ import (
 )
 
 func TestGitHubRepository_Serialize(t *testing.T) {
-	repo := &githubRepository{
+	repo := &gitRepository{
 		repositoryCommon: repositoryCommon{
 			provider: "github",
 			project:  "test-project",
@@ -35,7 +35,7 @@ func TestGitHubRepository_Register(t *testing.T) {
 	assert.NilError(t, err)
 	defer db.Close()
 
-	repo := &githubRepository{
+	repo := &gitRepository{
 		repositoryCommon: repositoryCommon{
 			kv:       db,
 			provider: "github",
@@ -63,7 +63,7 @@ func TestGitHubRepository_Delete(t *testing.T) {
 	assert.NilError(t, err)
 	defer db.Close()
 
-	repo := &githubRepository{
+	repo := &gitRepository{
 		repositoryCommon: repositoryCommon{
 			kv:       db,
 			provider: "github",
@@ -100,7 +100,7 @@ func TestExist(t *testing.T) {
 	assert.Assert(t, !exists)
 
 	// Create a repository
-	repo := &githubRepository{
+	repo := &gitRepository{
 		repositoryCommon: repositoryCommon{
 			kv:       db,
 			provider: "github",
@@ -132,7 +132,7 @@ func TestExistOn(t *testing.T) {
 	assert.Assert(t, !exists)
 
 	// Create a repository
-	repo := &githubRepository{
+	repo := &gitRepository{
 		repositoryCommon: repositoryCommon{
 			kv:       db,
 			provider: "github",
@@ -165,7 +165,7 @@ func TestProvider(t *testing.T) {
 	assert.Assert(t, err.Error() != "")
 
 	// Create a repository
-	repo := &githubRepository{
+	repo := &gitRepository{
 		repositoryCommon: repositoryCommon{
 			kv:       db,
 			provider: "github",
@@ -257,7 +257,7 @@ func TestGitHubRepository_Hooks(t *testing.T) {
 	assert.NilError(t, err)
 	defer db.Close()
 
-	repo := &githubRepository{
+	repo := &gitRepository{
 		repositoryCommon: repositoryCommon{
 			kv:       db,
 			provider: "github",
@@ -294,11 +294,11 @@ func TestFetchGithub(t *testing.T) {
 	defer db.Close()
 
 	// Test with non-existent repository
-	_, err = fetchGithub(ctx, db, 999)
+	_, err = fetchOn(ctx, db, "github", 999)
 	assert.Assert(t, err != nil)
 
 	// Create a repository
-	repo := &githubRepository{
+	repo := &gitRepository{
 		repositoryCommon: repositoryCommon{
 			kv:       db,
 			provider: "github",
@@ -312,7 +312,7 @@ func TestFetchGithub(t *testing.T) {
 	assert.NilError(t, err)
 
 	// Test with existing repository
-	fetchedRepo, err := fetchGithub(ctx, db, 123)
+	fetchedRepo, err := fetchOn(ctx, db, "github", 123)
 	assert.NilError(t, err)
 	assert.Assert(t, fetchedRepo != nil)
 
@@ -336,7 +336,7 @@ func TestFetch(t *testing.T) {
 	assert.Assert(t, err.Error() != "")
 
 	// Create a repository
-	repo := &githubRepository{
+	repo := &gitRepository{
 		repositoryCommon: repositoryCommon{
 			kv:       db,
 			provider: "github",
@@ -410,3 +410,33 @@ func TestGitProviders(t *testing.T) {
 
 // Note: Repository interface doesn't expose Project() or Key() methods
 // These fields are internal to the implementation
+
+func TestFetchOnProvider(t *testing.T) {
+	mockKV := mock.New()
+	defer mockKV.Close()
+
+	ctx := context.Background()
+	db, err := mockKV.New(nil, "test", 5)
+	assert.NilError(t, err)
+	defer db.Close()
+
+	// The same id on two providers is two repositories.
+	for _, provider := range []string{"github", "gitea"} {
+		repo, err := New(db, Data{"id": 7, "provider": provider, "key": provider + "-key"})
+		assert.NilError(t, err)
+		assert.NilError(t, repo.Register(ctx))
+	}
+
+	repo, err := FetchOn(ctx, db, "gitea", "7")
+	assert.NilError(t, err)
+	assert.Equal(t, repo.Provider(), "gitea")
+	assert.Equal(t, repo.Serialize()["key"], "gitea-key")
+	assert.Assert(t, ExistOn(ctx, db, "gitea", "7"))
+	assert.Assert(t, !ExistOn(ctx, db, "gitlab", "7"))
+
+	_, err = FetchOn(ctx, db, "bitbucket", "7")
+	assert.ErrorContains(t, err, "unsupported git provider")
+
+	_, err = New(db, Data{"id": 7, "provider": "bitbucket", "key": "k"})
+	assert.ErrorContains(t, err, "unknown repo type")
+}
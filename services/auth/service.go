@@ -24,6 +24,7 @@ func New(ctx context.Context, cfg tauConfig.Config) (*AuthService, error) {
 	srv.ctx = ctx
 
 	srv.newGitHubClient = NewGitHubClient
+	srv.newProviderAPI = srv.defaultProviderAPI
 
 	srv.webHookUrl = fmt.Sprintf(`https://patrick.tau.%s`, cfg.NetworkFqdn())
 
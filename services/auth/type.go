@@ -7,6 +7,7 @@ import (
 	"github.com/taubyte/tau/p2p/peer"
 	streams "github.com/taubyte/tau/p2p/streams/service"
 
+	"github.com/taubyte/tau/pkg/git/providers"
 	http "github.com/taubyte/tau/pkg/http"
 
 	iface "github.com/taubyte/tau/core/services/auth"
@@ -33,6 +34,7 @@ type AuthService struct {
 	dvPublicKey  []byte
 
 	newGitHubClient func(context.Context, string) (GitHubClient, error)
+	newProviderAPI  func(provider, token string) (providers.API, error)
 
 	// identityClientNode is the node initIdentity builds its client on, when
 	// the build needs one.
@@ -0,0 +1,95 @@
+package breaker
+
+import (
+	"math"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+func New() *Breakers {
+	return &Breakers{
+		nodes: make(map[peer.ID]*breaker),
+		now:   time.Now,
+	}
+}
+
+// Allow tells if requests can go to pid.
+func (b *Breakers) Allow(pid peer.ID) bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	n, ok := b.nodes[pid]
+	if !ok || n.openUntil.IsZero() {
+		return true
+	}
+
+	now := b.now()
+	if now.Before(n.openUntil) {
+		return false
+	}
+
+	// half-open: one probe at a time, assumed lost after a Cooldown
+	if !n.probing.IsZero() && now.Sub(n.probing) < Cooldown {
+		return false
+	}
+
+	n.probing = now
+	return true
+}
+
+// Success records a request pid served.
+func (b *Breakers) Success(pid peer.ID) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	n := b.node(pid)
+	n.successes++
+
+	if !n.openUntil.IsZero() && !b.now().Before(n.openUntil) {
+		*n = breaker{successes: 1, updated: n.updated}
+	}
+}
+
+// Failure records a request pid failed.
+func (b *Breakers) Failure(pid peer.ID) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	n := b.node(pid)
+	n.failures++
+
+	now := b.now()
+	switch {
+	case !n.openUntil.IsZero():
+		if !now.Before(n.openUntil) {
+			n.openUntil, n.probing = now.Add(Cooldown), time.Time{}
+		}
+	// outcomes start decaying right away: round their weight to count them
+	case math.Round(n.successes+n.failures) >= MinVolume && n.failures/(n.successes+n.failures) >= FailureRatio:
+		n.openUntil = now.Add(Cooldown)
+	}
+}
+
+// node returns the breaker of pid, with its outcomes decayed to now.
+func (b *Breakers) node(pid peer.ID) *breaker {
+	n, ok := b.nodes[pid]
+	if !ok {
+		n = &breaker{updated: b.now()}
+		b.nodes[pid] = n
+	}
+
+	b.decay(n)
+
+	return n
+}
+
+func (b *Breakers) decay(n *breaker) {
+	now := b.now()
+	if elapsed := now.Sub(n.updated); elapsed > 0 {
+		factor := math.Pow(0.5, float64(elapsed)/float64(HalfLife))
+		n.successes *= factor
+		n.failures *= factor
+		n.updated = now
+	}
+}
@@ -0,0 +1,102 @@
+package breaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"gotest.tools/v3/assert"
+)
+
+func newTestBreakers() (*Breakers, *time.Time) {
+	now := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	b := New()
+	b.now = func() time.Time { return now }
+	return b, &now
+}
+
+func TestOpens(t *testing.T) {
+	b, _ := newTestBreakers()
+	pid := peer.ID("node")
+
+	for range 4 {
+		b.Failure(pid)
+	}
+	// not enough volume yet
+	assert.Assert(t, b.Allow(pid))
+
+	b.Failure(pid)
+	assert.Assert(t, !b.Allow(pid))
+	assert.Assert(t, b.Allow("other"))
+}
+
+func TestStaysClosedWhenMostlyServing(t *testing.T) {
+	b, _ := newTestBreakers()
+	pid := peer.ID("node")
+
+	for range 10 {
+		b.Success(pid)
+		b.Failure(pid)
+		b.Success(pid)
+	}
+
+	assert.Assert(t, b.Allow(pid))
+}
+
+func TestDecays(t *testing.T) {
+	b, now := newTestBreakers()
+	pid := peer.ID("node")
+
+	for range 4 {
+		b.Failure(pid)
+	}
+
+	// old failures weigh too little to open the breaker
+	*now = now.Add(10 * HalfLife)
+	b.Failure(pid)
+	assert.Assert(t, b.Allow(pid))
+}
+
+func TestHalfOpen(t *testing.T) {
+	b, now := newTestBreakers()
+	pid := peer.ID("node")
+
+	for range 5 {
+		b.Failure(pid)
+	}
+	assert.Assert(t, !b.Allow(pid))
+
+	// a single probe after the cooldown, that fails
+	*now = now.Add(Cooldown)
+	assert.Assert(t, b.Allow(pid))
+	assert.Assert(t, !b.Allow(pid))
+	b.Failure(pid)
+	assert.Assert(t, !b.Allow(pid))
+
+	// a probe that succeeds closes the breaker
+	*now = now.Add(Cooldown)
+	assert.Assert(t, b.Allow(pid))
+	b.Success(pid)
+	assert.Assert(t, b.Allow(pid))
+	assert.Assert(t, b.Allow(pid))
+
+	// a failure right after isn't enough to open it again
+	b.Failure(pid)
+	assert.Assert(t, b.Allow(pid))
+}
+
+func TestLostProbe(t *testing.T) {
+	b, now := newTestBreakers()
+	pid := peer.ID("node")
+
+	for range 5 {
+		b.Failure(pid)
+	}
+
+	*now = now.Add(Cooldown)
+	assert.Assert(t, b.Allow(pid))
+
+	// the probe never reported back
+	*now = now.Add(Cooldown)
+	assert.Assert(t, b.Allow(pid))
+}
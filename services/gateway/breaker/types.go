@@ -0,0 +1,29 @@
+package breaker
+
+import (
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// Breakers holds a circuit breaker per node. A node failing too often is
+// cut off for Cooldown, then let through once to probe it: its breaker closes
+// if that succeeds, and opens again otherwise. Outcomes weigh less as they age,
+// halving every HalfLife, so a node recovers its health over time.
+type Breakers struct {
+	lock  sync.Mutex
+	nodes map[peer.ID]*breaker
+	now   func() time.Time
+}
+
+type breaker struct {
+	successes float64
+	failures  float64
+	updated   time.Time
+
+	// openUntil is zero while the breaker is closed
+	openUntil time.Time
+	// probing is when the probe of a half-open breaker was let through
+	probing time.Time
+}
@@ -0,0 +1,14 @@
+package breaker
+
+import "time"
+
+var (
+	// HalfLife is how long it takes for an outcome to weigh half as much.
+	HalfLife = 30 * time.Second
+	// FailureRatio is the share of failures opening a breaker, once outcomes
+	// weigh at least MinVolume.
+	FailureRatio = 0.5
+	MinVolume    = 5.0
+	// Cooldown is how long an open breaker cuts a node off.
+	Cooldown = 30 * time.Second
+)
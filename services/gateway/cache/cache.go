@@ -0,0 +1,258 @@
+package cache
+
+import (
+	"container/list"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// New returns a cache of up to memory bytes in memory, then up to disk bytes
+// in dir. Whatever dir holds is removed, and it is not used if disk is zero.
+func New(memory, disk int64, dir string) (*Cache, error) {
+	if memory <= 0 {
+		return nil, errors.New("cache needs some memory")
+	}
+
+	c := &Cache{
+		now:       time.Now,
+		entries:   make(map[string]*list.Element),
+		resources: make(map[string]*resource),
+		held:      make(map[string]time.Time),
+		memory:    list.New(),
+		memoryMax: memory,
+	}
+
+	if disk > 0 {
+		if err := os.RemoveAll(dir); err != nil {
+			return nil, fmt.Errorf("cleaning cache directory `%s` failed with: %w", dir, err)
+		}
+
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return nil, fmt.Errorf("creating cache directory `%s` failed with: %w", dir, err)
+		}
+
+		c.dir, c.disk, c.diskMax = dir, list.New(), disk
+	}
+
+	return c, nil
+}
+
+// Handle answers r from the cache when it can, and with next otherwise,
+// caching what next answers when the response allows it.
+//
+// A cached response past its freshness, or one the request asks to check, is
+// revalidated with the node before it is served; it is served stale if the
+// node can't be reached, unless the response forbids it.
+func (c *Cache) Handle(w http.ResponseWriter, r *http.Request, next Next) error {
+	if bypasses(r) {
+		w.Header().Set(Header, string(Bypass))
+		_, err := next(w, r)
+		return err
+	}
+
+	now := c.now()
+	e := c.get(r)
+	if e != nil && e.fresh(now) && !revalidates(r, e.currentAge(now)) {
+		c.serve(w, r, e, Hit, now)
+		return nil
+	}
+
+	rec := &recorder{w: w, status: Miss}
+	req := r
+	if e != nil && r.Method == http.MethodGet && (e.etag != "" || e.lastModified != "") {
+		req = r.Clone(r.Context())
+		req.Header.Del("If-None-Match")
+		req.Header.Del("If-Modified-Since")
+		if e.etag != "" {
+			req.Header.Set("If-None-Match", e.etag)
+		}
+		if e.lastModified != "" {
+			req.Header.Set("If-Modified-Since", e.lastModified)
+		}
+
+		rec.revalidating = true
+	}
+
+	header := w.Header().Clone()
+	project, err := next(rec, req)
+
+	switch {
+	case rec.notModified:
+		resetHeader(w, header)
+		c.serve(w, r, c.revalidated(r, e, rec.header, now), Revalidated, now)
+		return nil
+	case !rec.wroteHeader:
+		if err != nil && e != nil && !e.mustRevalidate {
+			logger.Debugf("serving stale `%s` as %s", e.resource, err.Error())
+			resetHeader(w, header)
+			c.serve(w, r, e, Stale, now)
+			return nil
+		}
+
+		return err
+	case err == nil && rec.complete() && !c.holds(project, now):
+		if fresh, ok := newEntry(r, rec.code, w.Header(), now); ok {
+			fresh.project = project
+			fresh.header = storedHeader(w.Header())
+			fresh.body = rec.body.Bytes()
+			c.put(r, fresh)
+		}
+	}
+
+	return err
+}
+
+// revalidated returns e refreshed by the header of the 304 confirming it.
+func (c *Cache) revalidated(r *http.Request, e *entry, header http.Header, now time.Time) *entry {
+	refreshed := e.header.Clone()
+	for _, name := range []string{"Cache-Control", "Expires", "Date", "ETag", "Last-Modified", "Age"} {
+		if values := header.Values(name); len(values) > 0 {
+			refreshed[http.CanonicalHeaderKey(name)] = values
+		}
+	}
+
+	fresh, ok := newEntry(r, e.status, refreshed, now)
+	if !ok {
+		c.drop(e.key)
+		return e
+	}
+
+	fresh.project = e.project
+	fresh.header = storedHeader(refreshed)
+	fresh.body = e.body
+	c.put(r, fresh)
+
+	return fresh
+}
+
+// serve answers r with e.
+func (c *Cache) serve(w http.ResponseWriter, r *http.Request, e *entry, status Status, now time.Time) {
+	header := w.Header()
+	for k, v := range e.header {
+		header[k] = v
+	}
+
+	header.Set("Age", strconv.FormatInt(int64(e.currentAge(now)/time.Second), 10))
+	header.Set(Header, string(status))
+
+	if notModified(r, e) {
+		header.Del("Content-Length")
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.WriteHeader(e.status)
+	if r.Method != http.MethodHead {
+		w.Write(e.body)
+	}
+}
+
+// PurgeProject drops the responses of a project, and returns how many.
+func (c *Cache) PurgeProject(project string) int {
+	if project == "" {
+		return 0
+	}
+
+	return c.purge(func(e *entry) bool {
+		return e.project == project
+	})
+}
+
+// PurgeDomain drops the responses of a domain, and returns how many.
+func (c *Cache) PurgeDomain(fqdn string) int {
+	fqdn = strings.ToLower(strings.TrimSuffix(fqdn, "."))
+
+	return c.purge(func(e *entry) bool {
+		return e.host == fqdn
+	})
+}
+
+// Hold keeps the responses of a project from being stored for d. A project is
+// announced deployed before every node serves the deployment, and what a node
+// answers until it does is what was just purged.
+func (c *Cache) Hold(project string, d time.Duration) {
+	if project == "" {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.held[project] = c.now().Add(d)
+}
+
+func (c *Cache) holds(project string, now time.Time) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	until, ok := c.held[project]
+	if ok && !now.Before(until) {
+		delete(c.held, project)
+		return false
+	}
+
+	return ok
+}
+
+// Close drops every response, removing those cached on disk.
+func (c *Cache) Close() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.entries = make(map[string]*list.Element)
+	c.resources = make(map[string]*resource)
+	c.memory.Init()
+	c.memorySize = 0
+
+	if c.disk == nil {
+		return nil
+	}
+
+	c.disk.Init()
+	c.diskSize = 0
+
+	return os.RemoveAll(c.dir)
+}
+
+func (c *Cache) drop(key string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.remove(key)
+}
+
+func (e *entry) currentAge(now time.Time) time.Duration {
+	return e.age + max(now.Sub(e.stored), 0)
+}
+
+func (e *entry) fresh(now time.Time) bool {
+	return !e.noCache && e.currentAge(now) < e.ttl
+}
+
+// storedHeader returns the header of a response to cache.
+func storedHeader(header http.Header) http.Header {
+	stored := header.Clone()
+	stored.Del(Header)
+	stored.Del("Age")
+	for _, name := range ProxyHeaders {
+		stored.Del(name)
+	}
+
+	return stored
+}
+
+func resetHeader(w http.ResponseWriter, header http.Header) {
+	current := w.Header()
+	for k := range current {
+		delete(current, k)
+	}
+
+	for k, v := range header {
+		current[k] = v
+	}
+}
@@ -0,0 +1,363 @@
+package cache
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+type testNode struct {
+	calls   int
+	project string
+	err     error
+	handler http.HandlerFunc
+	// last is the last request the node got
+	last *http.Request
+}
+
+func (n *testNode) next(w http.ResponseWriter, r *http.Request) (string, error) {
+	n.calls++
+	n.last = r
+	if n.err != nil {
+		return "", n.err
+	}
+
+	n.handler(w, r)
+	return n.project, nil
+}
+
+func respond(body string, header ...string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		for i := 0; i+1 < len(header); i += 2 {
+			w.Header().Add(header[i], header[i+1])
+		}
+
+		if etag := w.Header().Get("ETag"); etag != "" && r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Write([]byte(body))
+	}
+}
+
+type testClock struct{ now time.Time }
+
+func (c *testClock) advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func newTestCache(t *testing.T, memory, disk int64) (*Cache, *testClock) {
+	c, err := New(memory, disk, t.TempDir()+"/cache")
+	assert.NilError(t, err)
+	t.Cleanup(func() { c.Close() })
+
+	clock := &testClock{now: time.Now()}
+	c.now = func() time.Time { return clock.now }
+
+	return c, clock
+}
+
+func get(t *testing.T, c *Cache, node *testNode, url string, header ...string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodGet, url, nil)
+	for i := 0; i+1 < len(header); i += 2 {
+		r.Header.Add(header[i], header[i+1])
+	}
+
+	w := httptest.NewRecorder()
+	assert.NilError(t, c.Handle(w, r, node.next))
+
+	return w
+}
+
+func TestCacheHit(t *testing.T) {
+	c, clock := newTestCache(t, 1<<20, 0)
+	node := &testNode{handler: respond("hello", "Cache-Control", "max-age=60")}
+
+	w := get(t, c, node, "http://hal.computers.com/ping")
+	assert.Equal(t, w.Body.String(), "hello")
+	assert.Equal(t, w.Header().Get(Header), string(Miss))
+
+	clock.advance(10 * time.Second)
+	w = get(t, c, node, "http://HAL.computers.com/ping")
+	assert.Equal(t, w.Body.String(), "hello")
+	assert.Equal(t, w.Header().Get(Header), string(Hit))
+	assert.Equal(t, w.Header().Get("Age"), "10")
+	assert.Equal(t, node.calls, 1)
+
+	// another query is another resource
+	get(t, c, node, "http://hal.computers.com/ping?v=2")
+	assert.Equal(t, node.calls, 2)
+
+	clock.advance(time.Minute)
+	w = get(t, c, node, "http://hal.computers.com/ping")
+	assert.Equal(t, w.Header().Get(Header), string(Miss))
+	assert.Equal(t, node.calls, 3)
+}
+
+func TestCacheSharedMaxAge(t *testing.T) {
+	c, clock := newTestCache(t, 1<<20, 0)
+	node := &testNode{handler: respond("hello", "Cache-Control", "max-age=5, s-maxage=60")}
+
+	get(t, c, node, "http://hal.computers.com/")
+	clock.advance(30 * time.Second)
+	assert.Equal(t, get(t, c, node, "http://hal.computers.com/").Header().Get(Header), string(Hit))
+}
+
+func TestCacheExpires(t *testing.T) {
+	c, clock := newTestCache(t, 1<<20, 0)
+	date := clock.now.UTC()
+	node := &testNode{handler: respond("hello",
+		"Date", date.Format(http.TimeFormat),
+		"Expires", date.Add(time.Minute).Format(http.TimeFormat),
+	)}
+
+	get(t, c, node, "http://hal.computers.com/")
+	clock.advance(30 * time.Second)
+	assert.Equal(t, get(t, c, node, "http://hal.computers.com/").Header().Get(Header), string(Hit))
+}
+
+func TestCacheNotStored(t *testing.T) {
+	for name, handler := range map[string]http.HandlerFunc{
+		"no-store":     respond("x", "Cache-Control", "no-store, max-age=60"),
+		"private":      respond("x", "Cache-Control", "private, max-age=60"),
+		"cookie":       respond("x", "Cache-Control", "max-age=60", "Set-Cookie", "id=1"),
+		"vary-all":     respond("x", "Cache-Control", "max-age=60", "Vary", "*"),
+		"no-freshness": respond("x"),
+		"status": func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Cache-Control", "max-age=60")
+			w.WriteHeader(http.StatusInternalServerError)
+		},
+		"too-large": respond(strings.Repeat("x", int(MaxEntrySize)+1), "Cache-Control", "max-age=60"),
+		"truncated": respond("x", "Cache-Control", "max-age=60", "Content-Length", "2"),
+	} {
+		t.Run(name, func(t *testing.T) {
+			c, _ := newTestCache(t, 32<<20, 0)
+			node := &testNode{handler: handler}
+
+			get(t, c, node, "http://hal.computers.com/")
+			get(t, c, node, "http://hal.computers.com/")
+			assert.Equal(t, node.calls, 2)
+		})
+	}
+}
+
+func TestCacheBypass(t *testing.T) {
+	c, _ := newTestCache(t, 1<<20, 0)
+	node := &testNode{handler: respond("hello", "Cache-Control", "max-age=60")}
+
+	get(t, c, node, "http://hal.computers.com/")
+	for _, header := range [][]string{
+		{"Authorization", "Bearer token"},
+		{"Cookie", "id=1"},
+		{"Range", "bytes=0-1"},
+		{"Cache-Control", "no-store"},
+	} {
+		w := get(t, c, node, "http://hal.computers.com/", header...)
+		assert.Equal(t, w.Header().Get(Header), string(Bypass))
+	}
+	assert.Equal(t, node.calls, 5)
+
+	r := httptest.NewRequest(http.MethodPost, "http://hal.computers.com/", nil)
+	assert.NilError(t, c.Handle(httptest.NewRecorder(), r, node.next))
+	assert.Equal(t, node.calls, 6)
+}
+
+func TestCacheVary(t *testing.T) {
+	c, _ := newTestCache(t, 1<<20, 0)
+	node := &testNode{handler: func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "max-age=60")
+		w.Header().Set("Vary", "Accept-Language")
+		w.Write([]byte(r.Header.Get("Accept-Language")))
+	}}
+
+	assert.Equal(t, get(t, c, node, "http://hal.computers.com/", "Accept-Language", "en").Body.String(), "en")
+	assert.Equal(t, get(t, c, node, "http://hal.computers.com/", "Accept-Language", "fr").Body.String(), "fr")
+	assert.Equal(t, node.calls, 2)
+
+	w := get(t, c, node, "http://hal.computers.com/", "Accept-Language", "en")
+	assert.Equal(t, w.Body.String(), "en")
+	assert.Equal(t, w.Header().Get(Header), string(Hit))
+	assert.Equal(t, node.calls, 2)
+}
+
+func TestCacheRevalidate(t *testing.T) {
+	c, clock := newTestCache(t, 1<<20, 0)
+	node := &testNode{handler: respond("hello", "Cache-Control", "max-age=10", "ETag", `"v1"`)}
+
+	get(t, c, node, "http://hal.computers.com/")
+	clock.advance(20 * time.Second)
+
+	w := get(t, c, node, "http://hal.computers.com/")
+	assert.Equal(t, node.last.Header.Get("If-None-Match"), `"v1"`)
+	assert.Equal(t, w.Code, http.StatusOK)
+	assert.Equal(t, w.Body.String(), "hello")
+	assert.Equal(t, w.Header().Get(Header), string(Revalidated))
+	assert.Equal(t, w.Header().Get("Age"), "0")
+
+	// fresh again
+	assert.Equal(t, get(t, c, node, "http://hal.computers.com/").Header().Get(Header), string(Hit))
+	assert.Equal(t, node.calls, 2)
+
+	// the client asking to check
+	w = get(t, c, node, "http://hal.computers.com/", "Cache-Control", "no-cache")
+	assert.Equal(t, w.Header().Get(Header), string(Revalidated))
+	assert.Equal(t, node.calls, 3)
+}
+
+func TestCacheNoCacheStored(t *testing.T) {
+	c, _ := newTestCache(t, 1<<20, 0)
+	node := &testNode{handler: respond("hello", "Cache-Control", "no-cache", "ETag", `"v1"`)}
+
+	get(t, c, node, "http://hal.computers.com/")
+	w := get(t, c, node, "http://hal.computers.com/")
+	assert.Equal(t, w.Header().Get(Header), string(Revalidated))
+	assert.Equal(t, node.calls, 2)
+}
+
+func TestCacheClientNotModified(t *testing.T) {
+	c, _ := newTestCache(t, 1<<20, 0)
+	node := &testNode{handler: respond("hello", "Cache-Control", "max-age=60", "ETag", `"v1"`)}
+
+	get(t, c, node, "http://hal.computers.com/")
+	w := get(t, c, node, "http://hal.computers.com/", "If-None-Match", `"v0", W/"v1"`)
+	assert.Equal(t, w.Code, http.StatusNotModified)
+	assert.Equal(t, w.Body.Len(), 0)
+	assert.Equal(t, node.calls, 1)
+}
+
+func TestCacheStale(t *testing.T) {
+	c, clock := newTestCache(t, 1<<20, 0)
+	node := &testNode{handler: respond("hello", "Cache-Control", "max-age=10")}
+
+	get(t, c, node, "http://hal.computers.com/")
+	clock.advance(20 * time.Second)
+
+	node.err = errors.New("no substrate match found")
+	w := get(t, c, node, "http://hal.computers.com/")
+	assert.Equal(t, w.Body.String(), "hello")
+	assert.Equal(t, w.Header().Get(Header), string(Stale))
+
+	c, clock = newTestCache(t, 1<<20, 0)
+	node = &testNode{handler: respond("hello", "Cache-Control", "max-age=10, must-revalidate")}
+
+	get(t, c, node, "http://hal.computers.com/")
+	clock.advance(20 * time.Second)
+
+	node.err = errors.New("no substrate match found")
+	r := httptest.NewRequest(http.MethodGet, "http://hal.computers.com/", nil)
+	assert.ErrorIs(t, c.Handle(httptest.NewRecorder(), r, node.next), node.err)
+}
+
+func TestCacheHead(t *testing.T) {
+	c, _ := newTestCache(t, 1<<20, 0)
+	node := &testNode{handler: respond("hello", "Cache-Control", "max-age=60")}
+
+	get(t, c, node, "http://hal.computers.com/")
+
+	w := httptest.NewRecorder()
+	assert.NilError(t, c.Handle(w, httptest.NewRequest(http.MethodHead, "http://hal.computers.com/", nil), node.next))
+	assert.Equal(t, w.Header().Get(Header), string(Hit))
+	assert.Equal(t, w.Body.Len(), 0)
+	assert.Equal(t, node.calls, 1)
+}
+
+func TestCacheDisk(t *testing.T) {
+	c, _ := newTestCache(t, 1024, 1<<20)
+	node := &testNode{handler: func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "max-age=60")
+		w.Write([]byte(strings.Repeat(r.URL.Path, 300)))
+	}}
+
+	get(t, c, node, "http://hal.computers.com/a")
+	get(t, c, node, "http://hal.computers.com/b")
+
+	files, err := os.ReadDir(c.dir)
+	assert.NilError(t, err)
+	assert.Equal(t, len(files), 1)
+
+	// back from disk
+	w := get(t, c, node, "http://hal.computers.com/a")
+	assert.Equal(t, w.Header().Get(Header), string(Hit))
+	assert.Equal(t, w.Body.String(), strings.Repeat("/a", 300))
+	assert.Equal(t, node.calls, 2)
+
+	assert.NilError(t, c.Close())
+	_, err = os.Stat(c.dir)
+	assert.Assert(t, os.IsNotExist(err))
+}
+
+func TestCacheEviction(t *testing.T) {
+	c, _ := newTestCache(t, 1024, 0)
+	node := &testNode{handler: func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "max-age=60")
+		w.Write([]byte(strings.Repeat(r.URL.Path, 300)))
+	}}
+
+	get(t, c, node, "http://hal.computers.com/a")
+	get(t, c, node, "http://hal.computers.com/b")
+	get(t, c, node, "http://hal.computers.com/a")
+	assert.Equal(t, node.calls, 3)
+	assert.Assert(t, c.memorySize <= c.memoryMax)
+	assert.Equal(t, len(c.entries), len(c.resources))
+}
+
+func TestCachePurge(t *testing.T) {
+	c, _ := newTestCache(t, 1<<20, 0)
+	node := &testNode{project: "p1", handler: respond("hello", "Cache-Control", "max-age=60")}
+
+	get(t, c, node, "http://hal.computers.com/a")
+	get(t, c, node, "http://hal.computers.com:443/b")
+	node.project = "p2"
+	get(t, c, node, "http://other.computers.com/c")
+
+	assert.Equal(t, c.PurgeDomain("HAL.computers.com."), 2)
+	assert.Equal(t, c.PurgeProject("p1"), 0)
+	assert.Equal(t, c.PurgeProject("p2"), 1)
+	assert.Equal(t, len(c.resources), 0)
+
+	get(t, c, node, "http://hal.computers.com/a")
+	assert.Equal(t, node.calls, 4)
+}
+
+func TestCacheHold(t *testing.T) {
+	c, clock := newTestCache(t, 1<<20, 0)
+	node := &testNode{project: "p1", handler: respond("hello", "Cache-Control", "max-age=600")}
+
+	c.Hold("p1", 30*time.Second)
+	get(t, c, node, "http://hal.computers.com/a")
+	get(t, c, node, "http://hal.computers.com/a")
+	assert.Equal(t, node.calls, 2)
+
+	// other projects are stored meanwhile
+	node.project = "p2"
+	get(t, c, node, "http://other.computers.com/a")
+	assert.Equal(t, get(t, c, node, "http://other.computers.com/a").Header().Get(Header), string(Hit))
+
+	node.project = "p1"
+	clock.advance(30 * time.Second)
+	get(t, c, node, "http://hal.computers.com/a")
+	assert.Equal(t, get(t, c, node, "http://hal.computers.com/a").Header().Get(Header), string(Hit))
+	assert.Equal(t, node.calls, 4)
+}
+
+func TestCacheProxyHeaders(t *testing.T) {
+	c, _ := newTestCache(t, 1<<20, 0)
+	node := &testNode{handler: respond("hello",
+		"Cache-Control", "max-age=60",
+		"X-Substrate-Peer", "12D3KooWPeer",
+		"X-Substrate-Tried", "12D3KooWPeer",
+		"X-Custom", "kept",
+	)}
+
+	get(t, c, node, "http://hal.computers.com/a")
+	w := get(t, c, node, "http://hal.computers.com/a")
+	assert.Equal(t, w.Header().Get(Header), string(Hit))
+	assert.Equal(t, w.Header().Get("X-Substrate-Peer"), "")
+	assert.Equal(t, w.Header().Get("X-Substrate-Tried"), "")
+	assert.Equal(t, w.Header().Get("X-Custom"), "kept")
+}
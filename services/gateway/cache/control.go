@@ -0,0 +1,193 @@
+package cache
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// directives are the directives of a Cache-Control header, by lower case name.
+type directives map[string]string
+
+func parseCacheControl(header http.Header) directives {
+	d := make(directives)
+	for _, value := range header.Values("Cache-Control") {
+		for _, part := range strings.Split(value, ",") {
+			name, arg, _ := strings.Cut(strings.TrimSpace(part), "=")
+			if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+				d[name] = strings.Trim(strings.TrimSpace(arg), `"`)
+			}
+		}
+	}
+
+	return d
+}
+
+func (d directives) has(name string) bool {
+	_, ok := d[name]
+	return ok
+}
+
+// seconds returns the delta seconds of a directive, false when it has none.
+func (d directives) seconds(name string) (time.Duration, bool) {
+	arg, ok := d[name]
+	if !ok {
+		return 0, false
+	}
+
+	n, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil || n < 0 {
+		// an invalid delta is stale
+		return 0, true
+	}
+
+	return time.Duration(n) * time.Second, true
+}
+
+// bypasses tells if the cache must not be used for r: only plain GET and HEAD
+// requests, not tied to a user, are answered from or stored in the cache.
+func bypasses(r *http.Request) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return true
+	}
+
+	for _, h := range []string{"Authorization", "Cookie", "Range"} {
+		if r.Header.Get(h) != "" {
+			return true
+		}
+	}
+
+	return parseCacheControl(r.Header).has("no-store")
+}
+
+// revalidates tells if r asks for a cached response of that age to be checked
+// with the node before it is served.
+func revalidates(r *http.Request, age time.Duration) bool {
+	d := parseCacheControl(r.Header)
+	if len(d) == 0 && strings.EqualFold(r.Header.Get("Pragma"), "no-cache") {
+		return true
+	}
+
+	if d.has("no-cache") {
+		return true
+	}
+
+	maxAge, ok := d.seconds("max-age")
+	return ok && age > maxAge
+}
+
+// newEntry returns the entry to store the response of r in, false when the
+// response can't be cached.
+func newEntry(r *http.Request, status int, header http.Header, now time.Time) (*entry, bool) {
+	if r.Method != http.MethodGet || !cacheableStatus[status] {
+		return nil, false
+	}
+
+	d := parseCacheControl(header)
+	if d.has("no-store") || d.has("private") || header.Get("Set-Cookie") != "" {
+		return nil, false
+	}
+
+	for _, v := range varyOf(header) {
+		if v == "*" {
+			return nil, false
+		}
+	}
+
+	e := &entry{
+		status:         status,
+		stored:         now,
+		noCache:        d.has("no-cache"),
+		mustRevalidate: d.has("must-revalidate") || d.has("proxy-revalidate"),
+		etag:           header.Get("ETag"),
+		lastModified:   header.Get("Last-Modified"),
+	}
+
+	var explicit bool
+	e.ttl, explicit = freshness(header, d, now)
+	if !explicit && !e.noCache {
+		// nothing says for how long it can be reused
+		return nil, false
+	}
+
+	if (e.noCache || e.ttl <= 0) && e.etag == "" && e.lastModified == "" {
+		// it would have to be fetched again anyway
+		return nil, false
+	}
+
+	if age, err := strconv.ParseInt(header.Get("Age"), 10, 64); err == nil && age > 0 {
+		e.age = time.Duration(age) * time.Second
+	}
+
+	return e, true
+}
+
+// freshness returns how long a response stays fresh, false when it does not
+// say. s-maxage, meant for shared caches, wins over max-age, and both win over
+// Expires.
+func freshness(header http.Header, d directives, now time.Time) (time.Duration, bool) {
+	if ttl, ok := d.seconds("s-maxage"); ok {
+		return ttl, true
+	}
+
+	if ttl, ok := d.seconds("max-age"); ok {
+		return ttl, true
+	}
+
+	if expires := header.Get("Expires"); expires != "" {
+		at, err := http.ParseTime(expires)
+		if err != nil {
+			return 0, true
+		}
+
+		date, err := http.ParseTime(header.Get("Date"))
+		if err != nil {
+			date = now
+		}
+
+		return max(at.Sub(date), 0), true
+	}
+
+	return 0, false
+}
+
+// varyOf returns the lower case header names a response varies by.
+func varyOf(header http.Header) []string {
+	var names []string
+	for _, value := range header.Values("Vary") {
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+				names = append(names, name)
+			}
+		}
+	}
+
+	return names
+}
+
+// notModified tells if the conditional headers of r match e, so the client
+// can be answered with a 304.
+func notModified(r *http.Request, e *entry) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		if e.etag == "" {
+			return false
+		}
+
+		for _, tag := range strings.Split(inm, ",") {
+			if tag = strings.TrimSpace(tag); tag == "*" || strings.TrimPrefix(tag, "W/") == strings.TrimPrefix(e.etag, "W/") {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	ims, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil || e.lastModified == "" {
+		return false
+	}
+
+	modified, err := http.ParseTime(e.lastModified)
+	return err == nil && !modified.After(ims)
+}
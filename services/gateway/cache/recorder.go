@@ -0,0 +1,82 @@
+package cache
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+)
+
+// recorder writes the response of a node through to the client, keeping a
+// copy of it to cache. When revalidating, a 304 is kept from the client, which
+// is answered from the cache instead.
+type recorder struct {
+	w      http.ResponseWriter
+	status Status
+	// revalidating is set when the request was made conditional by the cache
+	revalidating bool
+
+	wroteHeader bool
+	code        int
+	// notModified is set when the node confirmed the cached response
+	notModified bool
+	header      http.Header
+
+	// body is nil once the response is known not to be cached
+	body *bytes.Buffer
+}
+
+func (rec *recorder) Header() http.Header {
+	return rec.w.Header()
+}
+
+func (rec *recorder) WriteHeader(code int) {
+	if rec.wroteHeader {
+		return
+	}
+
+	rec.wroteHeader, rec.code = true, code
+
+	if rec.revalidating && code == http.StatusNotModified {
+		rec.notModified = true
+		rec.header = rec.w.Header().Clone()
+		return
+	}
+
+	rec.w.Header().Set(Header, string(rec.status))
+	rec.w.WriteHeader(code)
+
+	if n, err := strconv.ParseInt(rec.w.Header().Get("Content-Length"), 10, 64); err != nil || n <= MaxEntrySize {
+		rec.body = new(bytes.Buffer)
+	}
+}
+
+func (rec *recorder) Write(b []byte) (int, error) {
+	if !rec.wroteHeader {
+		rec.WriteHeader(http.StatusOK)
+	}
+
+	if rec.notModified {
+		return len(b), nil
+	}
+
+	if rec.body != nil {
+		if int64(rec.body.Len()+len(b)) > MaxEntrySize {
+			rec.body = nil
+		} else {
+			rec.body.Write(b)
+		}
+	}
+
+	return rec.w.Write(b)
+}
+
+// complete tells if the whole response was recorded. A node failing once the
+// response started is only noticed by a body shorter than announced.
+func (rec *recorder) complete() bool {
+	if rec.body == nil {
+		return false
+	}
+
+	n, err := strconv.ParseInt(rec.w.Header().Get("Content-Length"), 10, 64)
+	return err != nil || n == int64(rec.body.Len())
+}
@@ -0,0 +1,205 @@
+package cache
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// resourceKey identifies what r asks for, whatever its variant.
+func resourceKey(r *http.Request) string {
+	return strings.ToLower(r.Host) + r.URL.RequestURI()
+}
+
+// variantKey identifies the variant of resource r asks for, by the values of
+// the headers named.
+func variantKey(resource string, names []string, r *http.Request) string {
+	var key strings.Builder
+	key.WriteString(resource)
+	for _, name := range names {
+		key.WriteString("\n" + name + ":")
+		key.WriteString(strings.Join(r.Header.Values(name), ","))
+	}
+
+	return key.String()
+}
+
+// get returns a copy of the entry cached for r, bringing it back to memory if
+// it was on disk.
+func (c *Cache) get(r *http.Request) *entry {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	key := resourceKey(r)
+	res, ok := c.resources[key]
+	if !ok {
+		return nil
+	}
+
+	el, ok := c.entries[variantKey(key, res.vary, r)]
+	if !ok {
+		return nil
+	}
+
+	e := el.Value.(*entry)
+	if e.onDisk {
+		body, err := os.ReadFile(c.file(e.key))
+		c.remove(e.key)
+		if err != nil {
+			logger.Errorf("reading cached `%s` failed with: %s", e.resource, err.Error())
+			return nil
+		}
+
+		e.body, e.onDisk = body, false
+		c.insert(e)
+	} else {
+		c.memory.MoveToFront(el)
+	}
+
+	cp := *e
+	cp.header = e.header.Clone()
+
+	return &cp
+}
+
+// put caches e as the response to r.
+func (c *Cache) put(r *http.Request, e *entry) {
+	e.resource = resourceKey(r)
+	e.host = hostname(r.Host)
+	e.size = int64(len(e.body)) + headerSize(e.header)
+	if e.size > c.memoryMax {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	names := varyOf(e.header)
+	if res, ok := c.resources[e.resource]; ok {
+		// variants by other headers can't be looked up anymore
+		res.vary = names
+	}
+	e.key = variantKey(e.resource, names, r)
+
+	c.remove(e.key)
+	c.insert(e)
+}
+
+// insert adds e, not cached yet, to memory.
+func (c *Cache) insert(e *entry) {
+	res, ok := c.resources[e.resource]
+	if !ok {
+		res = &resource{vary: varyOf(e.header)}
+		c.resources[e.resource] = res
+	}
+	res.variants++
+
+	c.entries[e.key] = c.memory.PushFront(e)
+	c.memorySize += e.size
+
+	for c.memorySize > c.memoryMax {
+		c.demote(c.memory.Back())
+	}
+
+	for c.diskSize > c.diskMax {
+		c.remove(c.disk.Back().Value.(*entry).key)
+	}
+}
+
+// demote moves the entry of el from memory to disk, or drops it if it does
+// not fit on disk.
+func (c *Cache) demote(el *list.Element) {
+	e := c.memory.Remove(el).(*entry)
+	c.memorySize -= e.size
+
+	if c.disk == nil || e.size > c.diskMax {
+		c.forget(e)
+		return
+	}
+
+	if err := os.WriteFile(c.file(e.key), e.body, 0o600); err != nil {
+		logger.Errorf("writing cached `%s` to disk failed with: %s", e.resource, err.Error())
+		c.forget(e)
+		return
+	}
+
+	e.body, e.onDisk = nil, true
+	c.entries[e.key] = c.disk.PushFront(e)
+	c.diskSize += e.size
+}
+
+// remove drops the entry of key, if cached.
+func (c *Cache) remove(key string) {
+	el, ok := c.entries[key]
+	if !ok {
+		return
+	}
+
+	e := el.Value.(*entry)
+	if e.onDisk {
+		c.disk.Remove(el)
+		c.diskSize -= e.size
+		if err := os.Remove(c.file(key)); err != nil && !os.IsNotExist(err) {
+			logger.Errorf("removing cached `%s` from disk failed with: %s", e.resource, err.Error())
+		}
+	} else {
+		c.memory.Remove(el)
+		c.memorySize -= e.size
+	}
+
+	c.forget(e)
+}
+
+// forget drops the index of e, removed from its tier.
+func (c *Cache) forget(e *entry) {
+	delete(c.entries, e.key)
+
+	if res, ok := c.resources[e.resource]; ok {
+		if res.variants--; res.variants <= 0 {
+			delete(c.resources, e.resource)
+		}
+	}
+}
+
+// purge drops the entries matching.
+func (c *Cache) purge(match func(e *entry) bool) (count int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for key, el := range c.entries {
+		if match(el.Value.(*entry)) {
+			c.remove(key)
+			count++
+		}
+	}
+
+	return
+}
+
+func (c *Cache) file(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:]))
+}
+
+func headerSize(header http.Header) (size int64) {
+	for k, values := range header {
+		for _, v := range values {
+			size += int64(len(k) + len(v))
+		}
+	}
+
+	return
+}
+
+// hostname returns host without its port, in lower case.
+func hostname(host string) string {
+	if i := strings.LastIndexByte(host, ':'); i > strings.LastIndexByte(host, ']') {
+		host = host[:i]
+	}
+
+	return strings.ToLower(host)
+}
@@ -0,0 +1,69 @@
+package cache
+
+import (
+	"container/list"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Cache holds the responses the gateway proxied, as far as their
+// Cache-Control allows, in memory first then, once evicted from memory, on
+// disk. Both tiers evict their least recently used entries past their size.
+type Cache struct {
+	lock sync.Mutex
+	now  func() time.Time
+
+	// entries maps the key of a variant to its element in memory or on disk
+	entries map[string]*list.Element
+	// resources maps the key of a resource to its variants
+	resources map[string]*resource
+	// held maps a project to until when its responses are not stored
+	held map[string]time.Time
+
+	memory     *list.List
+	memorySize int64
+	memoryMax  int64
+
+	dir      string
+	disk     *list.List
+	diskSize int64
+	diskMax  int64
+}
+
+// Next handles a request the cache can't answer, writing the response to w,
+// and returns the project the response came from.
+type Next func(w http.ResponseWriter, r *http.Request) (project string, err error)
+
+// Status tells, in Header, how the cache answered a request.
+type Status string
+
+type resource struct {
+	// vary are the request headers the variants differ by
+	vary     []string
+	variants int
+}
+
+type entry struct {
+	key      string
+	resource string
+	project  string
+	host     string
+
+	status int
+	header http.Header
+	// body is nil while the entry is on disk
+	body   []byte
+	size   int64
+	onDisk bool
+
+	// stored is when the response was received, age how old it was then
+	stored time.Time
+	age    time.Duration
+	ttl    time.Duration
+
+	noCache        bool
+	mustRevalidate bool
+	etag           string
+	lastModified   string
+}
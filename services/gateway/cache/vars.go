@@ -0,0 +1,53 @@
+package cache
+
+import (
+	"net/http"
+
+	"github.com/ipfs/go-log/v2"
+)
+
+var logger = log.Logger("tau.gateway.cache")
+
+const (
+	// Hit is a response served from the cache.
+	Hit Status = "HIT"
+	// Miss is a response proxied to a node.
+	Miss Status = "MISS"
+	// Revalidated is a stale response served from the cache once the node
+	// confirmed it did not change.
+	Revalidated Status = "REVALIDATED"
+	// Stale is a stale response served from the cache because the node could
+	// not be reached.
+	Stale Status = "STALE"
+	// Bypass is a response the cache was not used for.
+	Bypass Status = "BYPASS"
+)
+
+var (
+	// Header tells how the cache answered a request.
+	Header = "X-Gateway-Cache"
+	// ProxyHeaders are those the gateway sets on a response naming the nodes
+	// it was proxied to; they tell of one response, so they are not cached.
+	ProxyHeaders = []string{"X-Substrate-Peer", "X-Substrate-Tried", "X-Substrate-Hedged"}
+	// MaxEntrySize is the size of the largest body cached.
+	MaxEntrySize int64 = 8 << 20
+
+	DefaultMemory int64 = 128 << 20
+	DefaultDisk   int64 = 1 << 30
+
+	// cacheableStatus are the statuses cached when the response says for how
+	// long.
+	cacheableStatus = map[int]bool{
+		http.StatusOK:                   true,
+		http.StatusNonAuthoritativeInfo: true,
+		http.StatusNoContent:            true,
+		http.StatusMultipleChoices:      true,
+		http.StatusMovedPermanently:     true,
+		http.StatusNotFound:             true,
+		http.StatusMethodNotAllowed:     true,
+		http.StatusGone:                 true,
+		http.StatusRequestURITooLong:    true,
+		http.StatusNotImplemented:       true,
+		http.StatusPermanentRedirect:    true,
+	}
+)
@@ -0,0 +1,53 @@
+package gateway
+
+import (
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/taubyte/tau/core/services/patrick"
+)
+
+// subscribeDeployments purges the responses cached for a project whenever
+// patrick announces it was deployed.
+func (g *Gateway) subscribeDeployments() error {
+	return g.node.PubSubSubscribe(
+		patrick.DeploymentsTopic,
+		func(msg *pubsub.Message) {
+			g.purgeDeployment(msg.Data)
+		},
+		func(err error) {
+			// re-establish if fails
+			if err.Error() != "context canceled" {
+				logger.Errorf("gateway pubsub subscription to `%s` failed with: %s", patrick.DeploymentsTopic, err.Error())
+				if err := g.subscribeDeployments(); err != nil {
+					logger.Errorf("resubscribe to `%s` failed with: %s", patrick.DeploymentsTopic, err.Error())
+				}
+			}
+		},
+	)
+}
+
+func (g *Gateway) purgeDeployment(data []byte) {
+	var deployment patrick.Deployment
+	if err := cbor.Unmarshal(data, &deployment); err != nil {
+		logger.Errorf("unmarshal deployment failed with: %s", err.Error())
+		return
+	}
+
+	// nodes serve the deployment once they see it, and until then still
+	// answer with what is purged here: nothing of the project is stored
+	// meanwhile, and it is purged again once they all should.
+	g.cache.Hold(deployment.Project, DeploymentSettle)
+	g.purge(&deployment)
+	time.AfterFunc(DeploymentSettle, func() { g.purge(&deployment) })
+}
+
+func (g *Gateway) purge(deployment *patrick.Deployment) {
+	count := g.cache.PurgeProject(deployment.Project)
+	for _, fqdn := range deployment.Domains {
+		count += g.cache.PurgeDomain(fqdn)
+	}
+
+	logger.Debugf("deployment of project `%s` purged %d cached responses", deployment.Project, count)
+}
@@ -3,15 +3,17 @@ package gateway
 import (
 	"errors"
 	"fmt"
-	"sort"
+	"net"
+	"time"
 
 	goHttp "net/http"
 
+	"github.com/taubyte/tau/clients/p2p/substrate"
 	"github.com/taubyte/tau/p2p/streams/client"
-	tunnel "github.com/taubyte/tau/p2p/streams/tunnels/http"
 	http "github.com/taubyte/tau/pkg/http"
 	functionSpec "github.com/taubyte/tau/pkg/specs/function"
 	websiteSpec "github.com/taubyte/tau/pkg/specs/website"
+	"github.com/taubyte/tau/services/gateway/selection"
 	"github.com/taubyte/tau/services/substrate/components/metrics"
 )
 
@@ -19,7 +21,14 @@ func (g *Gateway) attach() {
 	g.http.LowLevel(&http.LowLevelDefinition{
 		PathPrefix: "/",
 		Handler: func(w goHttp.ResponseWriter, r *goHttp.Request) {
-			if err := g.handleHttp(w, r); err != nil {
+			var err error
+			if g.cache != nil {
+				err = g.cache.Handle(w, r, g.handleHttp)
+			} else {
+				_, err = g.handleHttp(w, r)
+			}
+
+			if err != nil {
 				w.WriteHeader(500)
 				w.Write([]byte(err.Error()))
 			}
@@ -36,10 +45,13 @@ func (wr wrappedResponse) Decode(data interface{}) (err error) {
 	}
 }
 
-func (g *Gateway) handleHttp(w goHttp.ResponseWriter, r *goHttp.Request) error {
+// handleHttp proxies r to a substrate node serving it, and returns the project
+// serving it.
+func (g *Gateway) handleHttp(w goHttp.ResponseWriter, r *goHttp.Request) (string, error) {
+	start := time.Now()
 	resCh, err := g.substrateClient.ProxyHTTP(r.Host, r.URL.Path, r.Method)
 	if err != nil {
-		return fmt.Errorf("substrate client proxyHttp failed with: %w", err)
+		return "", fmt.Errorf("substrate client proxyHttp failed with: %w", err)
 	}
 
 	websiteMatches := make([]wrappedResponse, 0)
@@ -51,7 +63,7 @@ func (g *Gateway) handleHttp(w goHttp.ResponseWriter, r *goHttp.Request) error {
 		}
 
 		if _metrics, err := response.Get(websiteSpec.PathVariable.String()); err == nil {
-			wres := wrappedResponse{Response: response, metrics: new(metrics.Website)}
+			wres := wrappedResponse{Response: response, metrics: new(metrics.Website), latency: time.Since(start)}
 			if err = wres.Decode(_metrics); err == nil {
 				websiteMatches = append(websiteMatches, wres)
 				continue
@@ -59,7 +71,7 @@ func (g *Gateway) handleHttp(w goHttp.ResponseWriter, r *goHttp.Request) error {
 		}
 
 		if _metrics, err := response.Get(functionSpec.PathVariable.String()); err == nil {
-			wres := wrappedResponse{Response: response, metrics: new(metrics.Function)}
+			wres := wrappedResponse{Response: response, metrics: new(metrics.Function), latency: time.Since(start)}
 			if err = wres.Decode(_metrics); err == nil {
 				funcMatches = append(funcMatches, wres)
 				continue
@@ -80,23 +92,34 @@ func (g *Gateway) handleHttp(w goHttp.ResponseWriter, r *goHttp.Request) error {
 		}
 	}()
 	if len(websiteMatches)+len(funcMatches) < 1 {
-		return errors.New("no substrate match found")
+		return "", errors.New("no substrate match found")
 	}
 
-	var pick *client.Response
+	matches := funcMatches
 	if len(websiteMatches) > len(funcMatches) {
-		sort.Slice(websiteMatches, func(i, j int) bool { return websiteMatches[j].metrics.Less(websiteMatches[i].metrics) })
-		pick = websiteMatches[0].Response
-	} else {
-		sort.Slice(funcMatches, func(i, j int) bool { return funcMatches[j].metrics.Less(funcMatches[i].metrics) })
-		pick = funcMatches[0].Response
+		matches = websiteMatches
+	}
+
+	candidates := make([]*candidate, len(matches))
+	for i, m := range matches {
+		candidates[i] = &candidate{
+			upstream: m.Response,
+			node:     &selection.Node{ID: m.PID(), Metrics: m.metrics, Latency: m.latency},
+		}
 	}
 
-	w.Header().Add(ProxyHeader, pick.PID().String())
+	project, _ := matches[0].Get(substrate.BodyProject)
+	projectId, _ := project.(string)
+
+	return projectId, g.proxy(w, r, clientIP(r), candidates)
+}
 
-	if err := tunnel.Frontend(w, r, pick); err != nil {
-		return fmt.Errorf("tunneling Frontend failed with: %w", err)
+// clientIP returns the ip r came from.
+func clientIP(r *goHttp.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
 	}
 
-	return nil
+	return host
 }
@@ -14,5 +14,15 @@ func (g *Gateway) Http() http.Service {
 }
 
 func (g *Gateway) Close() error {
+	if g.stopDirectory != nil {
+		g.stopDirectory()
+	}
+
+	if g.cache != nil {
+		if err := g.cache.Close(); err != nil {
+			logger.Errorf("closing response cache failed with: %s", err.Error())
+		}
+	}
+
 	return g.substrateClient.Close()
 }
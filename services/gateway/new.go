@@ -6,19 +6,26 @@ import (
 	"fmt"
 	"path"
 
+	"github.com/alecthomas/units"
 	"github.com/ipfs/go-log/v2"
+	seerClient "github.com/taubyte/tau/clients/p2p/seer"
 	substrate "github.com/taubyte/tau/clients/p2p/substrate"
 	iface "github.com/taubyte/tau/core/services/gateway"
+	"github.com/taubyte/tau/p2p/peer"
 	tauConfig "github.com/taubyte/tau/pkg/config"
 	servicesCommon "github.com/taubyte/tau/services/common"
 	"github.com/taubyte/tau/services/common/httpsvc"
+	"github.com/taubyte/tau/services/gateway/breaker"
+	"github.com/taubyte/tau/services/gateway/cache"
+	"github.com/taubyte/tau/services/gateway/selection"
 )
 
 var logger = log.Logger("tau.gateway.service")
 
 func New(ctx context.Context, cfg tauConfig.Config) (gateway iface.Service, err error) {
 	g := &Gateway{
-		ctx: ctx,
+		ctx:      ctx,
+		breakers: breaker.New(),
 	}
 
 	g.dev, g.verbose = cfg.DevMode(), cfg.Verbose()
@@ -50,6 +57,72 @@ func New(ctx context.Context, cfg tauConfig.Config) (gateway iface.Service, err
 		return nil, fmt.Errorf("new streams client failed with: %w", err)
 	}
 
+	if err = g.newSelector(clientNode, cfg); err != nil {
+		g.substrateClient.Close()
+		return nil, fmt.Errorf("new node selector failed with: %w", err)
+	}
+
+	if err = g.newCache(cfg); err != nil {
+		g.Close()
+		return nil, fmt.Errorf("new response cache failed with: %w", err)
+	}
+
 	g.attach()
 	return g, nil
 }
+
+// newSelector sets up the node selection policy of the config, following seer
+// when the policy needs more than the metrics nodes reply with.
+func (g *Gateway) newSelector(clientNode peer.Node, cfg tauConfig.Config) (err error) {
+	config := cfg.Gateway().Selection
+
+	var directory selection.Directory
+	if config.Policy != "" && config.Policy != selection.Metrics {
+		sc, err := seerClient.New(g.ctx, clientNode, nil)
+		if err != nil {
+			return fmt.Errorf("new seer client failed with: %w", err)
+		}
+
+		var ctx context.Context
+		ctx, g.stopDirectory = context.WithCancel(g.ctx)
+		directory = selection.NewDirectory(ctx, sc)
+	}
+
+	if g.selector, err = selection.New(config.Policy, config.Sticky, cfg.Location(), directory); err != nil && g.stopDirectory != nil {
+		g.stopDirectory()
+	}
+
+	return
+}
+
+// newCache sets up the response cache of the config, purged as projects are
+// deployed.
+func (g *Gateway) newCache(cfg tauConfig.Config) (err error) {
+	config := cfg.Gateway().Cache
+	if config.Disabled {
+		return nil
+	}
+
+	memory, disk := cache.DefaultMemory, cache.DefaultDisk
+	if config.Memory != "" {
+		size, err := units.ParseBase2Bytes(config.Memory)
+		if err != nil {
+			return fmt.Errorf("parsing cache memory `%s` failed with: %w", config.Memory, err)
+		}
+		memory = int64(size)
+	}
+
+	if config.Disk != "" {
+		size, err := units.ParseBase2Bytes(config.Disk)
+		if err != nil {
+			return fmt.Errorf("parsing cache disk `%s` failed with: %w", config.Disk, err)
+		}
+		disk = int64(size)
+	}
+
+	if g.cache, err = cache.New(memory, disk, path.Join(cfg.Root(), "storage", cfg.Shape(), "gateway", "cache")); err != nil {
+		return err
+	}
+
+	return g.subscribeDeployments()
+}
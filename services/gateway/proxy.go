@@ -0,0 +1,350 @@
+package gateway
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	goHttp "net/http"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	tunnel "github.com/taubyte/tau/p2p/streams/tunnels/http"
+	"github.com/taubyte/tau/services/gateway/selection"
+)
+
+var (
+	errNoResponse = errors.New("node closed the tunnel without responding")
+	errLost       = errors.New("another node answered first")
+)
+
+// proxy tunnels r to the best of candidates. An idempotent request failing
+// before anything reached the client is sent again to the next best node, up
+// to MaxAttempts nodes; a GET not answered within HedgeDelay is also sent to
+// the next best node, the first to answer serving it. Nodes whose breaker is
+// open are only tried when no other node is left.
+func (g *Gateway) proxy(w goHttp.ResponseWriter, r *goHttp.Request, ip string, candidates []*candidate) error {
+	p := &proxying{
+		gateway:    g,
+		w:          w,
+		r:          r,
+		ip:         ip,
+		candidates: candidates,
+	}
+
+	maxAttempts := 1
+	if p.replayable() {
+		maxAttempts = MaxAttempts
+	}
+
+	results := make(chan attemptResult, maxAttempts)
+	inflight, attempts := 0, 0
+	start := func(hedge bool) bool {
+		a := p.next(hedge)
+		if a == nil {
+			return false
+		}
+
+		inflight++
+		attempts++
+		go func() {
+			results <- attemptResult{a, tunnel.Frontend(a, p.request(), a.target)}
+		}()
+
+		return true
+	}
+
+	if !start(false) {
+		return errors.New("no substrate node to proxy to")
+	}
+
+	var hedge <-chan time.Time
+	if r.Method == goHttp.MethodGet && maxAttempts > 1 {
+		hedge = time.After(HedgeDelay)
+	}
+
+	var err error
+	for inflight > 0 {
+		select {
+		case <-hedge:
+			hedge = nil
+			if !p.answered() && attempts < maxAttempts {
+				start(true)
+			}
+		case res := <-results:
+			inflight--
+
+			pid := res.attempt.target.PID()
+			if res.attempt.lost() {
+				continue
+			}
+
+			won := p.winner() == res.attempt
+			if res.err == nil && won {
+				g.breakers.Success(pid)
+				return nil
+			}
+
+			if res.err == nil {
+				res.err = errNoResponse
+			}
+
+			g.breakers.Failure(pid)
+			err = fmt.Errorf("proxying to node `%s` failed with: %w", pid.String(), res.err)
+
+			if won {
+				// the response is on its way to the client, it can't be retried
+				logger.Errorf("%s after answering %s %s%s", err.Error(), r.Method, r.Host, r.URL.Path)
+				return nil
+			}
+
+			logger.Debugf("%s; trying another node", err.Error())
+			if inflight == 0 && attempts < maxAttempts {
+				start(false)
+			}
+		}
+	}
+
+	return err
+}
+
+// upstream is a node a request can be tunneled to.
+type upstream interface {
+	io.ReadWriter
+	PID() peer.ID
+	Close()
+}
+
+type candidate struct {
+	upstream
+	node *selection.Node
+}
+
+type proxying struct {
+	gateway *Gateway
+	w       goHttp.ResponseWriter
+	r       *goHttp.Request
+	ip      string
+	// body is set when the request can be sent more than once
+	body []byte
+
+	// candidates are the nodes not tried yet
+	candidates []*candidate
+
+	lock     sync.Mutex
+	attempts []*attempt
+	answer   *attempt
+}
+
+type attemptResult struct {
+	attempt *attempt
+	err     error
+}
+
+// replayable reads the body of idempotent requests, if small enough, so they
+// can be sent to more than one node.
+func (p *proxying) replayable() bool {
+	switch p.r.Method {
+	case goHttp.MethodGet, goHttp.MethodHead, goHttp.MethodOptions, goHttp.MethodPut, goHttp.MethodDelete:
+	default:
+		return false
+	}
+
+	if p.r.Body == nil || p.r.Body == goHttp.NoBody {
+		p.body = []byte{}
+		return true
+	}
+
+	if p.r.ContentLength > MaxReplayBody {
+		return false
+	}
+
+	body, err := io.ReadAll(io.LimitReader(p.r.Body, MaxReplayBody+1))
+	if err != nil || int64(len(body)) > MaxReplayBody {
+		// hand what was read back with the rest
+		p.r.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), p.r.Body), p.r.Body}
+		return false
+	}
+
+	p.body = body
+	return true
+}
+
+// request returns the request to send to a node.
+func (p *proxying) request() *goHttp.Request {
+	if p.body == nil {
+		return p.r
+	}
+
+	r := p.r.Clone(p.r.Context())
+	r.Body = io.NopCloser(bytes.NewReader(p.body))
+
+	return r
+}
+
+// next returns an attempt to send the request to the best node left, nil if
+// none is left or the request was answered.
+func (p *proxying) next(hedge bool) *attempt {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.answer != nil {
+		return nil
+	}
+
+	var skipped []*candidate
+	defer func() {
+		p.candidates = append(p.candidates, skipped...)
+	}()
+
+	for len(p.candidates) > 0 {
+		c := p.pick()
+		if p.gateway.breakers.Allow(c.PID()) {
+			return p.newAttempt(c, hedge)
+		}
+
+		skipped = append(skipped, c)
+	}
+
+	// every node left is cut off: try them anyway
+	if len(skipped) > 0 {
+		p.candidates, skipped = skipped, nil
+		return p.newAttempt(p.pick(), hedge)
+	}
+
+	return nil
+}
+
+// pick removes the node selected among candidates, and returns it.
+func (p *proxying) pick() *candidate {
+	nodes := make([]*selection.Node, len(p.candidates))
+	for i, c := range p.candidates {
+		nodes[i] = c.node
+	}
+
+	picked := p.gateway.selector.Pick(p.ip, nodes)
+	i := 0
+	for ; i < len(nodes)-1; i++ {
+		if nodes[i] == picked {
+			break
+		}
+	}
+
+	c := p.candidates[i]
+	p.candidates = append(p.candidates[:i:i], p.candidates[i+1:]...)
+
+	return c
+}
+
+func (p *proxying) newAttempt(c *candidate, hedge bool) *attempt {
+	a := &attempt{
+		proxying: p,
+		target:   c,
+		header:   make(goHttp.Header),
+		hedge:    hedge,
+	}
+	p.attempts = append(p.attempts, a)
+
+	return a
+}
+
+func (p *proxying) answered() bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	return p.answer != nil
+}
+
+func (p *proxying) winner() *attempt {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	return p.answer
+}
+
+// claim makes a the attempt answering the client, if none is, and tells if
+// it is. The other attempts are cancelled.
+func (p *proxying) claim(a *attempt) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.answer != nil {
+		return p.answer == a
+	}
+	p.answer = a
+
+	tried := make([]string, 0, len(p.attempts))
+	hedges := make([]string, 0, 1)
+	for _, other := range p.attempts {
+		pid := other.target.PID().String()
+		tried = append(tried, pid)
+		if other.hedge {
+			hedges = append(hedges, pid)
+		}
+
+		if other != a {
+			other.target.Close()
+		}
+	}
+
+	header := p.w.Header()
+	for k, v := range a.header {
+		header[k] = v
+	}
+
+	header.Set(ProxyHeader, a.target.PID().String())
+	header.Set(TriedHeader, strings.Join(tried, ", "))
+	if len(hedges) > 0 {
+		header.Set(HedgedHeader, strings.Join(hedges, ", "))
+	}
+
+	return true
+}
+
+// attempt is the response writer of the request sent to a node. Only the
+// attempt claiming the response, by answering first, writes to the client.
+type attempt struct {
+	*proxying
+	target *candidate
+	header goHttp.Header
+	hedge  bool
+
+	wroteHeader bool
+}
+
+func (a *attempt) Header() goHttp.Header {
+	return a.header
+}
+
+func (a *attempt) WriteHeader(code int) {
+	if a.wroteHeader || !a.claim(a) {
+		return
+	}
+
+	a.wroteHeader = true
+	a.w.WriteHeader(code)
+}
+
+func (a *attempt) Write(b []byte) (int, error) {
+	if !a.wroteHeader {
+		a.WriteHeader(goHttp.StatusOK)
+	}
+
+	if !a.wroteHeader {
+		return 0, errLost
+	}
+
+	return a.w.Write(b)
+}
+
+// lost tells if another attempt answered the client.
+func (a *attempt) lost() bool {
+	w := a.winner()
+	return w != nil && w != a
+}
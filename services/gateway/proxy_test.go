@@ -0,0 +1,162 @@
+package gateway
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	tunnel "github.com/taubyte/tau/p2p/streams/tunnels/http"
+	"github.com/taubyte/tau/services/gateway/breaker"
+	"github.com/taubyte/tau/services/gateway/selection"
+	"github.com/taubyte/tau/services/substrate/components/metrics"
+	"gotest.tools/v3/assert"
+)
+
+type testUpstream struct {
+	net.Conn
+	pid peer.ID
+}
+
+func (u *testUpstream) PID() peer.ID { return u.pid }
+func (u *testUpstream) Close()       { u.Conn.Close() }
+
+// testCandidate returns a node handling requests with handler, or closing the
+// tunnel without answering when handler is nil. The higher rank, the better
+// the node.
+func testCandidate(pid string, rank float32, handler http.HandlerFunc) *candidate {
+	client, server := net.Pipe()
+	go func() {
+		defer server.Close()
+
+		w, r, err := tunnel.Backend(server)
+		if err != nil || handler == nil {
+			return
+		}
+
+		handler(w, r)
+	}()
+
+	return &candidate{
+		upstream: &testUpstream{Conn: client, pid: peer.ID(pid)},
+		node:     &selection.Node{ID: peer.ID(pid), Metrics: &metrics.Website{Cached: rank}},
+	}
+}
+
+func answer(body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(body + string(data)))
+	}
+}
+
+func newTestGateway(t *testing.T) *Gateway {
+	selector, err := selection.New(selection.Metrics, false, nil, nil)
+	assert.NilError(t, err)
+
+	return &Gateway{selector: selector, breakers: breaker.New()}
+}
+
+func testProxy(t *testing.T, g *Gateway, method, body string, candidates ...*candidate) (*httptest.ResponseRecorder, error) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(method, "http://hal.computers.com/ping", strings.NewReader(body))
+
+	err := g.proxy(w, r, "10.0.0.1", candidates)
+	for _, c := range candidates {
+		c.Close()
+	}
+
+	return w, err
+}
+
+func TestProxyBest(t *testing.T) {
+	w, err := testProxy(t, newTestGateway(t), http.MethodGet, "",
+		testCandidate("worse", 1, answer("worse")),
+		testCandidate("best", 2, answer("best")),
+	)
+	assert.NilError(t, err)
+
+	assert.Equal(t, w.Body.String(), "best")
+	assert.Equal(t, w.Header().Get(ProxyHeader), peer.ID("best").String())
+	assert.Equal(t, w.Header().Get(TriedHeader), peer.ID("best").String())
+}
+
+func TestProxyFailover(t *testing.T) {
+	w, err := testProxy(t, newTestGateway(t), http.MethodPut, "-body",
+		testCandidate("best", 3, nil),
+		testCandidate("next", 2, answer("next")),
+		testCandidate("worst", 1, answer("worst")),
+	)
+	assert.NilError(t, err)
+
+	assert.Equal(t, w.Body.String(), "next-body")
+	assert.Equal(t, w.Header().Get(ProxyHeader), peer.ID("next").String())
+	assert.Equal(t, w.Header().Get(TriedHeader), peer.ID("best").String()+", "+peer.ID("next").String())
+	assert.Equal(t, w.Header().Get(HedgedHeader), "")
+}
+
+func TestProxyNoFailoverOfPost(t *testing.T) {
+	_, err := testProxy(t, newTestGateway(t), http.MethodPost, "",
+		testCandidate("best", 2, nil),
+		testCandidate("next", 1, answer("next")),
+	)
+	assert.ErrorIs(t, err, errNoResponse)
+}
+
+func TestProxyGivesUp(t *testing.T) {
+	candidates := make([]*candidate, 0, MaxAttempts+1)
+	for i := range MaxAttempts {
+		candidates = append(candidates, testCandidate(string(rune('a'+i)), float32(MaxAttempts+1-i), nil))
+	}
+	candidates = append(candidates, testCandidate("last", 0, answer("last")))
+
+	_, err := testProxy(t, newTestGateway(t), http.MethodGet, "", candidates...)
+	assert.ErrorIs(t, err, errNoResponse)
+}
+
+func TestProxyHedges(t *testing.T) {
+	defer func(delay time.Duration) { HedgeDelay = delay }(HedgeDelay)
+	HedgeDelay = 20 * time.Millisecond
+
+	slow := func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(time.Second)
+		answer("slow")(w, r)
+	}
+
+	start := time.Now()
+	w, err := testProxy(t, newTestGateway(t), http.MethodGet, "",
+		testCandidate("slow", 2, slow),
+		testCandidate("fast", 1, answer("fast")),
+	)
+	assert.NilError(t, err)
+	assert.Assert(t, time.Since(start) < time.Second)
+
+	assert.Equal(t, w.Body.String(), "fast")
+	assert.Equal(t, w.Header().Get(ProxyHeader), peer.ID("fast").String())
+	assert.Equal(t, w.Header().Get(TriedHeader), peer.ID("slow").String()+", "+peer.ID("fast").String())
+	assert.Equal(t, w.Header().Get(HedgedHeader), peer.ID("fast").String())
+}
+
+func TestProxySkipsOpenBreakers(t *testing.T) {
+	g := newTestGateway(t)
+	for range 5 {
+		g.breakers.Failure("best")
+	}
+
+	w, err := testProxy(t, g, http.MethodGet, "",
+		testCandidate("best", 2, answer("best")),
+		testCandidate("next", 1, answer("next")),
+	)
+	assert.NilError(t, err)
+	assert.Equal(t, w.Body.String(), "next")
+
+	// cut off nodes are still tried when no other is left
+	w, err = testProxy(t, g, http.MethodGet, "", testCandidate("best", 2, answer("best")))
+	assert.NilError(t, err)
+	assert.Equal(t, w.Body.String(), "best")
+}
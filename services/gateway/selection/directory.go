@@ -0,0 +1,137 @@
+package selection
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	seerIface "github.com/taubyte/tau/core/services/seer"
+)
+
+var _ Directory = &SeerDirectory{}
+
+// SeerDirectory is a Directory refreshed from seer, in the background, every
+// DirectoryRefreshInterval.
+type SeerDirectory struct {
+	client seerIface.Client
+
+	lock      sync.RWMutex
+	locations map[string]seerIface.Location
+	usage     map[string]usage
+}
+
+type usage struct {
+	cpuTotal int
+	cpuIdle  int
+	headroom float64
+}
+
+// NewDirectory returns a SeerDirectory refreshed until ctx is done, when client
+// is closed.
+func NewDirectory(ctx context.Context, client seerIface.Client) *SeerDirectory {
+	d := &SeerDirectory{
+		client:    client,
+		locations: make(map[string]seerIface.Location),
+		usage:     make(map[string]usage),
+	}
+
+	go func() {
+		for {
+			d.refresh()
+
+			select {
+			case <-ctx.Done():
+				if c, ok := client.(interface{ Close() }); ok {
+					c.Close()
+				}
+				return
+			case <-time.After(DirectoryRefreshInterval):
+			}
+		}
+	}()
+
+	return d
+}
+
+func (d *SeerDirectory) Location(pid peer.ID) (seerIface.Location, bool) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+
+	loc, ok := d.locations[pid.String()]
+	return loc, ok
+}
+
+func (d *SeerDirectory) Headroom(pid peer.ID) (float64, bool) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+
+	u, ok := d.usage[pid.String()]
+	return u.headroom, ok
+}
+
+func (d *SeerDirectory) refresh() {
+	locations := make(map[string]seerIface.Location)
+	if peers, err := d.client.Geo().All(); err != nil {
+		logger.Errorf("listing node locations failed with: %s", err.Error())
+	} else {
+		for _, p := range peers {
+			locations[p.Id] = p.Location.Location
+		}
+	}
+
+	ids, listErr := d.client.Usage().ListServiceId("substrate")
+	if listErr != nil {
+		logger.Errorf("listing substrate nodes failed with: %s", listErr.Error())
+	}
+
+	d.lock.RLock()
+	previous := d.usage
+	d.lock.RUnlock()
+
+	current := make(map[string]usage, len(ids))
+	for _, id := range ids {
+		u, err := d.client.Usage().Get(id)
+		if err != nil {
+			logger.Debugf("getting usage of `%s` failed with: %s", id, err.Error())
+			continue
+		}
+
+		prev, ok := previous[id]
+		current[id] = newUsage(u, prev, ok)
+	}
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if len(locations) > 0 {
+		d.locations = locations
+	}
+
+	if listErr == nil {
+		d.usage = current
+	}
+}
+
+// newUsage returns the usage of u. Cpu counters accumulate since boot, so the
+// cpu left is measured since the previous usage, when there is one.
+func newUsage(u *seerIface.UsageReturn, prev usage, hasPrev bool) usage {
+	ret := usage{cpuTotal: u.TotalCpu, cpuIdle: u.CpuIdle, headroom: 1}
+
+	total, idle := u.TotalCpu, u.CpuIdle
+	if hasPrev && total > prev.cpuTotal && idle >= prev.cpuIdle {
+		total, idle = total-prev.cpuTotal, idle-prev.cpuIdle
+	}
+
+	if total > 0 {
+		ret.headroom = min(ret.headroom, float64(idle)/float64(total))
+	}
+
+	if u.TotalMem > 0 {
+		ret.headroom = min(ret.headroom, float64(u.FreeMem)/float64(u.TotalMem))
+	}
+
+	ret.headroom = max(ret.headroom, 0)
+
+	return ret
+}
@@ -0,0 +1,20 @@
+package selection
+
+import (
+	"testing"
+
+	seerIface "github.com/taubyte/tau/core/services/seer"
+	"gotest.tools/v3/assert"
+)
+
+func TestNewUsage(t *testing.T) {
+	u := newUsage(&seerIface.UsageReturn{TotalCpu: 1000, CpuIdle: 800, TotalMem: 100, FreeMem: 50}, usage{}, false)
+	assert.Equal(t, u.headroom, 0.5)
+
+	// cpu is measured since the previous usage
+	u = newUsage(&seerIface.UsageReturn{TotalCpu: 2000, CpuIdle: 900, TotalMem: 100, FreeMem: 50}, u, true)
+	assert.Equal(t, u.headroom, 0.1)
+
+	u = newUsage(&seerIface.UsageReturn{}, usage{}, false)
+	assert.Equal(t, u.headroom, 1.0)
+}
@@ -0,0 +1,85 @@
+package selection
+
+import (
+	"math"
+	"sort"
+
+	seerIface "github.com/taubyte/tau/core/services/seer"
+)
+
+// metricsPolicy ranks nodes by the metrics they replied with.
+type metricsPolicy struct{}
+
+func (metricsPolicy) Weights(nodes []*Node) []float64 {
+	order := make([]int, len(nodes))
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.Slice(order, func(i, j int) bool { return nodes[order[j]].Metrics.Less(nodes[order[i]].Metrics) })
+
+	weights := make([]float64, len(nodes))
+	for rank, i := range order {
+		weights[i] = float64(len(nodes) - rank)
+	}
+
+	return weights
+}
+
+// proximityPolicy prefers nodes close to origin. Nodes, or an origin, without
+// a location are placed by how long they took to reply.
+type proximityPolicy struct {
+	origin    *seerIface.Location
+	directory Directory
+}
+
+func (p *proximityPolicy) Weights(nodes []*Node) []float64 {
+	weights := make([]float64, len(nodes))
+	for i, n := range nodes {
+		weights[i] = 1 / (1 + p.distance(n)/ProximityScale)
+	}
+
+	return weights
+}
+
+// distance returns how far, in meters, n is from origin.
+func (p *proximityPolicy) distance(n *Node) float64 {
+	if p.origin != nil && p.directory != nil {
+		if loc, ok := p.directory.Location(n.ID); ok {
+			return distance(*p.origin, loc)
+		}
+	}
+
+	return float64(n.Latency) * MetersPerLatency
+}
+
+// headroomPolicy prefers nodes with cpu and memory left.
+type headroomPolicy struct {
+	directory Directory
+}
+
+func (p *headroomPolicy) Weights(nodes []*Node) []float64 {
+	weights := make([]float64, len(nodes))
+	for i, n := range nodes {
+		weights[i] = UnknownHeadroom
+		if p.directory != nil {
+			if headroom, ok := p.directory.Headroom(n.ID); ok {
+				weights[i] = headroom
+			}
+		}
+	}
+
+	return weights
+}
+
+// distance returns the great-circle distance, in meters, between a and b.
+func distance(a, b seerIface.Location) float64 {
+	const earthRadius = 6378100
+
+	la1, lo1 := float64(a.Latitude)*math.Pi/180, float64(a.Longitude)*math.Pi/180
+	la2, lo2 := float64(b.Latitude)*math.Pi/180, float64(b.Longitude)*math.Pi/180
+
+	h := math.Pow(math.Sin((la2-la1)/2), 2) + math.Cos(la1)*math.Cos(la2)*math.Pow(math.Sin((lo2-lo1)/2), 2)
+
+	return 2 * earthRadius * math.Asin(math.Sqrt(h))
+}
@@ -0,0 +1,112 @@
+package selection
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/rand/v2"
+
+	seerIface "github.com/taubyte/tau/core/services/seer"
+)
+
+// New returns a Selector applying policy. Proximity is measured from origin,
+// when known. Directory can be nil with the metrics policy.
+func New(policy string, sticky bool, origin *seerIface.Location, directory Directory) (*Selector, error) {
+	s := &Selector{
+		sticky: sticky,
+		rand:   rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())),
+	}
+
+	switch policy {
+	case "", Metrics:
+		s.policy = metricsPolicy{}
+	case Proximity:
+		s.policy = &proximityPolicy{origin: origin, directory: directory}
+	case Headroom:
+		s.policy = &headroomPolicy{directory: directory}
+	case Weighted:
+		s.policy = &headroomPolicy{directory: directory}
+		s.random = true
+	default:
+		return nil, fmt.Errorf("unknown node selection policy `%s`", policy)
+	}
+
+	return s, nil
+}
+
+// Pick returns the node to proxy the request of client to, nil if there are
+// no nodes. With stickiness, a client is hashed to a node, with the chances of
+// each node following its weight, so it keeps landing on the same node for as
+// long as the nodes don't change.
+func (s *Selector) Pick(client string, nodes []*Node) *Node {
+	switch len(nodes) {
+	case 0:
+		return nil
+	case 1:
+		return nodes[0]
+	}
+
+	weights := s.policy.Weights(nodes)
+
+	var total float64
+	for _, w := range weights {
+		total += w
+	}
+
+	if total <= 0 {
+		for i := range weights {
+			weights[i] = 1
+		}
+		total = float64(len(weights))
+	}
+
+	switch {
+	case s.sticky && client != "":
+		return nodes[rendezvous(client, nodes, weights)]
+	case s.random:
+		return nodes[s.weightedRandom(weights, total)]
+	default:
+		best := 0
+		for i, w := range weights {
+			if w > weights[best] {
+				best = i
+			}
+		}
+		return nodes[best]
+	}
+}
+
+func (s *Selector) weightedRandom(weights []float64, total float64) int {
+	r := s.rand.Float64() * total
+	for i, w := range weights {
+		if r < w {
+			return i
+		}
+		r -= w
+	}
+
+	return len(weights) - 1
+}
+
+// rendezvous returns the node client hashes to, with weighted highest random
+// weight hashing: each node scores -weight/ln(hash), the highest wins.
+func rendezvous(client string, nodes []*Node, weights []float64) int {
+	best, bestScore := 0, math.Inf(-1)
+	for i, n := range nodes {
+		if weights[i] <= 0 {
+			continue
+		}
+
+		h := fnv.New64a()
+		h.Write([]byte(client))
+		h.Write([]byte(n.ID))
+
+		// map the hash to (0, 1)
+		u := (float64(h.Sum64()>>11) + 0.5) / (1 << 53)
+		if score := -weights[i] / math.Log(u); score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+
+	return best
+}
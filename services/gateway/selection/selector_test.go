@@ -0,0 +1,136 @@
+package selection
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	seerIface "github.com/taubyte/tau/core/services/seer"
+	"github.com/taubyte/tau/services/substrate/components/metrics"
+	"gotest.tools/v3/assert"
+)
+
+type testDirectory struct {
+	locations map[peer.ID]seerIface.Location
+	headroom  map[peer.ID]float64
+}
+
+func (d *testDirectory) Location(pid peer.ID) (seerIface.Location, bool) {
+	loc, ok := d.locations[pid]
+	return loc, ok
+}
+
+func (d *testDirectory) Headroom(pid peer.ID) (float64, bool) {
+	h, ok := d.headroom[pid]
+	return h, ok
+}
+
+func testNodes(n int) []*Node {
+	nodes := make([]*Node, n)
+	for i := range nodes {
+		nodes[i] = &Node{
+			ID:      peer.ID(fmt.Sprintf("node%d", i)),
+			Metrics: &metrics.Website{Cached: float32(i)},
+			Latency: time.Duration(n-i) * time.Millisecond,
+		}
+	}
+
+	return nodes
+}
+
+func TestUnknownPolicy(t *testing.T) {
+	_, err := New("closest", false, nil, nil)
+	assert.ErrorContains(t, err, "closest")
+}
+
+func TestMetrics(t *testing.T) {
+	s, err := New("", false, nil, nil)
+	assert.NilError(t, err)
+
+	nodes := []*Node{
+		{ID: "cold", Metrics: &metrics.Function{Cached: 0}},
+		{ID: "cached", Metrics: &metrics.Function{Cached: 1}},
+	}
+
+	assert.Equal(t, s.Pick("", nodes).ID, peer.ID("cached"))
+}
+
+func TestProximity(t *testing.T) {
+	paris := seerIface.Location{Latitude: 48.85, Longitude: 2.35}
+	nodes := testNodes(3)
+	// about 1000km away
+	nodes[2].Latency = 10 * time.Millisecond
+	directory := &testDirectory{locations: map[peer.ID]seerIface.Location{
+		// london
+		nodes[0].ID: {Latitude: 51.5, Longitude: -0.12},
+		// new york
+		nodes[1].ID: {Latitude: 40.71, Longitude: -74},
+	}}
+
+	s, err := New(Proximity, false, &paris, directory)
+	assert.NilError(t, err)
+	assert.Equal(t, s.Pick("", nodes), nodes[0])
+
+	// without a location, the node replying first is the closest
+	s, err = New(Proximity, false, nil, directory)
+	assert.NilError(t, err)
+	assert.Equal(t, s.Pick("", nodes), nodes[1])
+}
+
+func TestHeadroom(t *testing.T) {
+	nodes := testNodes(3)
+	directory := &testDirectory{headroom: map[peer.ID]float64{
+		nodes[0].ID: 0.2,
+		nodes[1].ID: 0.9,
+	}}
+
+	s, err := New(Headroom, false, nil, directory)
+	assert.NilError(t, err)
+	assert.Equal(t, s.Pick("", nodes), nodes[1])
+}
+
+func TestWeighted(t *testing.T) {
+	nodes := testNodes(2)
+	directory := &testDirectory{headroom: map[peer.ID]float64{
+		nodes[0].ID: 0.75,
+		nodes[1].ID: 0.25,
+	}}
+
+	s, err := New(Weighted, false, nil, directory)
+	assert.NilError(t, err)
+
+	picks := make(map[peer.ID]int)
+	for range 10000 {
+		picks[s.Pick("", nodes).ID]++
+	}
+
+	assert.Assert(t, picks[nodes[0].ID] > 7000 && picks[nodes[0].ID] < 8000, "picks: %v", picks)
+}
+
+func TestSticky(t *testing.T) {
+	nodes := testNodes(4)
+	directory := &testDirectory{headroom: map[peer.ID]float64{}}
+
+	s, err := New(Weighted, true, nil, directory)
+	assert.NilError(t, err)
+
+	clients := make(map[peer.ID]int)
+	for i := range 1000 {
+		client := fmt.Sprintf("10.0.%d.%d", i/256, i%256)
+		pick := s.Pick(client, nodes)
+		for range 5 {
+			assert.Equal(t, s.Pick(client, nodes), pick)
+		}
+		clients[pick.ID]++
+
+		// clients of the nodes left keep them
+		if pick != nodes[3] {
+			assert.Equal(t, s.Pick(client, nodes[:3]), pick)
+		}
+	}
+
+	for _, n := range nodes {
+		assert.Assert(t, clients[n.ID] > 150, "clients: %v", clients)
+	}
+}
@@ -0,0 +1,40 @@
+package selection
+
+import (
+	"math/rand/v2"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	seerIface "github.com/taubyte/tau/core/services/seer"
+	"github.com/taubyte/tau/services/substrate/components/metrics"
+)
+
+// Node is a substrate node that matched a request.
+type Node struct {
+	ID      peer.ID
+	Metrics metrics.Iface
+	// Latency is how long the node took to reply to the request fan-out.
+	Latency time.Duration
+}
+
+// Directory tells what seer knows of nodes.
+type Directory interface {
+	// Location returns where pid reported to be.
+	Location(pid peer.ID) (seerIface.Location, bool)
+	// Headroom returns the share, from 0 to 1, of cpu and memory pid has left.
+	Headroom(pid peer.ID) (float64, bool)
+}
+
+// Policy weighs the nodes matching a request: the heavier a node, the more
+// it is preferred. Weights are not negative.
+type Policy interface {
+	Weights(nodes []*Node) []float64
+}
+
+// Selector picks a node with a Policy.
+type Selector struct {
+	policy Policy
+	random bool
+	sticky bool
+	rand   *rand.Rand
+}
@@ -0,0 +1,30 @@
+package selection
+
+import (
+	"time"
+
+	"github.com/ipfs/go-log/v2"
+)
+
+// Policies
+const (
+	Metrics   = "metrics"
+	Proximity = "proximity"
+	Headroom  = "headroom"
+	Weighted  = "weighted"
+)
+
+var (
+	// UnknownHeadroom is the headroom of nodes seer has no usage of.
+	UnknownHeadroom = 0.5
+	// MetersPerLatency estimates how far a node is from how long it took to
+	// reply: light travels about 200km a millisecond in fiber, back and forth.
+	MetersPerLatency = 100_000 / float64(time.Millisecond)
+	// ProximityScale is the distance, in meters, at which a node weighs half
+	// as much as one right next to this one.
+	ProximityScale float64 = 1_000_000
+
+	DirectoryRefreshInterval = 30 * time.Second
+)
+
+var logger = log.Logger("tau.gateway.selection")
@@ -2,11 +2,15 @@ package gateway
 
 import (
 	"context"
+	"time"
 
 	"github.com/taubyte/tau/core/services/substrate"
 	"github.com/taubyte/tau/p2p/peer"
 	"github.com/taubyte/tau/p2p/streams/client"
 	http "github.com/taubyte/tau/pkg/http"
+	"github.com/taubyte/tau/services/gateway/breaker"
+	"github.com/taubyte/tau/services/gateway/cache"
+	"github.com/taubyte/tau/services/gateway/selection"
 	"github.com/taubyte/tau/services/substrate/components/metrics"
 )
 
@@ -16,6 +20,10 @@ type Gateway struct {
 	http http.Service
 
 	substrateClient substrate.ProxyClient
+	selector        *selection.Selector
+	breakers        *breaker.Breakers
+	cache           *cache.Cache
+	stopDirectory   context.CancelFunc
 
 	cluster string
 	dev     bool
@@ -24,5 +32,6 @@ type Gateway struct {
 
 type wrappedResponse struct {
 	metrics metrics.Iface
+	latency time.Duration
 	*client.Response
 }
@@ -5,5 +5,23 @@ import "time"
 var (
 	ChannelTimeout time.Duration = 100 * time.Millisecond
 	ProxyHeader                  = "X-Substrate-Peer"
-	MaxScore                     = 50
+	// TriedHeader lists the nodes the request was sent to, in order.
+	TriedHeader = "X-Substrate-Tried"
+	// HedgedHeader lists the nodes a hedged request was sent to.
+	HedgedHeader = "X-Substrate-Hedged"
+	MaxScore     = 50
+
+	// MaxAttempts is how many nodes an idempotent request is sent to, at most.
+	MaxAttempts = 3
+	// HedgeDelay is how long a GET waits for an answer before it is also sent
+	// to the next best node.
+	HedgeDelay = 500 * time.Millisecond
+	// MaxReplayBody is the size of the largest body a request can have to be
+	// sent more than once.
+	MaxReplayBody int64 = 1 << 20
+
+	// DeploymentSettle is how long nodes take to serve a deployment patrick
+	// announced: their tns caches drop what it changed as tns publishes it,
+	// and they check what they serve against tns on the next request.
+	DeploymentSettle = 30 * time.Second
 )
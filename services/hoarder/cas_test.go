@@ -0,0 +1,218 @@
+package hoarder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/taubyte/tau/core/kvdb"
+	"github.com/taubyte/tau/p2p/streams/command"
+	hoarderSpecs "github.com/taubyte/tau/pkg/specs/hoarder"
+	"github.com/taubyte/tau/utils/maps"
+)
+
+func casBody(key string, expected, value []byte) command.Body {
+	body := command.Body{
+		hoarderSpecs.BodyKVOp:  hoarderSpecs.KVCas,
+		hoarderSpecs.BodyKey:   key,
+		hoarderSpecs.BodyValue: value,
+	}
+	if expected != nil {
+		body[hoarderSpecs.BodyExpected] = expected
+	}
+	return body
+}
+
+func batchBody(ops ...command.Body) command.Body {
+	raw := make([]interface{}, len(ops))
+	for i, o := range ops {
+		raw[i] = map[string]interface{}(o)
+	}
+	return command.Body{hoarderSpecs.BodyKVOp: hoarderSpecs.KVBatch, hoarderSpecs.BodyOps: raw}
+}
+
+func checkOp(key string, expected []byte) command.Body {
+	op := command.Body{hoarderSpecs.BodyKVOp: hoarderSpecs.KVCheck, hoarderSpecs.BodyKey: key}
+	if expected != nil {
+		op[hoarderSpecs.BodyExpected] = expected
+	}
+	return op
+}
+
+func TestKVCas_Semantics(t *testing.T) {
+	srv := newTestService(t)
+	ctx := t.Context()
+	hash := "cas-test-instance"
+	handle, err := srv.load(hash)
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+
+	// Expecting absence on a fresh key → swapped.
+	resp, err := srv.kvCas(ctx, handle, hash, casBody("k", nil, []byte("v1")))
+	if err != nil {
+		t.Fatalf("cas failed: %v", err)
+	}
+	if swapped, _ := maps.Bool(resp, hoarderSpecs.BodySwapped); !swapped {
+		t.Fatal("cas on absent key did not swap")
+	}
+
+	// Expecting absence on a present key → refused, current value returned.
+	resp, err = srv.kvCas(ctx, handle, hash, casBody("k", nil, []byte("v2")))
+	if err != nil {
+		t.Fatalf("cas failed: %v", err)
+	}
+	if swapped, _ := maps.Bool(resp, hoarderSpecs.BodySwapped); swapped {
+		t.Fatal("cas expecting absence swapped a present key")
+	}
+	if v, _ := maps.ByteArray(resp, hoarderSpecs.BodyValue); string(v) != "v1" {
+		t.Fatalf("failed cas returned current %q, want v1", v)
+	}
+
+	// Stale expectation → refused.
+	resp, _ = srv.kvCas(ctx, handle, hash, casBody("k", []byte("v0"), []byte("v2")))
+	if swapped, _ := maps.Bool(resp, hoarderSpecs.BodySwapped); swapped {
+		t.Fatal("cas with stale expectation swapped")
+	}
+
+	// Matching expectation → swapped.
+	resp, _ = srv.kvCas(ctx, handle, hash, casBody("k", []byte("v1"), []byte("v2")))
+	if swapped, _ := maps.Bool(resp, hoarderSpecs.BodySwapped); !swapped {
+		t.Fatal("cas with matching expectation did not swap")
+	}
+
+	got, _ := srv.kvGet(ctx, handle, command.Body{hoarderSpecs.BodyKey: "k"})
+	if v, _ := maps.ByteArray(got, hoarderSpecs.BodyValue); string(v) != "v2" {
+		t.Fatalf("got %q after swap, want v2", v)
+	}
+}
+
+// TestKVCas_Counter runs concurrent read-modify-write increments through cas;
+// every successful swap must be counted exactly once.
+func TestKVCas_Counter(t *testing.T) {
+	srv := newTestService(t)
+	ctx := t.Context()
+	hash := "cas-counter-instance"
+	handle, err := srv.load(hash)
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+
+	const workers, incs = 8, 16
+	var swaps atomic.Int64
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < incs; i++ {
+				for {
+					var expected []byte
+					n := 0
+					if got, _ := srv.kvGet(ctx, handle, command.Body{hoarderSpecs.BodyKey: "counter"}); got != nil {
+						if v, err := maps.ByteArray(got, hoarderSpecs.BodyValue); err == nil {
+							expected = v
+							fmt.Sscanf(string(v), "%d", &n)
+						}
+					}
+					resp, err := srv.kvCas(ctx, handle, hash, casBody("counter", expected, []byte(fmt.Sprint(n+1))))
+					if err != nil {
+						t.Errorf("cas failed: %v", err)
+						return
+					}
+					if swapped, _ := maps.Bool(resp, hoarderSpecs.BodySwapped); swapped {
+						swaps.Add(1)
+						break
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	got, _ := srv.kvGet(ctx, handle, command.Body{hoarderSpecs.BodyKey: "counter"})
+	v, _ := maps.ByteArray(got, hoarderSpecs.BodyValue)
+	if want := fmt.Sprint(workers * incs); string(v) != want || swaps.Load() != workers*incs {
+		t.Fatalf("counter = %q after %d swaps, want %s", v, swaps.Load(), want)
+	}
+}
+
+func TestKVBatch_Checks(t *testing.T) {
+	srv := newTestService(t)
+	ctx := t.Context()
+	hash := "batch-check-instance"
+	handle, err := srv.load(hash)
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+
+	if _, err := srv.kvPut(ctx, handle, hash, putBody("stock", []byte("3"))); err != nil {
+		t.Fatalf("put failed: %v", err)
+	}
+
+	// A failed check rejects the whole batch.
+	resp, err := srv.kvBatch(ctx, handle, hash, batchBody(
+		checkOp("stock", []byte("2")),
+		putBody("stock", []byte("1")),
+		putBody("order", []byte("x")),
+	))
+	if err != nil {
+		t.Fatalf("batch failed: %v", err)
+	}
+	if code := maps.TryString(resp, hoarderSpecs.BodyCode); code != hoarderSpecs.CodeConflict {
+		t.Fatalf("code = %q, want conflict", code)
+	}
+	if key := maps.TryString(resp, hoarderSpecs.BodyKey); key != "stock" {
+		t.Fatalf("conflict key = %q, want stock", key)
+	}
+	if got, _ := srv.kvGet(ctx, handle, command.Body{hoarderSpecs.BodyKey: "order"}); maps.TryString(got, hoarderSpecs.BodyCode) != hoarderSpecs.CodeNotFound {
+		t.Fatal("rejected batch wrote a key")
+	}
+
+	// Passing checks (value match + absence) commit every write.
+	resp, err = srv.kvBatch(ctx, handle, hash, batchBody(
+		checkOp("stock", []byte("3")),
+		checkOp("order", nil),
+		putBody("stock", []byte("2")),
+		putBody("order", []byte("x")),
+	))
+	if err != nil || maps.TryString(resp, hoarderSpecs.BodyCode) != "" {
+		t.Fatalf("batch with passing checks refused: %v %v", resp, err)
+	}
+	got, _ := srv.kvGet(ctx, handle, command.Body{hoarderSpecs.BodyKey: "stock"})
+	if v, _ := maps.ByteArray(got, hoarderSpecs.BodyValue); string(v) != "2" {
+		t.Fatalf("stock = %q, want 2", v)
+	}
+}
+
+func TestWithoutChecks(t *testing.T) {
+	body := batchBody(checkOp("a", nil), putBody("a", []byte("1")))
+	repl := withoutChecks(body, body[hoarderSpecs.BodyOps].([]interface{}))
+	ops := repl[hoarderSpecs.BodyOps].([]interface{})
+	if len(ops) != 1 || maps.TryString(maps.SafeInterfaceToStringKeys(ops[0]), hoarderSpecs.BodyKVOp) != hoarderSpecs.KVPut {
+		t.Fatalf("checks not stripped: %v", ops)
+	}
+	if len(body[hoarderSpecs.BodyOps].([]interface{})) != 2 {
+		t.Fatal("withoutChecks mutated the original body")
+	}
+}
+
+// failingGet is a kvdb whose reads fail for a reason other than a missing key.
+type failingGet struct {
+	kvdb.KVDB
+}
+
+func (failingGet) Get(context.Context, string) ([]byte, error) {
+	return nil, errors.New("datastore unavailable")
+}
+
+func TestExpectationHolds_ReadError(t *testing.T) {
+	for _, body := range []command.Body{casBody("k", nil, []byte("v")), casBody("k", []byte("v0"), []byte("v"))} {
+		if _, ok, err := expectationHolds(t.Context(), failingGet{}, "k", body); err == nil || ok {
+			t.Fatalf("read error gave (%v, %v), want a failed expectation", ok, err)
+		}
+	}
+}
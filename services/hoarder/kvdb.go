@@ -1,10 +1,14 @@
 package hoarder
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/fxamacker/cbor/v2"
+	ds "github.com/ipfs/go-datastore"
 	peerCore "github.com/libp2p/go-libp2p/core/peer"
 	"github.com/taubyte/tau/core/kvdb"
 	hoarderIface "github.com/taubyte/tau/core/services/hoarder"
@@ -41,6 +45,8 @@ func (srv *Service) kvdbHandler(ctx context.Context, conn streams.Connection, bo
 		resp, err = srv.kvPut(ctx, handle, hash, body)
 	case hoarderSpecs.KVPutNx:
 		resp, err = srv.kvPutNx(ctx, handle, hash, body)
+	case hoarderSpecs.KVCas:
+		resp, err = srv.kvCas(ctx, handle, hash, body)
 	case hoarderSpecs.KVDelete:
 		resp, err = srv.kvDelete(ctx, handle, hash, body)
 	case hoarderSpecs.KVList:
@@ -57,11 +63,73 @@ func (srv *Service) kvdbHandler(ctx context.Context, conn streams.Connection, bo
 	if err != nil {
 		return nil, err
 	}
+	srv.publishChanges(ctx, hash, op, body, resp)
 	// Tell the client which node served it so it can pin for read-your-writes.
 	resp[hoarderSpecs.BodyServedBy] = srv.node.ID().String()
 	return resp, nil
 }
 
+// publishChanges announces a served write on the instance's watch topic. Only
+// the serving hoarder publishes; replication pushes and refused or no-op writes
+// (a conflict, an existing putnx key, a failed cas) are silent.
+func (srv *Service) publishChanges(ctx context.Context, hash, op string, body command.Body, resp cr.Response) {
+	if nb, _ := maps.Bool(body, hoarderSpecs.BodyNoBarrier); nb || maps.TryString(resp, hoarderSpecs.BodyCode) != "" {
+		return
+	}
+
+	events := changesOf(op, body, resp)
+	if len(events) == 0 {
+		return
+	}
+
+	b, err := cbor.Marshal(events)
+	if err != nil {
+		return
+	}
+	if err := srv.node.PubSubPublish(ctx, hoarderSpecs.WatchTopic(hash), b); err != nil && ctx.Err() == nil {
+		logger.Warnf("publishing changes of %s failed with: %s", hash, err)
+	}
+}
+
+// changesOf lists the changes a served write made, in order.
+func changesOf(op string, body command.Body, resp cr.Response) []kvdb.Event {
+	var events []kvdb.Event
+	switch op {
+	case hoarderSpecs.KVPut:
+		events = append(events, changeOf(hoarderSpecs.KVPut, body))
+	case hoarderSpecs.KVPutNx:
+		if existed, _ := maps.Bool(resp, hoarderSpecs.BodyExisted); !existed {
+			events = append(events, changeOf(hoarderSpecs.KVPut, body))
+		}
+	case hoarderSpecs.KVCas:
+		if swapped, _ := maps.Bool(resp, hoarderSpecs.BodySwapped); swapped {
+			events = append(events, changeOf(hoarderSpecs.KVPut, body))
+		}
+	case hoarderSpecs.KVDelete:
+		events = append(events, changeOf(hoarderSpecs.KVDelete, body))
+	case hoarderSpecs.KVBatch:
+		rawOps, _ := body[hoarderSpecs.BodyOps].([]interface{})
+		for _, ro := range rawOps {
+			opMap := maps.SafeInterfaceToStringKeys(ro)
+			if kvop := maps.TryString(opMap, hoarderSpecs.BodyKVOp); kvop != hoarderSpecs.KVCheck {
+				events = append(events, changeOf(kvop, opMap))
+			}
+		}
+	}
+	return events
+}
+
+func changeOf(op string, body map[string]interface{}) kvdb.Event {
+	ev := kvdb.Event{Key: ds.NewKey(maps.TryString(body, hoarderSpecs.BodyKey)).String()}
+	if op == hoarderSpecs.KVDelete {
+		ev.Type = kvdb.EventDelete
+	} else {
+		ev.Type = kvdb.EventPut
+		ev.Value, _ = maps.ByteArray(body, hoarderSpecs.BodyValue)
+	}
+	return ev
+}
+
 // resolveInstance decides this node's role for the requested instance using
 // deterministic HRW placement — no auction:
 //   - the resource is unknown → record its meta and notify the fleet;
@@ -194,9 +262,15 @@ func (srv *Service) kvPut(ctx context.Context, handle kvdb.KVDB, hash string, bo
 	}
 	// Local commit under the instance write lock so putnx's check-and-write is
 	// atomic against it; the replication barrier runs outside the lock.
+	expiry, expires := putExpiry(body)
 	mu := srv.writeLock(hash)
 	mu.Lock()
-	err = handle.Put(ctx, key, value)
+	if expires {
+		// a replica hearing of the write after its expiry still stores it, hidden
+		err = handle.PutWithTTL(ctx, key, value, max(time.Until(expiry), time.Nanosecond))
+	} else {
+		err = handle.Put(ctx, key, value)
+	}
 	mu.Unlock()
 	if err != nil {
 		return nil, fmt.Errorf("put failed with: %w", err)
@@ -205,6 +279,24 @@ func (srv *Service) kvPut(ctx context.Context, handle kvdb.KVDB, hash string, bo
 	return cr.Response{}, nil
 }
 
+// putExpiry returns when a put expires. A put with a BodyTTL is stamped here,
+// on the hoarder serving it, and the body carries the absolute BodyExpiry to
+// the co-owners it is replicated to.
+func putExpiry(body command.Body) (time.Time, bool) {
+	if expiry, err := maps.Int(body, hoarderSpecs.BodyExpiry); err == nil && expiry > 0 {
+		return time.Unix(0, int64(expiry)), true
+	}
+
+	ttl, err := maps.Int(body, hoarderSpecs.BodyTTL)
+	if err != nil || ttl <= 0 {
+		return time.Time{}, false
+	}
+
+	expiry := time.Now().Add(time.Duration(ttl) * time.Millisecond)
+	body[hoarderSpecs.BodyExpiry] = expiry.UnixNano()
+	return expiry, true
+}
+
 // kvPutNx writes the key only if it is absent, atomically against concurrent
 // writes on this node (put/delete/batch share the same per-instance write
 // lock). Used when older data is replayed into a live instance: a value written
@@ -241,6 +333,79 @@ func (srv *Service) kvPutNx(ctx context.Context, handle kvdb.KVDB, hash string,
 	return cr.Response{}, nil
 }
 
+// kvCas writes the key only if its current value equals the expected one (an
+// absent expected value means the key must be absent), atomically against
+// concurrent writes on this node through the shared per-instance write lock.
+// The swap is decided here only: it is replicated as a plain put so a lagging
+// co-owner converges on the winning value instead of re-evaluating the
+// comparison against its own state. Response carries swapped, and the current
+// value when the comparison failed so the caller can retry without a re-read.
+func (srv *Service) kvCas(ctx context.Context, handle kvdb.KVDB, hash string, body command.Body) (cr.Response, error) {
+	key, err := maps.String(body, hoarderSpecs.BodyKey)
+	if err != nil {
+		return nil, err
+	}
+	value, err := maps.ByteArray(body, hoarderSpecs.BodyValue)
+	if err != nil {
+		return nil, fmt.Errorf("missing value: %w", err)
+	}
+	if err := srv.admitWrite(maps.TryString(body, hoarderSpecs.BodyProject), len(value)); err != nil {
+		return cr.Response{hoarderSpecs.BodyCode: hoarderSpecs.CodeOverCapacity}, nil
+	}
+
+	mu := srv.writeLock(hash)
+	mu.Lock()
+	cur, ok, err := expectationHolds(ctx, handle, key, body)
+	if err != nil {
+		mu.Unlock()
+		return nil, fmt.Errorf("cas failed with: %w", err)
+	}
+	if !ok {
+		mu.Unlock()
+		resp := cr.Response{hoarderSpecs.BodySwapped: false}
+		if cur != nil {
+			resp[hoarderSpecs.BodyValue] = cur
+		}
+		return resp, nil
+	}
+	err = handle.Put(ctx, key, value)
+	mu.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("cas failed with: %w", err)
+	}
+
+	repl := command.Body{}
+	for k, v := range body {
+		repl[k] = v
+	}
+	repl[hoarderSpecs.BodyKVOp] = hoarderSpecs.KVPut
+	delete(repl, hoarderSpecs.BodyExpected)
+	srv.replicateWrite(ctx, hash, repl)
+	return cr.Response{hoarderSpecs.BodySwapped: true}, nil
+}
+
+// expectationHolds compares the key's current value against body's expected
+// value; a missing BodyExpected expects the key to be absent. It returns the
+// current value (nil when absent). Only a missing key counts as absent: any
+// other read error is returned, so the write it guards fails. Callers hold the
+// instance write lock.
+func expectationHolds(ctx context.Context, handle kvdb.KVDB, key string, body command.Body) ([]byte, bool, error) {
+	cur, err := handle.Get(ctx, key)
+	if errors.Is(err, ds.ErrNotFound) {
+		cur = nil
+	} else if err != nil {
+		return nil, false, fmt.Errorf("reading `%s` failed with: %w", key, err)
+	}
+	if _, ok := body[hoarderSpecs.BodyExpected]; !ok {
+		return cur, cur == nil, nil
+	}
+	expected, err := maps.ByteArray(body, hoarderSpecs.BodyExpected)
+	if err != nil || cur == nil {
+		return cur, false, nil
+	}
+	return cur, bytes.Equal(cur, expected), nil
+}
+
 func (srv *Service) kvDelete(ctx context.Context, handle kvdb.KVDB, hash string, body command.Body) (cr.Response, error) {
 	key, err := maps.String(body, hoarderSpecs.BodyKey)
 	if err != nil {
@@ -395,13 +560,34 @@ func (srv *Service) kvBatch(ctx context.Context, handle kvdb.KVDB, hash string,
 	if resp != nil || err != nil {
 		return resp, err
 	}
-	srv.replicateWrite(ctx, hash, body)
+	srv.replicateWrite(ctx, hash, withoutChecks(body, rawOps))
 	return cr.Response{}, nil
 }
 
+// withoutChecks strips check ops from a committed batch before replication:
+// the checks were decided on this node, so co-owners apply the writes as-is.
+func withoutChecks(body command.Body, rawOps []interface{}) command.Body {
+	ops := make([]interface{}, 0, len(rawOps))
+	for _, ro := range rawOps {
+		if maps.TryString(maps.SafeInterfaceToStringKeys(ro), hoarderSpecs.BodyKVOp) != hoarderSpecs.KVCheck {
+			ops = append(ops, ro)
+		}
+	}
+	if len(ops) == len(rawOps) {
+		return body
+	}
+
+	repl := command.Body{}
+	for k, v := range body {
+		repl[k] = v
+	}
+	repl[hoarderSpecs.BodyOps] = ops
+	return repl
+}
+
 // applyBatch builds and commits the grouped ops. Runs under the instance write
-// lock. A non-nil response is a typed refusal (over-capacity); (nil, nil) is
-// success.
+// lock, so check ops read the state the batch commits on top of. A non-nil
+// response is a typed refusal (over-capacity, conflict); (nil, nil) is success.
 func (srv *Service) applyBatch(ctx context.Context, handle kvdb.KVDB, body command.Body, rawOps []interface{}) (cr.Response, error) {
 	batch, err := handle.Batch(ctx)
 	if err != nil {
@@ -434,6 +620,14 @@ func (srv *Service) applyBatch(ctx context.Context, handle kvdb.KVDB, body comma
 			if err := batch.Delete(key); err != nil {
 				return nil, err
 			}
+		case hoarderSpecs.KVCheck:
+			_, ok, err := expectationHolds(ctx, handle, key, opMap)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				return cr.Response{hoarderSpecs.BodyCode: hoarderSpecs.CodeConflict, hoarderSpecs.BodyKey: key}, nil
+			}
 		default:
 			return nil, fmt.Errorf("unsupported batch op %q", kvop)
 		}
@@ -9,7 +9,6 @@ import (
 	"github.com/fxamacker/cbor/v2"
 	hoarderIface "github.com/taubyte/tau/core/services/hoarder"
 	hoarderSpecs "github.com/taubyte/tau/pkg/specs/hoarder"
-	mh "github.com/taubyte/tau/utils/multihash"
 )
 
 // RegistryMeta is the durable placement record for one database/storage
@@ -41,7 +40,7 @@ type StashMeta struct {
 // and to the DHT discovery namespace — one string names the CRDT, the registry
 // entry, and the rendezvous.
 func instanceHash(m hoarderIface.MetaData) string {
-	return mh.Hash(m.ProjectId + m.ApplicationId + m.Match)
+	return hoarderSpecs.InstanceHash(m.ProjectId, m.ApplicationId, m.Match)
 }
 
 // --- resource meta ---
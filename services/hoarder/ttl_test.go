@@ -0,0 +1,35 @@
+package hoarder
+
+import (
+	"testing"
+	"time"
+
+	"github.com/taubyte/tau/p2p/streams/command"
+	hoarderSpecs "github.com/taubyte/tau/pkg/specs/hoarder"
+)
+
+func TestPutExpiry(t *testing.T) {
+	body := putBody("k", []byte("v"))
+	if _, ok := putExpiry(body); ok {
+		t.Fatal("a put without ttl expired")
+	}
+
+	// The serving hoarder stamps the expiry into the body it replicates.
+	body[hoarderSpecs.BodyTTL] = int64(time.Minute / time.Millisecond)
+	before := time.Now()
+	expiry, ok := putExpiry(body)
+	if !ok || expiry.Before(before.Add(time.Minute)) || expiry.After(time.Now().Add(time.Minute)) {
+		t.Fatalf("expiry %s is not a minute after the put", expiry)
+	}
+
+	// A co-owner expires the key at the stamped time, however late it hears of it.
+	repl := command.Body{}
+	for k, v := range body {
+		repl[k] = v
+	}
+	repl[hoarderSpecs.BodyTTL] = int64(time.Hour / time.Millisecond)
+	replExpiry, ok := putExpiry(repl)
+	if !ok || !replExpiry.Equal(expiry) {
+		t.Fatalf("replica expiry %s, want %s", replExpiry, expiry)
+	}
+}
@@ -0,0 +1,38 @@
+package hoarder
+
+import (
+	"testing"
+
+	"github.com/taubyte/tau/core/kvdb"
+	"github.com/taubyte/tau/p2p/streams/command"
+	cr "github.com/taubyte/tau/p2p/streams/command/response"
+	hoarderSpecs "github.com/taubyte/tau/pkg/specs/hoarder"
+)
+
+func TestChangesOf(t *testing.T) {
+	put := command.Body{hoarderSpecs.BodyKey: "a", hoarderSpecs.BodyValue: []byte("1")}
+
+	if evs := changesOf(hoarderSpecs.KVPut, put, cr.Response{}); len(evs) != 1 ||
+		evs[0].Type != kvdb.EventPut || evs[0].Key != "/a" || string(evs[0].Value) != "1" {
+		t.Fatalf("put: %+v", evs)
+	}
+	if evs := changesOf(hoarderSpecs.KVPutNx, put, cr.Response{hoarderSpecs.BodyExisted: true}); len(evs) != 0 {
+		t.Fatalf("putnx on existing key reported %+v", evs)
+	}
+	if evs := changesOf(hoarderSpecs.KVCas, put, cr.Response{hoarderSpecs.BodySwapped: false}); len(evs) != 0 {
+		t.Fatalf("failed cas reported %+v", evs)
+	}
+	if evs := changesOf(hoarderSpecs.KVGet, put, cr.Response{}); len(evs) != 0 {
+		t.Fatalf("read reported %+v", evs)
+	}
+
+	batch := command.Body{hoarderSpecs.BodyOps: []interface{}{
+		map[string]interface{}{hoarderSpecs.BodyKVOp: hoarderSpecs.KVCheck, hoarderSpecs.BodyKey: "a"},
+		map[string]interface{}{hoarderSpecs.BodyKVOp: hoarderSpecs.KVPut, hoarderSpecs.BodyKey: "b", hoarderSpecs.BodyValue: []byte("2")},
+		map[string]interface{}{hoarderSpecs.BodyKVOp: hoarderSpecs.KVDelete, hoarderSpecs.BodyKey: "c"},
+	}}
+	evs := changesOf(hoarderSpecs.KVBatch, batch, cr.Response{})
+	if len(evs) != 2 || evs[0].Key != "/b" || evs[0].Type != kvdb.EventPut || evs[1].Key != "/c" || evs[1].Type != kvdb.EventDelete {
+		t.Fatalf("batch: %+v", evs)
+	}
+}
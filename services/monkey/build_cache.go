@@ -0,0 +1,95 @@
+package monkey
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"time"
+
+	"github.com/taubyte/tau/pkg/builder/cache"
+	"github.com/taubyte/tau/pkg/specs/common"
+)
+
+const (
+	buildCacheCleanInterval = time.Hour
+	// buildCacheMaxAge is how long a cache no build used is kept.
+	buildCacheMaxAge = 7 * 24 * time.Hour
+)
+
+// newBuildCache sets up the dependency cache builds share on this node, and
+// with other nodes when the config says so.
+func (srv *Service) newBuildCache() (*cache.Cache, error) {
+	cfg := srv.config.BuildCache()
+	if cfg.Disabled {
+		return nil, nil
+	}
+
+	var options []cache.Option
+	if cfg.Share {
+		options = append(options, cache.Share(buildCacheStore{srv}))
+	}
+
+	c, err := cache.New(path.Join(srv.config.Root(), "storage", srv.config.Shape(), "monkey", "build-cache"), options...)
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		for {
+			select {
+			case <-srv.ctx.Done():
+				return
+			case <-time.After(buildCacheCleanInterval):
+				if err := c.Clean(buildCacheMaxAge); err != nil {
+					logger.Errorf("cleaning build cache failed with: %s", err.Error())
+				}
+			}
+		}
+	}()
+
+	return c, nil
+}
+
+// buildCacheStore shares build caches through hoarder: a cache is stashed like
+// any build, and TNS maps its key to the cid.
+type buildCacheStore struct {
+	srv *Service
+}
+
+func buildCachePath(key string) []string {
+	return []string{"builds", "cache", key}
+}
+
+func (s buildCacheStore) Get(key string) (io.ReadCloser, error) {
+	obj, err := s.srv.tnsClient.Fetch(common.NewTnsPath(buildCachePath(key)))
+	if err != nil {
+		return nil, fmt.Errorf("fetching build cache `%s` failed with: %w", key, err)
+	}
+
+	cid, ok := obj.Interface().(string)
+	if !ok || cid == "" {
+		return nil, errors.New("build cache not shared")
+	}
+
+	return s.srv.node.GetFile(s.srv.ctx, cid)
+}
+
+func (s buildCacheStore) Put(key string, r io.Reader) error {
+	cid, err := s.srv.node.AddFile(r)
+	if err != nil {
+		return fmt.Errorf("adding build cache to node failed with: %w", err)
+	}
+
+	f, err := s.srv.node.GetFile(s.srv.ctx, cid)
+	if err != nil {
+		return fmt.Errorf("re-opening %s failed with: %w", cid, err)
+	}
+	defer f.Close()
+
+	if err = s.srv.hoarderClient.Stash(cid, f); err != nil {
+		return fmt.Errorf("stashing build cache failed with: %w", err)
+	}
+
+	return s.srv.tnsClient.Push(buildCachePath(key), cid)
+}
@@ -52,10 +52,11 @@ var (
 
 func (m *Monkey) tryGetGitRepo(
 	ac auth.Client,
+	provider string,
 	repoID int,
-) (gitRepo auth.GithubRepository, err error) {
+) (gitRepo auth.GitRepository, err error) {
 	for i := 0; i < GetGitRepoMaxRetries; i++ {
-		gitRepo, err = ac.Repositories().Github().Get(repoID)
+		gitRepo, err = ac.Repositories().Provider(provider).Get(repoID)
 		if err != nil {
 			return gitRepo, fmt.Errorf("fetching repository %d from auth failed with %w", repoID, err)
 		}
@@ -36,12 +36,17 @@ func (m *Monkey) RunJob() (err error) {
 	var p *auth.Project
 	repoType := repositorytype.UnknownRepository
 
-	gitRepo, err := m.tryGetGitRepo(ac, repo.ID)
+	gitRepo, err := m.tryGetGitRepo(ac, repo.GitProvider(), repo.ID)
 	if err != nil {
 		return fmt.Errorf("run job failed during fetching with %w", err)
 	}
 
-	projectId = gitRepo.Project()
+	// Build under the project patrick scheduled the job for; jobs queued
+	// before patrick recorded it fall back on the repository's.
+	projectId = m.Job.Project
+	if projectId == "" {
+		projectId = gitRepo.Project()
+	}
 	if projectId != "" {
 		p = ac.Projects().Get(projectId)
 		if p == nil {
@@ -98,6 +103,7 @@ func (m *Monkey) RunJob() (err error) {
 		DVPublicKey:           m.Service.dvPublicKey,
 		GeneratedDomainRegExp: m.generatedDomainRegExp,
 		NetworkFqdn:           m.Service.config.NetworkFqdn(),
+		BuildCache:            m.Service.buildCache,
 	}
 
 	c.Context(m.ctx)
@@ -105,9 +111,10 @@ func (m *Monkey) RunJob() (err error) {
 	if repoType == repositorytype.CodeRepository {
 		c.ConfigRepoId = p.Git.Config.Id()
 
-		configRepo, err := ac.Repositories().Github().Get(p.Git.Config.Id())
+		// A project's config and code repositories live on the same provider.
+		configRepo, err := ac.Repositories().Provider(m.Job.Meta.Repository.GitProvider()).Get(p.Git.Config.Id())
 		if err != nil {
-			return fmt.Errorf("auth github get failed with: %w", err)
+			return fmt.Errorf("auth %s get failed with: %w", m.Job.Meta.Repository.GitProvider(), err)
 		}
 		c.ConfigPrivateKey = configRepo.PrivateKey()
 	}
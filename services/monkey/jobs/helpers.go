@@ -10,12 +10,24 @@ import (
 	"strings"
 	"time"
 
+	build "github.com/taubyte/tau/pkg/builder"
 	"github.com/taubyte/tau/pkg/git"
 	specs "github.com/taubyte/tau/pkg/specs/common"
 	"github.com/taubyte/tau/pkg/specs/methods"
 	"github.com/taubyte/tau/utils/maps"
 )
 
+// builderOptions are the options every build of the job runs with. Builds
+// share their dependency cache with the builds of the same project only.
+func (c Context) builderOptions() []build.Option {
+	options := []build.Option{build.Dev(c.Monkey.Dev())}
+	if c.BuildCache != nil && c.ProjectID != "" {
+		options = append(options, build.Cache(c.BuildCache, c.ProjectID))
+	}
+
+	return options
+}
+
 func (c Context) storeLogFile(file *os.File) (string, error) {
 	file.Seek(0, io.SeekStart)
 	cid, err := c.Node.AddFile(file)
@@ -34,7 +46,7 @@ func (c Context) storeLogFile(file *os.File) (string, error) {
 }
 
 func (c Context) fetchConfigSshUrl() (sshString string, err error) {
-	tnsPath := specs.NewTnsPath([]string{"resolve", "repo", "github", strconv.Itoa(c.ConfigRepoId)})
+	tnsPath := specs.NewTnsPath([]string{"resolve", "repo", c.Job.Meta.Repository.GitProvider(), strconv.Itoa(c.ConfigRepoId)})
 	tnsObj, err := c.Tns.Fetch(tnsPath)
 	// TODO: This should return
 	if err != nil {
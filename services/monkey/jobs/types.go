@@ -11,6 +11,7 @@ import (
 	"github.com/taubyte/tau/core/services/patrick"
 	"github.com/taubyte/tau/core/services/tns"
 	"github.com/taubyte/tau/p2p/peer"
+	"github.com/taubyte/tau/pkg/builder/cache"
 	ci "github.com/taubyte/tau/pkg/containers"
 )
 
@@ -42,6 +43,9 @@ type Context struct {
 	// NetworkFqdn is the cloud FQDN this monkey is compiling for. Empty in
 	// dream/local; WithCloud is a no-op when empty.
 	NetworkFqdn string
+
+	// BuildCache keeps the dependencies builds download; nil when disabled.
+	BuildCache *cache.Cache
 }
 
 type Op struct {
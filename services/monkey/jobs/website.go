@@ -10,7 +10,7 @@ import (
 )
 
 func (w website) handle() (err error) {
-	builder, err := build.New(w.ctx, w.LogFile, w.WorkDir, build.Dev(w.Monkey.Dev()))
+	builder, err := build.New(w.ctx, w.LogFile, w.WorkDir, w.builderOptions()...)
 	if err != nil {
 		return fmt.Errorf("creating new builder for git website repo `%d` failed with: %w", w.Job.Meta.Repository.ID, err)
 	}
@@ -39,6 +39,10 @@ func (w website) handle() (err error) {
 		return fmt.Errorf("building website failed with: %w", err)
 	}
 
+	if err = precompressWebsite(asset.OutDir()); err != nil {
+		return fmt.Errorf("precompressing website assets failed with: %w", err)
+	}
+
 	if compressedAsset, err = asset.Compress(builders.Website); err != nil {
 		return fmt.Errorf("compressing build files failed with: %w", err)
 	}
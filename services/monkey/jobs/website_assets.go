@@ -0,0 +1,254 @@
+package jobs
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+	websiteSpec "github.com/taubyte/tau/pkg/specs/builders/website"
+)
+
+// compressible are the extensions of the website files worth precompressing;
+// images, fonts and archives are compressed already.
+var compressible = map[string]bool{
+	".html": true, ".htm": true, ".css": true, ".js": true, ".mjs": true,
+	".json": true, ".map": true, ".svg": true, ".xml": true, ".txt": true,
+	".wasm": true, ".ico": true, ".webmanifest": true,
+}
+
+// minCompressSize is the size under which a file is not precompressed: the
+// saving would not pay for the Content-Encoding round trip.
+const minCompressSize = 1024
+
+// hashToken matches a content hash in a file name: at least 8 hex characters.
+var hashToken = regexp.MustCompile(`^[0-9a-f]{8,}$`)
+
+// buildManifests are where build tools list the files they output, relative
+// to the output directory: Vite, and webpack through create-react-app.
+var buildManifests = []string{".vite/manifest.json", "asset-manifest.json"}
+
+// precompressWebsite writes, next to each compressible file of the website
+// built in outDir, its brotli and gzip variants when they are smaller, and the
+// manifest of the build with the content hash of every file.
+//
+// outDir is written by the build: only regular files are considered, and any
+// existing file in the way of a variant is removed rather than written through.
+func precompressWebsite(outDir string) error {
+	listed, err := readBuildManifests(outDir)
+	if err != nil {
+		return err
+	}
+
+	manifest := websiteSpec.Manifest{Files: make(map[string]websiteSpec.Asset)}
+	err = filepath.WalkDir(outDir, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(outDir, file)
+		if err != nil {
+			return err
+		}
+
+		name := "/" + filepath.ToSlash(rel)
+		if name == "/"+websiteSpec.ManifestFile || isVariant(name) {
+			return nil
+		}
+
+		asset, err := precompressFile(file)
+		if err != nil {
+			return fmt.Errorf("precompressing `%s` failed with: %w", name, err)
+		}
+
+		asset.Immutable = fingerprinted(name, listed)
+		manifest.Files[name] = asset
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("marshalling asset manifest failed with: %w", err)
+	}
+
+	return writeNew(filepath.Join(outDir, websiteSpec.ManifestFile), func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+}
+
+// fingerprinted reports whether name carries a content hash, like
+// `main.3f2a9c1b.js`: a dot or dash separated token of hex characters mixing
+// digits and letters, so a date stamp like `report-20240115.pdf` is not one.
+// When the build tool listed its output, name must be listed too.
+func fingerprinted(name string, listed map[string]bool) bool {
+	if listed != nil && !listed[name] {
+		return false
+	}
+
+	tokens := strings.FieldsFunc(path.Base(name), func(r rune) bool { return r == '.' || r == '-' })
+	if len(tokens) < 3 {
+		return false
+	}
+
+	// the hash sits between the name and the extension
+	for _, token := range tokens[1 : len(tokens)-1] {
+		if hashToken.MatchString(token) && strings.ContainsAny(token, "0123456789") && strings.ContainsAny(token, "abcdef") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// readBuildManifests returns the names of the files the build tool listed in
+// its manifests, as rooted paths; nil when it left none.
+func readBuildManifests(outDir string) (map[string]bool, error) {
+	var listed map[string]bool
+	for _, file := range buildManifests {
+		file = filepath.Join(outDir, file)
+		if info, err := os.Lstat(file); err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		var manifest any
+		if err = json.Unmarshal(data, &manifest); err != nil {
+			return nil, fmt.Errorf("reading build manifest `%s` failed with: %w", file, err)
+		}
+
+		if listed == nil {
+			listed = make(map[string]bool)
+		}
+		listPaths(manifest, listed)
+	}
+
+	return listed, nil
+}
+
+// listPaths adds every string in a decoded manifest to listed, as a rooted
+// path; manifests nest the files they list differently, but only as strings.
+func listPaths(v any, listed map[string]bool) {
+	switch v := v.(type) {
+	case string:
+		if !strings.Contains(v, "://") {
+			listed[path.Clean("/"+v)] = true
+		}
+	case []any:
+		for _, e := range v {
+			listPaths(e, listed)
+		}
+	case map[string]any:
+		for _, e := range v {
+			listPaths(e, listed)
+		}
+	}
+}
+
+func isVariant(name string) bool {
+	for _, enc := range websiteSpec.Encodings {
+		if strings.HasSuffix(name, enc.Ext) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func precompressFile(file string) (asset websiteSpec.Asset, err error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return
+	}
+
+	sum := sha256.Sum256(data)
+	asset.Hash = hex.EncodeToString(sum[:])
+	asset.Size = int64(len(data))
+
+	if len(data) < minCompressSize || !compressible[strings.ToLower(path.Ext(file))] {
+		return
+	}
+
+	for _, enc := range websiteSpec.Encodings {
+		var compressed bytes.Buffer
+		if err = compress(&compressed, enc.Name, data); err != nil {
+			return
+		}
+
+		// keep the variant only if it saves at least a tenth of the size
+		if compressed.Len() > len(data)*9/10 {
+			continue
+		}
+
+		if err = writeNew(file+enc.Ext, func(w io.Writer) error {
+			_, err := compressed.WriteTo(w)
+			return err
+		}); err != nil {
+			return
+		}
+
+		asset.Encodings = append(asset.Encodings, enc.Name)
+	}
+
+	return
+}
+
+func compress(w io.Writer, encoding string, data []byte) error {
+	var cw io.WriteCloser
+	switch encoding {
+	case websiteSpec.Brotli:
+		cw = brotli.NewWriterLevel(w, brotli.BestCompression)
+	case websiteSpec.Gzip:
+		cw, _ = gzip.NewWriterLevel(w, gzip.BestCompression)
+	default:
+		return fmt.Errorf("encoding `%s` not supported", encoding)
+	}
+
+	if _, err := cw.Write(data); err != nil {
+		cw.Close()
+		return err
+	}
+
+	return cw.Close()
+}
+
+// writeNew creates file, removing whatever was there, without following links.
+func writeNew(file string, write func(w io.Writer) error) error {
+	if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	f, err := os.OpenFile(file, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	if err = write(f); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
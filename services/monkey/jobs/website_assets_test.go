@@ -0,0 +1,115 @@
+package jobs
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	websiteSpec "github.com/taubyte/tau/pkg/specs/builders/website"
+	"gotest.tools/v3/assert"
+)
+
+func TestPrecompressWebsite(t *testing.T) {
+	outDir := t.TempDir()
+	page := strings.Repeat("<p>hello world</p>\n", 200)
+
+	assert.NilError(t, os.MkdirAll(filepath.Join(outDir, "assets"), 0755))
+	assert.NilError(t, os.WriteFile(filepath.Join(outDir, "index.html"), []byte(page), 0644))
+	assert.NilError(t, os.WriteFile(filepath.Join(outDir, "assets", "main.3f2a9c1b.js"), []byte("small"), 0644))
+	assert.NilError(t, os.WriteFile(filepath.Join(outDir, "logo.png"), bytes.Repeat([]byte{0}, 4096), 0644))
+
+	// a variant in the way is replaced, not written through
+	outside := filepath.Join(t.TempDir(), "outside")
+	assert.NilError(t, os.WriteFile(outside, []byte("untouched"), 0644))
+	assert.NilError(t, os.Symlink(outside, filepath.Join(outDir, "index.html.gz")))
+
+	assert.NilError(t, precompressWebsite(outDir))
+
+	data, err := os.ReadFile(filepath.Join(outDir, websiteSpec.ManifestFile))
+	assert.NilError(t, err)
+
+	var manifest websiteSpec.Manifest
+	assert.NilError(t, json.Unmarshal(data, &manifest))
+	assert.Equal(t, len(manifest.Files), 3)
+
+	index := manifest.Files["/index.html"]
+	assert.Equal(t, index.Size, int64(len(page)))
+	assert.Equal(t, len(index.Hash), 64)
+	assert.DeepEqual(t, index.Encodings, []string{websiteSpec.Brotli, websiteSpec.Gzip})
+	assert.Equal(t, index.Immutable, false)
+
+	js := manifest.Files["/assets/main.3f2a9c1b.js"]
+	assert.Equal(t, len(js.Encodings), 0)
+	assert.Equal(t, js.Immutable, true)
+	assert.Equal(t, len(manifest.Files["/logo.png"].Encodings), 0)
+
+	br, err := os.Open(filepath.Join(outDir, "index.html.br"))
+	assert.NilError(t, err)
+	defer br.Close()
+	decoded, err := io.ReadAll(brotli.NewReader(br))
+	assert.NilError(t, err)
+	assert.Equal(t, string(decoded), page)
+
+	gz, err := os.Open(filepath.Join(outDir, "index.html.gz"))
+	assert.NilError(t, err)
+	defer gz.Close()
+	gzr, err := gzip.NewReader(gz)
+	assert.NilError(t, err)
+	decoded, err = io.ReadAll(gzr)
+	assert.NilError(t, err)
+	assert.Equal(t, string(decoded), page)
+
+	data, err = os.ReadFile(outside)
+	assert.NilError(t, err)
+	assert.Equal(t, string(data), "untouched")
+}
+
+func TestFingerprinted(t *testing.T) {
+	for name, want := range map[string]bool{
+		"/assets/main.3f2a9c1b.js":       true,
+		"/assets/app-4e5d6c7b8a9f.css":   true,
+		"/static/js/2.a1b2c3d4.chunk.js": true,
+		"/report-20240115.pdf":           false,
+		"/backup.20240115.tar.gz":        false,
+		"/decade.deadbeef.js":            false,
+		"/main.3f2a9c1.js":               false,
+		"/3f2a9c1b.js":                   false,
+		"/index.html":                    false,
+	} {
+		assert.Equal(t, fingerprinted(name, nil), want, name)
+	}
+
+	// with a build manifest, only the files it lists are fingerprinted
+	listed := map[string]bool{"/assets/main.3f2a9c1b.js": true}
+	assert.Assert(t, fingerprinted("/assets/main.3f2a9c1b.js", listed))
+	assert.Assert(t, !fingerprinted("/vendor/lib.3f2a9c1b.js", listed))
+}
+
+func TestReadBuildManifests(t *testing.T) {
+	outDir := t.TempDir()
+
+	listed, err := readBuildManifests(outDir)
+	assert.NilError(t, err)
+	assert.Assert(t, listed == nil)
+
+	assert.NilError(t, os.MkdirAll(filepath.Join(outDir, ".vite"), 0755))
+	assert.NilError(t, os.WriteFile(filepath.Join(outDir, ".vite", "manifest.json"), []byte(
+		`{"index.html":{"file":"assets/index.3f2a9c1b.js","css":["assets/index.4e5d6c7b.css"],"isEntry":true}}`,
+	), 0644))
+	assert.NilError(t, os.WriteFile(filepath.Join(outDir, "asset-manifest.json"), []byte(
+		`{"files":{"main.js":"/static/js/main.a1b2c3d4.js"},"entrypoints":["static/js/main.a1b2c3d4.js"]}`,
+	), 0644))
+
+	listed, err = readBuildManifests(outDir)
+	assert.NilError(t, err)
+	assert.Assert(t, listed["/assets/index.3f2a9c1b.js"])
+	assert.Assert(t, listed["/assets/index.4e5d6c7b.css"])
+	assert.Assert(t, listed["/static/js/main.a1b2c3d4.js"])
+	assert.Assert(t, !listed["/index.html"])
+}
@@ -69,6 +69,9 @@ func New(ctx context.Context, cfg tauConfig.Config) (*Service, error) {
 	if srv.hoarderClient, err = hoarder.New(ctx, srv.clientNode); err != nil {
 		return nil, err
 	}
+	if srv.buildCache, err = srv.newBuildCache(); err != nil {
+		return nil, err
+	}
 
 	go srv.pollJobs()
 
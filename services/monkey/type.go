@@ -15,6 +15,7 @@ import (
 	tnsClient "github.com/taubyte/tau/core/services/tns"
 	"github.com/taubyte/tau/p2p/peer"
 	streams "github.com/taubyte/tau/p2p/streams/service"
+	"github.com/taubyte/tau/pkg/builder/cache"
 	"github.com/taubyte/tau/pkg/config"
 )
 
@@ -50,6 +51,7 @@ type Service struct {
 	tnsClient     tnsClient.Client
 	clientNode    peer.Node
 	hoarderClient hoarderIface.Client
+	buildCache    *cache.Cache
 
 	config  config.Config
 	cluster string
@@ -35,6 +35,7 @@ func (srv *PatrickService) setupStreamRoutes() {
 
 func (srv *PatrickService) setupHTTPRoutes() {
 	srv.setupGithubRoutes()
+	srv.setupProviderRoutes()
 	srv.setupJobRoutes()
 }
 
@@ -77,7 +78,9 @@ func (p *PatrickService) requestServiceHandler(ctx context.Context, conn streams
 	}
 
 	jid := ""
-	if action != "list" && action != "dequeue" {
+	switch action {
+	case "list", "dequeue", "retryPolicy", "setRetryPolicy", "schedulePolicy", "setSchedulePolicy", "deadLetters", "requeue":
+	default:
 		jid, err = maps.String(body, "jid")
 		if err != nil {
 			return nil, fmt.Errorf("failed getting jid from body with error: %v", err)
@@ -102,6 +105,18 @@ func (p *PatrickService) requestServiceHandler(ctx context.Context, conn streams
 		return nil, p.timeoutHandler(ctx, jid, cidMap)
 	case "hasJob":
 		return p.hasJobHandler(ctx, jid)
+	case "retryPolicy":
+		return p.retryPolicyHandler(ctx, body)
+	case "setRetryPolicy":
+		return nil, p.setRetryPolicyHandler(ctx, body)
+	case "schedulePolicy":
+		return p.schedulePolicyHandler(ctx, body)
+	case "setSchedulePolicy":
+		return nil, p.setSchedulePolicyHandler(ctx, body)
+	case "deadLetters":
+		return p.deadLettersHandler(ctx)
+	case "requeue":
+		return p.requeueHandler(ctx, body)
 	}
 
 	return nil, nil
@@ -164,7 +179,7 @@ func (p *PatrickService) infoHandler(ctx context.Context, jid string) (cr.Respon
 
 // dequeueHandler pops the next job from the queue, records the assignment, and returns the job.
 func (p *PatrickService) dequeueHandler(ctx context.Context, conn streams.Connection) (cr.Response, error) {
-	id, _, err := p.jobQueue.Pop(5 * time.Second)
+	id, sched, err := p.dequeue(ctx)
 	if err != nil {
 		if errors.Is(err, raft.ErrQueueEmpty) {
 			return cr.Response{"available": false}, nil
@@ -190,6 +205,7 @@ func (p *PatrickService) dequeueHandler(ctx context.Context, conn streams.Connec
 	assignment := Assignment{
 		MonkeyPID: monkeyPID.String(),
 		Timestamp: time.Now().Unix(),
+		Project:   sched.Project,
 	}
 	assignData, err := cbor.Marshal(assignment)
 	if err != nil {
@@ -226,23 +242,11 @@ func (p *PatrickService) timeoutHandler(ctx context.Context, jid string, cid_log
 			return fmt.Errorf("failed finding job %s in timeoutHandler with %v", jid, err)
 		}
 
-		if job.Attempt == servicesCommon.MaxJobAttempts {
-			job.Status = commonIface.JobStatusFailed
+		policy := p.jobRetryPolicy(ctx, job)
+		if job.Attempt >= policy.MaxAttempts {
 			job.Logs = cid_log
-
-			jobData, err := cbor.Marshal(job)
-			if err != nil {
-				return fmt.Errorf("marshal in updateStatus error: %w", err)
-			}
-
-			err = p.db.Put(ctx, "/archive/jobs/"+jid, jobData)
-			if err != nil {
-				return fmt.Errorf("failed put in timeoutHandler with %w", err)
-			}
-
-			err = p.deleteJob(ctx, jid, "/assigned/", "/jobs/")
-			if err != nil {
-				return fmt.Errorf("failed delete in timeoutHandler with %w", err)
+			if err = p.deadLetter(ctx, job); err != nil {
+				return fmt.Errorf("failed dead-lettering in timeoutHandler with %w", err)
 			}
 
 			return nil
@@ -251,6 +255,7 @@ func (p *PatrickService) timeoutHandler(ctx context.Context, jid string, cid_log
 		job.Attempt++
 		job.Timestamp = time.Now().Unix()
 		job.Status = commonIface.JobStatusOpen
+		retryLater(job, policy)
 
 		if err = p.db.Delete(ctx, "/assigned/"+jid); err != nil {
 			return fmt.Errorf("failed deleting assignment for %s: %w", jid, err)
@@ -265,7 +270,7 @@ func (p *PatrickService) timeoutHandler(ctx context.Context, jid string, cid_log
 			return err
 		}
 
-		if err = p.jobQueue.Push(jid, nil, 5*time.Second); err != nil {
+		if err = p.enqueue(job); err != nil {
 			return fmt.Errorf("failed to push job %s back onto queue: %w", jid, err)
 		}
 
@@ -323,16 +328,33 @@ func (p *PatrickService) updateStatus(ctx context.Context, pid peer.ID, jid stri
 		job.Attempt++
 	}
 
+	if job.Status == commonIface.JobStatusFailed {
+		policy := p.jobRetryPolicy(ctx, job)
+		if job.Attempt >= policy.MaxAttempts || policy.TimeoutOnly {
+			if err = p.deadLetter(ctx, job); err != nil {
+				return fmt.Errorf("updateStatus failed with error: %w", err)
+			}
+
+			return nil
+		}
+
+		retryLater(job, policy)
+	}
+
 	jobData, err := cbor.Marshal(job)
 	if err != nil {
 		return fmt.Errorf("marshal in updateStatus error: %w", err)
 	}
 
-	if job.Status == commonIface.JobStatusSuccess || job.Status == commonIface.JobStatusCancelled || job.Attempt >= servicesCommon.MaxJobAttempts {
+	if job.Status == commonIface.JobStatusSuccess || job.Status == commonIface.JobStatusCancelled {
 		p.deleteJob(ctx, jid, "/jobs/")
 		if err = p.db.Put(ctx, "/archive/jobs/"+jid, jobData); err != nil {
 			return fmt.Errorf("updateStatus put failed with error: %w", err)
 		}
+
+		if job.Status == commonIface.JobStatusSuccess {
+			p.announceDeployment(ctx, job)
+		}
 	} else {
 		if err = p.db.Put(ctx, "/jobs/"+jid, jobData); err != nil {
 			return fmt.Errorf("updateStatus put failed with error: %w", err)
@@ -514,15 +536,16 @@ func (srv *PatrickService) retryJob(ctx http.Context) (iface interface{}, err er
 	if job.Status == commonIface.JobStatusCancelled || job.Status == commonIface.JobStatusFailed || job.Status == commonIface.JobStatusSuccess {
 		job.Status = commonIface.JobStatusOpen
 		job.Attempt = 0
+		job.NotBefore = 0
 		job_byte, err := cbor.Marshal(job)
 		if err != nil {
 			logger.Errorf("failed cbor marshall on job %s with err: %w", job.Id, err)
 			return nil, fmt.Errorf("failed marshalling job %s with err %w", job.Id, err)
 		}
 
-		err = srv.db.Delete(requestCtx, "/archive/jobs/"+jid)
+		err = srv.deleteJob(requestCtx, jid, "/archive/jobs/", deadLetterPrefix)
 		if err != nil {
-			return nil, fmt.Errorf("failed deleting job %s with error: %w", jid, err)
+			return nil, err
 		}
 
 		err = srv.db.Put(requestCtx, "/jobs/"+job.Id, job_byte)
@@ -531,7 +554,7 @@ func (srv *PatrickService) retryJob(ctx http.Context) (iface interface{}, err er
 			return nil, fmt.Errorf("failed putting job %s with %w", job.Id, err)
 		}
 
-		if err = srv.jobQueue.Push(job.Id, nil, 5*time.Second); err != nil {
+		if err = srv.enqueue(job); err != nil {
 			return nil, fmt.Errorf("failed to push retried job %s onto queue: %w", job.Id, err)
 		}
 
@@ -80,6 +80,8 @@ type mockJobQueue struct {
 	pushErr   error
 	pushCalls []pushCall
 	popCalls  int
+	// items, when set, are what PopFunc picks from instead of popID.
+	items []raft.QueueItem
 }
 
 func (m *mockJobQueue) Push(id string, data []byte, _ time.Duration) error {
@@ -90,6 +92,28 @@ func (m *mockJobQueue) Pop(_ time.Duration) (string, []byte, error) {
 	m.popCalls++
 	return m.popID, m.popData, m.popErr
 }
+func (m *mockJobQueue) PopFunc(pick func([]raft.QueueItem) int, _ time.Duration) (string, []byte, error) {
+	m.popCalls++
+	if m.popErr != nil {
+		return "", nil, m.popErr
+	}
+
+	items := m.items
+	if items == nil {
+		items = []raft.QueueItem{{ID: m.popID, Data: m.popData}}
+	}
+
+	idx := pick(items)
+	if idx < 0 {
+		return "", nil, raft.ErrQueueEmpty
+	}
+
+	item := items[idx]
+	if m.items != nil {
+		m.items = slices.Delete(m.items, idx, idx+1)
+	}
+	return item.ID, item.Data, nil
+}
 func (m *mockJobQueue) Peek() (string, []byte, bool) { return "", nil, false }
 func (m *mockJobQueue) Len() int                     { return 0 }
 func (m *mockJobQueue) Close() error                 { return nil }
@@ -104,6 +128,8 @@ func createTestService() *PatrickService {
 		db:           mockDB,
 		node:         &mockNode{},
 		monkeyClient: &mockMonkeyClient{},
+		authClient:   &mockAuthClient{},
+		tnsClient:    &mockTNSClient{},
 		jobQueue:     &mockJobQueue{popErr: raft.ErrQueueEmpty},
 	}
 }
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	commonIface "github.com/taubyte/tau/core/services/patrick"
+	commonSpec "github.com/taubyte/tau/pkg/specs/common"
+)
+
+// announceDeployment publishes a job of a project succeeded, so caches of what
+// the project serves can be dropped. It is best effort: failing to announce
+// does not fail the job.
+func (p *PatrickService) announceDeployment(ctx context.Context, job *commonIface.Job) {
+	if job.Project == "" || p.node == nil {
+		return
+	}
+
+	deployment := &commonIface.Deployment{
+		Project:   job.Project,
+		Job:       job.Id,
+		Timestamp: time.Now().UnixNano(),
+		Domains:   p.projectDomains(job.Project),
+	}
+
+	data, err := cbor.Marshal(deployment)
+	if err != nil {
+		logger.Errorf("marshal deployment of job %s failed with: %s", job.Id, err.Error())
+		return
+	}
+
+	if err = p.node.PubSubPublish(ctx, commonIface.DeploymentsTopic, data); err != nil {
+		logger.Errorf("announcing deployment of job %s failed with: %s", job.Id, err.Error())
+	}
+}
+
+// projectDomains returns the fqdns of the global domains of a project.
+func (p *PatrickService) projectDomains(projectId string) []string {
+	if p.tnsClient == nil {
+		return nil
+	}
+
+	domains, _, _, err := p.tnsClient.Domain().Global(projectId, commonSpec.DefaultBranches...).List()
+	if err != nil {
+		logger.Debugf("listing domains of project %s failed with: %s", projectId, err.Error())
+		return nil
+	}
+
+	fqdns := make([]string, 0, len(domains))
+	for _, domain := range domains {
+		if domain != nil && domain.Fqdn != "" {
+			fqdns = append(fqdns, domain.Fqdn)
+		}
+	}
+
+	return fqdns
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/taubyte/tau/core/services/patrick"
+	"github.com/taubyte/tau/core/services/tns"
+	structureSpec "github.com/taubyte/tau/pkg/specs/structure"
+	"gotest.tools/v3/assert"
+)
+
+type mockDomains struct {
+	tns.StructureIface[*structureSpec.Domain]
+	tns.StructureGetter[*structureSpec.Domain]
+	domains map[string]*structureSpec.Domain
+	err     error
+}
+
+func (m *mockDomains) Global(projectId string, branches ...string) tns.StructureGetter[*structureSpec.Domain] {
+	return m
+}
+
+func (m *mockDomains) List() (map[string]*structureSpec.Domain, string, string, error) {
+	return m.domains, "", "", m.err
+}
+
+type mockTNSClientWithDomains struct {
+	*mockTNSClient
+	domains *mockDomains
+}
+
+func (m *mockTNSClientWithDomains) Domain() tns.StructureIface[*structureSpec.Domain] {
+	return m.domains
+}
+
+func succeedJob(t *testing.T, service *PatrickService, project string) {
+	job := createTestJob("test-job")
+	job.Project = project
+	service.db.Put(context.Background(), "/jobs/test-job", marshalJob(job))
+
+	assert.NilError(t, service.updateStatus(context.Background(), "", "test-job", nil, patrick.JobStatusSuccess, nil))
+}
+
+func TestAnnounceDeployment(t *testing.T) {
+	service := createTestService()
+	service.tnsClient = &mockTNSClientWithDomains{
+		mockTNSClient: &mockTNSClient{},
+		domains: &mockDomains{domains: map[string]*structureSpec.Domain{
+			"d1": {Fqdn: "hal.computers.com"},
+		}},
+	}
+
+	succeedJob(t, service, "project")
+
+	node := service.node.(*mockNode)
+	assert.Equal(t, len(node.pubsubCalls), 1)
+
+	var deployment patrick.Deployment
+	assert.NilError(t, cbor.Unmarshal(node.pubsubCalls[0], &deployment))
+	assert.Equal(t, deployment.Project, "project")
+	assert.Equal(t, deployment.Job, "test-job")
+	assert.DeepEqual(t, deployment.Domains, []string{"hal.computers.com"})
+}
+
+func TestAnnounceDeploymentBestEffort(t *testing.T) {
+	service := createTestService()
+	service.node = &mockNode{pubsubError: errors.New("pubsub down")}
+	service.tnsClient = &mockTNSClientWithDomains{
+		mockTNSClient: &mockTNSClient{},
+		domains:       &mockDomains{err: errors.New("tns down")},
+	}
+
+	succeedJob(t, service, "project")
+
+	node := service.node.(*mockNode)
+	assert.Equal(t, len(node.pubsubCalls), 1)
+
+	var deployment patrick.Deployment
+	assert.NilError(t, cbor.Unmarshal(node.pubsubCalls[0], &deployment))
+	assert.Equal(t, len(deployment.Domains), 0)
+}
+
+func TestAnnounceDeploymentWithoutProject(t *testing.T) {
+	service := createTestService()
+
+	succeedJob(t, service, "")
+
+	assert.Equal(t, len(service.node.(*mockNode).pubsubCalls), 0)
+}
@@ -47,7 +47,8 @@ func (srv *PatrickService) githubCheckHookAndExtractSecret(ctx http.Context) (in
 	return nil, nil
 }
 
-func (srv *PatrickService) githubHookHandler(ctx http.Context) (interface{}, error) {
+// newPushJob returns an open job, to be filled from a push delivery.
+func newPushJob() *iface.Job {
 	newJob := &iface.Job{
 		Status:    iface.JobStatusOpen,
 		Timestamp: time.Now().Unix(),
@@ -56,17 +57,23 @@ func (srv *PatrickService) githubHookHandler(ctx http.Context) (interface{}, err
 		Attempt:   0,
 	}
 
-	secret, err := ctx.GetStringVariable("GithubSecret") // comes from auth
-	if err != nil {
-		return nil, err
-	}
-
 	if servicesCommon.DelayJob {
 		newJob.Delay = &iface.DelayConfig{
 			Time: int(servicesCommon.DelayJobTime),
 		}
 	}
 
+	return newJob
+}
+
+func (srv *PatrickService) githubHookHandler(ctx http.Context) (interface{}, error) {
+	newJob := newPushJob()
+
+	secret, err := ctx.GetStringVariable("GithubSecret") // comes from auth
+	if err != nil {
+		return nil, err
+	}
+
 	hook, err := github.New(github.Options.Secret(secret))
 	if err != nil {
 		return nil, fmt.Errorf("creating hook failed with %w", err)
@@ -104,38 +111,44 @@ func (srv *PatrickService) githubHookHandler(ctx http.Context) (interface{}, err
 		newJob.Meta.Repository.Provider = "github"
 		newJob.Meta.Repository.Branch = strings.Replace(newJob.Meta.Ref, "refs/heads/", "", 1)
 
-		if !slices.Contains(commonSpec.DefaultBranches, newJob.Meta.Repository.Branch) && !srv.devMode {
-			return nil, fmt.Errorf("only builds main branches %v got `%s`", commonSpec.DefaultBranches, newJob.Meta.Repository.Branch)
-		}
-
-		newJob.Id = iface.PushEventJobID(&newJob.Meta)
+		return srv.acceptPush(ctx.Request().Context(), newJob)
+	default:
+		return nil, fmt.Errorf("this is not a push event. but a %T", payload)
+	}
+}
 
-		if existing, err := srv.getJob(ctx.Request().Context(), "/jobs/", newJob.Id); err == nil && existing != nil {
-			return existing, nil
-		}
+// acceptPush registers the job for a decoded push, whichever provider it came
+// from. A redelivered push returns the job it already created.
+func (srv *PatrickService) acceptPush(ctx context.Context, newJob *iface.Job) (interface{}, error) {
+	if !slices.Contains(commonSpec.DefaultBranches, newJob.Meta.Repository.Branch) && !srv.devMode {
+		return nil, fmt.Errorf("only builds main branches %v got `%s`", commonSpec.DefaultBranches, newJob.Meta.Repository.Branch)
+	}
 
-		// Pushing useful information to tns (ssh key stores effective URI for backward compat)
-		repoInfo := map[string]string{
-			"id":  fmt.Sprintf("%d", newJob.Meta.Repository.ID),
-			"ssh": newJob.Meta.Repository.URI,
-		}
+	newJob.Id = iface.PushEventJobID(&newJob.Meta)
 
-		err = srv.tnsClient.Push([]string{"resolve", "repo", "github", fmt.Sprintf("%d", newJob.Meta.Repository.ID)}, repoInfo)
-		if err != nil {
-			return nil, fmt.Errorf("failed registering new job repo %d into tns with error: %v", newJob.Meta.Repository.ID, err)
-		}
+	if existing, err := srv.getJob(ctx, "/jobs/", newJob.Id); err == nil && existing != nil {
+		return existing, nil
+	}
 
-		err = srv.RegisterJob(ctx.Request().Context(), newJob)
-		if err != nil {
-			return nil, fmt.Errorf("failed registering job with error: %w", err)
-		}
+	// Pushing useful information to tns (ssh key stores effective URI for backward compat)
+	repoInfo := map[string]string{
+		"id":  fmt.Sprintf("%d", newJob.Meta.Repository.ID),
+		"ssh": newJob.Meta.Repository.URI,
+	}
 
-		logger.Debugf("Got job: %#v", newJob)
+	err := srv.tnsClient.Push([]string{"resolve", "repo", newJob.Meta.Repository.Provider, fmt.Sprintf("%d", newJob.Meta.Repository.ID)}, repoInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed registering new job repo %d into tns with error: %v", newJob.Meta.Repository.ID, err)
+	}
 
-		return newJob, nil
-	default:
-		return nil, fmt.Errorf("this is not a push event. but a %T", payload)
+	err = srv.RegisterJob(ctx, newJob)
+	if err != nil {
+		return nil, fmt.Errorf("failed registering job with error: %w", err)
 	}
+
+	logger.Debugf("Got job: %#v", newJob)
+
+	return newJob, nil
 }
 
 func (srv *PatrickService) RegisterJob(ctx context.Context, newJob *iface.Job) error {
@@ -143,6 +156,11 @@ func (srv *PatrickService) RegisterJob(ctx context.Context, newJob *iface.Job) e
 		return nil
 	}
 
+	// The project and priority schedule the job. A failed project lookup
+	// fails connectToProject below, once the job is stored.
+	newJob.Project, _ = srv.getProjectIDFromJob(newJob)
+	newJob.Priority = jobPriority(newJob)
+
 	job_byte, err := cbor.Marshal(newJob)
 	if err != nil {
 		return fmt.Errorf("failed cbor marshall on job structure with err: %w", err)
@@ -166,7 +184,7 @@ func (srv *PatrickService) RegisterJob(ctx context.Context, newJob *iface.Job) e
 	if exists {
 		return nil
 	}
-	if err = srv.jobQueue.Push(newJob.Id, nil, 5*time.Second); err != nil {
+	if err = srv.enqueue(newJob); err != nil {
 		return fmt.Errorf("failed to push job onto queue: %w", err)
 	}
 	return nil
@@ -47,19 +47,30 @@ func (p *PatrickService) ReannounceJobs(ctx context.Context) error {
 
 // republishJob pushes a job back onto the queue (idempotent by id).
 func (p *PatrickService) republishJob(ctx context.Context, jid string) error {
-	if err := p.jobQueue.Push(jid, nil, 5*time.Second); err != nil {
+	job, err := p.getJob(ctx, "/jobs/", jid)
+	if err != nil {
+		// Without the job there is nothing to schedule it by; it still has to build.
+		job = &patrick.Job{Id: jid}
+	}
+
+	if err := p.enqueue(job); err != nil {
 		return fmt.Errorf("failed to re-push job in republishJob: %w", err)
 	}
 	return nil
 }
 
+// connectToProject indexes the job under its project, looking the project up
+// unless the job already knows it.
 func (srv *PatrickService) connectToProject(ctx context.Context, job *patrick.Job) error {
-	projectID, err := srv.getProjectIDFromJob(job)
-	if err != nil {
-		return err
+	if job.Project == "" {
+		projectID, err := srv.getProjectIDFromJob(job)
+		if err != nil {
+			return err
+		}
+		job.Project = projectID
 	}
 
-	err = srv.db.Put(ctx, fmt.Sprintf("/by/project/%s/%s", projectID, job.Id), []byte{})
+	err := srv.db.Put(ctx, fmt.Sprintf("/by/project/%s/%s", job.Project, job.Id), []byte{})
 	if err != nil {
 		return fmt.Errorf("failed putting job into project with error: %w", err)
 	}
@@ -68,7 +79,7 @@ func (srv *PatrickService) connectToProject(ctx context.Context, job *patrick.Jo
 }
 
 func (srv *PatrickService) getProjectIDFromJob(job *patrick.Job) (projectID string, err error) {
-	repo, _ := srv.authClient.Repositories().Github().Get(job.Meta.Repository.ID)
+	repo, _ := srv.authClient.Repositories().Provider(job.Meta.Repository.GitProvider()).Get(job.Meta.Repository.ID)
 
 	if repo != nil {
 		projectID = repo.Project()
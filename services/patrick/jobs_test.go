@@ -95,6 +95,10 @@ func (m mockRepositories) Github() auth.GithubRepositories {
 	return mockGithubRepos{repos: m.repos}
 }
 
+func (m mockRepositories) Provider(name string) auth.GitRepositories {
+	return mockGithubRepos{repos: m.repos}
+}
+
 type mockGithubRepos struct {
 	auth.GithubRepositories
 	repos map[int]mockRepo
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	iface "github.com/taubyte/tau/core/services/patrick"
+	"github.com/taubyte/tau/pkg/git/providers"
+	http "github.com/taubyte/tau/pkg/http"
+	servicesCommon "github.com/taubyte/tau/services/common"
+)
+
+// Push webhook handlers for the git providers other than GitHub
+
+func (srv *PatrickService) providerCheckHookAndExtractSecret(provider providers.Provider) http.Handler {
+	return func(ctx http.Context) (interface{}, error) {
+		if servicesCommon.FakeSecret && srv.devMode {
+			ctx.SetVariable("ProviderSecret", "taubyte_secret")
+			return nil, nil
+		}
+
+		hook_uuid, err := ctx.GetStringVariable("hook")
+		if err != nil {
+			return nil, fmt.Errorf("get string context failed with %w", err)
+		}
+
+		hook, err := srv.getHook(hook_uuid)
+		if err != nil {
+			return nil, fmt.Errorf("get hook failed with %w", err)
+		}
+
+		provider_hook, err := hook.ProviderHook()
+		if err != nil {
+			return nil, fmt.Errorf("%s hook failed with %w", provider.Name(), err)
+		}
+
+		// A hook only verifies deliveries from the provider it was created on.
+		if provider_hook.Provider != provider.Name() {
+			return nil, fmt.Errorf("hook `%s` is not a %s hook", hook_uuid, provider.Name())
+		}
+		ctx.SetVariable("ProviderSecret", provider_hook.Secret)
+
+		return nil, nil
+	}
+}
+
+func (srv *PatrickService) providerHookHandler(provider providers.Provider) http.Handler {
+	return func(ctx http.Context) (interface{}, error) {
+		secret, err := ctx.GetStringVariable("ProviderSecret") // comes from auth
+		if err != nil {
+			return nil, err
+		}
+
+		header, body := ctx.Request().Header, ctx.Body()
+		if err = provider.VerifyHook(header, body, secret); err != nil {
+			return nil, err
+		}
+
+		push, err := provider.ParsePush(header, body)
+		if err != nil {
+			return nil, fmt.Errorf("parsing hook failed with %w", err)
+		}
+
+		logger.Debugf("Hook triggred. %s push: %v", provider.Name(), push)
+
+		newJob := newPushJob()
+		newJob.Meta = iface.Meta{
+			Ref:        push.Ref,
+			Before:     push.Before,
+			After:      push.After,
+			HeadCommit: iface.HeadCommit{ID: push.HeadCommit},
+			Repository: iface.Repository{
+				ID:       push.Repository.ID,
+				Provider: provider.Name(),
+				SSHURL:   push.Repository.SSHURL,
+				URI:      push.Repository.SSHURL,
+				Branch:   strings.Replace(push.Ref, "refs/heads/", "", 1),
+				PushedAt: push.PushedAt,
+			},
+		}
+
+		return srv.acceptPush(ctx.Request().Context(), newJob)
+	}
+}
+
+func (srv *PatrickService) setupProviderRoutes() {
+	hosts := srv.config.RouteHosts(servicesCommon.Patrick)
+	for _, name := range providers.Names() {
+		provider, _ := providers.Get(name)
+		srv.http.POST(&http.RouteDefinition{
+			Hosts: hosts,
+			Path:  "/" + name + "/{hook}",
+			Vars: http.Variables{
+				Required: []string{"hook"},
+			},
+			Scope: []string{"hook/push"},
+			Auth: http.RouteAuthHandler{
+				Validator: srv.providerCheckHookAndExtractSecret(provider),
+			},
+			Handler: srv.providerHookHandler(provider),
+		})
+	}
+}
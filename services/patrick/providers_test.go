@@ -0,0 +1,110 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/taubyte/tau/core/services/auth"
+	"github.com/taubyte/tau/core/services/patrick"
+	"github.com/taubyte/tau/pkg/git/providers"
+	"gotest.tools/v3/assert"
+)
+
+const giteaPushPayload = `{
+	"ref": "refs/heads/main",
+	"before": "1111",
+	"after": "2222",
+	"head_commit": {"id": "2222", "timestamp": "2024-05-01T10:00:00Z"},
+	"repository": {"id": 12345, "name": "web", "full_name": "acme/web", "ssh_url": "git@gitea.local:acme/web.git"}
+}`
+
+type mockProviderHooks struct {
+	auth.Hooks
+	hook *auth.ProviderHook
+}
+
+func (m mockProviderHooks) Get(hookid string) (auth.Hook, error) {
+	return m.hook, nil
+}
+
+type mockProviderAuthClient struct {
+	*mockAuthClient
+	hook *auth.ProviderHook
+}
+
+func (m *mockProviderAuthClient) Hooks() auth.Hooks {
+	return mockProviderHooks{hook: m.hook}
+}
+
+func TestProviderHookHandler(t *testing.T) {
+	ts := createTestSetup(false)
+	ts.service.authClient = &mockProviderAuthClient{
+		mockAuthClient: ts.authClient,
+		hook:           &auth.ProviderHook{Id: "hook-1", Provider: "gitea", ProviderId: 77, Secret: "hooksecret"},
+	}
+
+	body := []byte(giteaPushPayload)
+	mac := hmac.New(sha256.New, []byte("hooksecret"))
+	mac.Write(body)
+	ts.ctx.SetHeaders(map[string]string{
+		"X-Gitea-Event":     "push",
+		"X-Gitea-Signature": hex.EncodeToString(mac.Sum(nil)),
+	})
+	ts.ctx.SetBody(body)
+	ts.ctx.SetVariable("hook", "hook-1")
+
+	// A gitea hook does not verify gitlab deliveries.
+	_, err := ts.service.providerCheckHookAndExtractSecret(providers.GitLab)(ts.ctx)
+	assert.ErrorContains(t, err, "is not a gitlab hook")
+
+	_, err = ts.service.providerCheckHookAndExtractSecret(providers.Gitea)(ts.ctx)
+	assert.NilError(t, err)
+
+	result, err := ts.service.providerHookHandler(providers.Gitea)(ts.ctx)
+	assert.NilError(t, err)
+
+	job := result.(*patrick.Job)
+	assert.Equal(t, job.Meta.Repository.Provider, "gitea")
+	assert.Equal(t, job.Meta.Repository.URI, "git@gitea.local:acme/web.git")
+	assert.Equal(t, job.Meta.Repository.Branch, "main")
+	assert.Equal(t, job.Meta.HeadCommit.ID, "2222")
+	assert.Equal(t, job.Id, patrick.PushEventJobID(&job.Meta))
+
+	// A delivery signed with another secret is refused.
+	ts.ctx.SetVariable("ProviderSecret", "other")
+	_, err = ts.service.providerHookHandler(providers.Gitea)(ts.ctx)
+	assert.ErrorIs(t, err, providers.ErrSignature)
+}
+
+func TestGenericHookHandler(t *testing.T) {
+	ts := createTestSetup(false)
+	ts.service.authClient = &mockProviderAuthClient{
+		mockAuthClient: ts.authClient,
+		hook:           &auth.ProviderHook{Id: "hook-1", Provider: "generic", Secret: "hooksecret"},
+	}
+
+	body := []byte(`{"uri":"git@git.local:acme/web.git","ref":"refs/heads/main","before":"1111","after":"2222","pushed_at":1714557600}`)
+	mac := hmac.New(sha256.New, []byte("hooksecret"))
+	mac.Write(body)
+	ts.ctx.SetHeaders(map[string]string{
+		providers.GenericEventHeader:     "push",
+		providers.GenericSignatureHeader: "sha256=" + hex.EncodeToString(mac.Sum(nil)),
+	})
+	ts.ctx.SetBody(body)
+	ts.ctx.SetVariable("hook", "hook-1")
+
+	_, err := ts.service.providerCheckHookAndExtractSecret(providers.Generic)(ts.ctx)
+	assert.NilError(t, err)
+
+	result, err := ts.service.providerHookHandler(providers.Generic)(ts.ctx)
+	assert.NilError(t, err)
+
+	job := result.(*patrick.Job)
+	assert.Equal(t, job.Meta.Repository.Provider, "generic")
+	assert.Equal(t, job.Meta.Repository.ID, providers.RepositoryID("git@git.local:acme/web.git"))
+	assert.Equal(t, job.Meta.Repository.URI, "git@git.local:acme/web.git")
+	assert.Equal(t, job.Meta.Repository.PushedAt, int64(1714557600))
+	assert.Equal(t, job.Id, patrick.PushEventJobID(&job.Meta))
+}
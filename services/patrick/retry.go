@@ -0,0 +1,232 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	commonIface "github.com/taubyte/tau/core/services/patrick"
+	"github.com/taubyte/tau/p2p/streams/command"
+	cr "github.com/taubyte/tau/p2p/streams/command/response"
+	servicesCommon "github.com/taubyte/tau/services/common"
+	"github.com/taubyte/tau/utils/maps"
+)
+
+// Retry policies and the dead-letter queue
+
+const (
+	retryPolicyPrefix = "/policies/retry/"
+	// A dead-lettered job stays archived; this marks it as given up on.
+	deadLetterPrefix = "/deadletter/"
+)
+
+// defaultRetryPolicy applies to projects without a policy of their own.
+func defaultRetryPolicy() *commonIface.RetryPolicy {
+	return &commonIface.RetryPolicy{MaxAttempts: servicesCommon.MaxJobAttempts}
+}
+
+func (p *PatrickService) retryPolicy(ctx context.Context, projectId string) (*commonIface.RetryPolicy, error) {
+	data, err := p.db.Get(ctx, retryPolicyPrefix+projectId)
+	if err != nil {
+		return defaultRetryPolicy(), nil
+	}
+
+	var policy commonIface.RetryPolicy
+	if err = cbor.Unmarshal(data, &policy); err != nil {
+		return nil, fmt.Errorf("unmarshal retry policy of %s failed with %w", projectId, err)
+	}
+
+	return &policy, nil
+}
+
+// setRetryPolicy sets the policy of a project; a nil policy puts it back on the default.
+func (p *PatrickService) setRetryPolicy(ctx context.Context, projectId string, policy *commonIface.RetryPolicy) error {
+	if projectId == "" {
+		return errors.New("retry policy needs a project id")
+	}
+
+	if policy == nil {
+		return p.db.Delete(ctx, retryPolicyPrefix+projectId)
+	}
+
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+
+	data, err := cbor.Marshal(policy)
+	if err != nil {
+		return fmt.Errorf("marshal retry policy failed with %w", err)
+	}
+
+	return p.db.Put(ctx, retryPolicyPrefix+projectId, data)
+}
+
+// jobRetryPolicy returns the policy of the project the job builds. Jobs whose
+// project can not be found get the default one.
+func (p *PatrickService) jobRetryPolicy(ctx context.Context, job *commonIface.Job) *commonIface.RetryPolicy {
+	projectId, err := p.getProjectIDFromJob(job)
+	if err != nil || projectId == "" {
+		return defaultRetryPolicy()
+	}
+
+	policy, err := p.retryPolicy(ctx, projectId)
+	if err != nil {
+		logger.Errorf("retry policy of %s: %s", projectId, err.Error())
+		return defaultRetryPolicy()
+	}
+
+	return policy
+}
+
+// retryLater holds the next attempt of the job back according to policy. The
+// job stays queued until then rather than being handed to a monkey that waits
+// on it, so backing off never outlives an assignment.
+func retryLater(job *commonIface.Job, policy *commonIface.RetryPolicy) {
+	job.NotBefore = 0
+	if delay := policy.Delay(job.Attempt); delay > 0 {
+		job.NotBefore = time.Now().Unix() + int64(delay)
+	}
+}
+
+// deadLetter archives a job that will not be retried again.
+func (p *PatrickService) deadLetter(ctx context.Context, job *commonIface.Job) error {
+	job.Status = commonIface.JobStatusFailed
+
+	jobData, err := cbor.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("marshal dead-lettered job %s failed with %w", job.Id, err)
+	}
+
+	if err = p.db.Put(ctx, "/archive/jobs/"+job.Id, jobData); err != nil {
+		return fmt.Errorf("failed archiving job %s with %w", job.Id, err)
+	}
+
+	if err = p.db.Put(ctx, deadLetterPrefix+job.Id, []byte{}); err != nil {
+		return fmt.Errorf("failed dead-lettering job %s with %w", job.Id, err)
+	}
+
+	return p.deleteJob(ctx, job.Id, "/assigned/", "/jobs/")
+}
+
+func (p *PatrickService) deadLetters(ctx context.Context) ([]string, error) {
+	keys, err := p.db.List(ctx, deadLetterPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed listing dead letters with %w", err)
+	}
+
+	jids := make([]string, len(keys))
+	for idx, key := range keys {
+		jids[idx] = strings.TrimPrefix(key, deadLetterPrefix)
+	}
+
+	return jids, nil
+}
+
+// requeue gives dead-lettered jobs a fresh set of attempts. Without jids, it
+// requeues every dead-lettered job.
+func (p *PatrickService) requeue(ctx context.Context, jids []string) ([]string, error) {
+	if len(jids) == 0 {
+		var err error
+		if jids, err = p.deadLetters(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	requeued := make([]string, 0, len(jids))
+	for _, jid := range jids {
+		if _, err := p.db.Get(ctx, deadLetterPrefix+jid); err != nil {
+			return requeued, fmt.Errorf("job %s is not dead-lettered", jid)
+		}
+
+		job, err := p.getJob(ctx, "/archive/jobs/", jid)
+		if err != nil {
+			return requeued, fmt.Errorf("failed grabbing dead-lettered job %s with %w", jid, err)
+		}
+
+		job.Status = commonIface.JobStatusOpen
+		job.Attempt = 0
+		job.NotBefore = 0
+		jobData, err := cbor.Marshal(job)
+		if err != nil {
+			return requeued, fmt.Errorf("failed marshalling job %s with %w", jid, err)
+		}
+
+		if err = p.db.Put(ctx, "/jobs/"+jid, jobData); err != nil {
+			return requeued, fmt.Errorf("failed putting job %s with %w", jid, err)
+		}
+
+		if err = p.deleteJob(ctx, jid, "/archive/jobs/", deadLetterPrefix); err != nil {
+			return requeued, err
+		}
+
+		if err = p.enqueue(job); err != nil {
+			return requeued, fmt.Errorf("failed to push requeued job %s onto queue: %w", jid, err)
+		}
+
+		requeued = append(requeued, jid)
+	}
+
+	return requeued, nil
+}
+
+func (p *PatrickService) retryPolicyHandler(ctx context.Context, body command.Body) (cr.Response, error) {
+	projectId, err := maps.String(body, "project")
+	if err != nil {
+		return nil, fmt.Errorf("failed getting project from body with error: %v", err)
+	}
+
+	policy, err := p.retryPolicy(ctx, projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	return cr.Response{"policy": policy}, nil
+}
+
+func (p *PatrickService) setRetryPolicyHandler(ctx context.Context, body command.Body) error {
+	projectId, err := maps.String(body, "project")
+	if err != nil {
+		return fmt.Errorf("failed getting project from body with error: %v", err)
+	}
+
+	var policy commonIface.RetryPolicy
+	ok, err := policyFromBody(body, &policy)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return p.setRetryPolicy(ctx, projectId, nil)
+	}
+
+	return p.setRetryPolicy(ctx, projectId, &policy)
+}
+
+func (p *PatrickService) deadLettersHandler(ctx context.Context) (cr.Response, error) {
+	jids, err := p.deadLetters(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return cr.Response{"Ids": jids}, nil
+}
+
+func (p *PatrickService) requeueHandler(ctx context.Context, body command.Body) (cr.Response, error) {
+	var jids []string
+	if _, ok := body["jids"]; ok {
+		var err error
+		if jids, err = maps.StringArray(body, "jids"); err != nil {
+			return nil, fmt.Errorf("failed getting jids from body with error: %v", err)
+		}
+	}
+
+	requeued, err := p.requeue(ctx, jids)
+	if err != nil {
+		return nil, err
+	}
+
+	return cr.Response{"requeued": requeued}, nil
+}
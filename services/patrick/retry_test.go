@@ -0,0 +1,105 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/taubyte/tau/core/services/patrick"
+	"github.com/taubyte/tau/p2p/streams/command"
+	"gotest.tools/v3/assert"
+)
+
+func TestRetryPolicyBackoffAndDeadLetter(t *testing.T) {
+	ctx := t.Context()
+	ts := createTestSetup(false)
+	mq := &mockJobQueue{}
+	ts.service.jobQueue = mq
+
+	// Jobs of repository 12345 belong to project-456.
+	assert.NilError(t, ts.service.setRetryPolicy(ctx, "project-456", &patrick.RetryPolicy{MaxAttempts: 3, Backoff: 10}))
+
+	job := createTestJob("retry-job")
+	assert.NilError(t, ts.service.db.Put(ctx, "/jobs/retry-job", marshalJob(job)))
+
+	for attempt, delay := range []int64{10, 20} {
+		before := time.Now().Unix()
+		assert.NilError(t, ts.service.updateStatus(ctx, "", "retry-job", nil, patrick.JobStatusFailed, nil))
+
+		job, err := ts.service.getJob(ctx, "/jobs/", "retry-job")
+		assert.NilError(t, err)
+		assert.Equal(t, job.Attempt, attempt+1)
+		assert.Assert(t, job.NotBefore >= before+delay && job.NotBefore <= time.Now().Unix()+delay)
+		assert.Assert(t, job.Delay == nil, "backing off must not make monkeys wait")
+	}
+
+	assert.NilError(t, ts.service.updateStatus(ctx, "", "retry-job", nil, patrick.JobStatusFailed, nil))
+	_, err := ts.service.getJob(ctx, "/jobs/", "retry-job")
+	assert.Assert(t, err != nil, "a job out of attempts should leave /jobs/")
+
+	dead, err := ts.service.deadLetters(ctx)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, dead, []string{"retry-job"})
+
+	requeued, err := ts.service.requeue(ctx, nil)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, requeued, []string{"retry-job"})
+	assert.Equal(t, len(mq.pushCalls), 1)
+
+	job, err = ts.service.getJob(ctx, "/jobs/", "retry-job")
+	assert.NilError(t, err)
+	assert.Equal(t, job.Status, patrick.JobStatusOpen)
+	assert.Equal(t, job.Attempt, 0)
+	assert.Equal(t, job.NotBefore, int64(0))
+
+	dead, err = ts.service.deadLetters(ctx)
+	assert.NilError(t, err)
+	assert.Equal(t, len(dead), 0)
+
+	_, err = ts.service.requeue(ctx, []string{"retry-job"})
+	assert.ErrorContains(t, err, "is not dead-lettered")
+}
+
+func TestRetryPolicyTimeoutOnly(t *testing.T) {
+	ctx := t.Context()
+	ts := createTestSetup(false)
+	mq := &mockJobQueue{}
+	ts.service.jobQueue = mq
+
+	assert.NilError(t, ts.service.setRetryPolicy(ctx, "project-456", &patrick.RetryPolicy{MaxAttempts: 3, TimeoutOnly: true}))
+
+	assert.NilError(t, ts.service.db.Put(ctx, "/jobs/timeout-job", marshalJob(createTestJob("timeout-job"))))
+	assert.NilError(t, ts.service.timeoutHandler(ctx, "timeout-job", nil))
+	assert.Equal(t, len(mq.pushCalls), 1)
+
+	// A failed build is not worth another attempt.
+	assert.NilError(t, ts.service.updateStatus(ctx, "", "timeout-job", nil, patrick.JobStatusFailed, nil))
+	dead, err := ts.service.deadLetters(ctx)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, dead, []string{"timeout-job"})
+}
+
+func TestSetRetryPolicyHandler(t *testing.T) {
+	ctx := t.Context()
+	ts := createTestSetup(false)
+
+	// The policy reaches the handler as cbor decodes it off the stream.
+	data, err := cbor.Marshal(&patrick.RetryPolicy{MaxAttempts: 5, Backoff: 30, MaxBackoff: 300})
+	assert.NilError(t, err)
+	var policy interface{}
+	assert.NilError(t, cbor.Unmarshal(data, &policy))
+
+	assert.NilError(t, ts.service.setRetryPolicyHandler(ctx, command.Body{"project": "project-456", "policy": policy}))
+	resp, err := ts.service.retryPolicyHandler(ctx, command.Body{"project": "project-456"})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, resp["policy"], &patrick.RetryPolicy{MaxAttempts: 5, Backoff: 30, MaxBackoff: 300})
+
+	err = ts.service.setRetryPolicyHandler(ctx, command.Body{"project": "project-456", "policy": map[interface{}]interface{}{uint64(1): int64(-1)}})
+	assert.ErrorContains(t, err, "negative")
+
+	// Without a policy the project goes back to the default.
+	assert.NilError(t, ts.service.setRetryPolicyHandler(ctx, command.Body{"project": "project-456"}))
+	resp, err = ts.service.retryPolicyHandler(ctx, command.Body{"project": "project-456"})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, resp["policy"], defaultRetryPolicy())
+}
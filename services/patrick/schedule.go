@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	commonIface "github.com/taubyte/tau/core/services/patrick"
+	"github.com/taubyte/tau/p2p/streams/command"
+	cr "github.com/taubyte/tau/p2p/streams/command/response"
+	"github.com/taubyte/tau/pkg/raft"
+	commonSpec "github.com/taubyte/tau/pkg/specs/common"
+	"github.com/taubyte/tau/utils/maps"
+)
+
+// Job priorities, per-project concurrency caps and fair-share dequeueing
+
+const schedulePolicyPrefix = "/policies/schedule/"
+
+// schedule is what the queue keeps of a job to pick the next one to build.
+type schedule struct {
+	Project  string               `cbor:"1,keyasint"`
+	Priority commonIface.Priority `cbor:"2,keyasint"`
+	// NotBefore is the unix time before which the job is not due.
+	NotBefore int64 `cbor:"3,keyasint"`
+}
+
+// jobPriority builds pushes to the main branches ahead of other branches.
+func jobPriority(job *commonIface.Job) commonIface.Priority {
+	if slices.Contains(commonSpec.DefaultBranches, job.Meta.Repository.Branch) {
+		return commonIface.PriorityHigh
+	}
+	return commonIface.PriorityNormal
+}
+
+// enqueue pushes a job onto the queue along with what it is scheduled by.
+func (p *PatrickService) enqueue(job *commonIface.Job) error {
+	data, err := cbor.Marshal(schedule{Project: job.Project, Priority: job.Priority, NotBefore: job.NotBefore})
+	if err != nil {
+		return fmt.Errorf("marshal schedule of job %s failed with %w", job.Id, err)
+	}
+
+	return p.jobQueue.Push(job.Id, data, 5*time.Second)
+}
+
+// dequeue pops the job to build next: the highest priority first, and among
+// equal priorities the one of the project with the fewest jobs building.
+// Jobs of projects at their concurrency cap, and jobs backing off, wait.
+func (p *PatrickService) dequeue(ctx context.Context) (string, *schedule, error) {
+	running, err := p.runningByProject(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+
+	caps := make(map[string]int)
+	capOf := func(project string) int {
+		if limit, ok := caps[project]; ok {
+			return limit
+		}
+
+		limit := 0
+		if project != "" {
+			if policy, err := p.schedulePolicy(ctx, project); err == nil {
+				limit = policy.MaxConcurrent
+			}
+		}
+		caps[project] = limit
+
+		return limit
+	}
+
+	now := time.Now().Unix()
+	var picked schedule
+	id, _, err := p.jobQueue.PopFunc(func(items []raft.QueueItem) int {
+		best := -1
+		for i, item := range items {
+			// Jobs queued without a schedule are scheduled as unknown projects at normal priority.
+			var s schedule
+			cbor.Unmarshal(item.Data, &s)
+
+			if s.NotBefore > now {
+				continue
+			}
+
+			if limit := capOf(s.Project); limit > 0 && running[s.Project] >= limit {
+				continue
+			}
+
+			if best < 0 || s.Priority > picked.Priority || (s.Priority == picked.Priority && running[s.Project] < running[picked.Project]) {
+				best, picked = i, s
+			}
+		}
+
+		return best
+	}, 5*time.Second)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return id, &picked, nil
+}
+
+// runningByProject counts the jobs of each project monkeys are building.
+// Assignments older than the reannounce window are not counted: their jobs
+// are back on the queue.
+func (p *PatrickService) runningByProject(ctx context.Context) (map[string]int, error) {
+	keys, err := p.db.List(ctx, "/assigned/")
+	if err != nil {
+		return nil, fmt.Errorf("failed listing assignments with %w", err)
+	}
+
+	running := make(map[string]int)
+	for _, key := range keys {
+		data, err := p.db.Get(ctx, key)
+		if err != nil {
+			continue
+		}
+
+		var assignment Assignment
+		if err = cbor.Unmarshal(data, &assignment); err != nil {
+			continue
+		}
+
+		if time.Now().Unix()-assignment.Timestamp > int64(p.reAnnounceJobTime.Seconds()) {
+			continue
+		}
+
+		running[assignment.Project]++
+	}
+
+	return running, nil
+}
+
+// schedulePolicy returns the policy of a project; projects without one are uncapped.
+func (p *PatrickService) schedulePolicy(ctx context.Context, projectId string) (*commonIface.SchedulePolicy, error) {
+	data, err := p.db.Get(ctx, schedulePolicyPrefix+projectId)
+	if err != nil {
+		return &commonIface.SchedulePolicy{}, nil
+	}
+
+	var policy commonIface.SchedulePolicy
+	if err = cbor.Unmarshal(data, &policy); err != nil {
+		return nil, fmt.Errorf("unmarshal schedule policy of %s failed with %w", projectId, err)
+	}
+
+	return &policy, nil
+}
+
+// setSchedulePolicy sets the policy of a project; a nil policy removes it.
+func (p *PatrickService) setSchedulePolicy(ctx context.Context, projectId string, policy *commonIface.SchedulePolicy) error {
+	if projectId == "" {
+		return errors.New("schedule policy needs a project id")
+	}
+
+	if policy == nil {
+		return p.db.Delete(ctx, schedulePolicyPrefix+projectId)
+	}
+
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+
+	data, err := cbor.Marshal(policy)
+	if err != nil {
+		return fmt.Errorf("marshal schedule policy failed with %w", err)
+	}
+
+	return p.db.Put(ctx, schedulePolicyPrefix+projectId, data)
+}
+
+func (p *PatrickService) schedulePolicyHandler(ctx context.Context, body command.Body) (cr.Response, error) {
+	projectId, err := maps.String(body, "project")
+	if err != nil {
+		return nil, fmt.Errorf("failed getting project from body with error: %v", err)
+	}
+
+	policy, err := p.schedulePolicy(ctx, projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	return cr.Response{"policy": policy}, nil
+}
+
+func (p *PatrickService) setSchedulePolicyHandler(ctx context.Context, body command.Body) error {
+	projectId, err := maps.String(body, "project")
+	if err != nil {
+		return fmt.Errorf("failed getting project from body with error: %v", err)
+	}
+
+	var policy commonIface.SchedulePolicy
+	ok, err := policyFromBody(body, &policy)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return p.setSchedulePolicy(ctx, projectId, nil)
+	}
+
+	return p.setSchedulePolicy(ctx, projectId, &policy)
+}
+
+// policyFromBody decodes the "policy" of a request into policy. It reports
+// false when the request has none.
+func policyFromBody(body command.Body, policy interface{}) (bool, error) {
+	_policy, ok := body["policy"]
+	if !ok || _policy == nil {
+		return false, nil
+	}
+
+	policy_bytes, err := cbor.Marshal(_policy)
+	if err != nil {
+		return false, fmt.Errorf("marshal policy failed with %w", err)
+	}
+
+	if err = cbor.Unmarshal(policy_bytes, policy); err != nil {
+		return false, fmt.Errorf("unmarshal policy failed with %w", err)
+	}
+
+	return true, nil
+}
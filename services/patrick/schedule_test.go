@@ -0,0 +1,127 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/taubyte/tau/core/services/patrick"
+	"github.com/taubyte/tau/pkg/raft"
+	"gotest.tools/v3/assert"
+)
+
+func queueItem(id, project string, priority patrick.Priority) raft.QueueItem {
+	data, _ := cbor.Marshal(schedule{Project: project, Priority: priority})
+	return raft.QueueItem{ID: id, Data: data}
+}
+
+func addJobBuilding(t *testing.T, s *PatrickService, jid, project string) {
+	data, err := cbor.Marshal(Assignment{MonkeyPID: "monkey", Timestamp: time.Now().Unix(), Project: project})
+	assert.NilError(t, err)
+	assert.NilError(t, s.db.Put(t.Context(), "/assigned/"+jid, data))
+}
+
+func TestDequeuePriorityAndFairShare(t *testing.T) {
+	ctx := t.Context()
+	service := createTestService()
+	service.reAnnounceJobTime = time.Minute
+	mq := &mockJobQueue{items: []raft.QueueItem{
+		queueItem("a-feature", "project-a", patrick.PriorityNormal),
+		queueItem("a-main", "project-a", patrick.PriorityHigh),
+		queueItem("legacy", "", patrick.PriorityNormal),
+		queueItem("b-main", "project-b", patrick.PriorityHigh),
+	}}
+	service.jobQueue = mq
+
+	// project-a already has a job building, so project-b goes first.
+	addJobBuilding(t, service, "a-building", "project-a")
+
+	var order []string
+	for {
+		id, _, err := service.dequeue(ctx)
+		if err != nil {
+			assert.ErrorIs(t, err, raft.ErrQueueEmpty)
+			break
+		}
+		order = append(order, id)
+	}
+
+	assert.DeepEqual(t, order, []string{"b-main", "a-main", "legacy", "a-feature"})
+}
+
+func TestDequeueConcurrencyCap(t *testing.T) {
+	ctx := t.Context()
+	service := createTestService()
+	service.reAnnounceJobTime = time.Minute
+	service.jobQueue = &mockJobQueue{items: []raft.QueueItem{
+		queueItem("a-1", "project-a", patrick.PriorityHigh),
+		queueItem("b-1", "project-b", patrick.PriorityNormal),
+	}}
+
+	assert.NilError(t, service.setSchedulePolicy(ctx, "project-a", &patrick.SchedulePolicy{MaxConcurrent: 1}))
+	addJobBuilding(t, service, "a-building", "project-a")
+
+	assert.NilError(t, service.db.Put(ctx, "/jobs/b-1", marshalJob(createTestJob("b-1"))))
+	resp, err := service.dequeueHandler(ctx, &mockConnection{remotePeer: peer.ID("monkey-1")})
+	assert.NilError(t, err)
+	assert.Equal(t, resp["job"].(*patrick.Job).Id, "b-1")
+
+	// The assignment records the project, so it counts against project-b's cap too.
+	running, err := service.runningByProject(ctx)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, running, map[string]int{"project-a": 1, "project-b": 1})
+
+	// project-a is at its cap: its job waits.
+	resp, err = service.dequeueHandler(ctx, &mockConnection{remotePeer: peer.ID("monkey-2")})
+	assert.NilError(t, err)
+	assert.Equal(t, resp["available"], false)
+
+	// A stale assignment no longer holds a slot.
+	service.reAnnounceJobTime = 0
+	data, _ := cbor.Marshal(Assignment{MonkeyPID: "monkey", Timestamp: time.Now().Unix() - 60, Project: "project-a"})
+	assert.NilError(t, service.db.Put(ctx, "/assigned/a-building", data))
+	id, _, err := service.dequeue(ctx)
+	assert.NilError(t, err)
+	assert.Equal(t, id, "a-1")
+}
+
+func TestDequeueNotBefore(t *testing.T) {
+	ctx := t.Context()
+	service := createTestService()
+	service.reAnnounceJobTime = time.Minute
+
+	later, _ := cbor.Marshal(schedule{Project: "project-a", Priority: patrick.PriorityHigh, NotBefore: time.Now().Unix() + 60})
+	due, _ := cbor.Marshal(schedule{Project: "project-b", Priority: patrick.PriorityNormal, NotBefore: time.Now().Unix() - 1})
+	service.jobQueue = &mockJobQueue{items: []raft.QueueItem{
+		{ID: "backing-off", Data: later},
+		{ID: "due", Data: due},
+	}}
+
+	id, _, err := service.dequeue(ctx)
+	assert.NilError(t, err)
+	assert.Equal(t, id, "due")
+
+	// A job backing off stays queued.
+	_, _, err = service.dequeue(ctx)
+	assert.ErrorIs(t, err, raft.ErrQueueEmpty)
+}
+
+func TestRegisterJobSchedules(t *testing.T) {
+	ts := createTestSetup(false)
+	mq := &mockJobQueue{}
+	ts.service.jobQueue = mq
+
+	job := createGitHubTestJob("main-job")
+	assert.NilError(t, ts.service.RegisterJob(t.Context(), job))
+
+	stored, err := ts.service.getJob(t.Context(), "/jobs/", "main-job")
+	assert.NilError(t, err)
+	assert.Equal(t, stored.Project, "project-456")
+	assert.Equal(t, stored.Priority, patrick.PriorityHigh)
+
+	assert.Equal(t, len(mq.pushCalls), 1)
+	var s schedule
+	assert.NilError(t, cbor.Unmarshal(mq.pushCalls[0].data, &s))
+	assert.Equal(t, s, schedule{Project: "project-456", Priority: patrick.PriorityHigh})
+}
@@ -52,4 +52,5 @@ type PatrickService struct {
 type Assignment struct {
 	MonkeyPID string `cbor:"1,keyasint"`
 	Timestamp int64  `cbor:"2,keyasint"`
+	Project   string `cbor:"3,keyasint"`
 }
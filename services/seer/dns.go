@@ -61,6 +61,7 @@ func (seer *Service) newDnsServer(devMode bool, port int) error {
 	//Create cache nodes and spam requests
 	seer.positiveCache = ttlcache.New(ttlcache.WithTTL[string, []string](PositiveCacheTTL), ttlcache.WithDisableTouchOnHit[string, []string]())
 	seer.negativeCache = ttlcache.New(ttlcache.WithTTL[string, bool](DefaultBlockTime), ttlcache.WithDisableTouchOnHit[string, bool]())
+	seer.recordsCache = ttlcache.New(ttlcache.WithTTL[string, zone](PositiveCacheTTL), ttlcache.WithDisableTouchOnHit[string, zone]())
 
 	// Create TCP and UDP
 	validate.UseResolver(seer.dnsResolver)
@@ -78,6 +79,7 @@ func (seer *Service) newDnsServer(devMode bool, port int) error {
 
 	go seer.positiveCache.Start()
 	go seer.negativeCache.Start()
+	go seer.recordsCache.Start()
 
 	return nil
 }
@@ -165,6 +167,12 @@ func (h *dnsHandler) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
 				h.replyWithHTTPServicingNodes(ctx, w, r, errMsg, msg)
 				return
 			}
+
+			// a name a registered domain publishes records for
+			if rrs := h.records(name); len(rrs) > 0 {
+				h.replyWithRecords(w, r, errMsg, msg, rrs)
+				return
+			}
 		} else { // we have it, don't fetch it again
 			logger.Debugf("We have %s, it's a registered domain", name)
 			h.replyWithHTTPServicingNodes(ctx, w, r, errMsg, msg)
@@ -257,6 +265,15 @@ func (h *dnsHandler) tauDnsResolve(ctx context.Context, service, name string, w
 }
 
 func (h *dnsHandler) replyWithHTTPServicingNodes(ctx context.Context, w dns.ResponseWriter, r *dns.Msg, errMsg *dns.Msg, msg dns.Msg) {
+	// records published by the domain answer anything but its addresses
+	if qtype := r.Question[0].Qtype; qtype != dns.TypeA && qtype != dns.TypeAAAA {
+		name := strings.ToLower(strings.TrimSuffix(r.Question[0].Name, "."))
+		if rrs := h.records(name); len(matching(rrs, qtype)) > 0 {
+			h.replyWithRecords(w, r, errMsg, msg, rrs)
+			return
+		}
+	}
+
 	nodeIps, err := h.getServiceIpWithCache(ctx, "gateway", func(id string, ts int64, usage *iface.UsageData) bool {
 		if h.seer.poe != nil {
 			usageMap := usage.ToMap()
@@ -299,11 +316,21 @@ func (h *dnsHandler) replyWithHTTPServicingNodes(ctx context.Context, w dns.Resp
 	switch r.Question[0].Qtype {
 	case dns.TypeA:
 		for _, ip := range nodeIps {
-			msg.Answer = append(msg.Answer, &dns.A{
-				Hdr: dns.RR_Header{Name: r.Question[0].Name, Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: 60},
-				A:   net.ParseIP(ip),
-			})
-
+			if addr := net.ParseIP(ip).To4(); addr != nil {
+				msg.Answer = append(msg.Answer, &dns.A{
+					Hdr: dns.RR_Header{Name: r.Question[0].Name, Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: 60},
+					A:   addr,
+				})
+			}
+		}
+	case dns.TypeAAAA:
+		for _, ip := range nodeIps {
+			if addr := net.ParseIP(ip); addr != nil && addr.To4() == nil {
+				msg.Answer = append(msg.Answer, &dns.AAAA{
+					Hdr:  dns.RR_Header{Name: r.Question[0].Name, Rrtype: dns.TypeAAAA, Class: dns.ClassINET, Ttl: 60},
+					AAAA: addr,
+				})
+			}
 		}
 	case dns.TypeCAA:
 		msg.Answer = append(msg.Answer, &dns.CAA{
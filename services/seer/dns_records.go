@@ -0,0 +1,132 @@
+package seer
+
+import (
+	"strings"
+
+	"github.com/miekg/dns"
+	dv "github.com/taubyte/domain-validation"
+	spec "github.com/taubyte/tau/pkg/specs/common"
+	domainSpecs "github.com/taubyte/tau/pkg/specs/domain"
+	"github.com/taubyte/tau/pkg/specs/extract"
+	"github.com/taubyte/tau/utils/records"
+)
+
+// records returns the records name owns, published by the nearest registered
+// domain name is, or is a subdomain of.
+func (h *dnsHandler) records(name string) []dns.RR {
+	owner := dns.Fqdn(name)
+	for fqdn := name; strings.Contains(fqdn, "."); fqdn = fqdn[strings.IndexByte(fqdn, '.')+1:] {
+		rrs, registered := h.domainRecords(fqdn)
+		if !registered {
+			continue
+		}
+
+		owned := make([]dns.RR, 0, len(rrs))
+		for _, rr := range rrs {
+			if rr.Header().Name == owner {
+				owned = append(owned, rr)
+			}
+		}
+
+		return owned
+	}
+
+	return nil
+}
+
+// domainRecords returns the records published by the domain fqdn, and whether
+// it is registered. Only the projects that validated fqdn get to publish.
+func (h *dnsHandler) domainRecords(fqdn string) ([]dns.RR, bool) {
+	if item := h.seer.recordsCache.Get(fqdn); item != nil {
+		z := item.Value()
+		return z.records, z.registered
+	}
+
+	z := h.fetchDomainRecords(fqdn)
+	h.seer.recordsCache.Set(fqdn, z, PositiveCacheTTL)
+
+	return z.records, z.registered
+}
+
+func (h *dnsHandler) fetchDomainRecords(fqdn string) zone {
+	path, err := domainSpecs.Tns().BasicPath(fqdn)
+	if err != nil {
+		return zone{}
+	}
+
+	obj, err := h.seer.tns.Fetch(path.Versioning().Links())
+	if err != nil || obj.Interface() == nil {
+		return zone{}
+	}
+
+	z := zone{registered: true}
+
+	// compiled before domains linked their fqdn
+	links, ok := obj.Interface().([]interface{})
+	if !ok {
+		return z
+	}
+
+	for _, link := range links {
+		index, ok := link.(string)
+		if !ok {
+			continue
+		}
+
+		parser, err := extract.Tns().BasicPath(index)
+		if err != nil {
+			logger.Errorf("parsing domain index `%s` failed with: %s", index, err.Error())
+			continue
+		}
+
+		domain, err := h.seer.tns.Domain().Relative(parser.Project(), parser.Application(), spec.DefaultBranches...).GetById(parser.Resource())
+		if err != nil || domain == nil || len(domain.Records) == 0 {
+			continue
+		}
+
+		if err := domainSpecs.ValidateDNS(h.seer.config.GeneratedDomainRegExp(), parser.Project(), fqdn, h.seer.devMode, dv.PublicKey(h.seer.config.DomainValidation().PublicKey)); err != nil {
+			logger.Errorf("not serving records of `%s` for project `%s` as validating it failed with: %s", fqdn, parser.Project(), err.Error())
+			continue
+		}
+
+		for _, record := range domain.Records {
+			rr, err := records.Parse(fqdn, record)
+			if err != nil {
+				logger.Errorf("parsing record `%s` of `%s` failed with: %s", record, fqdn, err.Error())
+				continue
+			}
+
+			z.records = append(z.records, rr)
+		}
+	}
+
+	return z
+}
+
+// matching returns the records answering a question of type qtype: those of
+// that type, or the CNAME the name is an alias by.
+func matching(rrs []dns.RR, qtype uint16) []dns.RR {
+	answer := make([]dns.RR, 0, len(rrs))
+	for _, rr := range rrs {
+		if rrtype := rr.Header().Rrtype; rrtype == qtype || rrtype == dns.TypeCNAME {
+			answer = append(answer, dns.Copy(rr))
+		}
+	}
+
+	return answer
+}
+
+// replyWithRecords answers r with the records of rrs matching its question.
+// Having no matching record is not an error, as the name exists.
+func (h *dnsHandler) replyWithRecords(w dns.ResponseWriter, r *dns.Msg, errMsg *dns.Msg, msg dns.Msg, rrs []dns.RR) {
+	for _, rr := range matching(rrs, r.Question[0].Qtype) {
+		// keep the case the question was asked in
+		rr.Header().Name = r.Question[0].Name
+		msg.Answer = append(msg.Answer, rr)
+	}
+
+	if err := w.WriteMsg(&msg); err != nil {
+		logger.Errorf("writing records for `%s` failed with: %s", r.Question[0].Name, err.Error())
+		w.WriteMsg(errMsg)
+	}
+}
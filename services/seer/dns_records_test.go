@@ -0,0 +1,81 @@
+package seer
+
+import (
+	"testing"
+
+	"github.com/jellydator/ttlcache/v3"
+	"github.com/miekg/dns"
+	"github.com/taubyte/tau/utils/records"
+	"gotest.tools/v3/assert"
+)
+
+func newRecordsHandler(zones map[string]zone) *dnsHandler {
+	cache := ttlcache.New(ttlcache.WithTTL[string, zone](PositiveCacheTTL))
+	for fqdn, z := range zones {
+		cache.Set(fqdn, z, ttlcache.DefaultTTL)
+	}
+
+	return &dnsHandler{seer: &Service{recordsCache: cache}}
+}
+
+func mustRecords(t *testing.T, fqdn string, rs ...string) []dns.RR {
+	rrs := make([]dns.RR, len(rs))
+	for i, r := range rs {
+		rr, err := records.Parse(fqdn, r)
+		assert.NilError(t, err)
+		rrs[i] = rr
+	}
+
+	return rrs
+}
+
+func TestRecords(t *testing.T) {
+	h := newRecordsHandler(map[string]zone{
+		"example.com": {registered: true, records: mustRecords(t, "example.com",
+			"@ MX 10 mail.example.com.",
+			"_dmarc TXT \"v=DMARC1; p=none\"",
+			"www.blog CNAME blog.example.net.",
+		)},
+		"_dmarc.example.com":   {},
+		"blog.example.com":     {},
+		"www.blog.example.com": {},
+		"other.example.com":    {},
+		"shop.example.com":     {registered: true},
+		"www.shop.example.com": {},
+	})
+
+	rrs := h.records("example.com")
+	assert.Equal(t, len(rrs), 1)
+	assert.Equal(t, rrs[0].Header().Rrtype, dns.TypeMX)
+
+	rrs = h.records("_dmarc.example.com")
+	assert.Equal(t, len(rrs), 1)
+	assert.Equal(t, rrs[0].Header().Rrtype, dns.TypeTXT)
+
+	rrs = h.records("www.blog.example.com")
+	assert.Equal(t, len(rrs), 1)
+	assert.Equal(t, rrs[0].Header().Rrtype, dns.TypeCNAME)
+
+	assert.Equal(t, len(h.records("other.example.com")), 0)
+
+	// the nearest registered domain publishes for its subdomains
+	assert.Equal(t, len(h.records("www.shop.example.com")), 0)
+}
+
+func TestMatching(t *testing.T) {
+	rrs := mustRecords(t, "example.com",
+		"@ MX 10 mail.example.com.",
+		"@ TXT \"v=spf1 -all\"",
+		"@ TXT \"verification=1234\"",
+		"www CNAME example.net.",
+	)
+
+	assert.Equal(t, len(matching(rrs, dns.TypeTXT)), 3)
+	assert.Equal(t, len(matching(rrs, dns.TypeMX)), 2)
+	assert.Equal(t, len(matching(rrs[:3], dns.TypeCAA)), 0)
+
+	// answers are copies, safe to rename
+	answer := matching(rrs, dns.TypeMX)
+	answer[0].Header().Name = "EXAMPLE.COM."
+	assert.Equal(t, rrs[0].Header().Name, "example.com.")
+}
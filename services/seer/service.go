@@ -125,5 +125,6 @@ func (srv *Service) Close() error {
 
 	srv.positiveCache.Stop()
 	srv.negativeCache.Stop()
+	srv.recordsCache.Stop()
 	return nil
 }
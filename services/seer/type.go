@@ -32,6 +32,12 @@ type dnsServer struct {
 	Seer *Service
 }
 
+// zone is what seer serves for a domain besides the nodes serving it.
+type zone struct {
+	registered bool
+	records    []dns.RR
+}
+
 type nodeData struct {
 	Cid string `cbor:"1,keyasint,omitempty"`
 
@@ -59,6 +65,7 @@ type Service struct {
 	dns           *dnsServer
 	positiveCache *ttlcache.Cache[string, []string]
 	negativeCache *ttlcache.Cache[string, bool]
+	recordsCache  *ttlcache.Cache[string, zone]
 
 	config config.Config
 
@@ -1,17 +1,25 @@
 package substrate
 
 import (
+	"crypto/tls"
 	"fmt"
+	"path"
+	"time"
 
 	"github.com/taubyte/tau/core/services/substrate"
 	"github.com/taubyte/tau/pkg/config"
+	"github.com/taubyte/tau/pkg/raft"
 	counters "github.com/taubyte/tau/services/substrate/components/counters"
+	cron "github.com/taubyte/tau/services/substrate/components/cron"
 	database "github.com/taubyte/tau/services/substrate/components/database"
 	http "github.com/taubyte/tau/services/substrate/components/http"
 	p2p "github.com/taubyte/tau/services/substrate/components/p2p"
 	pubSub "github.com/taubyte/tau/services/substrate/components/pubsub"
+	queue "github.com/taubyte/tau/services/substrate/components/queue"
 	smartOps "github.com/taubyte/tau/services/substrate/components/smartops"
 	storage "github.com/taubyte/tau/services/substrate/components/storage"
+	"github.com/taubyte/tau/services/substrate/components/trigger"
+	watch "github.com/taubyte/tau/services/substrate/components/watch"
 )
 
 func attachNodesError(name string, err error) error {
@@ -22,12 +30,16 @@ func attachNodesError(name string, err error) error {
 }
 
 func (srv *Service) AttachCounters(counter substrate.CounterService) {
+	if srv.components.counters != nil {
+		srv.components.counters.Close()
+	}
+
 	srv.components.counters = counter
 }
 
 func (srv *Service) attachNodes(cfg config.Config) (err error) {
 	// Needs to happen first, as others depend on it
-	if err = srv.attachNodeCounters(); err != nil {
+	if err = srv.attachNodeCounters(cfg); err != nil {
 		return attachNodesError("counters", err)
 	}
 
@@ -36,7 +48,7 @@ func (srv *Service) attachNodes(cfg config.Config) (err error) {
 		return attachNodesError("smartops", err)
 	}
 
-	if err = srv.attachNodePubSub(); err != nil {
+	if err = srv.attachNodePubSub(cfg); err != nil {
 		return attachNodesError("pubsub", err)
 	}
 
@@ -56,6 +68,18 @@ func (srv *Service) attachNodes(cfg config.Config) (err error) {
 		return attachNodesError("http", err)
 	}
 
+	if err = srv.attachNodeCron(cfg); err != nil {
+		return attachNodesError("cron", err)
+	}
+
+	if err = srv.attachNodeQueue(cfg); err != nil {
+		return attachNodesError("queue", err)
+	}
+
+	if err = srv.attachNodeWatch(cfg); err != nil {
+		return attachNodesError("watch", err)
+	}
+
 	return nil
 }
 
@@ -64,8 +88,29 @@ func (srv *Service) attachNodeHttp(cfg config.Config) (err error) {
 	return
 }
 
-func (srv *Service) attachNodePubSub() (err error) {
-	srv.components.pubsub, err = pubSub.New(srv)
+// attachNodePubSub signs MQTT tokens with a secret every node of the cloud
+// shares, its swarm key or else its domain validation key, so that a token
+// issued by one node is accepted by all of them.
+func (srv *Service) attachNodePubSub(cfg config.Config) (err error) {
+	secret := cfg.SwarmKey()
+	if len(secret) == 0 {
+		secret = cfg.DomainValidation().PrivateKey
+	}
+
+	opts := []pubSub.Option{pubSub.MQTT(cfg.Ports()["mqtt"], secret)}
+	if mqttCfg := cfg.MQTT(); mqttCfg.Cert != "" || mqttCfg.Key != "" {
+		cert, err := tls.LoadX509KeyPair(mqttCfg.Cert, mqttCfg.Key)
+		if err != nil {
+			return fmt.Errorf("loading mqtt certificate failed with: %w", err)
+		}
+
+		opts = append(opts, pubSub.MQTTTLS(&tls.Config{
+			Certificates: []tls.Certificate{cert},
+			MinVersion:   tls.VersionTLS12,
+		}))
+	}
+
+	srv.components.pubsub, err = pubSub.New(srv, opts...)
 	return
 }
 
@@ -84,8 +129,8 @@ func (srv *Service) attachNodeP2P() (err error) {
 	return
 }
 
-func (srv *Service) attachNodeCounters() (err error) {
-	srv.components.counters, err = counters.New(srv)
+func (srv *Service) attachNodeCounters(cfg config.Config) (err error) {
+	srv.components.counters, err = counters.New(srv, counters.Sensors(cfg.SensorsRegistry()))
 	return
 }
 
@@ -93,3 +138,48 @@ func (srv *Service) attachNodeSmartOps() (err error) {
 	srv.components.smartops, err = smartOps.New(srv)
 	return
 }
+
+func (srv *Service) attachNodeCron(cfg config.Config) (err error) {
+	namespace, raftOpts := raftNamespace(cfg, "cron")
+	srv.components.cron, err = cron.New(srv, trigger.Cluster(namespace, raftOpts...))
+	return
+}
+
+func (srv *Service) attachNodeQueue(cfg config.Config) (err error) {
+	namespace, raftOpts := raftNamespace(cfg, "queues")
+	srv.components.queue, err = queue.New(srv, trigger.Cluster(namespace, raftOpts...))
+	return
+}
+
+func (srv *Service) attachNodeWatch(cfg config.Config) (err error) {
+	namespace, raftOpts := raftNamespace(cfg, "watches")
+	srv.components.watch, err = watch.New(srv, srv.components.database, trigger.Cluster(namespace, raftOpts...))
+	return
+}
+
+// raftNamespace returns the namespace of the raft cluster name of the cloud,
+// which all its substrate nodes join, and the options to start it with.
+func raftNamespace(cfg config.Config, name string) (string, []raft.Option) {
+	cluster := cfg.Cluster()
+	if cluster == "" {
+		cluster = "main"
+	}
+
+	namespace := path.Join(cluster, name)
+	raftOpts := []raft.Option{raft.WithSnapshotDir(raft.SnapshotDir(cfg.Root(), cfg.Shape(), namespace))}
+	if cfg.DevMode() {
+		raftOpts = append(raftOpts,
+			raft.WithBootstrapTimeout(5*time.Second),
+			raft.WithTimeouts(raft.TimeoutConfig{
+				HeartbeatTimeout:   1 * time.Second,
+				ElectionTimeout:    1 * time.Second,
+				CommitTimeout:      500 * time.Millisecond,
+				LeaderLeaseTimeout: 500 * time.Millisecond,
+				SnapshotInterval:   2 * time.Minute,
+				SnapshotThreshold:  8192,
+			}),
+		)
+	}
+
+	return namespace, raftOpts
+}
@@ -7,7 +7,10 @@ import (
 	pubSubIface "github.com/taubyte/tau/core/services/substrate/components/pubsub"
 	storageIface "github.com/taubyte/tau/core/services/substrate/components/storage"
 	tbPlugins "github.com/taubyte/tau/pkg/vm-low-orbit"
+	cronIface "github.com/taubyte/tau/services/substrate/components/cron"
 	httpIface "github.com/taubyte/tau/services/substrate/components/http"
+	queueIface "github.com/taubyte/tau/services/substrate/components/queue"
+	watchIface "github.com/taubyte/tau/services/substrate/components/watch"
 )
 
 // TODO: All of these components interfaces can be removed
@@ -17,6 +20,9 @@ type components struct {
 	database databaseIface.Service
 	storage  storageIface.Service
 	p2p      p2pIface.Service
+	cron     *cronIface.Service
+	queue    *queueIface.Service
+	watch    *watchIface.Service
 	counters iface.CounterService
 	smartops iface.SmartOpsService
 }
@@ -27,15 +33,21 @@ func (c *components) config() []tbPlugins.Option {
 		tbPlugins.DatabaseNode(c.database),
 		tbPlugins.StorageNode(c.storage),
 		tbPlugins.P2PNode(c.p2p),
+		tbPlugins.WebSocketNode(c.http.Sockets()),
+		tbPlugins.QueueNode(c.queue),
 	}
 }
 
 func (c *components) close() {
 	c.http.Close()
 	c.pubsub.Close()
+	// watches read from databases
+	c.watch.Close()
 	c.database.Close()
 	c.storage.Close()
 	c.p2p.Close()
+	c.cron.Close()
+	c.queue.Close()
 	c.counters.Close()
 	c.smartops.Close()
 }
@@ -0,0 +1,112 @@
+package counters_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/taubyte/tau/core/services/seer"
+	iface "github.com/taubyte/tau/core/services/substrate/counters"
+	"github.com/taubyte/tau/p2p/peer"
+	"github.com/taubyte/tau/pkg/sensors"
+	"github.com/taubyte/tau/services/substrate/components/counters"
+	"github.com/taubyte/tau/services/substrate/components/counters/metrics"
+	"github.com/taubyte/tau/services/substrate/components/structure"
+	"gotest.tools/v3/assert"
+)
+
+func TestCounters(t *testing.T) {
+	node := peer.Mock(t.Context())
+	registry := sensors.NewRegistry()
+
+	c, err := counters.New(structure.MockNodeService(node, t.Context()), counters.Sensors(registry), counters.ReportInterval(time.Hour))
+	assert.NilError(t, err)
+	defer c.Close()
+
+	path := iface.NewPath("project/function").Success()
+	push := func() {
+		c.Push(
+			&iface.WrappedMetric{Key: path.String(), Metric: metrics.NewSumMetric[uint64](1)},
+			&iface.WrappedMetric{Key: path.Time().String(), Metric: metrics.NewSumMetric[int64](10)},
+			&iface.WrappedMetric{Key: path.Memory().String(), Metric: metrics.NewMaxMetric[uint64](64)},
+		)
+	}
+
+	push()
+	push()
+	c.Flush()
+	assert.Equal(t, c.Totals()[path.String()], float64(2))
+
+	push()
+	c.Flush()
+
+	totals := c.Totals()
+	assert.Equal(t, totals[path.String()], float64(3))
+	assert.Equal(t, totals[path.Time().String()], float64(30))
+	assert.Equal(t, totals[path.Memory().String()], float64(64))
+
+	value, ok, err := registry.Get(iface.SensorName(path.String()))
+	assert.NilError(t, err)
+	assert.Assert(t, ok)
+	assert.Equal(t, value, float64(3))
+}
+
+func TestSensorNames(t *testing.T) {
+	path := iface.NewPath("project/function").Success()
+
+	assert.Equal(t, iface.SensorName(path.String()), "substrate.counters.project.function.s.count")
+	assert.Equal(t, iface.SensorName(path.Time().String()), "substrate.counters.project.function.s.time")
+	assert.Equal(t, iface.SensorName(path.Fuel().String()), "substrate.counters.project.function.s.fuel")
+	assert.Equal(t, iface.SensorName("project/api.example.com/s"), "substrate.counters.project.api_example_com.s.count")
+
+	// a count and its time nest side by side, whatever the order they are
+	// nested in
+	for range 20 {
+		usage := &seer.UsageData{CustomValues: map[string]float64{
+			iface.SensorName(path.String()):                                     3,
+			iface.SensorName(path.Time().String()):                              30,
+			iface.SensorName(path.ColdStart().Success().String()):               1,
+			iface.SensorName(path.ColdStart().Success().Time().String()):        5,
+			iface.SensorName(iface.NewPath("project/function").Fuel().String()): 100,
+		}}
+
+		custom := usage.ToMap()["custom"].(map[string]any)
+		function := custom["substrate"].(map[string]any)["counters"].(map[string]any)["project"].(map[string]any)["function"].(map[string]any)
+		success := function["s"].(map[string]any)
+
+		assert.Equal(t, success["count"], float64(3))
+		assert.Equal(t, success["time"], float64(30))
+		assert.Equal(t, success["cs"].(map[string]any)["s"].(map[string]any)["time"], float64(5))
+		assert.Equal(t, function["fuel"], float64(100))
+	}
+}
+
+func TestCountersDropIdleTotals(t *testing.T) {
+	node := peer.Mock(t.Context())
+	registry := sensors.NewRegistry()
+
+	c, err := counters.New(structure.MockNodeService(node, t.Context()), counters.Sensors(registry), counters.ReportInterval(time.Hour), counters.IdleTime(50*time.Millisecond))
+	assert.NilError(t, err)
+	defer c.Close()
+
+	idle := iface.NewPath("project/idle").Success()
+	busy := iface.NewPath("project/busy").Success()
+
+	c.Push(
+		&iface.WrappedMetric{Key: idle.String(), Metric: metrics.NewSumMetric[uint64](1)},
+		&iface.WrappedMetric{Key: busy.String(), Metric: metrics.NewSumMetric[uint64](1)},
+	)
+	c.Flush()
+
+	time.Sleep(100 * time.Millisecond)
+	c.Push(&iface.WrappedMetric{Key: busy.String(), Metric: metrics.NewSumMetric[uint64](1)})
+	c.Flush()
+
+	totals := c.Totals()
+	_, ok := totals[idle.String()]
+	assert.Assert(t, !ok, "idle total kept")
+	assert.Equal(t, totals[busy.String()], float64(2))
+
+	_, ok, err = registry.Get(iface.SensorName(idle.String()))
+	assert.NilError(t, err)
+	assert.Assert(t, !ok, "idle sensor kept")
+}
@@ -0,0 +1,138 @@
+package counters
+
+import (
+	"context"
+	"time"
+
+	"github.com/taubyte/tau/core/services/substrate/counters"
+	"github.com/taubyte/tau/services/substrate/components/counters/metrics"
+)
+
+func (s *Service) Push(wms ...*counters.WrappedMetric) {
+	s.ledgerLock.Lock()
+	defer s.ledgerLock.Unlock()
+
+	for _, wm := range wms {
+		if wm == nil || wm.Metric == nil {
+			continue
+		}
+
+		metric, ok := s.ledger[wm.Key]
+		if !ok {
+			s.ledger[wm.Key] = wm.Metric
+			continue
+		}
+
+		if err := metric.Aggregate(wm.Metric); err != nil {
+			logger.Errorf("aggregating metric `%s` failed with: %s", wm.Key, err)
+		}
+	}
+}
+
+func (*Service) Implemented() bool {
+	return true
+}
+
+func (s *Service) Context() context.Context {
+	return s.ctx
+}
+
+// Start runs the flush loop until the service is closed.
+func (s *Service) Start() {
+	go func() {
+		ticker := time.NewTicker(s.reportInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.ctx.Done():
+				return
+			case <-ticker.C:
+				s.Flush()
+			}
+		}
+	}()
+}
+
+// Flush folds the counters aggregated since the previous flush into the
+// running totals, and drops the totals of the counters that have not counted
+// for longer than the idle time.
+func (s *Service) Flush() {
+	s.ledgerLock.Lock()
+	ledger := s.ledger
+	s.ledger = make(map[string]counters.Metric)
+	s.ledgerLock.Unlock()
+
+	now := time.Now()
+
+	s.totalsLock.Lock()
+	defer s.totalsLock.Unlock()
+
+	for key, metric := range ledger {
+		if _, ok := metrics.Float64(metric); !ok {
+			logger.Errorf("metric `%s` of type %T is not numeric", key, metric)
+			continue
+		}
+
+		s.total(key, metric, now)
+	}
+
+	for key, t := range s.totals {
+		if now.Sub(t.updated) > s.idleTime {
+			s.drop(key)
+		}
+	}
+}
+
+func (s *Service) total(key string, metric counters.Metric, now time.Time) {
+	t, ok := s.totals[key]
+	if !ok {
+		t = &total{Metric: metric}
+		s.totals[key] = t
+	} else if err := t.Aggregate(metric); err != nil {
+		logger.Errorf("aggregating total `%s` failed with: %s", key, err)
+		return
+	}
+	t.updated = now
+
+	if s.sensors == nil {
+		return
+	}
+
+	value, _ := metrics.Float64(t.Metric)
+	if err := s.sensors.Set(counters.SensorName(key), value); err != nil {
+		logger.Errorf("setting sensor for `%s` failed with: %s", key, err)
+	}
+}
+
+func (s *Service) drop(key string) {
+	delete(s.totals, key)
+
+	if s.sensors == nil {
+		return
+	}
+
+	if err := s.sensors.Delete(counters.SensorName(key)); err != nil {
+		logger.Errorf("deleting sensor for `%s` failed with: %s", key, err)
+	}
+}
+
+// Totals returns the running totals of the counters this node counted.
+func (s *Service) Totals() map[string]float64 {
+	s.totalsLock.Lock()
+	defer s.totalsLock.Unlock()
+
+	totals := make(map[string]float64, len(s.totals))
+	for key, t := range s.totals {
+		totals[key], _ = metrics.Float64(t.Metric)
+	}
+
+	return totals
+}
+
+func (s *Service) Close() error {
+	s.Flush()
+
+	s.ctxCancel()
+	return nil
+}
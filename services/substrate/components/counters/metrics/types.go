@@ -1,6 +1,9 @@
 package metrics
 
-import "golang.org/x/exp/constraints"
+import (
+	"github.com/taubyte/tau/core/services/substrate/counters"
+	"golang.org/x/exp/constraints"
+)
 
 type singleNumber[T constraints.Integer | constraints.Float] struct {
 	Value T
@@ -21,3 +24,37 @@ func (s *singleNumber[T]) Reset() {
 func (s *singleNumber[T]) Interface() interface{} {
 	return s.Value
 }
+
+// Float64 returns the value of a numeric metric as a float64.
+func Float64(metric counters.Metric) (float64, bool) {
+	switch v := metric.Interface().(type) {
+	case int:
+		return float64(v), true
+	case int8:
+		return float64(v), true
+	case int16:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint8:
+		return float64(v), true
+	case uint16:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case uintptr:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,33 @@
+package counters
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ipfs/go-log/v2"
+	"github.com/taubyte/tau/core/services/substrate"
+	"github.com/taubyte/tau/core/services/substrate/counters"
+)
+
+var logger = log.Logger("tau.substrate.service.counters")
+
+func New(srv substrate.Service, options ...Option) (*Service, error) {
+	s := &Service{
+		Service:        srv,
+		ledger:         make(map[string]counters.Metric),
+		totals:         make(map[string]*total),
+		reportInterval: counters.DefaultReportTime,
+		idleTime:       counters.DefaultIdleTime,
+	}
+
+	for _, opt := range options {
+		if err := opt(s); err != nil {
+			return nil, fmt.Errorf("counters option failed with: %w", err)
+		}
+	}
+
+	s.ctx, s.ctxCancel = context.WithCancel(srv.Context())
+	s.Start()
+
+	return s, nil
+}
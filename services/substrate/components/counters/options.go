@@ -0,0 +1,43 @@
+package counters
+
+import (
+	"errors"
+	"time"
+
+	"github.com/taubyte/tau/pkg/sensors"
+)
+
+type Option func(*Service) error
+
+// Sensors sets the registry the running totals are exposed through.
+func Sensors(registry *sensors.Registry) Option {
+	return func(s *Service) error {
+		s.sensors = registry
+		return nil
+	}
+}
+
+// ReportInterval sets how often aggregated counters are flushed.
+func ReportInterval(interval time.Duration) Option {
+	return func(s *Service) error {
+		if interval <= 0 {
+			return errors.New("report interval must be positive")
+		}
+
+		s.reportInterval = interval
+		return nil
+	}
+}
+
+// IdleTime sets how long the total of a counter that stopped counting is
+// kept, in the registry too, before it is dropped.
+func IdleTime(idle time.Duration) Option {
+	return func(s *Service) error {
+		if idle <= 0 {
+			return errors.New("idle time must be positive")
+		}
+
+		s.idleTime = idle
+		return nil
+	}
+}
@@ -0,0 +1,37 @@
+package counters
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/taubyte/tau/core/services/substrate"
+	"github.com/taubyte/tau/core/services/substrate/counters"
+	"github.com/taubyte/tau/pkg/sensors"
+)
+
+var _ substrate.CounterService = &Service{}
+
+// Service aggregates the counters pushed by the substrate components and
+// periodically folds them into running totals, which are kept in the sensors
+// registry so they travel with the node's seer heartbeat.
+type Service struct {
+	substrate.Service
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+
+	ledger     map[string]counters.Metric
+	ledgerLock sync.Mutex
+	totals     map[string]*total
+	totalsLock sync.Mutex
+
+	sensors        *sensors.Registry
+	reportInterval time.Duration
+	idleTime       time.Duration
+}
+
+// total is the running total of a counter, and when it last counted.
+type total struct {
+	counters.Metric
+	updated time.Time
+}
@@ -0,0 +1,60 @@
+package cron
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/taubyte/tau/clients/p2p/substrate"
+	"github.com/taubyte/tau/p2p/streams/command"
+	structureSpec "github.com/taubyte/tau/pkg/specs/structure"
+	plugins "github.com/taubyte/tau/pkg/vm-low-orbit"
+	"github.com/taubyte/tau/pkg/vm-low-orbit/event"
+	"github.com/taubyte/tau/services/substrate/components/cron/common"
+	"github.com/taubyte/tau/services/substrate/components/trigger"
+)
+
+// dispatch runs the tick of j on a member of the cluster. Members are tried in
+// turn until one of them takes it. A member that timed out may be running the
+// tick all the same, so it counts as having taken it rather than running it
+// twice.
+func (s *Service) dispatch(j *job, tick time.Time) {
+	for _, pid := range s.Members(fmt.Sprintf("%s@%d", j.key(), tick.Unix())) {
+		err := s.send(pid, j, tick)
+		if err == nil {
+			return
+		} else if trigger.Ambiguous(err) {
+			common.Logger.Warnf("running tick %s of `%s` on %s timed out, it may have run: %s", tick.Format(time.RFC3339), j.key(), pid.String(), err.Error())
+			return
+		}
+
+		common.Logger.Warnf("running tick %s of `%s` on %s failed with: %s", tick.Format(time.RFC3339), j.key(), pid.String(), err.Error())
+	}
+
+	common.Logger.Errorf("no node ran tick %s of `%s`", tick.Format(time.RFC3339), j.key())
+}
+
+func (s *Service) send(pid peer.ID, j *job, tick time.Time) error {
+	if pid == s.Node().ID() {
+		return s.Run(&j.MatchDefinition, tick)
+	}
+
+	return s.Send(substrate.CommandCron, command.Body{
+		substrate.BodyProject:     j.Project,
+		substrate.BodyApplication: j.Application,
+		substrate.BodyFunction:    j.Function,
+		substrate.BodyTick:        tick.Unix(),
+	}, pid)
+}
+
+// Run runs the tick of the cron function of matcher in the background, once
+// it is found and ready.
+func (s *Service) Run(matcher *common.MatchDefinition, tick time.Time) error {
+	return s.Service.Run(matcher, func(sdk plugins.Instance, config *structureSpec.Function) *event.Event {
+		return sdk.CreateCronEvent(config.Schedule, tick)
+	}, func(err error) {
+		if err != nil {
+			common.Logger.Errorf("running tick %s of function `%s` failed with: %s", tick.Format(time.RFC3339), matcher.Function, err.Error())
+		}
+	})
+}
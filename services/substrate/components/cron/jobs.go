@@ -0,0 +1,109 @@
+package cron
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/taubyte/tau/core/services/tns"
+	spec "github.com/taubyte/tau/pkg/specs/common"
+	functionSpec "github.com/taubyte/tau/pkg/specs/function"
+	"github.com/taubyte/tau/pkg/specs/methods"
+	"github.com/taubyte/tau/services/substrate/components/cron/common"
+	"github.com/taubyte/tau/services/substrate/components/trigger"
+)
+
+// job is a cron function to schedule.
+type job struct {
+	common.MatchDefinition
+	Schedule string
+}
+
+// key is where the last tick dispatched of the job is kept.
+func (j *job) key() string {
+	return path.Join(ticksPrefix, j.Project, j.Application, j.Function)
+}
+
+// jobs returns the cron functions of the current commit of every project.
+func (s *Service) jobs() ([]*job, error) {
+	keys, err := trigger.Keys(s.Tns(), spec.ProjectPathVariable.String())
+	if err != nil {
+		return nil, fmt.Errorf("listing projects failed with: %w", err)
+	}
+
+	jobs := make([]*job, 0)
+	for _, project := range trigger.ProjectIds(keys) {
+		commit, branch, err := s.Tns().Simple().Commit(project, spec.DefaultBranches...)
+		if err != nil {
+			continue
+		}
+
+		prefix := methods.ProjectPrefix(project, branch, commit)
+		keys, err := trigger.Keys(s.Tns(), prefix.Slice()...)
+		if err != nil {
+			common.Logger.Errorf("listing keys of project `%s` failed with: %s", project, err.Error())
+			continue
+		}
+
+		for _, key := range keys {
+			app, id, ok := scheduleKey(prefix.Slice(), key)
+			if !ok {
+				continue
+			}
+
+			// a function whose type changed may have kept its schedule
+			p := trigger.Split(key)
+			if typ, _ := fetchString(s.Tns(), append(p[:len(p)-1:len(p)-1], "type")); typ != functionSpec.TypeCron {
+				continue
+			}
+
+			schedule, err := fetchString(s.Tns(), p)
+			if err != nil {
+				common.Logger.Errorf("fetching schedule of function `%s` failed with: %s", id, err.Error())
+				continue
+			}
+
+			jobs = append(jobs, &job{
+				MatchDefinition: common.MatchDefinition{Project: project, Application: app, Function: id},
+				Schedule:        schedule,
+			})
+		}
+	}
+
+	return jobs, nil
+}
+
+func fetchString(client tns.Client, path []string) (string, error) {
+	obj, err := client.Fetch(spec.NewTnsPath(path))
+	if err != nil {
+		return "", err
+	}
+
+	value, ok := obj.Interface().(string)
+	if !ok {
+		return "", fmt.Errorf("value at `%s` is a %T", strings.Join(path, "/"), obj.Interface())
+	}
+
+	return value, nil
+}
+
+// scheduleKey returns the application and the id of the function whose
+// schedule is at key, a key of the project at prefix.
+func scheduleKey(prefix []string, key string) (app, id string, ok bool) {
+	p := trigger.Split(key)
+	if len(p) <= len(prefix) || !slices.Equal(p[:len(prefix)], prefix) {
+		return
+	}
+	p = p[len(prefix):]
+
+	if len(p) == 5 && p[0] == spec.ApplicationPathVariable.String() {
+		app, p = p[1], p[2:]
+	}
+
+	if len(p) != 3 || p[0] != functionSpec.PathVariable.String() || p[2] != "schedule" {
+		return "", "", false
+	}
+
+	return app, p[1], true
+}
@@ -0,0 +1,26 @@
+package cron
+
+import "testing"
+
+func TestScheduleKey(t *testing.T) {
+	prefix := []string{"branches", "main", "commit", "c1", "projects", "p1"}
+
+	for _, tc := range []struct {
+		key     string
+		app, id string
+		ok      bool
+	}{
+		{"/branches/main/commit/c1/projects/p1/functions/f1/schedule", "", "f1", true},
+		{"/branches/main/commit/c1/projects/p1/applications/a1/functions/f2/schedule", "a1", "f2", true},
+		{"/branches/main/commit/c1/projects/p1/functions/f1/type", "", "", false},
+		{"/branches/main/commit/c1/projects/p1/applications/a1/functions/f2/type", "", "", false},
+		{"/branches/main/commit/c1/projects/p1/websites/w1/schedule", "", "", false},
+		{"/branches/main/commit/c2/projects/p1/functions/f1/schedule", "", "", false},
+		{"/branches/main/commit/c1/projects/p1", "", "", false},
+	} {
+		app, id, ok := scheduleKey(prefix, tc.key)
+		if app != tc.app || id != tc.id || ok != tc.ok {
+			t.Errorf("%s: got (%q, %q, %v)", tc.key, app, id, ok)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package cron
+
+import (
+	"github.com/taubyte/tau/core/services/substrate"
+	"github.com/taubyte/tau/pkg/raft"
+	functionSpec "github.com/taubyte/tau/pkg/specs/function"
+	"github.com/taubyte/tau/services/substrate/components/trigger"
+)
+
+func New(srv substrate.Service, options ...trigger.Option) (*Service, error) {
+	t, err := trigger.New(srv, functionSpec.TypeCron, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Service{Service: t}
+	s.Start(Resolution, func(cluster raft.Cluster) trigger.Leader {
+		return newScheduler(cluster, s.jobs, s.dispatch)
+	})
+
+	return s, nil
+}
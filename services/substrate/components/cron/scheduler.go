@@ -0,0 +1,139 @@
+package cron
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/taubyte/tau/pkg/raft"
+	"github.com/taubyte/tau/services/substrate/components/cron/common"
+	"github.com/taubyte/tau/utils/cron"
+)
+
+type scheduledJob struct {
+	*job
+	schedule cron.Schedule
+}
+
+// scheduler runs on every member of the raft cluster, but only the leader
+// schedules: it records each tick in the cluster before dispatching it, so a
+// new leader never dispatches a tick again. A tick recorded by a leader that
+// fails before dispatching it is lost, and of the ticks missed while the
+// cluster had no leader only the latest runs.
+type scheduler struct {
+	cluster  raft.Cluster
+	jobs     func() ([]*job, error)
+	dispatch func(j *job, tick time.Time)
+	now      func() time.Time
+
+	refreshed time.Time
+	current   []*scheduledJob
+}
+
+func newScheduler(cluster raft.Cluster, jobs func() ([]*job, error), dispatch func(*job, time.Time)) *scheduler {
+	return &scheduler{
+		cluster:  cluster,
+		jobs:     jobs,
+		dispatch: dispatch,
+		now:      time.Now,
+	}
+}
+
+// Follow makes the scheduler refresh as soon as this node becomes the leader.
+func (s *scheduler) Follow() {
+	s.refreshed = time.Time{}
+}
+
+func (s *scheduler) Lead(context.Context) {
+	now := s.now()
+	if now.Sub(s.refreshed) >= RefreshInterval {
+		s.refresh(now)
+	}
+
+	for _, j := range s.current {
+		s.schedule(j, now)
+	}
+}
+
+// refresh replaces the jobs scheduled, and forgets the ticks of the functions
+// that are gone.
+func (s *scheduler) refresh(now time.Time) {
+	s.refreshed = now
+
+	jobs, err := s.jobs()
+	if err != nil {
+		common.Logger.Errorf("listing cron functions failed with: %s", err.Error())
+		return
+	}
+
+	current := make([]*scheduledJob, 0, len(jobs))
+	keys := make(map[string]struct{}, len(jobs))
+	for _, j := range jobs {
+		schedule, err := cron.Parse(j.Schedule)
+		if err != nil {
+			common.Logger.Errorf("parsing schedule of function `%s` failed with: %s", j.Function, err.Error())
+			continue
+		}
+
+		current = append(current, &scheduledJob{job: j, schedule: schedule})
+		keys[j.key()] = struct{}{}
+	}
+	s.current = current
+
+	for _, key := range s.cluster.Keys(ticksPrefix) {
+		if _, ok := keys[key]; !ok {
+			if err := s.cluster.Delete(key, ApplyTimeout); err != nil {
+				common.Logger.Errorf("deleting `%s` failed with: %s", key, err.Error())
+			}
+		}
+	}
+}
+
+// schedule dispatches the latest tick of j due at now, unless it already was.
+func (s *scheduler) schedule(j *scheduledJob, now time.Time) {
+	key := j.key()
+
+	last, ok := s.last(key)
+	if !ok {
+		// a new function runs from its next tick on
+		if err := s.cluster.Set(key, encodeTick(now), ApplyTimeout); err != nil {
+			common.Logger.Errorf("recording first tick of `%s` failed with: %s", key, err.Error())
+		}
+		return
+	}
+
+	tick := j.schedule.Next(last)
+	if tick.IsZero() || tick.After(now) {
+		return
+	}
+
+	for next := j.schedule.Next(tick); !next.IsZero() && !next.After(now); next = j.schedule.Next(tick) {
+		tick = next
+	}
+
+	if err := s.cluster.Set(key, encodeTick(tick), ApplyTimeout); err != nil {
+		common.Logger.Errorf("recording tick of `%s` failed with: %s", key, err.Error())
+		return
+	}
+
+	go s.dispatch(j.job, tick)
+}
+
+func (s *scheduler) last(key string) (time.Time, bool) {
+	data, ok := s.cluster.Get(key)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	sec, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return time.Unix(sec, 0).UTC(), true
+}
+
+func encodeTick(t time.Time) []byte {
+	return []byte(strconv.FormatInt(t.Unix(), 10))
+}
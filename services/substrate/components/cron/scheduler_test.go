@@ -0,0 +1,143 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/taubyte/tau/pkg/raft"
+	"github.com/taubyte/tau/services/substrate/components/cron/common"
+)
+
+type dispatched struct {
+	function string
+	tick     time.Time
+}
+
+func newTestScheduler(t *testing.T, jobs ...*job) (*scheduler, *raft.MockCluster, chan dispatched, *time.Time) {
+	t.Helper()
+
+	cluster := raft.NewMockCluster()
+	ticks := make(chan dispatched, 16)
+	now := time.Date(2026, 1, 1, 10, 0, 30, 0, time.UTC)
+
+	s := newScheduler(cluster, func() ([]*job, error) {
+		return jobs, nil
+	}, func(j *job, tick time.Time) {
+		ticks <- dispatched{j.Function, tick}
+	})
+	s.now = func() time.Time { return now }
+
+	return s, cluster, ticks, &now
+}
+
+func testJob(function, schedule string) *job {
+	return &job{
+		MatchDefinition: common.MatchDefinition{Project: "project", Function: function},
+		Schedule:        schedule,
+	}
+}
+
+func expectTicks(t *testing.T, ticks chan dispatched, expected ...dispatched) {
+	t.Helper()
+
+	for _, e := range expected {
+		select {
+		case d := <-ticks:
+			if d.function != e.function || !d.tick.Equal(e.tick) {
+				t.Fatalf("dispatched %s at %s, expected %s at %s", d.function, d.tick, e.function, e.tick)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("%s was not dispatched at %s", e.function, e.tick)
+		}
+	}
+
+	select {
+	case d := <-ticks:
+		t.Fatalf("unexpected dispatch of %s at %s", d.function, d.tick)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestSchedulerDispatchesEachTickOnce(t *testing.T) {
+	s, _, ticks, now := newTestScheduler(t, testJob("fn", "* * * * *"))
+
+	// first sight only records a baseline
+	s.Lead(context.Background())
+	expectTicks(t, ticks)
+
+	*now = now.Add(20 * time.Second)
+	s.Lead(context.Background())
+	expectTicks(t, ticks)
+
+	*now = now.Add(20 * time.Second)
+	s.Lead(context.Background())
+	expectTicks(t, ticks, dispatched{"fn", time.Date(2026, 1, 1, 10, 1, 0, 0, time.UTC)})
+
+	*now = now.Add(5 * time.Second)
+	s.Lead(context.Background())
+	expectTicks(t, ticks)
+}
+
+func TestSchedulerRunsLatestMissedTick(t *testing.T) {
+	s, _, ticks, now := newTestScheduler(t, testJob("fn", "*/5 * * * *"))
+
+	s.Lead(context.Background())
+
+	*now = now.Add(17 * time.Minute)
+	s.Lead(context.Background())
+	expectTicks(t, ticks, dispatched{"fn", time.Date(2026, 1, 1, 10, 15, 0, 0, time.UTC)})
+}
+
+func TestSchedulerResumesFromCluster(t *testing.T) {
+	s, cluster, ticks, now := newTestScheduler(t, testJob("fn", "* * * * *"))
+
+	s.Lead(context.Background())
+	*now = now.Add(40 * time.Second)
+	s.Lead(context.Background())
+	expectTicks(t, ticks, dispatched{"fn", time.Date(2026, 1, 1, 10, 1, 0, 0, time.UTC)})
+
+	// a new leader picks up from the ticks recorded in the cluster
+	next := newScheduler(cluster, s.jobs, s.dispatch)
+	next.now = s.now
+	next.Lead(context.Background())
+	expectTicks(t, ticks)
+}
+
+func TestSchedulerForgetsRemovedFunctions(t *testing.T) {
+	s, cluster, _, now := newTestScheduler(t, testJob("fn", "* * * * *"))
+	s.Lead(context.Background())
+
+	if len(cluster.Keys(ticksPrefix)) != 1 {
+		t.Fatalf("expected a tick recorded, got %v", cluster.Keys(ticksPrefix))
+	}
+
+	s.jobs = func() ([]*job, error) { return nil, nil }
+	*now = now.Add(RefreshInterval)
+	s.Lead(context.Background())
+
+	if keys := cluster.Keys(ticksPrefix); len(keys) != 0 {
+		t.Fatalf("expected no tick recorded, got %v", keys)
+	}
+}
+
+func TestSchedulerKeepsJobsOnListingError(t *testing.T) {
+	s, _, ticks, now := newTestScheduler(t, testJob("fn", "* * * * *"))
+	s.Lead(context.Background())
+
+	s.jobs = func() ([]*job, error) { return nil, errors.New("tns unreachable") }
+	*now = now.Add(RefreshInterval)
+	s.Lead(context.Background())
+	expectTicks(t, ticks, dispatched{"fn", time.Date(2026, 1, 1, 10, 1, 0, 0, time.UTC)})
+}
+
+func TestSchedulerSkipsInvalidSchedules(t *testing.T) {
+	s, cluster, ticks, _ := newTestScheduler(t, testJob("bad", "every now and then"), testJob("fn", "@hourly"))
+	s.Lead(context.Background())
+	expectTicks(t, ticks)
+
+	if keys := cluster.Keys(ticksPrefix); len(keys) != 1 || keys[0] != "/ticks/project/fn" {
+		t.Fatalf("unexpected ticks recorded %v", keys)
+	}
+}
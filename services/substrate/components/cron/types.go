@@ -0,0 +1,10 @@
+package cron
+
+import "github.com/taubyte/tau/services/substrate/components/trigger"
+
+// Service runs cron functions. When started with trigger.Cluster, the node
+// also joins a raft cluster whose leader decides, for the whole cloud, when
+// each cron function runs and on which member.
+type Service struct {
+	*trigger.Service
+}
@@ -0,0 +1,17 @@
+package cron
+
+import "time"
+
+var (
+	// Resolution is how often the leader checks for ticks that are due.
+	Resolution = time.Second
+	// RefreshInterval is how often the leader refreshes the list of cron
+	// functions from TNS.
+	RefreshInterval = time.Minute
+	// ApplyTimeout bounds the raft writes recording ticks.
+	ApplyTimeout = 5 * time.Second
+)
+
+// ticksPrefix is where the last tick dispatched of each function is kept in
+// the raft namespace of the scheduler.
+const ticksPrefix = "/ticks/"
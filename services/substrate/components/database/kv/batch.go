@@ -0,0 +1,107 @@
+package kv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+
+	hoarderIface "github.com/taubyte/tau/core/services/hoarder"
+	iface "github.com/taubyte/tau/core/services/substrate/components/database"
+)
+
+// batch buffers ops and commits them, with their size entries, through one
+// kvdb batch so the writes land together.
+type batch struct {
+	kv     *kv
+	ops    []batchOp
+	checks int
+}
+
+type batchOp struct {
+	kind  int
+	key   string
+	value []byte
+}
+
+const (
+	opPut = iota
+	opDelete
+	opCheck
+)
+
+var _ iface.Batch = &batch{}
+
+func (b *batch) Put(key string, v []byte) error {
+	b.ops = append(b.ops, batchOp{kind: opPut, key: key, value: v})
+	return nil
+}
+
+func (b *batch) Delete(key string) error {
+	b.ops = append(b.ops, batchOp{kind: opDelete, key: key})
+	return nil
+}
+
+func (b *batch) Check(key string, expected []byte) error {
+	b.ops = append(b.ops, batchOp{kind: opCheck, key: key, value: expected})
+	b.checks++
+	return nil
+}
+
+func (b *batch) Commit(ctx context.Context) error {
+	if len(b.ops) == 0 {
+		return nil
+	}
+
+	var input []byte
+	for _, op := range b.ops {
+		if op.kind == opPut {
+			input = append(input, op.value...)
+		}
+	}
+
+	err := b.kv.checkValidSize(ctx, input)
+	if err != nil {
+		return err
+	}
+
+	kb, err := b.kv.database.Batch(ctx)
+	if err != nil {
+		return fmt.Errorf("opening batch on database %s failed with error: %v", b.kv.name, err)
+	}
+
+	cb, conditional := kb.(hoarderIface.ConditionalBatch)
+	if b.checks > 0 && !conditional {
+		return iface.ErrNotSupported
+	}
+
+	for _, op := range b.ops {
+		switch op.kind {
+		case opPut:
+			err = kb.Put(op.key, op.value)
+			if err == nil {
+				err = kb.Put(path.Join("size", op.key), []byte(strconv.Itoa(len(op.value))))
+			}
+		case opDelete:
+			err = kb.Delete(op.key)
+			if err == nil {
+				err = kb.Delete(path.Join("size", op.key))
+			}
+		case opCheck:
+			err = cb.Check(op.key, op.value)
+		}
+		if err != nil {
+			return fmt.Errorf("adding %s to batch failed with error: %v", op.key, err)
+		}
+	}
+
+	err = kb.Commit()
+	if errors.Is(err, hoarderIface.ErrConflict) {
+		return fmt.Errorf("committing batch on database %s: %w", b.kv.name, iface.ErrConflict)
+	} else if err != nil {
+		return fmt.Errorf("committing batch on database %s failed with error: %v", b.kv.name, err)
+	}
+
+	return nil
+}
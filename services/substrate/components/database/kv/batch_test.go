@@ -0,0 +1,70 @@
+package kv
+
+import (
+	"errors"
+	"testing"
+
+	logging "github.com/ipfs/go-log/v2"
+	iface "github.com/taubyte/tau/core/services/substrate/components/database"
+	kvdbMock "github.com/taubyte/tau/pkg/kvdb/mock"
+	"gotest.tools/v3/assert"
+)
+
+func newTestKV(t *testing.T, size uint64) iface.KV {
+	store, err := kvdbMock.New().New(logging.Logger("kv-test"), t.Name(), 0)
+	assert.NilError(t, err)
+	return New(size, t.Name(), store)
+}
+
+func TestBatchCommit(t *testing.T) {
+	ctx := t.Context()
+	db := newTestKV(t, 1024)
+	assert.NilError(t, db.Put(ctx, "old", []byte("gone")))
+
+	b, err := db.Batch(ctx)
+	assert.NilError(t, err)
+	assert.NilError(t, b.Put("a", []byte("123")))
+	assert.NilError(t, b.Put("b", []byte("45")))
+	assert.NilError(t, b.Delete("old"))
+	assert.NilError(t, b.Commit(ctx))
+
+	v, err := db.Get(ctx, "a")
+	assert.NilError(t, err)
+	assert.Equal(t, string(v), "123")
+
+	_, err = db.Get(ctx, "old")
+	assert.Assert(t, err != nil)
+
+	// Size entries follow the batch: 3 + 2 bytes used.
+	left, err := db.Size(ctx)
+	assert.NilError(t, err)
+	assert.Equal(t, left, uint64(1024-5))
+}
+
+func TestBatchOverSize(t *testing.T) {
+	ctx := t.Context()
+	db := newTestKV(t, 4)
+
+	b, err := db.Batch(ctx)
+	assert.NilError(t, err)
+	assert.NilError(t, b.Put("a", []byte("123")))
+	assert.NilError(t, b.Put("b", []byte("45")))
+	assert.Assert(t, b.Commit(ctx) != nil)
+
+	_, err = db.Get(ctx, "a")
+	assert.Assert(t, err != nil)
+}
+
+func TestConditionalNotSupported(t *testing.T) {
+	ctx := t.Context()
+	db := newTestKV(t, 1024)
+
+	_, err := db.CompareAndSwap(ctx, "a", nil, []byte("1"))
+	assert.Assert(t, errors.Is(err, iface.ErrNotSupported))
+
+	b, err := db.Batch(ctx)
+	assert.NilError(t, err)
+	assert.NilError(t, b.Check("a", nil))
+	assert.NilError(t, b.Put("a", []byte("1")))
+	assert.Assert(t, errors.Is(b.Commit(ctx), iface.ErrNotSupported))
+}
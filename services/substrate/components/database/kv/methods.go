@@ -6,6 +6,10 @@ import (
 	"path"
 	"strconv"
 	"strings"
+	"time"
+
+	hoarderIface "github.com/taubyte/tau/core/services/hoarder"
+	iface "github.com/taubyte/tau/core/services/substrate/components/database"
 )
 
 func (kv *kv) Get(ctx context.Context, key string) (data []byte, err error) {
@@ -39,6 +43,27 @@ func (kv *kv) Put(ctx context.Context, key string, v []byte) error {
 	return nil
 }
 
+func (kv *kv) PutWithTTL(ctx context.Context, key string, v []byte, ttl time.Duration) error {
+	err := kv.checkValidSize(ctx, v)
+	if err != nil {
+		return err
+	}
+
+	err = kv.database.PutWithTTL(ctx, key, v, ttl)
+	if err != nil {
+		return fmt.Errorf("failed putting %s with ttl in database %s with error: %v", key, kv.name, err)
+	}
+
+	// The size entry expires with the data so it stops counting once the key is gone
+	sizeString := strconv.Itoa(len(v))
+	err = kv.database.PutWithTTL(ctx, path.Join("size", key), []byte(sizeString), ttl)
+	if err != nil {
+		return fmt.Errorf("failed putting size for key %s in database with error: %v", key, err)
+	}
+
+	return nil
+}
+
 func (kv *kv) Delete(ctx context.Context, key string) error {
 	// Delete key
 	err := kv.database.Delete(ctx, key)
@@ -96,3 +121,35 @@ func (kv *kv) Size(ctx context.Context) (uint64, error) {
 
 	return kv.maxSize - uint64(used), nil
 }
+
+func (kv *kv) CompareAndSwap(ctx context.Context, key string, expected, v []byte) (bool, error) {
+	store, ok := kv.database.(hoarderIface.CasKVDB)
+	if !ok {
+		return false, iface.ErrNotSupported
+	}
+
+	err := kv.checkValidSize(ctx, v)
+	if err != nil {
+		return false, err
+	}
+
+	swapped, _, err := store.CompareAndSwap(ctx, key, expected, v)
+	if err != nil {
+		return false, fmt.Errorf("compare-and-swap of %s in database %s failed with error: %v", key, kv.name, err)
+	}
+	if !swapped {
+		return false, nil
+	}
+
+	// The size entry is bookkeeping: written after the swap, outside its atomicity
+	err = kv.database.Put(ctx, path.Join("size", key), []byte(strconv.Itoa(len(v))))
+	if err != nil {
+		return true, fmt.Errorf("failed putting size for key %s in database with error: %v", key, err)
+	}
+
+	return true, nil
+}
+
+func (kv *kv) Batch(ctx context.Context) (iface.Batch, error) {
+	return &batch{kv: kv}, nil
+}
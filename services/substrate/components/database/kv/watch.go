@@ -0,0 +1,38 @@
+package kv
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/taubyte/tau/core/kvdb"
+)
+
+// sizePrefix holds the size entries written next to every key; they are
+// bookkeeping and never reported to watchers.
+const sizePrefix = "size/"
+
+func (kv *kv) Watch(ctx context.Context, prefix string) (<-chan kvdb.Event, error) {
+	events, err := kv.database.Watch(ctx, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("watching `%s` in database %s failed with: %w", prefix, kv.name, err)
+	}
+
+	ch := make(chan kvdb.Event, cap(events))
+	go func() {
+		defer close(ch)
+		for ev := range events {
+			if strings.HasPrefix(strings.TrimPrefix(ev.Key, "/"), sizePrefix) {
+				continue
+			}
+
+			select {
+			case ch <- ev:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, nil
+}
@@ -0,0 +1,46 @@
+package kv
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/taubyte/tau/core/kvdb"
+	"gotest.tools/v3/assert"
+)
+
+func nextEvent(t *testing.T, ch <-chan kvdb.Event) kvdb.Event {
+	t.Helper()
+	select {
+	case ev, ok := <-ch:
+		assert.Assert(t, ok, "watch closed")
+		return ev
+	case <-time.After(time.Second):
+		t.Fatal("no event")
+	}
+	return kvdb.Event{}
+}
+
+func TestWatchHidesSizeEntries(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	db := newTestKV(t, 1024)
+
+	events, err := db.Watch(ctx, "")
+	assert.NilError(t, err)
+
+	assert.NilError(t, db.Put(ctx, "a", []byte("1")))
+	assert.NilError(t, db.Delete(ctx, "a"))
+
+	ev := nextEvent(t, events)
+	assert.Equal(t, ev.Type, kvdb.EventPut)
+	assert.Equal(t, ev.Key, "a")
+	assert.Equal(t, string(ev.Value), "1")
+
+	ev = nextEvent(t, events)
+	assert.Equal(t, ev.Type, kvdb.EventDelete)
+	assert.Equal(t, ev.Key, "a")
+
+	cancel()
+	for range events {
+	}
+}
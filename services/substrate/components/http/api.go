@@ -1,12 +1,15 @@
 package http
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
 	goHttp "net/http"
 
+	"github.com/gorilla/websocket"
 	iface "github.com/taubyte/tau/core/services/substrate/components/http"
+	"github.com/taubyte/tau/core/services/substrate/smartops"
 	http "github.com/taubyte/tau/pkg/http"
 	"github.com/taubyte/tau/services/substrate/components/http/common"
 	"github.com/taubyte/tau/services/substrate/runtime/counter"
@@ -44,12 +47,17 @@ func (s *Service) Lookup(matcher *common.MatchDefinition) (iface.Serviceable, er
 func (s *Service) handle(w goHttp.ResponseWriter, r *goHttp.Request) error {
 	startTime := time.Now()
 	matcher := common.New(helpers.ExtractHost(r.Host), r.URL.Path, r.Method)
+	matcher.WebSocket = websocket.IsWebSocketUpgrade(r)
 
 	pick, err := s.Lookup(matcher)
 	if err != nil {
 		return fmt.Errorf("looking up serviceable failed with: %w", err)
 	}
 
+	if s.limited(w, r, pick) {
+		return nil
+	}
+
 	if !pick.IsProvisioned() {
 		pick, err = pick.Provision()
 		if err != nil {
@@ -66,7 +74,13 @@ func (s *Service) handle(w goHttp.ResponseWriter, r *goHttp.Request) error {
 }
 
 func (s *Service) Handler(w goHttp.ResponseWriter, r *goHttp.Request) {
-	if err := s.handle(w, r); err != nil {
+	err := s.handle(w, r)
+
+	var denied *smartops.Denied
+	if errors.As(err, &denied) {
+		status := denied.HTTPStatus()
+		goHttp.Error(w, goHttp.StatusText(status), status)
+	} else if err != nil {
 		w.WriteHeader(500)
 		w.Write([]byte(err.Error()))
 	}
@@ -78,5 +92,13 @@ func (s *Service) attach() error {
 		Handler:    s.Handler,
 	})
 
+	if err := s.sockets.Subscribe(s.Context()); err != nil {
+		return fmt.Errorf("subscribing to websocket requests failed with: %w", err)
+	}
+
+	if err := s.limiter.Start(s.Context()); err != nil {
+		return fmt.Errorf("starting rate limiter failed with: %w", err)
+	}
+
 	return nil
 }
@@ -21,6 +21,9 @@ type Request struct {
 	Host   string
 	Path   string
 	Method string
+	// WebSocket is set for websocket upgrade requests, which websocket
+	// functions are matched for first.
+	WebSocket bool
 }
 
 // TODO: Maybe move this to interfaces?
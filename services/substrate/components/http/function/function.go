@@ -13,6 +13,8 @@ import (
 	"github.com/taubyte/tau/clients/p2p/seer/usage"
 	"github.com/taubyte/tau/core/services/substrate/components"
 	httpComp "github.com/taubyte/tau/core/services/substrate/components/http"
+	"github.com/taubyte/tau/core/services/substrate/smartops"
+	functionSpec "github.com/taubyte/tau/pkg/specs/function"
 	matcherSpec "github.com/taubyte/tau/pkg/specs/matcher"
 	"github.com/taubyte/tau/services/substrate/components/http/common"
 	"github.com/taubyte/tau/services/substrate/components/metrics"
@@ -51,6 +53,21 @@ func (f *Function) Provision() (function httpComp.Serviceable, err error) {
 }
 
 func (f *Function) Handle(w goHttp.ResponseWriter, r *goHttp.Request, matcher components.MatchDefinition) (t time.Time, err error) {
+	if len(f.config.SmartOps) > 0 {
+		val, err := f.SmartOps()
+		if err != nil {
+			return t, fmt.Errorf("running smart ops failed with: %w", err)
+		}
+
+		if val > 0 {
+			return t, &smartops.Denied{Code: val}
+		}
+	}
+
+	if f.config.Type == functionSpec.TypeWebSocket {
+		return f.handleWebSocket(w, r)
+	}
+
 	instance, err := f.Instantiate(f.instanceCtx)
 	if err != nil {
 		return t, fmt.Errorf("instantiate failed with: %w", err)
@@ -71,7 +88,10 @@ func (f *Function) Metrics() *metrics.Function {
 	if f.provisioned {
 		m.AvgRunTime = f.CallTime().Nanoseconds()
 		m.ColdStart = f.ColdStart().Nanoseconds()
+		m.WarmCalls = f.WarmCalls()
+		m.ColdCalls = f.ColdCalls()
 		maxMemory = f.MemoryMax()
+		runtimeMetrics(&m, f.Function)
 	}
 
 	// Memory == 0 no memory limit
@@ -88,6 +108,27 @@ func (f *Function) Metrics() *metrics.Function {
 	return &m
 }
 
+// runtimeMetrics fills m with the stats of the function's runtimes.
+func runtimeMetrics(m *metrics.Function, f *runtime.Function) {
+	m.AvgCompileTime = f.CompileTime().Nanoseconds()
+	m.AvgInstantiateTime = f.InstantiateTime().Nanoseconds()
+
+	stats := f.Stats()
+	m.PeakMemory = stats.PeakMemory
+	if stats.Calls == 0 {
+		return
+	}
+
+	m.AvgWallTime = stats.WallTime.Nanoseconds() / int64(stats.Calls)
+	m.HostCalls = make(map[string]metrics.HostCalls, len(stats.HostCalls))
+	for group, calls := range stats.HostCalls {
+		m.HostCalls[group] = metrics.HostCalls{
+			Calls: float32(calls.Calls) / float32(stats.Calls),
+			Time:  calls.Time.Nanoseconds() / int64(stats.Calls),
+		}
+	}
+}
+
 func (f *Function) Match(matcher components.MatchDefinition) (currentMatchIndex matcherSpec.Index) {
 	currentMatch := matcherSpec.NoMatch
 	_matcher, ok := matcher.(*common.MatchDefinition)
@@ -102,17 +143,36 @@ func (f *Function) Match(matcher components.MatchDefinition) (currentMatchIndex
 	// before checking, and advertises the uppercase set as canonical). Without
 	// this, a function authored `method: get` validated, compiled, deployed —
 	// and silently never routed.
-	if _matcher.Method == strings.ToUpper(f.config.Method) {
-		for _, path := range f.config.Paths {
-			if path == _matcher.Path {
-				currentMatch = matcherSpec.HighMatch
-			}
+	if f.config.Type == functionSpec.TypeWebSocket {
+		if _matcher.WebSocket && f.matchPath(_matcher.Path) {
+			currentMatch = matcherSpec.HighMatch
+		}
+
+		return currentMatch
+	}
+
+	if _matcher.Method == strings.ToUpper(f.config.Method) && f.matchPath(_matcher.Path) {
+		currentMatch = matcherSpec.HighMatch
+		// an upgrade request goes to a websocket function on the same path
+		// first, and only falls back to this one
+		if _matcher.WebSocket {
+			currentMatch--
 		}
 	}
 
 	return currentMatch
 }
 
+func (f *Function) matchPath(_path string) bool {
+	for _, path := range f.config.Paths {
+		if path == _path {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (f *Function) Validate(matcher components.MatchDefinition) error {
 	if f.Match(matcher) == matcherSpec.NoMatch {
 		return errors.New("no match")
@@ -3,6 +3,7 @@ package function
 import (
 	"testing"
 
+	functionSpec "github.com/taubyte/tau/pkg/specs/function"
 	matcherSpec "github.com/taubyte/tau/pkg/specs/matcher"
 	structureSpec "github.com/taubyte/tau/pkg/specs/structure"
 	"github.com/taubyte/tau/services/substrate/components/http/common"
@@ -64,3 +65,40 @@ func TestMatchCanonicalizesTheConfiguredMethod(t *testing.T) {
 		}
 	})
 }
+
+func TestMatchWebSocket(t *testing.T) {
+	const path = "/chat"
+
+	upgrade := func(path string) *common.MatchDefinition {
+		m := common.New("example.com", path, "GET")
+		m.WebSocket = true
+		return m
+	}
+
+	ws := &Function{config: structureSpec.Function{Type: functionSpec.TypeWebSocket, Paths: []string{path}}}
+	get := &Function{config: structureSpec.Function{Type: "https", Method: "GET", Paths: []string{path}}}
+
+	t.Run("a websocket function takes upgrade requests on its paths", func(t *testing.T) {
+		if got := ws.Match(upgrade(path)); got != matcherSpec.HighMatch {
+			t.Fatalf("upgrade request must match, got %v", got)
+		}
+		if got := ws.Match(upgrade("/other")); got != matcherSpec.NoMatch {
+			t.Fatalf("upgrade request on another path must not match, got %v", got)
+		}
+	})
+
+	t.Run("a websocket function does not take plain requests", func(t *testing.T) {
+		if got := ws.Match(common.New("example.com", path, "GET")); got != matcherSpec.NoMatch {
+			t.Fatalf("plain request must not match, got %v", got)
+		}
+	})
+
+	t.Run("an http function on the same path comes second", func(t *testing.T) {
+		if got := get.Match(upgrade(path)); got <= matcherSpec.NoMatch || got >= ws.Match(upgrade(path)) {
+			t.Fatalf("http function must match below the websocket one, got %v", got)
+		}
+		if got := get.Match(common.New("example.com", path, "GET")); got != matcherSpec.HighMatch {
+			t.Fatalf("plain request must still match, got %v", got)
+		}
+	})
+}
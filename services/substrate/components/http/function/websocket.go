@@ -0,0 +1,84 @@
+package function
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	goHttp "net/http"
+
+	"github.com/gorilla/websocket"
+	service "github.com/taubyte/tau/pkg/http"
+	"github.com/taubyte/tau/pkg/vm-low-orbit/event"
+	"github.com/taubyte/tau/services/substrate/components/http/sockets"
+)
+
+// socketService is the http service, holding the connections of websocket
+// functions.
+type socketService interface {
+	Sockets() *sockets.Registry
+}
+
+// handleWebSocket upgrades r, then calls the function for the connect event of
+// the connection, each message it receives, and its close event, one at a
+// time and in order, until the connection closes. A connection the connect
+// call fails for is closed right away.
+func (f *Function) handleWebSocket(w goHttp.ResponseWriter, r *goHttp.Request) (t time.Time, err error) {
+	srv, ok := f.srv.(socketService)
+	if !ok {
+		return t, errors.New("websocket connections are not supported")
+	}
+
+	conn, err := service.Upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return t, fmt.Errorf("upgrading to websocket failed with: %w", err)
+	}
+
+	registry := srv.Sockets()
+	c := registry.Add(f.project, conn)
+	defer registry.Remove(c)
+
+	// the connection does not outlive the function, when it is shut down
+	stop := context.AfterFunc(f.instanceCtx, func() {
+		c.Close(websocket.CloseGoingAway, "")
+	})
+	defer stop()
+
+	t = time.Now()
+	if err = f.callWebSocket(c.Id(), event.WebSocketConnect, nil, false); err != nil {
+		c.Close(websocket.CloseInternalServerErr, "")
+		logger.Errorf("websocket connect of `%s` failed with: %s", f.config.Name, err.Error())
+		return t, nil
+	}
+
+	for {
+		data, binary, err := c.Read()
+		if err != nil {
+			break
+		}
+
+		if err = f.callWebSocket(c.Id(), event.WebSocketMessage, data, binary); err != nil {
+			logger.Errorf("websocket message of `%s` failed with: %s", f.config.Name, err.Error())
+		}
+	}
+
+	if err = f.callWebSocket(c.Id(), event.WebSocketClose, nil, false); err != nil {
+		logger.Errorf("websocket close of `%s` failed with: %s", f.config.Name, err.Error())
+	}
+
+	// the response was hijacked by the upgrade, errors are logged instead
+	return t, nil
+}
+
+func (f *Function) callWebSocket(connection string, kind event.WebSocketEventKind, data []byte, binary bool) error {
+	instance, err := f.Instantiate(f.instanceCtx)
+	if err != nil {
+		return fmt.Errorf("instantiate failed with: %w", err)
+	}
+	defer instance.Free()
+
+	ev := instance.SDK().CreateWebSocketEvent(connection, kind, data, binary)
+
+	return f.Call(instance, ev.Id)
+}
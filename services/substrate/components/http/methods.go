@@ -2,6 +2,7 @@ package http
 
 import (
 	commonIface "github.com/taubyte/tau/core/services/substrate/components"
+	"github.com/taubyte/tau/services/substrate/components/http/sockets"
 )
 
 func (s *Service) Close() error {
@@ -15,3 +16,8 @@ func (s *Service) Close() error {
 func (s *Service) Cache() commonIface.Cache {
 	return s.cache
 }
+
+// Sockets returns the websocket connections owned by the functions of this node.
+func (s *Service) Sockets() *sockets.Registry {
+	return s.sockets
+}
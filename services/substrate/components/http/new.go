@@ -4,6 +4,8 @@ import (
 	"fmt"
 
 	"github.com/taubyte/tau/pkg/config"
+	"github.com/taubyte/tau/services/substrate/components/http/ratelimit"
+	"github.com/taubyte/tau/services/substrate/components/http/sockets"
 	"github.com/taubyte/tau/services/substrate/runtime/cache"
 
 	nodeIface "github.com/taubyte/tau/core/services/substrate"
@@ -14,6 +16,9 @@ func New(srv nodeIface.Service, cfg config.Config, options ...Option) (*Service,
 		Service: srv,
 		config:  cfg,
 		cache:   cache.New(),
+		sockets: sockets.New(srv.Node()),
+		limiter: ratelimit.New(srv.Node()),
+		domains: domainRules{rules: make(map[string]domainRule)},
 	}
 
 	var err error
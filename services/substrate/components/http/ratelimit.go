@@ -0,0 +1,112 @@
+package http
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ipfs/go-log/v2"
+	iface "github.com/taubyte/tau/core/services/substrate/components/http"
+	spec "github.com/taubyte/tau/pkg/specs/common"
+	"github.com/taubyte/tau/services/substrate/components/http/ratelimit"
+	"github.com/taubyte/tau/services/substrate/runtime/helpers"
+)
+
+var logger = log.Logger("tau.substrate.components.http")
+
+// DomainRuleTTL is how long the rate limit of a domain is used before it is
+// fetched again.
+var DomainRuleTTL = time.Minute
+
+type domainRules struct {
+	lock  sync.Mutex
+	rules map[string]domainRule
+}
+
+type domainRule struct {
+	rule    *ratelimit.Rule
+	expires time.Time
+}
+
+// limited answers r with 429 Too Many Requests, and returns true, if its
+// client is past the rate limits of pick or of the domain it was made to.
+func (s *Service) limited(w http.ResponseWriter, r *http.Request, pick iface.Serviceable) bool {
+	var rules []ratelimit.Rule
+	if function, ok := pick.(iface.Function); ok {
+		if config := function.Config(); config != nil {
+			rules = append(rules, ratelimit.Rule{
+				Scope: "functions/" + pick.Project() + "/" + pick.Id(),
+				Limit: ratelimit.Limit{
+					PerIP:     config.RateLimitIP,
+					PerKey:    config.RateLimitKey,
+					KeyHeader: config.RateLimitHeader,
+					Burst:     config.RateLimitBurst,
+				},
+			})
+		}
+	}
+
+	if rule, err := s.domainRule(pick, helpers.ExtractHost(r.Host)); err != nil {
+		logger.Debugf("fetching rate limit of `%s` failed with: %s", r.Host, err.Error())
+	} else if rule != nil {
+		rules = append(rules, *rule)
+	}
+
+	retryAfter, ok := s.limiter.Allow(r, rules...)
+	if ok {
+		return false
+	}
+
+	w.Header().Set("Retry-After", strconv.Itoa(max(int(math.Ceil(retryAfter.Seconds())), 1)))
+	w.WriteHeader(http.StatusTooManyRequests)
+
+	return true
+}
+
+// domainRule returns the rate limit of the domain of pick serving host, or nil
+// if it has none.
+func (s *Service) domainRule(pick iface.Serviceable, host string) (*ratelimit.Rule, error) {
+	key := pick.Project() + "/" + pick.Application() + "/" + host
+
+	s.domains.lock.Lock()
+	cached, ok := s.domains.rules[key]
+	s.domains.lock.Unlock()
+	if ok && time.Now().Before(cached.expires) {
+		return cached.rule, nil
+	}
+
+	domains, _, _, err := s.Tns().Domain().All(pick.Project(), pick.Application(), spec.DefaultBranches...).List()
+	if err != nil {
+		return nil, fmt.Errorf("listing domains failed with: %w", err)
+	}
+
+	var rule *ratelimit.Rule
+	for _, domain := range domains {
+		if helpers.ExtractHost(domain.Fqdn) != host {
+			continue
+		}
+
+		if domain.RateLimitIP > 0 || domain.RateLimitKey > 0 {
+			rule = &ratelimit.Rule{
+				Scope: "domains/" + pick.Project() + "/" + domain.Id,
+				Limit: ratelimit.Limit{
+					PerIP:     domain.RateLimitIP,
+					PerKey:    domain.RateLimitKey,
+					KeyHeader: domain.RateLimitHeader,
+					Burst:     domain.RateLimitBurst,
+				},
+			}
+		}
+
+		break
+	}
+
+	s.domains.lock.Lock()
+	s.domains.rules[key] = domainRule{rule: rule, expires: time.Now().Add(DomainRuleTTL)}
+	s.domains.lock.Unlock()
+
+	return rule, nil
+}
@@ -0,0 +1,213 @@
+package ratelimit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/ipfs/go-log/v2"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/taubyte/tau/p2p/peer"
+)
+
+var logger = log.Logger("tau.substrate.components.http.ratelimit")
+
+func New(node peer.Node) *Limiter {
+	return &Limiter{
+		node:     node,
+		now:      time.Now,
+		buckets:  make(map[string]*bucket),
+		admitted: make(map[string]uint32),
+	}
+}
+
+// Start counts the requests reported by other nodes, and reports those of this
+// one, until ctx is done.
+func (l *Limiter) Start(ctx context.Context) error {
+	err := l.node.PubSubSubscribeContext(ctx, Topic, l.handle, func(err error) {
+		logger.Errorf("rate limit subscription failed with: %s", err.Error())
+	})
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		ticker := time.NewTicker(ReportInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				l.report(ctx)
+				l.sweep()
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Allow tells if r is within rules, counting it if so. Otherwise, it returns
+// how long the client should wait before trying again.
+func (l *Limiter) Allow(r *http.Request, rules ...Rule) (retryAfter time.Duration, ok bool) {
+	type take struct {
+		key         string
+		rate, burst float64
+	}
+
+	var takes []take
+	for _, rule := range rules {
+		burst := rule.Burst
+		if rule.PerIP > 0 {
+			takes = append(takes, take{rule.Scope + "|ip|" + clientIP(r), float64(rule.PerIP), float64(burstOf(burst, rule.PerIP))})
+		}
+
+		if rule.PerKey > 0 && rule.KeyHeader != "" {
+			if key := r.Header.Get(rule.KeyHeader); key != "" {
+				takes = append(takes, take{rule.Scope + "|key|" + hash(key), float64(rule.PerKey), float64(burstOf(burst, rule.PerKey))})
+			}
+		}
+	}
+
+	if len(takes) == 0 {
+		return 0, true
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := l.now()
+	buckets := make([]*bucket, len(takes))
+	for i, t := range takes {
+		b, exists := l.buckets[t.key]
+		if !exists {
+			b = &bucket{tokens: t.burst, last: now}
+			l.buckets[t.key] = b
+		}
+
+		// limits change with deployments
+		b.rate, b.burst = t.rate, t.burst
+		b.refill(now)
+
+		if b.tokens < 1 {
+			retryAfter = max(retryAfter, time.Duration((1-b.tokens)/b.rate*float64(time.Second)))
+		}
+
+		buckets[i] = b
+	}
+
+	if retryAfter > 0 {
+		return retryAfter, false
+	}
+
+	for i, b := range buckets {
+		b.tokens--
+		l.admitted[takes[i].key]++
+	}
+
+	return 0, true
+}
+
+// report publishes the requests admitted since the last report.
+func (l *Limiter) report(ctx context.Context) {
+	l.lock.Lock()
+	if len(l.admitted) == 0 {
+		l.lock.Unlock()
+		return
+	}
+
+	counts := l.admitted
+	l.admitted = make(map[string]uint32)
+	l.lock.Unlock()
+
+	data, err := cbor.Marshal(report{Counts: counts})
+	if err != nil {
+		logger.Errorf("encoding rate limit report failed with: %s", err.Error())
+		return
+	}
+
+	if err := l.node.PubSubPublish(ctx, Topic, data); err != nil {
+		logger.Debugf("publishing rate limit report failed with: %s", err.Error())
+	}
+}
+
+// handle counts the requests another node admitted against the buckets of
+// this one. A bucket this node has not used yet is left alone.
+func (l *Limiter) handle(msg *pubsub.Message) {
+	if msg.ReceivedFrom == l.node.ID() {
+		return
+	}
+
+	var rep report
+	if err := cbor.Unmarshal(msg.GetData(), &rep); err != nil {
+		logger.Errorf("decoding rate limit report failed with: %s", err.Error())
+		return
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := l.now()
+	for key, count := range rep.Counts {
+		if b, ok := l.buckets[key]; ok {
+			b.refill(now)
+			// a debt past a burst would lock clients out for too long
+			b.tokens = max(b.tokens-float64(count), -b.burst)
+		}
+	}
+}
+
+// sweep drops the buckets left full for IdleTimeout.
+func (l *Limiter) sweep() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := l.now()
+	for key, b := range l.buckets {
+		if now.Sub(b.last) < IdleTimeout {
+			continue
+		}
+
+		if b.refill(now); b.tokens >= b.burst {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+func (b *bucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = math.Min(b.burst, b.tokens+elapsed.Seconds()*b.rate)
+		b.last = now
+	}
+}
+
+// clientIP returns the ip r came from, as the gateway forwards it.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
+
+// hash keeps API keys out of memory and off the wire.
+func hash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:16])
+}
+
+func burstOf(burst, rate int) int {
+	if burst > 0 {
+		return burst
+	}
+
+	return rate
+}
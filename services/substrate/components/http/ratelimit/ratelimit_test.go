@@ -0,0 +1,147 @@
+package ratelimit
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/taubyte/tau/p2p/peer"
+	"gotest.tools/v3/assert"
+)
+
+func newLimiter(t *testing.T) (*Limiter, *time.Time) {
+	l := New(peer.Mock(t.Context()))
+	now := time.Unix(1000, 0)
+	l.now = func() time.Time { return now }
+
+	return l, &now
+}
+
+func TestAllowPerIP(t *testing.T) {
+	l, now := newLimiter(t)
+	rule := Rule{Scope: "functions/project/id", Limit: Limit{PerIP: 2}}
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r.RemoteAddr = "1.2.3.4:5678"
+
+	for range 2 {
+		_, ok := l.Allow(r, rule)
+		assert.Assert(t, ok)
+	}
+
+	retryAfter, ok := l.Allow(r, rule)
+	assert.Assert(t, !ok)
+	assert.Equal(t, retryAfter, 500*time.Millisecond)
+
+	// other clients have their own buckets
+	other := httptest.NewRequest("GET", "/", nil)
+	other.RemoteAddr = "4.3.2.1:5678"
+	_, ok = l.Allow(other, rule)
+	assert.Assert(t, ok)
+
+	*now = now.Add(500 * time.Millisecond)
+	_, ok = l.Allow(r, rule)
+	assert.Assert(t, ok)
+}
+
+func TestAllowBurst(t *testing.T) {
+	l, _ := newLimiter(t)
+	rule := Rule{Scope: "domains/project/id", Limit: Limit{PerIP: 1, Burst: 3}}
+
+	r := httptest.NewRequest("GET", "/", nil)
+	for range 3 {
+		_, ok := l.Allow(r, rule)
+		assert.Assert(t, ok)
+	}
+
+	_, ok := l.Allow(r, rule)
+	assert.Assert(t, !ok)
+}
+
+func TestAllowPerKey(t *testing.T) {
+	l, _ := newLimiter(t)
+	rule := Rule{Scope: "functions/project/id", Limit: Limit{PerKey: 1, KeyHeader: "X-Api-Key"}}
+
+	// requests without a key are not limited by it
+	r := httptest.NewRequest("GET", "/", nil)
+	for range 3 {
+		_, ok := l.Allow(r, rule)
+		assert.Assert(t, ok)
+	}
+
+	r.Header.Set("X-Api-Key", "secret")
+	_, ok := l.Allow(r, rule)
+	assert.Assert(t, ok)
+
+	// the key is limited whatever ip it comes from
+	r.RemoteAddr = "4.3.2.1:5678"
+	_, ok = l.Allow(r, rule)
+	assert.Assert(t, !ok)
+
+	for key := range l.buckets {
+		assert.Assert(t, !strings.Contains(key, "secret"))
+	}
+}
+
+func TestAllowAllOrNothing(t *testing.T) {
+	l, _ := newLimiter(t)
+	function := Rule{Scope: "functions/project/id", Limit: Limit{PerIP: 1}}
+	domain := Rule{Scope: "domains/project/id", Limit: Limit{PerIP: 5}}
+
+	r := httptest.NewRequest("GET", "/", nil)
+	_, ok := l.Allow(r, function, domain)
+	assert.Assert(t, ok)
+
+	_, ok = l.Allow(r, function, domain)
+	assert.Assert(t, !ok)
+
+	// the rejected request did not count against the domain
+	assert.Equal(t, l.admitted["domains/project/id|ip|192.0.2.1"], uint32(1))
+}
+
+func TestReports(t *testing.T) {
+	l, _ := newLimiter(t)
+	rule := Rule{Scope: "functions/project/id", Limit: Limit{PerIP: 10}}
+
+	r := httptest.NewRequest("GET", "/", nil)
+	_, ok := l.Allow(r, rule)
+	assert.Assert(t, ok)
+
+	// another node admitted the rest of what the client may make
+	data, err := cbor.Marshal(report{Counts: map[string]uint32{
+		"functions/project/id|ip|192.0.2.1":    9,
+		"functions/project/other|ip|192.0.2.1": 9,
+	}})
+	assert.NilError(t, err)
+	l.handle(&pubsub.Message{Message: &pb.Message{Data: data}})
+
+	_, ok = l.Allow(r, rule)
+	assert.Assert(t, !ok)
+
+	// buckets not used here are not created by reports
+	assert.Equal(t, len(l.buckets), 1)
+
+	// nor are reports of this node counted twice
+	l.handle(&pubsub.Message{Message: &pb.Message{Data: data}, ReceivedFrom: l.node.ID()})
+	assert.Equal(t, l.buckets["functions/project/id|ip|192.0.2.1"].tokens, float64(0))
+}
+
+func TestSweep(t *testing.T) {
+	l, now := newLimiter(t)
+	rule := Rule{Scope: "functions/project/id", Limit: Limit{PerIP: 1}}
+
+	r := httptest.NewRequest("GET", "/", nil)
+	_, ok := l.Allow(r, rule)
+	assert.Assert(t, ok)
+
+	l.sweep()
+	assert.Equal(t, len(l.buckets), 1)
+
+	*now = now.Add(IdleTimeout)
+	l.sweep()
+	assert.Equal(t, len(l.buckets), 0)
+}
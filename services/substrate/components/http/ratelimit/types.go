@@ -0,0 +1,50 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+
+	"github.com/taubyte/tau/p2p/peer"
+)
+
+// Limiter admits requests as long as their clients stay within the limits of
+// what they call. Each node counts the requests it admits, and reports them to
+// the others which count them too, so limits hold, approximately, cloud-wide.
+type Limiter struct {
+	node peer.Node
+	now  func() time.Time
+
+	lock    sync.Mutex
+	buckets map[string]*bucket
+	// admitted counts the requests admitted by key since the last report
+	admitted map[string]uint32
+}
+
+// Limit is how many requests per second a client may make, by its ip and by
+// the API key in KeyHeader. Zero leaves a client unlimited.
+type Limit struct {
+	PerIP     int
+	PerKey    int
+	KeyHeader string
+	// Burst is how many requests a client may make at once; it defaults to
+	// the rate.
+	Burst int
+}
+
+// Rule is the limit of a resource, scope naming it across nodes.
+type Rule struct {
+	Scope string
+	Limit
+}
+
+type bucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// report is what a node publishes of the requests it admitted.
+type report struct {
+	Counts map[string]uint32 `cbor:"1,keyasint"`
+}
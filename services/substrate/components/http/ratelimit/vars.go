@@ -0,0 +1,15 @@
+package ratelimit
+
+import "time"
+
+var (
+	// Topic is where nodes report the requests they admitted, for the others to
+	// count them against the same limits.
+	Topic = "/substrate/ratelimit/v1"
+
+	// ReportInterval is how often a node reports the requests it admitted.
+	ReportInterval = time.Second
+
+	// IdleTimeout is how long a full bucket is kept without being used.
+	IdleTimeout = time.Minute
+)
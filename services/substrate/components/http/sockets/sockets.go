@@ -0,0 +1,219 @@
+package sockets
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/gorilla/websocket"
+	"github.com/ipfs/go-log/v2"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	peerCore "github.com/libp2p/go-libp2p/core/peer"
+	httpIface "github.com/taubyte/tau/core/services/substrate/components/http"
+	"github.com/taubyte/tau/p2p/peer"
+)
+
+var logger = log.Logger("tau.substrate.components.http.sockets")
+
+var _ httpIface.WebSockets = &Registry{}
+
+// Registry holds the websocket connections owned by the functions of this
+// node. A connection id starts with the id of the node holding it, so sending
+// to a connection held elsewhere is forwarded to that node over pubsub.
+type Registry struct {
+	node  peer.Node
+	lock  sync.RWMutex
+	conns map[string]*Conn
+}
+
+// Conn is a connection of the registry, owned by a function of project.
+type Conn struct {
+	conn      *websocket.Conn
+	id        string
+	project   string
+	writeLock sync.Mutex
+}
+
+const (
+	opSend uint8 = iota
+	opClose
+)
+
+// request is what a node forwards to the node holding the connection.
+type request struct {
+	Op         uint8  `cbor:"1,keyasint"`
+	Project    string `cbor:"2,keyasint"`
+	Connection string `cbor:"3,keyasint"`
+	Data       []byte `cbor:"4,keyasint"`
+	Binary     bool   `cbor:"5,keyasint"`
+	Code       int    `cbor:"6,keyasint"`
+	Reason     string `cbor:"7,keyasint"`
+}
+
+func New(node peer.Node) *Registry {
+	return &Registry{node: node, conns: make(map[string]*Conn)}
+}
+
+func topic(pid peerCore.ID) string {
+	return TopicPrefix + pid.String()
+}
+
+// Subscribe handles the requests forwarded by other nodes for the connections
+// of this one, until ctx is done.
+func (r *Registry) Subscribe(ctx context.Context) error {
+	return r.node.PubSubSubscribeContext(ctx, topic(r.node.ID()), r.handle, func(err error) {
+		logger.Errorf("websocket subscription failed with: %s", err.Error())
+	})
+}
+
+func (r *Registry) handle(msg *pubsub.Message) {
+	var req request
+	if err := cbor.Unmarshal(msg.GetData(), &req); err != nil {
+		logger.Errorf("decoding forwarded websocket request failed with: %s", err.Error())
+		return
+	}
+
+	if err := r.do(req); err != nil {
+		logger.Debugf("forwarded websocket request to `%s` failed with: %s", req.Connection, err.Error())
+	}
+}
+
+// Add registers conn, owned by project, under a new connection id.
+func (r *Registry) Add(project string, conn *websocket.Conn) *Conn {
+	nonce := make([]byte, 16)
+	rand.Read(nonce)
+
+	c := &Conn{
+		conn:    conn,
+		id:      r.node.ID().String() + "/" + hex.EncodeToString(nonce),
+		project: project,
+	}
+
+	conn.SetReadLimit(MaxMessageSize)
+
+	r.lock.Lock()
+	r.conns[c.id] = c
+	r.lock.Unlock()
+
+	return c
+}
+
+// Remove drops c from the registry and closes it.
+func (r *Registry) Remove(c *Conn) {
+	r.lock.Lock()
+	delete(r.conns, c.id)
+	r.lock.Unlock()
+
+	c.conn.Close()
+}
+
+func (r *Registry) get(project, connection string) (*Conn, error) {
+	r.lock.RLock()
+	c, ok := r.conns[connection]
+	r.lock.RUnlock()
+
+	// a connection of another project is not for this one to see
+	if !ok || c.project != project {
+		return nil, httpIface.ErrConnectionNotFound
+	}
+
+	return c, nil
+}
+
+func (r *Registry) Send(ctx context.Context, projectId, connection string, data []byte, binary bool) error {
+	return r.route(ctx, request{Op: opSend, Project: projectId, Connection: connection, Data: data, Binary: binary})
+}
+
+func (r *Registry) CloseConnection(ctx context.Context, projectId, connection string, code int, reason string) error {
+	return r.route(ctx, request{Op: opClose, Project: projectId, Connection: connection, Code: code, Reason: reason})
+}
+
+// route runs req on this node if it holds the connection, and forwards it to
+// the node holding it otherwise. A forwarded request is not acknowledged.
+func (r *Registry) route(ctx context.Context, req request) error {
+	_pid, _, ok := strings.Cut(req.Connection, "/")
+	if !ok {
+		return fmt.Errorf("invalid connection id `%s`", req.Connection)
+	}
+
+	pid, err := peerCore.Decode(_pid)
+	if err != nil {
+		return fmt.Errorf("invalid connection id `%s`: %w", req.Connection, err)
+	}
+
+	if pid == r.node.ID() {
+		return r.do(req)
+	}
+
+	data, err := cbor.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("marshalling websocket request failed with: %w", err)
+	}
+
+	if err = r.node.PubSubPublish(ctx, topic(pid), data); err != nil {
+		return fmt.Errorf("forwarding websocket request to `%s` failed with: %w", pid, err)
+	}
+
+	return nil
+}
+
+func (r *Registry) do(req request) error {
+	c, err := r.get(req.Project, req.Connection)
+	if err != nil {
+		return err
+	}
+
+	switch req.Op {
+	case opSend:
+		return c.Write(req.Data, req.Binary)
+	case opClose:
+		return c.Close(req.Code, req.Reason)
+	default:
+		return fmt.Errorf("unknown websocket operation %d", req.Op)
+	}
+}
+
+func (c *Conn) Id() string {
+	return c.id
+}
+
+// Read returns the next message of the connection, and whether it is binary.
+func (c *Conn) Read() (data []byte, binary bool, err error) {
+	typ, data, err := c.conn.ReadMessage()
+	return data, typ == websocket.BinaryMessage, err
+}
+
+// Write sends data to the connection, as a binary message if binary is set.
+func (c *Conn) Write(data []byte, binary bool) error {
+	typ := websocket.TextMessage
+	if binary {
+		typ = websocket.BinaryMessage
+	}
+
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	c.conn.SetWriteDeadline(time.Now().Add(WriteTimeout))
+	return c.conn.WriteMessage(typ, data)
+}
+
+// Close sends a close message with code and reason, then closes the
+// connection. A code of 0 is a normal closure.
+func (c *Conn) Close(code int, reason string) error {
+	if code == 0 {
+		code = websocket.CloseNormalClosure
+	}
+
+	c.writeLock.Lock()
+	err := c.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(WriteTimeout))
+	c.writeLock.Unlock()
+
+	c.conn.Close()
+
+	return err
+}
@@ -0,0 +1,107 @@
+package sockets
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/gorilla/websocket"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pb "github.com/libp2p/go-libp2p-pubsub/pb"
+	httpIface "github.com/taubyte/tau/core/services/substrate/components/http"
+	"github.com/taubyte/tau/p2p/peer"
+	"gotest.tools/v3/assert"
+)
+
+// serve upgrades the connections to a test server, registering them for
+// project, and returns the client side of one along with its connection id.
+func serve(t *testing.T, r *Registry, project string) (*websocket.Conn, string) {
+	ids := make(chan string, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, req, nil)
+		if err != nil {
+			return
+		}
+
+		c := r.Add(project, conn)
+		defer r.Remove(c)
+		ids <- c.Id()
+
+		for {
+			if _, _, err := c.Read(); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	client, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	assert.NilError(t, err)
+	t.Cleanup(func() { client.Close() })
+
+	return client, <-ids
+}
+
+func TestSend(t *testing.T) {
+	ctx := t.Context()
+	r := New(peer.Mock(ctx))
+	client, id := serve(t, r, "project")
+
+	assert.Assert(t, strings.HasPrefix(id, r.node.ID().String()+"/"))
+
+	assert.NilError(t, r.Send(ctx, "project", id, []byte("hello"), false))
+	typ, data, err := client.ReadMessage()
+	assert.NilError(t, err)
+	assert.Equal(t, typ, websocket.TextMessage)
+	assert.Equal(t, string(data), "hello")
+
+	assert.NilError(t, r.Send(ctx, "project", id, []byte{0, 1}, true))
+	typ, data, err = client.ReadMessage()
+	assert.NilError(t, err)
+	assert.Equal(t, typ, websocket.BinaryMessage)
+	assert.DeepEqual(t, data, []byte{0, 1})
+}
+
+func TestSendOtherProject(t *testing.T) {
+	ctx := t.Context()
+	r := New(peer.Mock(ctx))
+	_, id := serve(t, r, "project")
+
+	err := r.Send(ctx, "other", id, []byte("hello"), false)
+	assert.Assert(t, errors.Is(err, httpIface.ErrConnectionNotFound))
+
+	err = r.Send(ctx, "project", r.node.ID().String()+"/unknown", []byte("hello"), false)
+	assert.Assert(t, errors.Is(err, httpIface.ErrConnectionNotFound))
+
+	assert.ErrorContains(t, r.Send(ctx, "project", "invalid", nil, false), "invalid connection id")
+}
+
+func TestCloseConnection(t *testing.T) {
+	ctx := t.Context()
+	r := New(peer.Mock(ctx))
+	client, id := serve(t, r, "project")
+
+	assert.NilError(t, r.CloseConnection(ctx, "project", id, 4000, "bye"))
+
+	_, _, err := client.ReadMessage()
+	var closeErr *websocket.CloseError
+	assert.Assert(t, errors.As(err, &closeErr))
+	assert.Equal(t, closeErr.Code, 4000)
+	assert.Equal(t, closeErr.Text, "bye")
+}
+
+func TestForwarded(t *testing.T) {
+	r := New(peer.Mock(t.Context()))
+	client, id := serve(t, r, "project")
+
+	data, err := cbor.Marshal(request{Op: opSend, Project: "project", Connection: id, Data: []byte("hello")})
+	assert.NilError(t, err)
+	r.handle(&pubsub.Message{Message: &pb.Message{Data: data}})
+
+	_, msg, err := client.ReadMessage()
+	assert.NilError(t, err)
+	assert.Equal(t, string(msg), "hello")
+}
@@ -0,0 +1,16 @@
+package sockets
+
+import "time"
+
+var (
+	// TopicPrefix is the prefix of the pubsub topic of each node, on which it
+	// receives requests for the connections it holds.
+	TopicPrefix = "/substrate/websocket/v1/"
+
+	// MaxMessageSize is the largest message read from a connection; a larger
+	// one closes it.
+	MaxMessageSize int64 = 1 << 20
+
+	// WriteTimeout bounds every write to a connection.
+	WriteTimeout = 10 * time.Second
+)
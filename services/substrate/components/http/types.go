@@ -3,6 +3,8 @@ package http
 import (
 	"github.com/taubyte/tau/core/services/substrate"
 	"github.com/taubyte/tau/pkg/config"
+	"github.com/taubyte/tau/services/substrate/components/http/ratelimit"
+	"github.com/taubyte/tau/services/substrate/components/http/sockets"
 	"github.com/taubyte/tau/services/substrate/runtime/cache"
 )
 
@@ -11,4 +13,7 @@ type Service struct {
 	config      config.Config
 	cache       *cache.Cache
 	dvPublicKey []byte
+	sockets     *sockets.Registry
+	limiter     *ratelimit.Limiter
+	domains     domainRules
 }
@@ -0,0 +1,130 @@
+package website
+
+import (
+	"encoding/json"
+	"fmt"
+	goHttp "net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/afero"
+	websiteSpec "github.com/taubyte/tau/pkg/specs/builders/website"
+)
+
+// loadManifest reads the asset manifest of the website build. Builds from
+// before precompression have none, and are served as they are.
+func (w *Website) loadManifest() error {
+	data, err := afero.ReadFile(w.root, "/"+websiteSpec.ManifestFile)
+	if err != nil {
+		w.manifest = nil
+		return nil
+	}
+
+	manifest := new(websiteSpec.Manifest)
+	if err = json.Unmarshal(data, manifest); err != nil {
+		return fmt.Errorf("decoding asset manifest failed with: %w", err)
+	}
+
+	w.manifest = manifest
+
+	return nil
+}
+
+// asset returns the file served at _path, resolving a directory to its
+// index.html, and its manifest entry.
+func (w *Website) asset(_path string) (string, websiteSpec.Asset, bool) {
+	if w.manifest == nil {
+		return "", websiteSpec.Asset{}, false
+	}
+
+	name := path.Clean(_path)
+	if st, err := w.root.Stat(name); err == nil && st.IsDir() {
+		name = path.Join(name, "index.html")
+	}
+
+	asset, ok := w.manifest.Files[name]
+	return name, asset, ok
+}
+
+// serveAsset serves the file name with a strong ETag, in the encoding the
+// client prefers among its precompressed variants. Conditional and range
+// requests are answered by http.ServeContent, against the representation
+// sent.
+func (w *Website) serveAsset(_w goHttp.ResponseWriter, r *goHttp.Request, name string, asset websiteSpec.Asset) error {
+	h := _w.Header()
+	if len(asset.Encodings) > 0 {
+		h.Add("Vary", "Accept-Encoding")
+	}
+
+	etag := asset.Hash
+	file := name
+	if encoding := negotiate(r.Header.Get("Accept-Encoding"), asset.Encodings); encoding != "" {
+		// each representation has its own strong ETag
+		etag += "-" + encoding
+		file = websiteSpec.Variant(name, encoding)
+		h.Set("Content-Encoding", encoding)
+	}
+
+	h.Set("Etag", strconv.Quote(etag))
+	if h.Get("Cache-Control") == "" {
+		if asset.Immutable {
+			h.Set("Cache-Control", "public, max-age=31536000, immutable")
+		} else {
+			h.Set("Cache-Control", "no-cache")
+		}
+	}
+
+	f, err := w.root.Open(file)
+	if err != nil {
+		goHttp.Error(_w, "500 internal server error", goHttp.StatusInternalServerError)
+		return fmt.Errorf("opening asset `%s` failed with: %w", file, err)
+	}
+	defer f.Close()
+
+	st, err := f.Stat()
+	if err != nil {
+		goHttp.Error(_w, "500 internal server error", goHttp.StatusInternalServerError)
+		return fmt.Errorf("stat of asset `%s` failed with: %w", file, err)
+	}
+
+	// the content type is the one of the file, not of its encoding
+	h.Del("Content-Type")
+	goHttp.ServeContent(_w, r, name, st.ModTime(), f)
+
+	return nil
+}
+
+// negotiate returns the encoding of available the client accepts with the
+// highest quality, favoring the order of available on a tie, or "" for the
+// identity.
+func negotiate(accept string, available []string) (encoding string) {
+	if accept == "" || len(available) == 0 {
+		return ""
+	}
+
+	qualities := make(map[string]float64)
+	for _, part := range strings.Split(accept, ",") {
+		coding, params, _ := strings.Cut(part, ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+				q = parsed
+			}
+		}
+		qualities[strings.ToLower(strings.TrimSpace(coding))] = q
+	}
+
+	best := 0.0
+	for _, enc := range available {
+		q, ok := qualities[enc]
+		if !ok {
+			q, ok = qualities["*"]
+		}
+		if ok && q > best {
+			encoding, best = enc, q
+		}
+	}
+
+	return
+}
@@ -0,0 +1,81 @@
+package website
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	websiteSpec "github.com/taubyte/tau/pkg/specs/builders/website"
+	structureSpec "github.com/taubyte/tau/pkg/specs/structure"
+)
+
+func TestNegotiate(t *testing.T) {
+	available := []string{websiteSpec.Brotli, websiteSpec.Gzip}
+	for accept, expected := range map[string]string{
+		"":                     "",
+		"gzip, deflate, br":    websiteSpec.Brotli,
+		"gzip":                 websiteSpec.Gzip,
+		"br;q=0.5, gzip;q=0.8": websiteSpec.Gzip,
+		"br;q=0, gzip;q=0":     "",
+		"*":                    websiteSpec.Brotli,
+		"identity":             "",
+	} {
+		if encoding := negotiate(accept, available); encoding != expected {
+			t.Errorf("Accept-Encoding %q: got %q, expected %q", accept, encoding, expected)
+		}
+	}
+}
+
+func TestServeAsset(t *testing.T) {
+	manifest, _ := json.Marshal(websiteSpec.Manifest{Files: map[string]websiteSpec.Asset{
+		"/index.html": {Hash: "abc", Size: 12, Encodings: []string{websiteSpec.Brotli}},
+	}})
+
+	w := newTestWebsite(t, structureSpec.Website{}, map[string]string{
+		"/index.html":                  "hello world!",
+		"/index.html.br":               "compressed",
+		"/" + websiteSpec.ManifestFile: string(manifest),
+	})
+	if err := w.loadManifest(); err != nil {
+		t.Fatal(err)
+	}
+
+	serve := func(header http.Header) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		r := httptest.NewRequest("GET", "/", nil)
+		r.Header = header
+		if err := w.serve(rec, r, "/", "/"); err != nil {
+			t.Fatal(err)
+		}
+		return rec
+	}
+
+	rec := serve(http.Header{"Accept-Encoding": {"gzip, br"}})
+	if rec.Code != http.StatusOK || rec.Body.String() != "compressed" || rec.Header().Get("Content-Encoding") != "br" {
+		t.Fatalf("expected the brotli variant, got %d %q", rec.Code, rec.Body.String())
+	}
+	if rec.Header().Get("Etag") != `"abc-br"` || rec.Header().Get("Content-Type") != "text/html; charset=utf-8" {
+		t.Errorf("unexpected headers %v", rec.Header())
+	}
+
+	rec = serve(http.Header{"If-None-Match": {`"abc"`}})
+	if rec.Code != http.StatusNotModified {
+		t.Errorf("expected not modified, got %d", rec.Code)
+	}
+
+	rec = serve(http.Header{"Range": {"bytes=6-10"}})
+	if rec.Code != http.StatusPartialContent || rec.Body.String() != "world" {
+		t.Errorf("expected a partial content, got %d %q", rec.Code, rec.Body.String())
+	}
+
+	// the manifest itself is not served; without SPA fallback it is not found
+	w.config.DisableSPA = true
+	rec = httptest.NewRecorder()
+	if err := w.serve(rec, httptest.NewRequest("GET", "/"+websiteSpec.ManifestFile, nil), "/", "/"+websiteSpec.ManifestFile); err != nil {
+		t.Fatal(err)
+	}
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected the manifest not to be found, got %d", rec.Code)
+	}
+}
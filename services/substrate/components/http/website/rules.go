@@ -0,0 +1,185 @@
+package website
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	goHttp "net/http"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+const (
+	redirectsFile = "/_redirects"
+	headersFile   = "/_headers"
+)
+
+// pattern matches a request path exactly or, ending in "/*", any path under it.
+type pattern struct {
+	path   string
+	prefix bool
+}
+
+func newPattern(p string) pattern {
+	if prefix, ok := strings.CutSuffix(p, "/*"); ok {
+		return pattern{path: prefix, prefix: true}
+	}
+	return pattern{path: p}
+}
+
+// match reports whether _path matches, along with the part matched by "*".
+func (p pattern) match(_path string) (splat string, ok bool) {
+	if !p.prefix {
+		return "", _path == p.path
+	}
+
+	if _path == p.path {
+		return "", true
+	}
+
+	splat, ok = strings.CutPrefix(_path, p.path+"/")
+	return
+}
+
+type redirect struct {
+	from   pattern
+	to     string
+	status int
+}
+
+type header struct {
+	path  pattern
+	name  string
+	value string
+}
+
+// parseRedirect parses a rule written as `<from> <to> [status]`; the status
+// defaults to 301, and 200 rewrites the path instead of redirecting.
+func parseRedirect(rule string) (redirect, error) {
+	fields := strings.Fields(rule)
+	if len(fields) < 2 || len(fields) > 3 {
+		return redirect{}, errors.New("expected `<from> <to> [status]`")
+	}
+
+	r := redirect{from: newPattern(fields[0]), to: fields[1], status: goHttp.StatusMovedPermanently}
+	if len(fields) == 3 {
+		status, err := strconv.Atoi(strings.TrimSuffix(fields[2], "!"))
+		if err != nil || (status != goHttp.StatusOK && (status < 300 || status > 399)) {
+			return redirect{}, fmt.Errorf("invalid status `%s`", fields[2])
+		}
+		r.status = status
+	}
+
+	if r.status == goHttp.StatusOK && !strings.HasPrefix(r.to, "/") {
+		return redirect{}, errors.New("a rewrite must lead to a path")
+	}
+
+	return r, nil
+}
+
+// parseHeader parses a rule written as `<path> <Name>: <value>`.
+func parseHeader(rule string) (header, error) {
+	_path, field, ok := strings.Cut(strings.TrimSpace(rule), " ")
+	if !ok {
+		return header{}, errors.New("expected `<path> <Name>: <value>`")
+	}
+
+	name, value, ok := strings.Cut(field, ":")
+	if name = strings.TrimSpace(name); !ok || name == "" {
+		return header{}, errors.New("expected `<path> <Name>: <value>`")
+	}
+
+	return header{path: newPattern(_path), name: name, value: strings.TrimSpace(value)}, nil
+}
+
+// headerRules turns a _headers file, where a path is followed by its indented
+// `Name: value` lines, into rules as written in the website config.
+func headerRules(data []byte) (rules []string) {
+	var _path string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "" || strings.HasPrefix(trimmed, "#"):
+		case line[0] != ' ' && line[0] != '\t':
+			_path = trimmed
+		case _path != "":
+			rules = append(rules, _path+" "+trimmed)
+		}
+	}
+
+	return
+}
+
+// redirectRules returns the rules of a _redirects file.
+func redirectRules(data []byte) (rules []string) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" && !strings.HasPrefix(line, "#") {
+			rules = append(rules, line)
+		}
+	}
+
+	return
+}
+
+// loadRules parses the redirects and headers of the website config followed by
+// those of the _redirects and _headers files of its build, if any. Invalid
+// rules are skipped rather than failing the website.
+func (w *Website) loadRules() {
+	redirects := w.config.Redirects
+	if data, err := afero.ReadFile(w.root, redirectsFile); err == nil {
+		redirects = append(redirects[:len(redirects):len(redirects)], redirectRules(data)...)
+	}
+
+	headers := w.config.Headers
+	if data, err := afero.ReadFile(w.root, headersFile); err == nil {
+		headers = append(headers[:len(headers):len(headers)], headerRules(data)...)
+	}
+
+	w.redirects = w.redirects[:0]
+	for _, rule := range redirects {
+		r, err := parseRedirect(rule)
+		if err != nil {
+			logger.Warnf("skipping redirect `%s` of website `%s`: %s", rule, w.config.Name, err.Error())
+			continue
+		}
+		w.redirects = append(w.redirects, r)
+	}
+
+	w.headers = w.headers[:0]
+	for _, rule := range headers {
+		h, err := parseHeader(rule)
+		if err != nil {
+			logger.Warnf("skipping header `%s` of website `%s`: %s", rule, w.config.Name, err.Error())
+			continue
+		}
+		w.headers = append(w.headers, h)
+	}
+}
+
+// redirect returns the first redirect matching _path, and where it leads with
+// ":splat" replaced.
+func (w *Website) redirect(_path string) (*redirect, string) {
+	for i := range w.redirects {
+		r := &w.redirects[i]
+		if splat, ok := r.from.match(_path); ok {
+			return r, strings.ReplaceAll(r.to, ":splat", splat)
+		}
+	}
+
+	return nil, ""
+}
+
+// setHeaders adds the headers of the rules matching _path.
+func (w *Website) setHeaders(h goHttp.Header, _path string) {
+	for _, rule := range w.headers {
+		if _, ok := rule.path.match(_path); ok {
+			h.Add(rule.name, rule.value)
+		}
+	}
+}
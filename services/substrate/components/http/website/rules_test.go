@@ -0,0 +1,94 @@
+package website
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/spf13/afero"
+	structureSpec "github.com/taubyte/tau/pkg/specs/structure"
+)
+
+func newTestWebsite(t *testing.T, config structureSpec.Website, files map[string]string) *Website {
+	root := afero.NewMemMapFs()
+	for name, data := range files {
+		if err := afero.WriteFile(root, name, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	w := &Website{config: config, root: root}
+	w.loadRules()
+
+	return w
+}
+
+func TestRules(t *testing.T) {
+	w := newTestWebsite(t, structureSpec.Website{
+		Redirects: []string{"/old /new", "/blog/* /posts/:splat 302", "invalid", "/app/* /app.html 200"},
+		Headers:   []string{"/assets/* Cache-Control: max-age=60"},
+	}, map[string]string{
+		"/_redirects": "# moved\n/docs https://docs.example.com 308\n",
+		"/_headers":   "/*\n  Content-Security-Policy: default-src 'self'\n",
+	})
+
+	if len(w.redirects) != 4 {
+		t.Fatalf("expected 4 redirects, got %d", len(w.redirects))
+	}
+
+	for _path, expected := range map[string]string{
+		"/old":        "/new",
+		"/blog/a/b":   "/posts/a/b",
+		"/app/x":      "/app.html",
+		"/docs":       "https://docs.example.com",
+		"/oldies":     "",
+		"/blogger/ab": "",
+	} {
+		_, to := w.redirect(_path)
+		if to != expected {
+			t.Errorf("redirect of %s: got %q, expected %q", _path, to, expected)
+		}
+	}
+
+	h := make(http.Header)
+	w.setHeaders(h, "/assets/app.js")
+	if h.Get("Cache-Control") != "max-age=60" || h.Get("Content-Security-Policy") != "default-src 'self'" {
+		t.Errorf("unexpected headers %v", h)
+	}
+}
+
+func TestServeRedirectAndNotFound(t *testing.T) {
+	w := newTestWebsite(t, structureSpec.Website{
+		NotFound:  "/404.html",
+		Redirects: []string{"/old /new 301"},
+	}, map[string]string{
+		"/404.html": "not here",
+	})
+
+	rec := httptest.NewRecorder()
+	if err := w.serve(rec, httptest.NewRequest("GET", "/site/old", nil), "/site", "/old"); err != nil {
+		t.Fatal(err)
+	}
+	if rec.Code != http.StatusMovedPermanently || rec.Header().Get("Location") != "/site/new" {
+		t.Errorf("expected a redirect to /site/new, got %d to %q", rec.Code, rec.Header().Get("Location"))
+	}
+
+	rec = httptest.NewRecorder()
+	if err := w.serve(rec, httptest.NewRequest("GET", "/missing", nil), "/", "/missing"); err != nil {
+		t.Fatal(err)
+	}
+	if rec.Code != http.StatusNotFound || rec.Body.String() != "not here" || rec.Header().Get("Content-Type") != "text/html; charset=utf-8" {
+		t.Errorf("expected the not-found page, got %d %q", rec.Code, rec.Body.String())
+	}
+
+	w = newTestWebsite(t, structureSpec.Website{DisableSPA: true}, map[string]string{"/_redirects": "/a /b"})
+	for _, _path := range []string{"/missing", "/_redirects"} {
+		rec = httptest.NewRecorder()
+		if err := w.serve(rec, httptest.NewRequest("GET", _path, nil), "/", _path); err != nil {
+			t.Fatal(err)
+		}
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("expected %s not to be found without SPA fallback, got %d", _path, rec.Code)
+		}
+	}
+}
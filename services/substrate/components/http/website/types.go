@@ -5,6 +5,7 @@ import (
 
 	"github.com/spf13/afero"
 	commonIface "github.com/taubyte/tau/core/services/substrate/components"
+	websiteSpec "github.com/taubyte/tau/pkg/specs/builders/website"
 	structureSpec "github.com/taubyte/tau/pkg/specs/structure"
 	"github.com/taubyte/tau/services/substrate/components/http/common"
 	"github.com/taubyte/tau/services/substrate/components/metrics"
@@ -16,6 +17,9 @@ type Website struct {
 	config        structureSpec.Website
 	computedPaths map[string][]string
 	root          afero.Fs
+	redirects     []redirect
+	headers       []header
+	manifest      *websiteSpec.Manifest
 
 	matcher     *common.MatchDefinition
 	project     string
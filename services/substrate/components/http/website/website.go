@@ -6,21 +6,27 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"mime"
 	goHttp "net/http"
 	"path"
 	"strings"
 	"time"
 
+	"github.com/ipfs/go-log/v2"
 	"github.com/spf13/afero/zipfs"
 	"github.com/taubyte/tau/core/services/substrate/components"
 	httpComp "github.com/taubyte/tau/core/services/substrate/components/http"
+	"github.com/taubyte/tau/core/services/substrate/smartops"
 	http "github.com/taubyte/tau/pkg/http"
+	websiteSpec "github.com/taubyte/tau/pkg/specs/builders/website"
 	matcherSpec "github.com/taubyte/tau/pkg/specs/matcher"
 	"github.com/taubyte/tau/services/substrate/components/http/common"
 	"github.com/taubyte/tau/services/substrate/components/metrics"
 	"github.com/taubyte/tau/utils/readerutil"
 )
 
+var logger = log.Logger("tau.substrate.components.http.website")
+
 func (w *Website) Provision() (web httpComp.Serviceable, err error) {
 	w.instanceCtx, w.instanceCtxC = context.WithCancel(w.srv.Context())
 	w.readyCtx, w.readyCtxC = context.WithCancel(w.srv.Context())
@@ -63,19 +69,103 @@ func (w *Website) Handle(_w goHttp.ResponseWriter, r *goHttp.Request, matcher co
 		return t, errors.New("invalid match definition")
 	}
 
+	if len(w.config.SmartOps) > 0 {
+		val, err := w.SmartOps()
+		if err != nil {
+			return t, fmt.Errorf("running smart ops failed with: %w", err)
+		}
+
+		if val > 0 {
+			return t, &smartops.Denied{Code: val}
+		}
+	}
+
 	pathMatch := _matcher.Get(common.PathMatch)
 	_path := path.Clean("/" + strings.TrimPrefix(r.URL.Path, pathMatch))
 	if strings.HasSuffix(r.URL.Path, "/") {
 		_path += "/"
 	}
 
+	return time.Now(), w.serve(_w, r, pathMatch, _path)
+}
+
+// serve answers r for _path, the request path relative to pathMatch where the
+// website is served.
+func (w *Website) serve(_w goHttp.ResponseWriter, r *goHttp.Request, pathMatch, _path string) error {
+	rulePath := path.Clean(_path)
+	w.setHeaders(_w.Header(), rulePath)
+
+	if rule, to := w.redirect(rulePath); rule != nil {
+		if rule.status != goHttp.StatusOK {
+			if strings.HasPrefix(to, "/") {
+				to = path.Join(pathMatch, to)
+			}
+			goHttp.Redirect(_w, r, to, rule.status)
+			return nil
+		}
+		_path = to
+	}
+
+	if !w.exists(_path) {
+		switch {
+		case w.config.NotFound != "":
+			return w.serveNotFound(_w)
+		case w.config.DisableSPA:
+			goHttp.NotFound(_w, r)
+			return nil
+		default:
+			_path = "/"
+		}
+	}
+
+	if name, asset, ok := w.asset(_path); ok {
+		return w.serveAsset(_w, r, name, asset)
+	}
+
 	r.URL.Path = _path
-	err = w.srv.Http().LowLevelAssetHandler(&http.HeadlessAssetsDefinition{
+	return w.srv.Http().LowLevelAssetHandler(&http.HeadlessAssetsDefinition{
 		FileSystem:            w.root,
-		SinglePageApplication: true,
+		SinglePageApplication: !w.config.DisableSPA,
 		Directory:             "/",
 	}, _w, r)
-	return time.Now(), err
+}
+
+// exists reports whether a file, or a directory with an index.html, is served
+// at _path. The rule files and the asset manifest are not.
+func (w *Website) exists(_path string) bool {
+	if _path == redirectsFile || _path == headersFile || _path == "/"+websiteSpec.ManifestFile {
+		return false
+	}
+
+	st, err := w.root.Stat(_path)
+	if err != nil {
+		return false
+	}
+
+	if st.IsDir() {
+		_, err = w.root.Stat(path.Join(_path, "index.html"))
+		return err == nil
+	}
+
+	return true
+}
+
+// serveNotFound serves the website's not-found page with a 404 status.
+func (w *Website) serveNotFound(_w goHttp.ResponseWriter) error {
+	f, err := w.root.Open(path.Clean("/" + w.config.NotFound))
+	if err != nil {
+		goHttp.Error(_w, "404 page not found", goHttp.StatusNotFound)
+		return fmt.Errorf("opening not-found page `%s` failed with: %w", w.config.NotFound, err)
+	}
+	defer f.Close()
+
+	if ctype := mime.TypeByExtension(path.Ext(w.config.NotFound)); ctype != "" {
+		_w.Header().Set("Content-Type", ctype)
+	}
+	_w.WriteHeader(goHttp.StatusNotFound)
+
+	_, err = io.Copy(_w, f)
+	return err
 }
 
 func (w *Website) Validate(matcher components.MatchDefinition) error {
@@ -117,7 +207,9 @@ func (w *Website) getAsset() error {
 	w.root = zipfs.New(zipReader)
 	dagReader.Close()
 
-	return nil
+	w.loadRules()
+
+	return w.loadManifest()
 }
 
 func (w *Website) Match(matcher components.MatchDefinition) (currentMatchIndex matcherSpec.Index) {
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/binary"
 	"errors"
+	"sort"
 )
 
 func (m *Function) Less(comp Iface) bool {
@@ -23,6 +24,29 @@ func (m *Function) Encode() []byte {
 	binary.Write(&buf, binary.LittleEndian, m.ColdStart)
 	binary.Write(&buf, binary.LittleEndian, m.Memory)
 	binary.Write(&buf, binary.LittleEndian, m.AvgRunTime)
+	binary.Write(&buf, binary.LittleEndian, m.PeakMemory)
+	binary.Write(&buf, binary.LittleEndian, m.AvgCompileTime)
+	binary.Write(&buf, binary.LittleEndian, m.AvgInstantiateTime)
+	binary.Write(&buf, binary.LittleEndian, m.AvgWallTime)
+
+	groups := make([]string, 0, len(m.HostCalls))
+	for group := range m.HostCalls {
+		if len(group) <= maxHostCallsGroup {
+			groups = append(groups, group)
+		}
+	}
+	sort.Strings(groups)
+
+	binary.Write(&buf, binary.LittleEndian, uint16(len(groups)))
+	for _, group := range groups {
+		buf.WriteByte(uint8(len(group)))
+		buf.WriteString(group)
+		binary.Write(&buf, binary.LittleEndian, m.HostCalls[group])
+	}
+
+	binary.Write(&buf, binary.LittleEndian, m.WarmCalls)
+	binary.Write(&buf, binary.LittleEndian, m.ColdCalls)
+
 	return buf.Bytes()
 }
 
@@ -55,5 +79,54 @@ func (m *Function) Decode(b []byte) error {
 		return err
 	}
 
+	// Nodes predating the runtime stats stop here.
+	if buf.Len() == 0 {
+		return nil
+	}
+
+	for _, field := range []any{&m.PeakMemory, &m.AvgCompileTime, &m.AvgInstantiateTime, &m.AvgWallTime} {
+		if err := binary.Read(buf, binary.LittleEndian, field); err != nil {
+			return err
+		}
+	}
+
+	var groups uint16
+	if err := binary.Read(buf, binary.LittleEndian, &groups); err != nil {
+		return err
+	}
+
+	m.HostCalls = make(map[string]HostCalls, groups)
+	for ; groups > 0; groups-- {
+		size, err := buf.ReadByte()
+		if err != nil {
+			return err
+		}
+
+		group := buf.Next(int(size))
+		if len(group) != int(size) {
+			return errors.New("host calls group truncated")
+		}
+
+		var calls HostCalls
+		if err = binary.Read(buf, binary.LittleEndian, &calls); err != nil {
+			return err
+		}
+
+		m.HostCalls[string(group)] = calls
+	}
+
+	// Nodes predating the warm/cold split stop here.
+	if buf.Len() == 0 {
+		return nil
+	}
+
+	if err := binary.Read(buf, binary.LittleEndian, &m.WarmCalls); err != nil {
+		return err
+	}
+
+	if err := binary.Read(buf, binary.LittleEndian, &m.ColdCalls); err != nil {
+		return err
+	}
+
 	return nil
 }
@@ -0,0 +1,43 @@
+package metrics
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestFunctionEncoding(t *testing.T) {
+	m := &Function{
+		Cached:             1,
+		ColdStart:          2,
+		Memory:             0.5,
+		AvgRunTime:         3,
+		PeakMemory:         1 << 20,
+		AvgCompileTime:     4,
+		AvgInstantiateTime: 5,
+		AvgWallTime:        6,
+		HostCalls: map[string]HostCalls{
+			"database": {Calls: 1.5, Time: 7},
+			"storage":  {Calls: 0.25, Time: 8},
+		},
+		WarmCalls: 9,
+		ColdCalls: 10,
+	}
+
+	var decoded Function
+	assert.NilError(t, decoded.Decode(m.Encode()))
+	assert.DeepEqual(t, &decoded, m)
+}
+
+func TestFunctionDecodeBeforeRuntimeStats(t *testing.T) {
+	m := &Function{Cached: 1, ColdStart: 2, Memory: 0.5, AvgRunTime: 3, AvgWallTime: 6}
+
+	// What nodes predating the runtime stats send: the first four metrics.
+	encoded := m.Encode()[:1+4+8+8+8]
+
+	var decoded Function
+	assert.NilError(t, decoded.Decode(encoded))
+	assert.DeepEqual(t, decoded, Function{Cached: 1, ColdStart: 2, Memory: 0.5, AvgRunTime: 3})
+
+	assert.ErrorContains(t, decoded.Decode(m.Encode()[:len(encoded)+3]), "EOF")
+}
@@ -15,6 +15,25 @@ type Function struct {
 	ColdStart  int64
 	Memory     float64
 	AvgRunTime int64
+
+	// Runtime stats; absent from metrics of nodes predating them.
+	PeakMemory         uint64
+	AvgCompileTime     int64
+	AvgInstantiateTime int64
+	AvgWallTime        int64
+	HostCalls          map[string]HostCalls
+
+	// Calls served by a warm, pooled instance, and by a cold one; ColdStart
+	// is the average time the cold ones took to start.
+	WarmCalls uint64
+	ColdCalls uint64
+}
+
+// HostCalls are the calls a function makes, on average, to a group of host
+// functions, and the time they take.
+type HostCalls struct {
+	Calls float32
+	Time  int64
 }
 
 type Iface interface {
@@ -1,3 +1,6 @@
 package metrics
 
 var EncodingVersion uint8 = 1
+
+// maxHostCallsGroup is the longest host calls group name that is encoded.
+const maxHostCallsGroup = 255
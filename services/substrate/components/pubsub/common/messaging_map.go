@@ -31,6 +31,7 @@ type MessagingMapItem struct {
 type MessagingMap struct {
 	Function  MessagingMapItem
 	WebSocket MessagingMapItem
+	MQTT      MessagingMapItem
 	HasAny    bool
 }
 
@@ -34,6 +34,14 @@ func (f *Function) HandleMessage(msg iface.Message) (t time.Time, err error) {
 
 	// Pass the interface message directly to the SDK
 	ev := instance.SDK().CreatePubsubEvent(msg)
+	val, err := f.SmartOps(ev)
+	if err != nil || val > 0 {
+		if err != nil {
+			return t, fmt.Errorf("running smart ops failed with: %s", err)
+		}
+
+		return t, fmt.Errorf("exited: %d", val)
+	}
 
 	return time.Now(), f.Call(instance, ev.Id)
 }
@@ -32,6 +32,9 @@ func (s *Service) getMessagingsMap(matcher *common.MatchDefinition) (*common.Mes
 			if m.WebSocket {
 				messagingsMap.WebSocket.Push(matcher.Project, "", m)
 			}
+			if m.MQTT {
+				messagingsMap.MQTT.Push(matcher.Project, "", m)
+			}
 			messagingsMap.Function.Push(matcher.Project, "", m)
 		}
 	}
@@ -7,6 +7,9 @@ import (
 )
 
 func (s *Service) Close() error {
+	if s.mqtt != nil {
+		s.mqtt.Close()
+	}
 	s.cache.Close()
 	return nil
 }
@@ -0,0 +1,62 @@
+package pubsub
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/taubyte/tau/services/substrate/components/pubsub/common"
+	"github.com/taubyte/tau/services/substrate/components/pubsub/mqtt"
+)
+
+var _ mqtt.Service = &Service{}
+
+// AllowMQTT returns an error unless channel matches a messaging of the project,
+// or of its application appId, with MQTT enabled.
+func (s *Service) AllowMQTT(projectId, appId, channel string) error {
+	matcher := &common.MatchDefinition{
+		Channel:     strings.TrimPrefix(channel, "/"),
+		Project:     projectId,
+		Application: appId,
+	}
+
+	messagingsMap, _, _, err := s.getMessagingsMap(matcher)
+	if err != nil {
+		return fmt.Errorf("getting messagings of `%s` failed with: %w", matcher.Channel, err)
+	}
+
+	if messagingsMap.MQTT.Len() == 0 {
+		return fmt.Errorf("no messaging with mqtt enabled matches channel `%s`", matcher.Channel)
+	}
+
+	return nil
+}
+
+func (s *Service) MQTTToken(projectId, appId string, expiry time.Time) (string, error) {
+	if s.mqtt == nil {
+		return "", errors.New("mqtt is not enabled on this node")
+	}
+
+	return mqtt.Token(s.mqttSecret, projectId, appId, expiry)
+}
+
+func (s *Service) startMQTT() error {
+	if s.mqttPort <= 0 {
+		return nil
+	}
+
+	s.mqtt = mqtt.New(s, s.mqttSecret)
+
+	listen := s.mqtt.Listen
+	if s.mqttTLS != nil {
+		listen = func(addr string) error { return s.mqtt.ListenTLS(addr, s.mqttTLS) }
+	}
+
+	if err := listen(fmt.Sprintf(":%d", s.mqttPort)); err != nil {
+		s.mqtt = nil
+		return err
+	}
+
+	return nil
+}
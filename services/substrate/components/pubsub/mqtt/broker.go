@@ -0,0 +1,210 @@
+package mqtt
+
+import (
+	"bufio"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	pubsubIface "github.com/taubyte/tau/core/services/substrate/components/pubsub"
+)
+
+// Service is the pubsub service MQTT clients are bridged to.
+type Service interface {
+	pubsubIface.ServiceWithLookup
+	// AllowMQTT returns an error unless channel matches a messaging of the
+	// project, or of its application appId, open to MQTT clients.
+	AllowMQTT(projectId, appId, channel string) error
+}
+
+// Broker is an MQTT 3.1.1 and 5 front-end to messaging channels. A client
+// connects with a token as password, which grants it the channels of a
+// project; a topic is the name of a channel, and must match one of its
+// messaging resources with MQTT enabled. Messages are published to and
+// received from the channel the same way libp2p pubsub messages are, so they
+// trigger the functions subscribed to it.
+//
+// Sessions are not persisted, retained messages are not supported, topic
+// filters cannot hold wildcards, and messages are sent to clients with at most
+// QoS 1.
+type Broker struct {
+	srv    Service
+	secret []byte
+
+	lock      sync.Mutex
+	listeners []net.Listener
+	sessions  map[string]*session
+	closed    bool
+}
+
+func New(srv Service, secret []byte) *Broker {
+	return &Broker{
+		srv:      srv,
+		secret:   secret,
+		sessions: make(map[string]*session),
+	}
+}
+
+// Listen accepts MQTT clients on the tcp address addr.
+func (b *Broker) Listen(addr string) error {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("listening on `%s` failed with: %w", addr, err)
+	}
+
+	go b.Serve(l)
+
+	return nil
+}
+
+// ListenTLS accepts MQTT clients over TLS, configured by config, on the tcp
+// address addr.
+func (b *Broker) ListenTLS(addr string, config *tls.Config) error {
+	l, err := tls.Listen("tcp", addr, config)
+	if err != nil {
+		return fmt.Errorf("listening with tls on `%s` failed with: %w", addr, err)
+	}
+
+	go b.Serve(l)
+
+	return nil
+}
+
+// Serve accepts MQTT clients on l until the broker is closed.
+func (b *Broker) Serve(l net.Listener) {
+	b.lock.Lock()
+	if b.closed {
+		b.lock.Unlock()
+		l.Close()
+		return
+	}
+	b.listeners = append(b.listeners, l)
+	b.lock.Unlock()
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				logger.Errorf("accepting mqtt connection failed with: %s", err.Error())
+			}
+			return
+		}
+
+		go b.handle(conn)
+	}
+}
+
+// Close stops accepting clients and disconnects those connected.
+func (b *Broker) Close() error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.closed = true
+	for _, l := range b.listeners {
+		l.Close()
+	}
+
+	for _, s := range b.sessions {
+		s.conn.Close()
+	}
+
+	return nil
+}
+
+func (b *Broker) handle(conn net.Conn) {
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	conn.SetDeadline(time.Now().Add(ConnectTimeout))
+	p, err := readPacket(r, MaxPacketSize)
+	if err != nil || p.typ != typeConnect {
+		return
+	}
+
+	c, err := decodeConnect(p)
+	if errors.Is(err, errBadVersion) {
+		conn.Write(encodeConnack(version311, connackBadVersion311, nil))
+		return
+	} else if err != nil {
+		return
+	}
+
+	s, code := b.connect(conn, c)
+	if s == nil {
+		conn.Write(encodeConnack(c.version, code, nil))
+		return
+	}
+	conn.SetDeadline(time.Time{})
+
+	defer b.unregister(s)
+	defer s.close()
+
+	go s.writer()
+	s.send(encodeConnack(s.version, connackAccepted, s.connackProperties()))
+
+	if err = s.serve(r, c.keepAlive); err != nil {
+		logger.Debugf("mqtt client `%s` disconnected with: %s", s.clientId, err.Error())
+	}
+}
+
+// connect authenticates c, and returns its session. A session of the same
+// client is taken over.
+func (b *Broker) connect(conn net.Conn, c *connect) (*session, byte) {
+	claims, err := verifyToken(b.secret, string(c.password))
+	if err != nil {
+		return nil, reason(c.version, connackBadCredentials311, reasonBadCredentials)
+	}
+
+	if c.clientId == "" && !c.cleanStart && c.version == version311 {
+		return nil, connackBadClientId311
+	}
+
+	s := newSession(b, conn, c, claims)
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.closed {
+		s.ctxC()
+		return nil, reason(c.version, connackUnavailable311, reasonServerUnavailable)
+	}
+
+	if old, ok := b.sessions[s.key]; ok {
+		old.conn.Close()
+	}
+	b.sessions[s.key] = s
+
+	return s, connackAccepted
+}
+
+func (b *Broker) unregister(s *session) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.sessions[s.key] == s {
+		delete(b.sessions, s.key)
+	}
+}
+
+// reason returns the code of version: code311 for MQTT 3.1.1, and code5 for
+// MQTT 5.
+func reason(version, code311, code5 byte) byte {
+	if version == version5 {
+		return code5
+	}
+
+	return code311
+}
+
+func encodeConnack(version, code byte, properties []byte) []byte {
+	body := []byte{0, code}
+	if version == version5 {
+		body = appendVarint(body, len(properties))
+		body = append(body, properties...)
+	}
+
+	return encode(typeConnack, 0, body)
+}
@@ -0,0 +1,159 @@
+package mqtt
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	pubsubIface "github.com/taubyte/tau/core/services/substrate/components/pubsub"
+	"github.com/taubyte/tau/p2p/peer"
+	"gotest.tools/v3/assert"
+)
+
+var secret = []byte("secret")
+
+type fakeService struct {
+	pubsubIface.ServiceWithLookup
+	ctx  context.Context
+	node peer.Node
+}
+
+func (f *fakeService) Node() peer.Node {
+	return f.node
+}
+
+func (f *fakeService) Context() context.Context {
+	return f.ctx
+}
+
+func (f *fakeService) AllowMQTT(projectId, appId, channel string) error {
+	if projectId == "project" && strings.HasPrefix(channel, "allowed") {
+		return nil
+	}
+
+	return errors.New("no messaging")
+}
+
+// newBroker returns the address of a broker. Subscriptions to a channel are
+// shared by the process, so tests use channels of their own.
+func newBroker(t *testing.T) string {
+	ctx := t.Context()
+	b := New(&fakeService{ctx: ctx, node: peer.Mock(ctx)}, secret)
+	t.Cleanup(func() { b.Close() })
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	go b.Serve(l)
+
+	return l.Addr().String()
+}
+
+type client struct {
+	t       *testing.T
+	conn    net.Conn
+	r       *bufio.Reader
+	version byte
+}
+
+func dial(t *testing.T, addr string, version byte, password string) (*client, byte) {
+	conn, err := net.Dial("tcp", addr)
+	assert.NilError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	body := appendString(nil, "MQTT")
+	body = append(body, version, 0x02|0x40, 0, 60)
+	if version == version5 {
+		body = append(body, 0)
+	}
+	body = appendString(body, "")
+	body = appendString(body, password)
+
+	c := &client{t: t, conn: conn, r: bufio.NewReader(conn), version: version}
+	c.write(encode(typeConnect, 0, body))
+
+	p := c.read()
+	assert.Equal(t, p.typ, typeConnack)
+	return c, p.body[1]
+}
+
+func (c *client) write(data []byte) {
+	_, err := c.conn.Write(data)
+	assert.NilError(c.t, err)
+}
+
+func (c *client) read() *packet {
+	c.conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+	p, err := readPacket(c.r, MaxPacketSize)
+	assert.NilError(c.t, err)
+	return p
+}
+
+func (c *client) subscribe(filter string) byte {
+	body := appendString([]byte{0, 1}, filter)
+	body = append(body, 1)
+	if c.version == version5 {
+		body = append([]byte{0, 1, 0}, body[2:]...)
+	}
+	c.write(encode(typeSubscribe, 0x02, body))
+
+	p := c.read()
+	assert.Equal(c.t, p.typ, typeSuback)
+	return p.body[len(p.body)-1]
+}
+
+func TestConnectBadCredentials(t *testing.T) {
+	addr := newBroker(t)
+
+	_, code := dial(t, addr, version311, "password")
+	assert.Equal(t, code, connackBadCredentials311)
+
+	_, code = dial(t, addr, version5, "password")
+	assert.Equal(t, code, reasonBadCredentials)
+}
+
+func TestSubscribeNotAuthorized(t *testing.T) {
+	addr := newBroker(t)
+	token, err := Token(secret, "project", "", time.Now().Add(time.Hour))
+	assert.NilError(t, err)
+
+	c, code := dial(t, addr, version5, token)
+	assert.Equal(t, code, connackAccepted)
+
+	assert.Equal(t, c.subscribe("denied"), reasonNotAuthorized)
+	assert.Equal(t, c.subscribe("allowed/#"), reasonWildcardNotSupported)
+	assert.Equal(t, c.subscribe("allowed/subscribe"), byte(1))
+}
+
+func TestPublishSubscribe(t *testing.T) {
+	addr := newBroker(t)
+	token, err := Token(secret, "project", "", time.Now().Add(time.Hour))
+	assert.NilError(t, err)
+
+	sub, code := dial(t, addr, version311, token)
+	assert.Equal(t, code, connackAccepted)
+	assert.Equal(t, sub.subscribe("allowed/publish"), byte(1))
+
+	pub, code := dial(t, addr, version5, token)
+	assert.Equal(t, code, connackAccepted)
+	pub.write(encodePublish(version5, "allowed/publish", 9, 1, []byte("hello")))
+
+	p := pub.read()
+	assert.Equal(t, p.typ, typePuback)
+	assert.DeepEqual(t, p.body, []byte{0, 9})
+
+	p = sub.read()
+	assert.Equal(t, p.typ, typePublish)
+	msg, err := decodePublish(p, version311)
+	assert.NilError(t, err)
+	assert.Equal(t, msg.topic, "allowed/publish")
+	assert.Equal(t, string(msg.payload), "hello")
+
+	pub.write(encodePublish(version5, "denied", 10, 1, []byte("hello")))
+	p = pub.read()
+	assert.Equal(t, p.typ, typePuback)
+	assert.DeepEqual(t, p.body, []byte{0, 10, reasonNotAuthorized})
+}
@@ -0,0 +1,395 @@
+package mqtt
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// control packet types
+const (
+	typeConnect     byte = 1
+	typeConnack     byte = 2
+	typePublish     byte = 3
+	typePuback      byte = 4
+	typePubrec      byte = 5
+	typePubrel      byte = 6
+	typePubcomp     byte = 7
+	typeSubscribe   byte = 8
+	typeSuback      byte = 9
+	typeUnsubscribe byte = 10
+	typeUnsuback    byte = 11
+	typePingreq     byte = 12
+	typePingresp    byte = 13
+	typeDisconnect  byte = 14
+)
+
+// protocol levels
+const (
+	version311 byte = 4
+	version5   byte = 5
+)
+
+// CONNACK return codes of MQTT 3.1.1, and the matching reason codes of MQTT 5
+const (
+	connackAccepted            byte = 0x00
+	connackBadVersion311       byte = 0x01
+	connackBadClientId311      byte = 0x02
+	connackUnavailable311      byte = 0x03
+	connackBadCredentials311   byte = 0x04
+	reasonUnspecified          byte = 0x80
+	reasonBadCredentials       byte = 0x86
+	reasonNotAuthorized        byte = 0x87
+	reasonServerUnavailable    byte = 0x88
+	reasonIdNotFound           byte = 0x92
+	reasonNoSubscription       byte = 0x11
+	reasonTopicFilterInvalid   byte = 0x8f
+	reasonSharedNotSupported   byte = 0x9e
+	reasonWildcardNotSupported byte = 0xa2
+	subackFailure311           byte = 0x80
+)
+
+// MQTT 5 properties the broker advertises
+const (
+	propAssignedClientId      byte = 0x12
+	propMaximumQoS            byte = 0x24
+	propRetainAvailable       byte = 0x25
+	propMaximumPacketSize     byte = 0x27
+	propWildcardAvailable     byte = 0x28
+	propSubscriptionIdAvail   byte = 0x29
+	propSharedSubscriptionAva byte = 0x2a
+)
+
+var errMalformed = errors.New("malformed packet")
+
+type packet struct {
+	typ   byte
+	flags byte
+	body  []byte
+}
+
+// readPacket reads the next control packet, refusing those larger than max.
+func readPacket(r *bufio.Reader, max int) (*packet, error) {
+	first, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+
+	var length, shift int
+	for i := 0; ; i++ {
+		if i == 4 {
+			return nil, errMalformed
+		}
+
+		b, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+
+		length |= int(b&0x7f) << shift
+		shift += 7
+		if b&0x80 == 0 {
+			break
+		}
+	}
+
+	if length > max {
+		return nil, fmt.Errorf("packet of %d bytes is over the maximum of %d", length, max)
+	}
+
+	p := &packet{typ: first >> 4, flags: first & 0x0f, body: make([]byte, length)}
+	if _, err = io.ReadFull(r, p.body); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// encode returns the packet of typ with flags and body, with its fixed header.
+func encode(typ, flags byte, body []byte) []byte {
+	out := make([]byte, 0, len(body)+5)
+	out = append(out, typ<<4|flags)
+	out = appendVarint(out, len(body))
+	return append(out, body...)
+}
+
+func appendVarint(b []byte, v int) []byte {
+	for {
+		digit := byte(v & 0x7f)
+		v >>= 7
+		if v > 0 {
+			digit |= 0x80
+		}
+		b = append(b, digit)
+		if v == 0 {
+			return b
+		}
+	}
+}
+
+func appendString(b []byte, s string) []byte {
+	b = binary.BigEndian.AppendUint16(b, uint16(len(s)))
+	return append(b, s...)
+}
+
+// decoder reads the fields of a packet body; the first error sticks.
+type decoder struct {
+	buf []byte
+	err error
+}
+
+func (d *decoder) fail() {
+	if d.err == nil {
+		d.err = errMalformed
+	}
+	d.buf = nil
+}
+
+func (d *decoder) byte() byte {
+	if len(d.buf) < 1 {
+		d.fail()
+		return 0
+	}
+
+	b := d.buf[0]
+	d.buf = d.buf[1:]
+	return b
+}
+
+func (d *decoder) uint16() uint16 {
+	if len(d.buf) < 2 {
+		d.fail()
+		return 0
+	}
+
+	v := binary.BigEndian.Uint16(d.buf)
+	d.buf = d.buf[2:]
+	return v
+}
+
+func (d *decoder) varint() int {
+	var v, shift int
+	for i := 0; i < 4; i++ {
+		b := d.byte()
+		v |= int(b&0x7f) << shift
+		shift += 7
+		if b&0x80 == 0 {
+			return v
+		}
+	}
+
+	d.fail()
+	return 0
+}
+
+func (d *decoder) binary() []byte {
+	n := int(d.uint16())
+	if len(d.buf) < n {
+		d.fail()
+		return nil
+	}
+
+	v := d.buf[:n:n]
+	d.buf = d.buf[n:]
+	return v
+}
+
+func (d *decoder) string() string {
+	v := d.binary()
+	if !utf8.Valid(v) || strings.IndexByte(string(v), 0) >= 0 {
+		d.fail()
+		return ""
+	}
+
+	return string(v)
+}
+
+// skipProperties skips the properties of an MQTT 5 packet; the broker acts on
+// none of those clients send.
+func (d *decoder) skipProperties(version byte) {
+	if version != version5 {
+		return
+	}
+
+	n := d.varint()
+	if len(d.buf) < n {
+		d.fail()
+		return
+	}
+
+	d.buf = d.buf[n:]
+}
+
+// message is an application message: published, or left as a will.
+type message struct {
+	topic   string
+	payload []byte
+	qos     byte
+}
+
+type connect struct {
+	version    byte
+	clientId   string
+	cleanStart bool
+	keepAlive  uint16
+	username   string
+	password   []byte
+	will       *message
+}
+
+var errBadVersion = errors.New("unsupported protocol version")
+
+func decodeConnect(p *packet) (*connect, error) {
+	d := &decoder{buf: p.body}
+
+	name := d.string()
+	c := &connect{version: d.byte()}
+	if d.err == nil && (name != "MQTT" || (c.version != version311 && c.version != version5)) {
+		return c, errBadVersion
+	}
+
+	flags := d.byte()
+	c.keepAlive = d.uint16()
+	d.skipProperties(c.version)
+	if flags&0x01 != 0 {
+		d.fail()
+	}
+
+	c.cleanStart = flags&0x02 != 0
+	c.clientId = d.string()
+
+	if flags&0x04 != 0 {
+		d.skipProperties(c.version)
+		c.will = &message{topic: d.string(), payload: d.binary(), qos: flags >> 3 & 0x03}
+		if c.will.qos > 2 || !validTopic(c.will.topic) {
+			d.fail()
+		}
+	}
+
+	if flags&0x80 != 0 {
+		c.username = d.string()
+	}
+
+	if flags&0x40 != 0 {
+		c.password = d.binary()
+	}
+
+	return c, d.err
+}
+
+type publish struct {
+	message
+	id  uint16
+	dup bool
+}
+
+func decodePublish(p *packet, version byte) (*publish, error) {
+	d := &decoder{buf: p.body}
+	pub := &publish{dup: p.flags&0x08 != 0}
+	pub.qos = p.flags >> 1 & 0x03
+	pub.topic = d.string()
+	if pub.qos > 0 {
+		pub.id = d.uint16()
+	}
+	d.skipProperties(version)
+	pub.payload = d.buf
+
+	if pub.qos > 2 || !validTopic(pub.topic) {
+		d.fail()
+	}
+
+	return pub, d.err
+}
+
+func encodePublish(version byte, topic string, id uint16, qos byte, payload []byte) []byte {
+	body := appendString(make([]byte, 0, len(topic)+len(payload)+5), topic)
+	if qos > 0 {
+		body = binary.BigEndian.AppendUint16(body, id)
+	}
+	if version == version5 {
+		body = append(body, 0)
+	}
+
+	return encode(typePublish, qos<<1, append(body, payload...))
+}
+
+type subscription struct {
+	filter string
+	qos    byte
+}
+
+type subscribe struct {
+	id      uint16
+	filters []subscription
+}
+
+func decodeSubscribe(p *packet, version byte) (*subscribe, error) {
+	d := &decoder{buf: p.body}
+	sub := &subscribe{id: d.uint16()}
+	d.skipProperties(version)
+
+	for d.err == nil && len(d.buf) > 0 {
+		sub.filters = append(sub.filters, subscription{filter: d.string(), qos: d.byte() & 0x03})
+	}
+
+	if p.flags != 0x02 || len(sub.filters) == 0 {
+		d.fail()
+	}
+
+	return sub, d.err
+}
+
+type unsubscribe struct {
+	id      uint16
+	filters []string
+}
+
+func decodeUnsubscribe(p *packet, version byte) (*unsubscribe, error) {
+	d := &decoder{buf: p.body}
+	unsub := &unsubscribe{id: d.uint16()}
+	d.skipProperties(version)
+
+	for d.err == nil && len(d.buf) > 0 {
+		unsub.filters = append(unsub.filters, d.string())
+	}
+
+	if p.flags != 0x02 || len(unsub.filters) == 0 {
+		d.fail()
+	}
+
+	return unsub, d.err
+}
+
+// encodeAck returns the PUBACK, PUBREC or PUBCOMP of id; in MQTT 5 a reason
+// code is only sent when it is not a success.
+func encodeAck(typ byte, version byte, id uint16, reason byte) []byte {
+	body := binary.BigEndian.AppendUint16(nil, id)
+	if version == version5 && reason != 0 {
+		body = append(body, reason)
+	}
+
+	return encode(typ, 0, body)
+}
+
+// encodeSubAck returns the SUBACK or UNSUBACK of id with a code per filter.
+// An MQTT 3.1.1 UNSUBACK has none.
+func encodeSubAck(typ byte, version byte, id uint16, codes []byte) []byte {
+	body := binary.BigEndian.AppendUint16(nil, id)
+	if version == version5 {
+		body = append(body, 0)
+	} else if typ == typeUnsuback {
+		codes = nil
+	}
+
+	return encode(typ, 0, append(body, codes...))
+}
+
+// validTopic reports whether topic can be published to: topics are not empty
+// and hold no wildcards.
+func validTopic(topic string) bool {
+	return topic != "" && !strings.ContainsAny(topic, "+#")
+}
@@ -0,0 +1,86 @@
+package mqtt
+
+import (
+	"bufio"
+	"bytes"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func read(t *testing.T, data []byte) *packet {
+	p, err := readPacket(bufio.NewReader(bytes.NewReader(data)), MaxPacketSize)
+	assert.NilError(t, err)
+	return p
+}
+
+func TestPublishRoundTrip(t *testing.T) {
+	for _, version := range []byte{version311, version5} {
+		p := read(t, encodePublish(version, "some/channel", 7, 1, []byte("hello")))
+		assert.Equal(t, p.typ, typePublish)
+
+		pub, err := decodePublish(p, version)
+		assert.NilError(t, err)
+		assert.Equal(t, pub.topic, "some/channel")
+		assert.Equal(t, pub.id, uint16(7))
+		assert.Equal(t, pub.qos, byte(1))
+		assert.Equal(t, string(pub.payload), "hello")
+	}
+}
+
+func TestReadPacketTooLarge(t *testing.T) {
+	data := encodePublish(version311, "channel", 0, 0, make([]byte, 200))
+	_, err := readPacket(bufio.NewReader(bytes.NewReader(data)), 100)
+	assert.ErrorContains(t, err, "over the maximum")
+}
+
+func TestDecodeConnect(t *testing.T) {
+	body := appendString(nil, "MQTT")
+	body = append(body, version5, 0x02|0x04|0x08|0x40, 0, 30, 0)
+	body = appendString(body, "client")
+	body = append(body, 0)
+	body = appendString(body, "will/topic")
+	body = appendString(body, "bye")
+	body = appendString(body, "secret")
+
+	c, err := decodeConnect(read(t, encode(typeConnect, 0, body)))
+	assert.NilError(t, err)
+	assert.Equal(t, c.version, version5)
+	assert.Equal(t, c.clientId, "client")
+	assert.Assert(t, c.cleanStart)
+	assert.Equal(t, c.keepAlive, uint16(30))
+	assert.Equal(t, c.will.topic, "will/topic")
+	assert.Equal(t, c.will.qos, byte(1))
+	assert.Equal(t, string(c.will.payload), "bye")
+	assert.Equal(t, string(c.password), "secret")
+
+	body = appendString(nil, "MQIsdp")
+	body = append(body, 3, 0x02, 0, 30)
+	_, err = decodeConnect(read(t, encode(typeConnect, 0, body)))
+	assert.ErrorIs(t, err, errBadVersion)
+}
+
+func TestDecodeSubscribe(t *testing.T) {
+	body := []byte{0, 3}
+	body = appendString(body, "a")
+	body = append(body, 1)
+	body = appendString(body, "b/+")
+	body = append(body, 0)
+
+	sub, err := decodeSubscribe(read(t, encode(typeSubscribe, 0x02, body)), version311)
+	assert.NilError(t, err)
+	assert.Equal(t, sub.id, uint16(3))
+	assert.Equal(t, len(sub.filters), 2)
+	assert.Equal(t, sub.filters[0], subscription{"a", 1})
+	assert.Equal(t, sub.filters[1], subscription{"b/+", 0})
+
+	_, err = decodeSubscribe(read(t, encode(typeSubscribe, 0, body)), version311)
+	assert.ErrorIs(t, err, errMalformed)
+}
+
+func TestValidTopic(t *testing.T) {
+	assert.Assert(t, validTopic("a/b"))
+	assert.Assert(t, !validTopic(""))
+	assert.Assert(t, !validTopic("a/#"))
+	assert.Assert(t, !validTopic("a/+/b"))
+}
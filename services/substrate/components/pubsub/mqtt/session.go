@@ -0,0 +1,430 @@
+package mqtt
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/taubyte/tau/services/substrate/components/pubsub/common"
+	"github.com/taubyte/tau/services/substrate/components/pubsub/websocket"
+)
+
+var errNotAuthorized = errors.New("not authorized")
+
+// topicSubscription is a topic filter a client subscribed to.
+type topicSubscription struct {
+	name string
+	id   int
+	qos  atomic.Uint32
+}
+
+type session struct {
+	broker   *Broker
+	conn     net.Conn
+	version  byte
+	clientId string
+	assigned bool
+
+	// key identifies the client: a client connecting again with the same
+	// key takes over the session.
+	key string
+	// source is set on the messages published by the client so they can be
+	// told apart from those of others.
+	source string
+
+	project     string
+	application string
+
+	ctx  context.Context
+	ctxC context.CancelFunc
+
+	outbox chan []byte
+
+	lock    sync.Mutex
+	subs    map[string]*topicSubscription
+	allowed map[string]time.Time
+	// released holds the ids of QoS 2 messages received and waiting for
+	// their PUBREL, so that retransmissions are not published again.
+	released map[uint16]struct{}
+	will     *message
+
+	nextId atomic.Uint32
+}
+
+func newSession(b *Broker, conn net.Conn, c *connect, claims *claims) *session {
+	s := &session{
+		broker:      b,
+		conn:        conn,
+		version:     c.version,
+		clientId:    c.clientId,
+		project:     claims.Project,
+		application: claims.Application,
+		outbox:      make(chan []byte, OutboxSize),
+		subs:        make(map[string]*topicSubscription),
+		allowed:     make(map[string]time.Time),
+		released:    make(map[uint16]struct{}),
+		will:        c.will,
+	}
+
+	if s.clientId == "" {
+		s.clientId = "tau-" + randomHex(8)
+		s.assigned = true
+	}
+
+	s.key = s.project + "/" + s.application + "/" + s.clientId
+	s.source = "mqtt/" + randomHex(16)
+	s.ctx, s.ctxC = context.WithCancel(b.srv.Context())
+
+	return s
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// connackProperties returns the MQTT 5 properties telling the client what the
+// broker does not support.
+func (s *session) connackProperties() []byte {
+	if s.version != version5 {
+		return nil
+	}
+
+	props := []byte{
+		propMaximumQoS, 1,
+		propRetainAvailable, 0,
+		propWildcardAvailable, 0,
+		propSubscriptionIdAvail, 0,
+		propSharedSubscriptionAva, 0,
+		propMaximumPacketSize, byte(MaxPacketSize >> 24), byte(MaxPacketSize >> 16), byte(MaxPacketSize >> 8), byte(MaxPacketSize),
+	}
+
+	if s.assigned {
+		props = appendString(append(props, propAssignedClientId), s.clientId)
+	}
+
+	return props
+}
+
+// send queues a packet for the client, waiting for room in the outbox.
+func (s *session) send(packet []byte) {
+	select {
+	case s.outbox <- packet:
+	case <-s.ctx.Done():
+	}
+}
+
+// offer queues a packet for the client, dropping it if the outbox is full.
+func (s *session) offer(packet []byte) {
+	select {
+	case s.outbox <- packet:
+	case <-s.ctx.Done():
+	default:
+		logger.Warnf("dropping message for mqtt client `%s`: outbox is full", s.clientId)
+	}
+}
+
+func (s *session) writer() {
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case packet := <-s.outbox:
+			s.conn.SetWriteDeadline(time.Now().Add(WriteTimeout))
+			if _, err := s.conn.Write(packet); err != nil {
+				s.ctxC()
+				s.conn.Close()
+				return
+			}
+		}
+	}
+}
+
+// serve reads the packets of the client until it disconnects. A client that
+// stays silent for one and a half times its keep alive is disconnected.
+func (s *session) serve(r *bufio.Reader, keepAlive uint16) error {
+	for {
+		deadline := time.Time{}
+		if keepAlive > 0 {
+			deadline = time.Now().Add(time.Duration(keepAlive) * 1500 * time.Millisecond)
+		}
+		s.conn.SetReadDeadline(deadline)
+
+		p, err := readPacket(r, MaxPacketSize)
+		if err != nil {
+			return err
+		}
+
+		switch p.typ {
+		case typePublish:
+			err = s.publish(p)
+		case typePubrel:
+			err = s.release(p)
+		case typePuback:
+			// messages are sent with at most QoS 1, and are not sent again
+		case typeSubscribe:
+			err = s.subscribe(p)
+		case typeUnsubscribe:
+			err = s.unsubscribe(p)
+		case typePingreq:
+			s.send(encode(typePingresp, 0, nil))
+		case typeDisconnect:
+			s.lock.Lock()
+			s.will = nil
+			s.lock.Unlock()
+			return nil
+		default:
+			return fmt.Errorf("unexpected packet of type %d", p.typ)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (s *session) publish(p *packet) error {
+	pub, err := decodePublish(p, s.version)
+	if err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	_, seen := s.released[pub.id]
+	s.lock.Unlock()
+
+	var code byte
+	if pub.qos < 2 || !seen {
+		if err = s.deliver(&pub.message); err != nil {
+			logger.Errorf("publishing to `%s` for mqtt client `%s` failed with: %s", pub.topic, s.clientId, err.Error())
+			code = reasonUnspecified
+			if errors.Is(err, errNotAuthorized) {
+				code = reasonNotAuthorized
+			}
+		}
+	}
+
+	switch pub.qos {
+	case 1:
+		s.send(encodeAck(typePuback, s.version, pub.id, code))
+	case 2:
+		if code == 0 {
+			s.lock.Lock()
+			s.released[pub.id] = struct{}{}
+			s.lock.Unlock()
+		}
+		s.send(encodeAck(typePubrec, s.version, pub.id, code))
+	}
+
+	return nil
+}
+
+func (s *session) release(p *packet) error {
+	d := &decoder{buf: p.body}
+	id := d.uint16()
+	if d.err != nil {
+		return d.err
+	}
+
+	s.lock.Lock()
+	_, ok := s.released[id]
+	delete(s.released, id)
+	s.lock.Unlock()
+
+	var code byte
+	if !ok {
+		code = reasonIdNotFound
+	}
+
+	s.send(encodeAck(typePubcomp, s.version, id, code))
+	return nil
+}
+
+// matcher returns the match definition of the channel of topic.
+func (s *session) matcher(topic string) *common.MatchDefinition {
+	return &common.MatchDefinition{
+		Channel:     strings.TrimPrefix(topic, "/"),
+		Project:     s.project,
+		Application: s.application,
+	}
+}
+
+// authorize returns an error unless the client may use the channel, caching
+// the answer for AuthorizationTTL.
+func (s *session) authorize(channel string) error {
+	s.lock.Lock()
+	expiry, ok := s.allowed[channel]
+	s.lock.Unlock()
+	if ok && time.Now().Before(expiry) {
+		return nil
+	}
+
+	if err := s.broker.srv.AllowMQTT(s.project, s.application, channel); err != nil {
+		return fmt.Errorf("%w: %w", errNotAuthorized, err)
+	}
+
+	s.lock.Lock()
+	s.allowed[channel] = time.Now().Add(AuthorizationTTL)
+	s.lock.Unlock()
+
+	return nil
+}
+
+// deliver publishes msg to its channel.
+func (s *session) deliver(msg *message) error {
+	matcher := s.matcher(msg.topic)
+	if err := s.authorize(matcher.Channel); err != nil {
+		return err
+	}
+
+	m, err := common.NewMessage(msg.payload, s.source)
+	if err != nil {
+		return fmt.Errorf("creating message failed with: %w", err)
+	}
+
+	data, err := m.Marshal()
+	if err != nil {
+		return fmt.Errorf("marshalling message failed with: %w", err)
+	}
+
+	return s.broker.srv.Node().PubSubPublish(s.broker.srv.Context(), matcher.String(), data)
+}
+
+func (s *session) subscribe(p *packet) error {
+	sub, err := decodeSubscribe(p, s.version)
+	if err != nil {
+		return err
+	}
+
+	codes := make([]byte, len(sub.filters))
+	for i, f := range sub.filters {
+		codes[i] = s.addSubscription(f)
+	}
+
+	s.send(encodeSubAck(typeSuback, s.version, sub.id, codes))
+	return nil
+}
+
+// addSubscription subscribes the client to the channel of f, and returns the
+// QoS granted or the reason it was not.
+func (s *session) addSubscription(f subscription) byte {
+	switch {
+	case strings.HasPrefix(f.filter, "$share/"):
+		return reason(s.version, subackFailure311, reasonSharedNotSupported)
+	case strings.ContainsAny(f.filter, "+#"):
+		return reason(s.version, subackFailure311, reasonWildcardNotSupported)
+	case !validTopic(f.filter) || f.qos > 2:
+		return reason(s.version, subackFailure311, reasonTopicFilterInvalid)
+	}
+
+	matcher := s.matcher(f.filter)
+	if err := s.authorize(matcher.Channel); err != nil {
+		logger.Errorf("subscribing mqtt client `%s` to `%s` failed with: %s", s.clientId, f.filter, err.Error())
+		return reason(s.version, subackFailure311, reasonNotAuthorized)
+	}
+
+	qos := min(f.qos, 1)
+
+	s.lock.Lock()
+	ts, ok := s.subs[f.filter]
+	s.lock.Unlock()
+	if ok {
+		ts.qos.Store(uint32(qos))
+		return qos
+	}
+
+	// the lock is not held while subscribing: messages are handed to forward
+	// under the lock of the subscription
+	ts = &topicSubscription{name: matcher.String()}
+	ts.qos.Store(uint32(qos))
+	id, err := websocket.AddSubscription(s.broker.srv, ts.name, func(msg *pubsub.Message) {
+		s.forward(f.filter, ts, msg)
+	}, func(err error) {
+		logger.Errorf("subscription of mqtt client `%s` to `%s` failed with: %s", s.clientId, f.filter, err.Error())
+	})
+	if err != nil {
+		logger.Errorf("subscribing mqtt client `%s` to `%s` failed with: %s", s.clientId, f.filter, err.Error())
+		return reason(s.version, subackFailure311, reasonUnspecified)
+	}
+
+	ts.id = id
+	s.lock.Lock()
+	s.subs[f.filter] = ts
+	s.lock.Unlock()
+
+	return qos
+}
+
+// forward sends a message received on the channel of topic to the client.
+func (s *session) forward(topic string, ts *topicSubscription, msg *pubsub.Message) {
+	message, err := common.NewMessage(msg, "")
+	if err != nil {
+		logger.Errorf("creating message failed with: %s", err.Error())
+		return
+	}
+
+	qos := byte(ts.qos.Load())
+	var id uint16
+	for qos > 0 && id == 0 {
+		id = uint16(s.nextId.Add(1))
+	}
+
+	s.offer(encodePublish(s.version, topic, id, qos, message.GetData()))
+}
+
+func (s *session) unsubscribe(p *packet) error {
+	unsub, err := decodeUnsubscribe(p, s.version)
+	if err != nil {
+		return err
+	}
+
+	codes := make([]byte, len(unsub.filters))
+
+	s.lock.Lock()
+	for i, filter := range unsub.filters {
+		ts, ok := s.subs[filter]
+		if !ok {
+			codes[i] = reasonNoSubscription
+			continue
+		}
+
+		websocket.RemoveSubscription(ts.name, ts.id)
+		delete(s.subs, filter)
+	}
+	s.lock.Unlock()
+
+	s.send(encodeSubAck(typeUnsuback, s.version, unsub.id, codes))
+	return nil
+}
+
+// close ends the session, publishing the will of a client that did not
+// disconnect properly.
+func (s *session) close() {
+	s.lock.Lock()
+	for filter, ts := range s.subs {
+		websocket.RemoveSubscription(ts.name, ts.id)
+		delete(s.subs, filter)
+	}
+	will := s.will
+	s.will = nil
+	s.lock.Unlock()
+
+	if will != nil {
+		if err := s.deliver(will); err != nil {
+			logger.Errorf("publishing will of mqtt client `%s` failed with: %s", s.clientId, err.Error())
+		}
+	}
+
+	s.ctxC()
+	s.conn.Close()
+}
@@ -0,0 +1,105 @@
+package mqtt
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+const tokenPrefix = "tau1."
+
+var (
+	errInvalidToken = errors.New("invalid token")
+	errExpiredToken = errors.New("token expired")
+	errTokenTTL     = errors.New("token outlives the longest lifetime allowed")
+)
+
+// claims are what a token grants: publishing and subscribing to the MQTT
+// channels of a project, or of one of its applications, until it expires.
+type claims struct {
+	Project     string `cbor:"1,keyasint"`
+	Application string `cbor:"2,keyasint,omitempty"`
+	Expiry      int64  `cbor:"3,keyasint"`
+}
+
+// tokenKey derives the key tokens are signed with from secret, so that the
+// secret is not used as is for anything else.
+func tokenKey(secret []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte("tau mqtt token"))
+	return mac.Sum(nil)
+}
+
+// Token returns the password MQTT clients connect with to the channels of the
+// project, or of its application appId if set, until expiry. It is the same
+// for the same arguments. Tokens expire at most MaxTokenTTL from now.
+func Token(secret []byte, projectId, appId string, expiry time.Time) (string, error) {
+	if len(secret) == 0 {
+		return "", errors.New("no secret to sign tokens with")
+	}
+
+	if expiry.After(time.Now().Add(MaxTokenTTL)) {
+		return "", fmt.Errorf("%w of %s", errTokenTTL, MaxTokenTTL)
+	}
+
+	data, err := cbor.Marshal(claims{Project: projectId, Application: appId, Expiry: expiry.Unix()})
+	if err != nil {
+		return "", err
+	}
+
+	mac := hmac.New(sha256.New, tokenKey(secret))
+	mac.Write(data)
+
+	enc := base64.RawURLEncoding
+	return tokenPrefix + enc.EncodeToString(data) + "." + enc.EncodeToString(mac.Sum(nil)), nil
+}
+
+func verifyToken(secret []byte, token string) (*claims, error) {
+	if len(secret) == 0 {
+		return nil, errInvalidToken
+	}
+
+	rest, ok := strings.CutPrefix(token, tokenPrefix)
+	if !ok {
+		return nil, errInvalidToken
+	}
+
+	_data, _sum, ok := strings.Cut(rest, ".")
+	if !ok {
+		return nil, errInvalidToken
+	}
+
+	enc := base64.RawURLEncoding
+	data, err := enc.DecodeString(_data)
+	if err != nil {
+		return nil, errInvalidToken
+	}
+
+	sum, err := enc.DecodeString(_sum)
+	if err != nil {
+		return nil, errInvalidToken
+	}
+
+	mac := hmac.New(sha256.New, tokenKey(secret))
+	mac.Write(data)
+	if !hmac.Equal(sum, mac.Sum(nil)) {
+		return nil, errInvalidToken
+	}
+
+	c := new(claims)
+	if err = cbor.Unmarshal(data, c); err != nil || c.Project == "" {
+		return nil, errInvalidToken
+	}
+
+	if time.Now().Unix() >= c.Expiry {
+		return nil, errExpiredToken
+	}
+
+	return c, nil
+}
@@ -0,0 +1,55 @@
+package mqtt
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestToken(t *testing.T) {
+	secret := []byte("secret")
+	expiry := time.Now().Add(time.Hour)
+
+	token, err := Token(secret, "project", "app", expiry)
+	assert.NilError(t, err)
+
+	again, err := Token(secret, "project", "app", expiry)
+	assert.NilError(t, err)
+	assert.Equal(t, token, again)
+
+	c, err := verifyToken(secret, token)
+	assert.NilError(t, err)
+	assert.Equal(t, c.Project, "project")
+	assert.Equal(t, c.Application, "app")
+	assert.Equal(t, c.Expiry, expiry.Unix())
+}
+
+func TestTokenInvalid(t *testing.T) {
+	secret := []byte("secret")
+
+	token, err := Token(secret, "project", "", time.Now().Add(time.Hour))
+	assert.NilError(t, err)
+
+	_, err = verifyToken([]byte("other"), token)
+	assert.ErrorIs(t, err, errInvalidToken)
+
+	tampered := strings.Replace(token, ".", ".A", 1)
+	_, err = verifyToken(secret, tampered)
+	assert.ErrorIs(t, err, errInvalidToken)
+
+	_, err = verifyToken(secret, "password")
+	assert.ErrorIs(t, err, errInvalidToken)
+
+	expired, err := Token(secret, "project", "", time.Now().Add(-time.Second))
+	assert.NilError(t, err)
+	_, err = verifyToken(secret, expired)
+	assert.ErrorIs(t, err, errExpiredToken)
+
+	_, err = Token(secret, "project", "", time.Now().Add(MaxTokenTTL+time.Minute))
+	assert.ErrorIs(t, err, errTokenTTL)
+
+	_, err = Token(nil, "project", "", time.Now())
+	assert.ErrorContains(t, err, "no secret")
+}
@@ -0,0 +1,32 @@
+package mqtt
+
+import (
+	"time"
+
+	"github.com/ipfs/go-log/v2"
+)
+
+var logger = log.Logger("tau.substrate.service.pubsub.mqtt")
+
+var (
+	// MaxPacketSize is the largest packet accepted from a client, and
+	// advertised to MQTT 5 ones.
+	MaxPacketSize = 1 << 20
+
+	// ConnectTimeout is how long a client has to send its CONNECT.
+	ConnectTimeout = 10 * time.Second
+
+	// WriteTimeout bounds every write to a client.
+	WriteTimeout = 10 * time.Second
+
+	// OutboxSize is how many messages may wait to be sent to a client; past
+	// it, messages for the client are dropped.
+	OutboxSize = 1024
+
+	// AuthorizationTTL is how long a client keeps using a channel it was
+	// authorized for before the messaging config is checked again.
+	AuthorizationTTL = time.Minute
+
+	// MaxTokenTTL is the longest a token may be issued for.
+	MaxTokenTTL = 24 * time.Hour
+)
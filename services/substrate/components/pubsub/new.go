@@ -1,17 +1,29 @@
 package pubsub
 
 import (
+	"fmt"
+
 	nodeIface "github.com/taubyte/tau/core/services/substrate"
 	"github.com/taubyte/tau/services/substrate/runtime/cache"
 )
 
-func New(srv nodeIface.Service) (*Service, error) {
+func New(srv nodeIface.Service, options ...Option) (*Service, error) {
 	s := &Service{
 		Service: srv,
 		cache:   cache.New(),
 	}
 
+	for _, opt := range options {
+		if err := opt(s); err != nil {
+			return nil, fmt.Errorf("options failed with: %w", err)
+		}
+	}
+
 	s.attach()
 
+	if err := s.startMQTT(); err != nil {
+		return nil, fmt.Errorf("starting mqtt broker failed with: %w", err)
+	}
+
 	return s, nil
 }
@@ -0,0 +1,23 @@
+package pubsub
+
+import "crypto/tls"
+
+type Option func(*Service) error
+
+// MQTT serves MQTT clients on port, with tokens signed with secret. No broker
+// is started when port is 0.
+func MQTT(port int, secret []byte) Option {
+	return func(s *Service) error {
+		s.mqttPort = port
+		s.mqttSecret = secret
+		return nil
+	}
+}
+
+// MQTTTLS has the broker serve MQTT clients over TLS, as configured by config.
+func MQTTTLS(config *tls.Config) Option {
+	return func(s *Service) error {
+		s.mqttTLS = config
+		return nil
+	}
+}
@@ -1,8 +1,11 @@
 package pubsub
 
 import (
+	"crypto/tls"
+
 	nodeIface "github.com/taubyte/tau/core/services/substrate"
 	pubsubIface "github.com/taubyte/tau/core/services/substrate/components/pubsub"
+	"github.com/taubyte/tau/services/substrate/components/pubsub/mqtt"
 	"github.com/taubyte/tau/services/substrate/runtime/cache"
 )
 
@@ -11,4 +14,9 @@ var _ pubsubIface.ServiceWithLookup = &Service{}
 type Service struct {
 	nodeIface.Service
 	cache *cache.Cache
+
+	mqtt       *mqtt.Broker
+	mqttPort   int
+	mqttSecret []byte
+	mqttTLS    *tls.Config
 }
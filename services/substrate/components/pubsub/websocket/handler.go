@@ -60,7 +60,7 @@ func Handler(srv pubsubIface.ServiceWithLookup, ctx service.Context, conn servic
 	}
 
 	conn.SetCloseHandler(func(code int, text string) error {
-		removeSubscription(handler.matcher.String(), id)
+		RemoveSubscription(handler.matcher.String(), id)
 		handler.Close()
 		return nil
 	})
@@ -92,11 +92,15 @@ func (sv *subViewer) err_handler(err error) {
 	}
 }
 
-func removeSubscription(name string, subIdx int) {
+func RemoveSubscription(name string, subIdx int) {
 	subs.Lock()
-	defer subs.Unlock()
-	if subset, ok := subs.subscriptions[name]; ok {
+	subset, ok := subs.subscriptions[name]
+	subs.Unlock()
+
+	if ok {
+		subset.Lock()
 		delete(subset.subs, subIdx)
+		subset.Unlock()
 	}
 }
 
@@ -135,9 +139,10 @@ func AddSubscription(srv pubsubIface.ServiceWithLookup, name string, handler p2p
 	newId := subset.getNextId()
 	subset.subs[newId] = &sub{
 		handler: handler,
+		// called by subset.err_handler, which holds the lock of subset
 		err_handler: func(err error) {
 			err_handler(err)
-			removeSubscription(name, newId)
+			delete(subset.subs, newId)
 		},
 	}
 
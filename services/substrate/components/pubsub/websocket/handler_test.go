@@ -197,7 +197,7 @@ func TestRemoveSubscription(t *testing.T) {
 		subs.subscriptions["test-topic"] = sv
 
 		// Remove subscription
-		removeSubscription("test-topic", 1)
+		RemoveSubscription("test-topic", 1)
 
 		// Verify subscription was removed
 		_, exists := sv.subs[1]
@@ -211,7 +211,7 @@ func TestRemoveSubscription(t *testing.T) {
 		}
 
 		// Try to remove from non-existent topic
-		removeSubscription("non-existent-topic", 1)
+		RemoveSubscription("non-existent-topic", 1)
 
 		// Should not panic
 	})
@@ -230,7 +230,7 @@ func TestRemoveSubscription(t *testing.T) {
 		subs.subscriptions["test-topic"] = sv
 
 		// Try to remove non-existent subscription
-		removeSubscription("test-topic", 999)
+		RemoveSubscription("test-topic", 999)
 
 		// Should not panic
 	})
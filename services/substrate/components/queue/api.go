@@ -0,0 +1,128 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	queueIface "github.com/taubyte/tau/core/services/substrate/components/queue"
+	"github.com/taubyte/tau/pkg/raft"
+	spec "github.com/taubyte/tau/pkg/specs/common"
+	structureSpec "github.com/taubyte/tau/pkg/specs/structure"
+)
+
+var _ queueIface.Service = &Service{}
+
+var errNoBroker = errors.New("queue broker is not started")
+
+// Enqueue adds a message holding data to the queue named, as seen from
+// application, and returns its id.
+func (s *Service) Enqueue(ctx context.Context, projectId, application, queue string, data []byte) (uint64, error) {
+	config, err := s.lookupQueue(projectId, application, queue)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := newMessageId()
+	if err != nil {
+		return 0, err
+	}
+
+	value, err := (&message{Data: data, Enqueued: time.Now().UnixNano()}).encode()
+	if err != nil {
+		return 0, fmt.Errorf("encoding message failed with: %w", err)
+	}
+
+	key := messageKey{project: projectId, queue: config.Id, id: id}
+	if err = s.set(key.under(messagesPrefix), value); err != nil {
+		return 0, fmt.Errorf("enqueuing to queue `%s` failed with: %w", queue, err)
+	}
+
+	return id, nil
+}
+
+// Ack records the ack of message of the queue named, as seen from application.
+// Acking a message that is gone, because it was acked or dead-lettered, does
+// nothing.
+func (s *Service) Ack(ctx context.Context, projectId, application, queue string, message uint64) error {
+	config, err := s.lookupQueue(projectId, application, queue)
+	if err != nil {
+		return err
+	}
+
+	return s.ack(messageKey{project: projectId, queue: config.Id, id: message})
+}
+
+func (s *Service) ack(key messageKey) error {
+	// the leader removes the message, and the ack, at its next step
+	if err := s.set(key.under(acksPrefix), []byte(strconv.FormatInt(time.Now().Unix(), 10))); err != nil {
+		return fmt.Errorf("acking message `%s` failed with: %w", formatMessageId(key.id), err)
+	}
+
+	return nil
+}
+
+func (s *Service) lookupQueue(projectId, application, queue string) (*structureSpec.Queue, error) {
+	config, err := s.Tns().Queue().All(projectId, application, spec.DefaultBranches...).GetByName(queue)
+	if err != nil {
+		return nil, fmt.Errorf("looking up queue `%s` failed with: %w", queue, err)
+	}
+
+	if config == nil {
+		return nil, fmt.Errorf("%w: `%s`", queueIface.ErrQueueNotFound, queue)
+	}
+
+	return config, nil
+}
+
+// set writes to the raft namespace, through the leader when this node is not.
+func (s *Service) set(key string, value []byte) error {
+	cluster, err := s.raftCluster()
+	if err != nil {
+		return err
+	}
+
+	if cluster.IsLeader() {
+		return cluster.Set(key, value, ApplyTimeout)
+	}
+
+	leader, err := cluster.Leader()
+	if err != nil {
+		return fmt.Errorf("finding leader failed with: %w", err)
+	}
+
+	client, err := s.client(cluster)
+	if err != nil {
+		return err
+	}
+
+	return client.Set(key, value, ApplyTimeout, leader)
+}
+
+func (s *Service) raftCluster() (raft.Cluster, error) {
+	cluster := s.Raft()
+	if cluster == nil {
+		return nil, errNoBroker
+	}
+
+	return cluster, nil
+}
+
+// client returns the client writing to the raft namespace of cluster through
+// its leader.
+func (s *Service) client(cluster raft.Cluster) (raft.Client, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.raftClient == nil {
+		c, err := raft.NewClient(s.Node(), cluster.Namespace(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("creating raft client of `%s` failed with: %w", cluster.Namespace(), err)
+		}
+		s.raftClient = c
+	}
+
+	return s.raftClient, nil
+}
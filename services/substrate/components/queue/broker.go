@@ -0,0 +1,226 @@
+package queue
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/taubyte/tau/pkg/raft"
+	"github.com/taubyte/tau/services/substrate/components/queue/common"
+)
+
+// delivery is a message the broker hands to a queue function.
+type delivery struct {
+	common.Delivery
+	consumer common.MatchDefinition
+}
+
+type pending struct {
+	key messageKey
+	*message
+}
+
+type projectTopology struct {
+	queues    map[string]*queueConfig
+	refreshed time.Time
+}
+
+// broker runs on every member of the raft cluster, but only the leader
+// delivers: it records each delivery in the cluster, pushing back the
+// visibility of the message by the visibility timeout of its queue, before
+// handing it to a queue function. A message that is not acked by then is
+// delivered again, and once it was delivered as many times as its queue
+// allows, it is dead-lettered instead. The leader is also the only one to
+// remove messages, for the acks other members record, so a delivery it
+// records never brings back a message acked meanwhile.
+type broker struct {
+	cluster  raft.Cluster
+	topology func(project string) (map[string]*queueConfig, error)
+	dispatch func(d *delivery)
+	now      func() time.Time
+
+	projects map[string]*projectTopology
+}
+
+func newBroker(cluster raft.Cluster, topology func(string) (map[string]*queueConfig, error), dispatch func(*delivery)) *broker {
+	return &broker{
+		cluster:  cluster,
+		topology: topology,
+		dispatch: dispatch,
+		now:      time.Now,
+		projects: make(map[string]*projectTopology),
+	}
+}
+
+// Follow makes the broker refresh as soon as this node becomes the leader.
+func (b *broker) Follow() {
+	clear(b.projects)
+}
+
+func (b *broker) Lead(context.Context) {
+	now := b.now()
+	acked := b.removeAcked()
+
+	for _, m := range b.pendingMessages(acked) {
+		if m.VisibleAt > now.UnixNano() {
+			continue
+		}
+
+		q := b.queue(m.key, now)
+		if q == nil {
+			continue
+		}
+
+		if q.maxAttempts > 0 && m.Attempts >= q.maxAttempts {
+			b.deadLetter(q, m, now)
+			continue
+		}
+
+		if len(q.consumers) == 0 {
+			continue
+		}
+
+		b.deliver(q, m, now)
+	}
+}
+
+// removeAcked removes the messages acked, and returns their keys.
+func (b *broker) removeAcked() map[string]struct{} {
+	acked := make(map[string]struct{})
+	for _, key := range b.cluster.Keys(acksPrefix) {
+		k, ok := parseMessageKey(acksPrefix, key)
+		if !ok {
+			continue
+		}
+
+		msgKey := k.under(messagesPrefix)
+		acked[msgKey] = struct{}{}
+
+		err := b.cluster.Batch([]raft.BatchOp{
+			{Delete: &raft.DeleteCommand{Key: msgKey}},
+			{Delete: &raft.DeleteCommand{Key: key}},
+		}, ApplyTimeout)
+		if err != nil {
+			common.Logger.Errorf("removing acked message `%s` failed with: %s", msgKey, err.Error())
+		}
+	}
+
+	return acked
+}
+
+// pendingMessages returns the messages of every queue not acked, oldest first.
+func (b *broker) pendingMessages(acked map[string]struct{}) []*pending {
+	messages := make([]*pending, 0)
+	for _, key := range b.cluster.Keys(messagesPrefix) {
+		if _, ok := acked[key]; ok {
+			continue
+		}
+
+		k, ok := parseMessageKey(messagesPrefix, key)
+		if !ok {
+			continue
+		}
+
+		data, ok := b.cluster.Get(key)
+		if !ok {
+			continue
+		}
+
+		m, err := decodeMessage(data)
+		if err != nil {
+			common.Logger.Errorf("decoding message `%s` failed with: %s", key, err.Error())
+			continue
+		}
+
+		messages = append(messages, &pending{key: k, message: m})
+	}
+
+	slices.SortStableFunc(messages, func(a, b *pending) int {
+		switch {
+		case a.Enqueued < b.Enqueued:
+			return -1
+		case a.Enqueued > b.Enqueued:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	return messages
+}
+
+// queue returns the queue of the message at k, refreshing what is known of
+// its project when it is stale.
+func (b *broker) queue(k messageKey, now time.Time) *queueConfig {
+	p, ok := b.projects[k.project]
+	if !ok || now.Sub(p.refreshed) >= RefreshInterval {
+		queues, err := b.topology(k.project)
+		if err != nil {
+			common.Logger.Errorf("listing queues of project `%s` failed with: %s", k.project, err.Error())
+		}
+
+		// retry failures at the next refresh, not at every step
+		p = &projectTopology{queues: queues, refreshed: now}
+		b.projects[k.project] = p
+	}
+
+	return p.queues[k.queue]
+}
+
+func (b *broker) deliver(q *queueConfig, m *pending, now time.Time) {
+	m.Attempts++
+	m.VisibleAt = now.Add(q.visibilityTimeout).UnixNano()
+
+	data, err := m.encode()
+	if err != nil {
+		common.Logger.Errorf("encoding message `%s` failed with: %s", m.key.under(messagesPrefix), err.Error())
+		return
+	}
+
+	if err = b.cluster.Set(m.key.under(messagesPrefix), data, ApplyTimeout); err != nil {
+		common.Logger.Errorf("recording delivery of message `%s` failed with: %s", m.key.under(messagesPrefix), err.Error())
+		return
+	}
+
+	go b.dispatch(&delivery{
+		Delivery: common.Delivery{
+			Project: m.key.project,
+			QueueId: q.id,
+			Queue:   q.name,
+			Message: m.key.id,
+			Data:    m.Data,
+			Attempt: m.Attempts,
+		},
+		// spread the messages of a queue across its consumers
+		consumer: q.consumers[m.key.id%uint64(len(q.consumers))],
+	})
+}
+
+// deadLetter moves the message to the dead letter queue of q, as a new
+// message, or keeps it aside when q has none.
+func (b *broker) deadLetter(q *queueConfig, m *pending, now time.Time) {
+	target := deadPrefix
+	if q.deadLetter != "" {
+		target = messagesPrefix
+		m.message = &message{Data: m.Data, Enqueued: now.UnixNano()}
+	}
+
+	data, err := m.encode()
+	if err != nil {
+		common.Logger.Errorf("encoding message `%s` failed with: %s", m.key.under(messagesPrefix), err.Error())
+		return
+	}
+
+	dead := m.key
+	if q.deadLetter != "" {
+		dead.queue = q.deadLetter
+	}
+
+	err = b.cluster.Batch([]raft.BatchOp{
+		{Delete: &raft.DeleteCommand{Key: m.key.under(messagesPrefix)}},
+		{Set: &raft.SetCommand{Key: dead.under(target), Value: data}},
+	}, ApplyTimeout)
+	if err != nil {
+		common.Logger.Errorf("dead-lettering message `%s` failed with: %s", m.key.under(messagesPrefix), err.Error())
+	}
+}
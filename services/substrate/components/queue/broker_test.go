@@ -0,0 +1,246 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/taubyte/tau/pkg/raft"
+	"github.com/taubyte/tau/services/substrate/components/queue/common"
+)
+
+func newTestBroker(t *testing.T, queues ...*queueConfig) (*broker, *raft.MockCluster, chan *delivery, *time.Time) {
+	t.Helper()
+
+	cluster := raft.NewMockCluster()
+	deliveries := make(chan *delivery, 16)
+	now := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	b := newBroker(cluster, func(project string) (map[string]*queueConfig, error) {
+		topology := make(map[string]*queueConfig)
+		for _, q := range queues {
+			topology[q.id] = q
+		}
+		return topology, nil
+	}, func(d *delivery) {
+		deliveries <- d
+	})
+	b.now = func() time.Time { return now }
+
+	return b, cluster, deliveries, &now
+}
+
+func testQueue(id string, maxAttempts uint32, consumers ...string) *queueConfig {
+	q := &queueConfig{
+		id:                id,
+		name:              "name_" + id,
+		visibilityTimeout: 30 * time.Second,
+		maxAttempts:       maxAttempts,
+	}
+
+	for _, c := range consumers {
+		q.consumers = append(q.consumers, common.MatchDefinition{Project: "project", Function: c})
+	}
+
+	return q
+}
+
+func enqueue(t *testing.T, cluster raft.Cluster, queue string, id uint64, data string, enqueued time.Time) {
+	t.Helper()
+
+	value, err := (&message{Data: []byte(data), Enqueued: enqueued.UnixNano()}).encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = cluster.Set(messageKey{project: "project", queue: queue, id: id}.under(messagesPrefix), value, 0); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// expectDeliveries expects the messages holding expected to be delivered, in
+// any order as they are dispatched concurrently, and nothing else.
+func expectDeliveries(t *testing.T, deliveries chan *delivery, expected ...string) {
+	t.Helper()
+
+	want := make(map[string]int)
+	for _, e := range expected {
+		want[e]++
+	}
+
+	for range expected {
+		select {
+		case d := <-deliveries:
+			if want[string(d.Data)] == 0 {
+				t.Fatalf("unexpected delivery of %q", d.Data)
+			}
+			want[string(d.Data)]--
+		case <-time.After(time.Second):
+			t.Fatalf("not all of %q were delivered", expected)
+		}
+	}
+
+	select {
+	case d := <-deliveries:
+		t.Fatalf("unexpected delivery of %q", d.Data)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestBrokerPendingOldestFirst(t *testing.T) {
+	b, cluster, _, now := newTestBroker(t)
+
+	enqueue(t, cluster, "q1", 2, "second", now.Add(-time.Second))
+	enqueue(t, cluster, "q2", 3, "third", *now)
+	enqueue(t, cluster, "q1", 1, "first", now.Add(-2*time.Second))
+
+	messages := b.pendingMessages(map[string]struct{}{
+		messageKey{project: "project", queue: "q2", id: 3}.under(messagesPrefix): {},
+	})
+	if len(messages) != 2 || string(messages[0].Data) != "first" || string(messages[1].Data) != "second" {
+		t.Fatalf("unexpected pending messages %v", messages)
+	}
+}
+
+func TestBrokerDelivers(t *testing.T) {
+	b, cluster, deliveries, now := newTestBroker(t, testQueue("q1", 0, "fn1"))
+
+	enqueue(t, cluster, "q1", 1, "message", *now)
+
+	b.Lead(context.Background())
+
+	d := <-deliveries
+	if d.Attempt != 1 || d.Queue != "name_q1" || d.QueueId != "q1" || d.Message != 1 || d.consumer.Function != "fn1" {
+		t.Fatalf("unexpected delivery %+v", d)
+	}
+}
+
+func TestBrokerRedeliversAfterVisibilityTimeout(t *testing.T) {
+	b, cluster, deliveries, now := newTestBroker(t, testQueue("q1", 0, "fn1"))
+
+	enqueue(t, cluster, "q1", 1, "message", *now)
+
+	b.Lead(context.Background())
+	expectDeliveries(t, deliveries, "message")
+
+	// invisible until its ack is due
+	*now = now.Add(29 * time.Second)
+	b.Lead(context.Background())
+	expectDeliveries(t, deliveries)
+
+	*now = now.Add(time.Second)
+	b.Lead(context.Background())
+
+	d := <-deliveries
+	if d.Attempt != 2 {
+		t.Fatalf("attempt %d, expected 2", d.Attempt)
+	}
+}
+
+func TestBrokerRemovesAcked(t *testing.T) {
+	b, cluster, deliveries, now := newTestBroker(t, testQueue("q1", 0, "fn1"))
+
+	enqueue(t, cluster, "q1", 1, "message", *now)
+
+	b.Lead(context.Background())
+	expectDeliveries(t, deliveries, "message")
+
+	key := messageKey{project: "project", queue: "q1", id: 1}
+	if err := cluster.Set(key.under(acksPrefix), []byte("1"), 0); err != nil {
+		t.Fatal(err)
+	}
+
+	*now = now.Add(time.Minute)
+	b.Lead(context.Background())
+	expectDeliveries(t, deliveries)
+
+	if len(cluster.Keys("/")) != 0 {
+		t.Fatalf("keys left: %v", cluster.Keys("/"))
+	}
+}
+
+func TestBrokerDeadLetters(t *testing.T) {
+	q1 := testQueue("q1", 2, "fn1")
+	q1.deadLetter = "q2"
+	b, cluster, deliveries, now := newTestBroker(t, q1, testQueue("q2", 0, "fn2"), testQueue("q3", 1, "fn3"))
+
+	enqueue(t, cluster, "q1", 1, "to q2", *now)
+	enqueue(t, cluster, "q3", 2, "kept aside", *now)
+
+	b.Lead(context.Background())
+	expectDeliveries(t, deliveries, "to q2", "kept aside")
+
+	// q3's exhausted its attempts, it is kept aside
+	*now = now.Add(time.Minute)
+	b.Lead(context.Background())
+	expectDeliveries(t, deliveries, "to q2")
+
+	// q1's exhausted its attempts: it moves to q2, and is delivered there at
+	// the next step
+	*now = now.Add(time.Minute)
+	b.Lead(context.Background())
+	b.Lead(context.Background())
+
+	d := <-deliveries
+	if d.QueueId != "q2" || d.Attempt != 1 || d.consumer.Function != "fn2" {
+		t.Fatalf("unexpected delivery %+v", d)
+	}
+	expectDeliveries(t, deliveries)
+
+	if _, ok := cluster.Get(messageKey{project: "project", queue: "q3", id: 2}.under(deadPrefix)); !ok {
+		t.Fatal("message of q3 was not kept aside")
+	}
+
+	if len(cluster.Keys(messagesPrefix)) != 1 {
+		t.Fatalf("messages left: %v", cluster.Keys(messagesPrefix))
+	}
+}
+
+func TestBrokerWaitsForConsumers(t *testing.T) {
+	b, cluster, deliveries, now := newTestBroker(t, testQueue("q1", 1))
+
+	enqueue(t, cluster, "q1", 1, "message", *now)
+
+	b.Lead(context.Background())
+	expectDeliveries(t, deliveries)
+
+	data, ok := cluster.Get(messageKey{project: "project", queue: "q1", id: 1}.under(messagesPrefix))
+	if !ok {
+		t.Fatal("message is gone")
+	}
+
+	m, err := decodeMessage(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if m.Attempts != 0 {
+		t.Fatalf("%d attempts without consumers", m.Attempts)
+	}
+}
+
+func TestBrokerRefreshesTopology(t *testing.T) {
+	b, cluster, deliveries, now := newTestBroker(t)
+
+	calls := 0
+	b.topology = func(string) (map[string]*queueConfig, error) {
+		calls++
+		return nil, errors.New("tns is down")
+	}
+
+	enqueue(t, cluster, "q1", 1, "message", *now)
+
+	b.Lead(context.Background())
+	b.Lead(context.Background())
+	expectDeliveries(t, deliveries)
+	if calls != 1 {
+		t.Fatalf("topology fetched %d times", calls)
+	}
+
+	*now = now.Add(RefreshInterval)
+	b.Lead(context.Background())
+	if calls != 2 {
+		t.Fatalf("topology fetched %d times", calls)
+	}
+}
@@ -0,0 +1,18 @@
+package common
+
+import "github.com/taubyte/tau/services/substrate/components/trigger/common"
+
+// MatchDefinition identifies a queue function.
+type MatchDefinition = common.MatchDefinition
+
+// Delivery is a message of a queue handed to a queue function.
+type Delivery struct {
+	Project string
+	// QueueId is the id of the queue, Queue its name.
+	QueueId string
+	Queue   string
+	Message uint64
+	Data    []byte
+	// Attempt counts the deliveries of the message, this one included.
+	Attempt uint32
+}
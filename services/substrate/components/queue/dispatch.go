@@ -0,0 +1,64 @@
+package queue
+
+import (
+	"fmt"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/taubyte/tau/clients/p2p/substrate"
+	"github.com/taubyte/tau/p2p/streams/command"
+	structureSpec "github.com/taubyte/tau/pkg/specs/structure"
+	plugins "github.com/taubyte/tau/pkg/vm-low-orbit"
+	"github.com/taubyte/tau/pkg/vm-low-orbit/event"
+	"github.com/taubyte/tau/services/substrate/components/queue/common"
+)
+
+// dispatch hands d to a member of the cluster. Members are tried in turn until
+// one of them takes it. A message no member takes is delivered again once its
+// visibility timeout is over.
+func (s *Service) dispatch(d *delivery) {
+	for _, pid := range s.Members(fmt.Sprintf("%s/%d", d.QueueId, d.Message)) {
+		err := s.send(pid, d)
+		if err == nil {
+			return
+		}
+
+		common.Logger.Warnf("delivering message %s of queue `%s` on %s failed with: %s", formatMessageId(d.Message), d.Queue, pid.String(), err.Error())
+	}
+
+	common.Logger.Errorf("no node took message %s of queue `%s`", formatMessageId(d.Message), d.Queue)
+}
+
+func (s *Service) send(pid peer.ID, d *delivery) error {
+	if pid == s.Node().ID() {
+		return s.Run(&d.consumer, &d.Delivery)
+	}
+
+	return s.Send(substrate.CommandQueue, command.Body{
+		substrate.BodyProject:     d.consumer.Project,
+		substrate.BodyApplication: d.consumer.Application,
+		substrate.BodyFunction:    d.consumer.Function,
+		substrate.BodyQueue:       d.QueueId,
+		substrate.BodyQueueName:   d.Queue,
+		substrate.BodyMessage:     formatMessageId(d.Message),
+		substrate.BodyData:        d.Data,
+		substrate.BodyAttempt:     d.Attempt,
+	}, pid)
+}
+
+// Run hands the delivery to the queue function of matcher in the background,
+// once it is found and ready. The message is acked when the function returns
+// without error; otherwise it is delivered again after its visibility timeout.
+func (s *Service) Run(matcher *common.MatchDefinition, d *common.Delivery) error {
+	return s.Service.Run(matcher, func(sdk plugins.Instance, _ *structureSpec.Function) *event.Event {
+		return sdk.CreateQueueEvent(d.Queue, d.Message, d.Data, d.Attempt)
+	}, func(err error) {
+		if err != nil {
+			common.Logger.Errorf("handling message %s of queue `%s` with function `%s` failed with: %s", formatMessageId(d.Message), d.Queue, matcher.Function, err.Error())
+			return
+		}
+
+		if err = s.ack(messageKey{project: d.Project, queue: d.QueueId, id: d.Message}); err != nil {
+			common.Logger.Errorf("acking message %s of queue `%s` failed with: %s", formatMessageId(d.Message), d.Queue, err.Error())
+		}
+	})
+}
@@ -0,0 +1,81 @@
+package queue
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// message is a message waiting in a queue, as kept in the raft namespace.
+type message struct {
+	Data []byte `cbor:"1,keyasint"`
+	// Enqueued is when the message was enqueued, in nanoseconds since the
+	// epoch; messages are delivered oldest first.
+	Enqueued int64 `cbor:"2,keyasint"`
+	// Attempts counts the deliveries of the message.
+	Attempts uint32 `cbor:"3,keyasint"`
+	// VisibleAt is when the message can be delivered, in nanoseconds since
+	// the epoch: the deadline of the ack of its last delivery.
+	VisibleAt int64 `cbor:"4,keyasint"`
+}
+
+func (m *message) encode() ([]byte, error) {
+	return cbor.Marshal(m)
+}
+
+func decodeMessage(data []byte) (*message, error) {
+	m := new(message)
+	if err := cbor.Unmarshal(data, m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// messageKey addresses a message of a queue.
+type messageKey struct {
+	project string
+	queue   string
+	id      uint64
+}
+
+func (k messageKey) under(prefix string) string {
+	return path.Join(prefix, k.project, k.queue, formatMessageId(k.id))
+}
+
+func parseMessageKey(prefix, key string) (k messageKey, ok bool) {
+	p := strings.Split(strings.TrimPrefix(key, prefix), "/")
+	if len(p) != 3 || p[0] == "" || p[1] == "" {
+		return
+	}
+
+	id, err := parseMessageId(p[2])
+	if err != nil {
+		return
+	}
+
+	return messageKey{project: p[0], queue: p[1], id: id}, true
+}
+
+// newMessageId returns a random id, so nodes enqueue without agreeing on ids.
+func newMessageId() (uint64, error) {
+	var buf [8]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return 0, fmt.Errorf("generating message id failed with: %w", err)
+	}
+
+	return binary.BigEndian.Uint64(buf[:]), nil
+}
+
+func formatMessageId(id uint64) string {
+	return fmt.Sprintf("%016x", id)
+}
+
+func parseMessageId(s string) (uint64, error) {
+	return strconv.ParseUint(s, 16, 64)
+}
@@ -0,0 +1,33 @@
+package queue
+
+import (
+	"github.com/taubyte/tau/core/services/substrate"
+	"github.com/taubyte/tau/pkg/raft"
+	functionSpec "github.com/taubyte/tau/pkg/specs/function"
+	"github.com/taubyte/tau/services/substrate/components/trigger"
+)
+
+func New(srv substrate.Service, options ...trigger.Option) (*Service, error) {
+	t, err := trigger.New(srv, functionSpec.TypeQueue, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Service{Service: t}
+	s.Start(Resolution, func(cluster raft.Cluster) trigger.Leader {
+		return newBroker(cluster, s.topology, s.dispatch)
+	})
+
+	return s, nil
+}
+
+func (s *Service) Close() error {
+	s.lock.Lock()
+	if s.raftClient != nil {
+		s.raftClient.Close()
+		s.raftClient = nil
+	}
+	s.lock.Unlock()
+
+	return s.Service.Close()
+}
@@ -0,0 +1,132 @@
+package queue
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	spec "github.com/taubyte/tau/pkg/specs/common"
+	functionSpec "github.com/taubyte/tau/pkg/specs/function"
+	"github.com/taubyte/tau/pkg/specs/methods"
+	structureSpec "github.com/taubyte/tau/pkg/specs/structure"
+	"github.com/taubyte/tau/services/substrate/components/queue/common"
+	"github.com/taubyte/tau/services/substrate/components/trigger"
+)
+
+// queueConfig is a queue of a project, as the broker delivers its messages.
+type queueConfig struct {
+	id                string
+	name              string
+	application       string
+	visibilityTimeout time.Duration
+	// maxAttempts is 0 for queues delivering messages until they are acked.
+	maxAttempts uint32
+	// deadLetter is the id of the queue dead-lettered messages move to, if any.
+	deadLetter string
+	consumers  []common.MatchDefinition
+}
+
+// scope holds the queues and functions of an application, or the global ones.
+type scope struct {
+	queues    map[string]*structureSpec.Queue
+	functions map[string]*structureSpec.Function
+}
+
+// topology returns the queues of the current commit of project, by id.
+func (s *Service) topology(project string) (map[string]*queueConfig, error) {
+	commit, branch, err := s.Tns().Simple().Commit(project, spec.DefaultBranches...)
+	if err != nil {
+		return nil, fmt.Errorf("fetching commit of project `%s` failed with: %w", project, err)
+	}
+
+	prefix := methods.ProjectPrefix(project, branch, commit)
+	keys, err := trigger.Keys(s.Tns(), prefix.Slice()...)
+	if err != nil {
+		return nil, fmt.Errorf("listing keys of project `%s` failed with: %w", project, err)
+	}
+
+	scopes := make(map[string]*scope)
+	for _, app := range append([]string{""}, trigger.Applications(prefix.Slice(), keys)...) {
+		// a scope without queues or functions has nothing to list
+		queues, _, _, _ := s.Tns().Queue().Relative(project, app, branch).List()
+		functions, _, _, _ := s.Tns().Function().Relative(project, app, branch).List()
+		scopes[app] = &scope{queues: queues, functions: functions}
+	}
+
+	return buildTopology(project, scopes), nil
+}
+
+// buildTopology resolves the queue names of queue functions and dead letter
+// queues the way functions name queues: globally first, then in their
+// application.
+func buildTopology(project string, scopes map[string]*scope) map[string]*queueConfig {
+	byName := make(map[string]map[string]*queueConfig)
+	topology := make(map[string]*queueConfig)
+	for app, sc := range scopes {
+		byName[app] = make(map[string]*queueConfig)
+		for id, q := range sc.queues {
+			config := &queueConfig{
+				id:                id,
+				name:              q.Name,
+				application:       app,
+				visibilityTimeout: time.Duration(q.VisibilityTimeout),
+				maxAttempts:       uint32(max(q.MaxAttempts, 0)),
+			}
+			if config.visibilityTimeout <= 0 {
+				config.visibilityTimeout = DefaultVisibilityTimeout
+			}
+
+			byName[app][q.Name] = config
+			topology[id] = config
+		}
+	}
+
+	resolve := func(app, name string) *queueConfig {
+		if q, ok := byName[""][name]; ok {
+			return q
+		}
+
+		return byName[app][name]
+	}
+
+	for app, sc := range scopes {
+		for id, q := range sc.queues {
+			if q.DeadLetter == "" {
+				continue
+			}
+
+			if dead := resolve(app, q.DeadLetter); dead != nil && dead.id != id {
+				topology[id].deadLetter = dead.id
+			} else {
+				common.Logger.Errorf("dead letter queue `%s` of queue `%s` of project `%s` not found", q.DeadLetter, q.Name, project)
+			}
+		}
+
+		for id, fn := range sc.functions {
+			if fn.Type != functionSpec.TypeQueue {
+				continue
+			}
+
+			q := resolve(app, fn.Queue)
+			if q == nil {
+				common.Logger.Errorf("queue `%s` of function `%s` of project `%s` not found", fn.Queue, fn.Name, project)
+				continue
+			}
+
+			q.consumers = append(q.consumers, common.MatchDefinition{Project: project, Application: app, Function: id})
+		}
+	}
+
+	// consumers are picked by index, keep the order stable across refreshes
+	for _, q := range topology {
+		slices.SortFunc(q.consumers, func(a, b common.MatchDefinition) int {
+			if c := strings.Compare(a.Application, b.Application); c != 0 {
+				return c
+			}
+			return strings.Compare(a.Function, b.Function)
+		})
+	}
+
+	return topology
+}
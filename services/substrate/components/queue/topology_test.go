@@ -0,0 +1,69 @@
+package queue
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	functionSpec "github.com/taubyte/tau/pkg/specs/function"
+	structureSpec "github.com/taubyte/tau/pkg/specs/structure"
+	"github.com/taubyte/tau/services/substrate/components/queue/common"
+)
+
+func TestBuildTopology(t *testing.T) {
+	topology := buildTopology("project", map[string]*scope{
+		"": {
+			queues: map[string]*structureSpec.Queue{
+				"g1": {Name: "jobs", MaxAttempts: 3, DeadLetter: "dead"},
+				"g2": {Name: "dead", VisibilityTimeout: uint64(time.Minute)},
+			},
+			functions: map[string]*structureSpec.Function{
+				"f1": {Name: "worker", Type: functionSpec.TypeQueue, Queue: "jobs"},
+				"f2": {Name: "ping", Type: functionSpec.TypeCron, Queue: "jobs"},
+			},
+		},
+		"app": {
+			queues: map[string]*structureSpec.Queue{
+				// shadowed by the global queue of the same name
+				"a1": {Name: "jobs"},
+				"a2": {Name: "local", DeadLetter: "missing"},
+			},
+			functions: map[string]*structureSpec.Function{
+				"f3": {Name: "app_worker", Type: functionSpec.TypeQueue, Queue: "jobs"},
+				"f4": {Name: "local_worker", Type: functionSpec.TypeQueue, Queue: "local"},
+				"f5": {Name: "lost", Type: functionSpec.TypeQueue, Queue: "missing"},
+			},
+		},
+	})
+
+	if len(topology) != 4 {
+		t.Fatalf("%d queues, expected 4", len(topology))
+	}
+
+	jobs := topology["g1"]
+	if jobs.maxAttempts != 3 || jobs.deadLetter != "g2" || jobs.visibilityTimeout != DefaultVisibilityTimeout {
+		t.Fatalf("unexpected queue %+v", jobs)
+	}
+
+	if !slices.Equal(jobs.consumers, []common.MatchDefinition{
+		{Project: "project", Application: "", Function: "f1"},
+		{Project: "project", Application: "app", Function: "f3"},
+	}) {
+		t.Fatalf("unexpected consumers %v", jobs.consumers)
+	}
+
+	if topology["g2"].visibilityTimeout != time.Minute {
+		t.Fatalf("unexpected visibility timeout %s", topology["g2"].visibilityTimeout)
+	}
+
+	if len(topology["a1"].consumers) != 0 {
+		t.Fatalf("shadowed queue has consumers %v", topology["a1"].consumers)
+	}
+
+	local := topology["a2"]
+	if local.application != "app" || local.deadLetter != "" || !slices.Equal(local.consumers, []common.MatchDefinition{
+		{Project: "project", Application: "app", Function: "f4"},
+	}) {
+		t.Fatalf("unexpected queue %+v", local)
+	}
+}
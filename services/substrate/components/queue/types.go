@@ -0,0 +1,18 @@
+package queue
+
+import (
+	"sync"
+
+	"github.com/taubyte/tau/pkg/raft"
+	"github.com/taubyte/tau/services/substrate/components/trigger"
+)
+
+// Service keeps the messages of the queues of projects in a raft cluster all
+// the nodes of the cloud join, and runs the queue functions. The leader of the
+// cluster decides when each message is delivered, and to which member.
+type Service struct {
+	*trigger.Service
+
+	lock       sync.Mutex
+	raftClient raft.Client
+}
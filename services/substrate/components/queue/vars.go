@@ -0,0 +1,29 @@
+package queue
+
+import "time"
+
+var (
+	// Resolution is how often the leader looks for messages to deliver.
+	Resolution = 500 * time.Millisecond
+	// RefreshInterval is how often the leader refreshes the queues and queue
+	// functions of a project from TNS.
+	RefreshInterval = time.Minute
+	// ApplyTimeout bounds the raft writes.
+	ApplyTimeout = 5 * time.Second
+	// DefaultVisibilityTimeout is how long a delivered message waits for its
+	// ack, for queues that do not set it.
+	DefaultVisibilityTimeout = 30 * time.Second
+)
+
+// Prefixes of the keys of the raft namespace, each followed by
+// <project>/<queue id>/<message id>.
+const (
+	// messagesPrefix is where the messages waiting in queues are kept.
+	messagesPrefix = "/messages/"
+	// acksPrefix is where acks are kept until the leader removes the
+	// messages they ack.
+	acksPrefix = "/acks/"
+	// deadPrefix is where dead-lettered messages of queues without a dead
+	// letter queue are kept aside.
+	deadPrefix = "/dead/"
+)
@@ -0,0 +1,108 @@
+package smartOps
+
+import (
+	"context"
+	"errors"
+	"path"
+	"sync"
+
+	"github.com/jellydator/ttlcache/v3"
+	"github.com/taubyte/tau/core/services/substrate/smartops"
+)
+
+var _ smartops.SmartOpsCache = &cache{}
+
+// cache keeps smartop instances warm between calls. Instances are evicted
+// once idle for InstanceIdleTTL, or as soon as their context is done, and
+// their runtime is released on eviction.
+type cache struct {
+	instances *ttlcache.Cache[string, smartops.Instance]
+	closeOnce sync.Once
+
+	// putLock makes replacing an instance and cancelling the one it displaces
+	// atomic, ttlcache does not evict a value overwritten by Set.
+	putLock sync.Mutex
+}
+
+func newCache(ctx context.Context) *cache {
+	c := &cache{
+		instances: ttlcache.New(
+			ttlcache.WithTTL[string, smartops.Instance](InstanceIdleTTL),
+		),
+	}
+
+	c.instances.OnEviction(func(_ context.Context, _ ttlcache.EvictionReason, item *ttlcache.Item[string, smartops.Instance]) {
+		item.Value().ContextCancel()
+	})
+
+	go c.instances.Start()
+	go func() {
+		<-ctx.Done()
+		c.Close()
+	}()
+
+	return c
+}
+
+func cacheKey(project, application, smartOpId string) string {
+	return path.Join(project, application, smartOpId)
+}
+
+func (c *cache) Get(project, application, smartOpId string, ctx context.Context) (smartops.Instance, bool) {
+	if ctx.Err() != nil {
+		return nil, false
+	}
+
+	item := c.instances.Get(cacheKey(project, application, smartOpId))
+	if item == nil {
+		return nil, false
+	}
+
+	instance := item.Value()
+	if instance.Context().Err() != nil {
+		c.instances.Delete(item.Key())
+		return nil, false
+	}
+
+	return instance, true
+}
+
+func (c *cache) Put(project, application, smartOpId string, ctx context.Context, instance smartops.Instance) error {
+	if instance == nil {
+		return errors.New("instance is nil")
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	key := cacheKey(project, application, smartOpId)
+
+	c.putLock.Lock()
+	previous := c.instances.Get(key, ttlcache.WithDisableTouchOnHit[string, smartops.Instance]())
+	c.instances.Set(key, instance, ttlcache.DefaultTTL)
+	c.putLock.Unlock()
+
+	if previous != nil && previous.Value() != instance {
+		previous.Value().ContextCancel()
+	}
+
+	go func() {
+		<-instance.Context().Done()
+		if item := c.instances.Get(key, ttlcache.WithDisableTouchOnHit[string, smartops.Instance]()); item != nil && item.Value() == instance {
+			c.instances.Delete(key)
+		}
+	}()
+
+	return nil
+}
+
+func (c *cache) Close() {
+	c.closeOnce.Do(func() {
+		c.instances.Stop()
+		for _, item := range c.instances.Items() {
+			item.Value().ContextCancel()
+		}
+		c.instances.DeleteAll()
+	})
+}
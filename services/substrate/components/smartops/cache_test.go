@@ -0,0 +1,96 @@
+package smartOps
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/taubyte/tau/core/services/substrate/smartops"
+	"gotest.tools/v3/assert"
+)
+
+type fakeInstance struct {
+	ctx  context.Context
+	ctxC context.CancelFunc
+}
+
+func newFakeInstance(ctx context.Context) *fakeInstance {
+	i := &fakeInstance{}
+	i.ctx, i.ctxC = context.WithCancel(ctx)
+	return i
+}
+
+func (i *fakeInstance) Context() context.Context                 { return i.ctx }
+func (i *fakeInstance) ContextCancel()                           { i.ctxC() }
+func (i *fakeInstance) Run(smartops.EventCaller) (uint32, error) { return 0, nil }
+
+func TestCache(t *testing.T) {
+	c := newCache(t.Context())
+	defer c.Close()
+
+	_, ok := c.Get("project", "app", "op", t.Context())
+	assert.Assert(t, !ok)
+
+	inst := newFakeInstance(t.Context())
+	assert.NilError(t, c.Put("project", "app", "op", t.Context(), inst))
+
+	got, ok := c.Get("project", "app", "op", t.Context())
+	assert.Assert(t, ok)
+	assert.Equal(t, got, smartops.Instance(inst))
+
+	_, ok = c.Get("project", "other", "op", t.Context())
+	assert.Assert(t, !ok)
+
+	inst.ContextCancel()
+	_, ok = c.Get("project", "app", "op", t.Context())
+	assert.Assert(t, !ok)
+}
+
+func TestCacheReplace(t *testing.T) {
+	c := newCache(t.Context())
+	defer c.Close()
+
+	old := newFakeInstance(t.Context())
+	assert.NilError(t, c.Put("project", "app", "op", t.Context(), old))
+
+	inst := newFakeInstance(t.Context())
+	assert.NilError(t, c.Put("project", "app", "op", t.Context(), inst))
+	assert.ErrorIs(t, old.Context().Err(), context.Canceled)
+	assert.NilError(t, inst.Context().Err())
+
+	got, ok := c.Get("project", "app", "op", t.Context())
+	assert.Assert(t, ok)
+	assert.Equal(t, got, smartops.Instance(inst))
+
+	assert.NilError(t, c.Put("project", "app", "op", t.Context(), inst))
+	assert.NilError(t, inst.Context().Err())
+}
+
+func TestCacheEviction(t *testing.T) {
+	ttl := InstanceIdleTTL
+	InstanceIdleTTL = 50 * time.Millisecond
+	defer func() { InstanceIdleTTL = ttl }()
+
+	c := newCache(t.Context())
+	defer c.Close()
+
+	inst := newFakeInstance(t.Context())
+	assert.NilError(t, c.Put("project", "app", "op", t.Context(), inst))
+
+	select {
+	case <-inst.Context().Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("idle instance was not evicted")
+	}
+}
+
+func TestCacheClose(t *testing.T) {
+	c := newCache(t.Context())
+
+	inst := newFakeInstance(t.Context())
+	assert.NilError(t, c.Put("project", "app", "op", t.Context(), inst))
+
+	c.Close()
+	c.Close()
+	assert.ErrorIs(t, inst.Context().Err(), context.Canceled)
+}
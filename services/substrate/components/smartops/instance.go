@@ -0,0 +1,139 @@
+package smartOps
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/taubyte/tau/core/services/substrate/smartops"
+	"github.com/taubyte/tau/core/vm"
+	smartOpSpec "github.com/taubyte/tau/pkg/specs/smartops"
+	structureSpec "github.com/taubyte/tau/pkg/specs/structure"
+	plugins "github.com/taubyte/tau/pkg/vm-low-orbit"
+	opsPlugins "github.com/taubyte/tau/pkg/vm-ops-orbit"
+	vmContext "github.com/taubyte/tau/pkg/vm/context"
+)
+
+// instantiate loads the smartop module, resolved through TNS, in a fresh
+// runtime with both the core and smartops host modules attached.
+func (s *Service) instantiate(project, application, branch, commit string, config *structureSpec.SmartOp) (_ *instance, err error) {
+	inst := &instance{
+		config: config,
+		commit: commit,
+	}
+	inst.ctx, inst.ctxC = context.WithCancel(s.Context())
+	defer func() {
+		if err != nil {
+			inst.ContextCancel()
+		}
+	}()
+
+	ctx, err := vmContext.New(
+		inst.ctx,
+		vmContext.Project(project),
+		vmContext.Application(application),
+		vmContext.Resource(config.Id),
+		vmContext.Branch(branch),
+		vmContext.Commit(commit),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating vm context failed with: %w", err)
+	}
+
+	vmInstance, err := s.Vm().New(ctx, vm.Config{MemoryLimitPages: memoryLimitPages(config.Memory)})
+	if err != nil {
+		return nil, fmt.Errorf("creating vm instance failed with: %w", err)
+	}
+
+	if inst.runtime, err = vmInstance.Runtime(); err != nil {
+		vmInstance.Close()
+		return nil, fmt.Errorf("creating runtime failed with: %w", err)
+	}
+
+	if _, _, err = inst.runtime.Attach(plugins.Plugin()); err != nil {
+		return nil, fmt.Errorf("attaching core plugins failed with: %w", err)
+	}
+
+	opsPi, _, err := inst.runtime.Attach(opsPlugins.Plugin())
+	if err != nil {
+		return nil, fmt.Errorf("attaching smartops plugins failed with: %w", err)
+	}
+
+	if inst.api, err = opsPlugins.With(opsPi); err != nil {
+		return nil, fmt.Errorf("loading smartops api failed with: %w", err)
+	}
+
+	module, err := inst.runtime.Module(smartOpSpec.ModuleName(config.Name))
+	if err != nil {
+		return nil, fmt.Errorf("loading module failed with: %w", err)
+	}
+
+	if inst.fx, err = module.Function(config.Call); err != nil {
+		return nil, fmt.Errorf("getting function `%s` failed with: %w", config.Call, err)
+	}
+
+	go func() {
+		<-inst.ctx.Done()
+		inst.lock.Lock()
+		defer inst.lock.Unlock()
+		inst.runtime.Close()
+		vmInstance.Close()
+	}()
+
+	return inst, nil
+}
+
+func memoryLimitPages(memory uint64) uint32 {
+	pages := memory / uint64(vm.MemoryPageSize)
+	if memory%uint64(vm.MemoryPageSize) != 0 {
+		pages++
+	}
+
+	if pages == 0 || pages > uint64(vm.MemoryLimitPages) {
+		return vm.MemoryLimitPages
+	}
+
+	return uint32(pages)
+}
+
+func (i *instance) Context() context.Context {
+	return i.ctx
+}
+
+func (i *instance) ContextCancel() {
+	i.ctxC()
+}
+
+// Run calls the smartop with a resource wrapping caller and returns the code
+// it exits with. A failed call retires the instance.
+func (i *instance) Run(caller smartops.EventCaller) (uint32, error) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	if err := i.ctx.Err(); err != nil {
+		return 0, fmt.Errorf("smartop instance closed: %w", err)
+	}
+
+	timeout := DefaultTimeout
+	if i.config.Timeout > 0 {
+		timeout = time.Duration(i.config.Timeout)
+	}
+
+	ctx, ctxC := context.WithTimeout(caller.Context(), timeout)
+	defer ctxC()
+
+	resource := i.api.CreateSmartOp(caller)
+	defer i.api.ReleaseSmartOp(resource)
+
+	ret, err := i.fx.RawCall(ctx, uint64(resource.Id))
+	if err != nil {
+		i.ctxC()
+		return 0, fmt.Errorf("calling `%s` failed with: %w", i.config.Call, err)
+	}
+
+	if len(ret) == 0 {
+		return 0, nil
+	}
+
+	return uint32(ret[0]), nil
+}
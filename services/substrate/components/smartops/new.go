@@ -0,0 +1,19 @@
+package smartOps
+
+import (
+	"github.com/taubyte/tau/core/services/substrate"
+)
+
+func New(srv substrate.Service) (*Service, error) {
+	s := &Service{
+		Service: srv,
+		cache:   newCache(srv.Context()),
+	}
+
+	return s, nil
+}
+
+func (s *Service) Close() error {
+	s.cache.Close()
+	return nil
+}
@@ -0,0 +1,68 @@
+package smartOps
+
+import (
+	"fmt"
+
+	"github.com/taubyte/tau/core/services/substrate/smartops"
+	spec "github.com/taubyte/tau/pkg/specs/common"
+)
+
+// Run executes the given smartops in order on behalf of caller. The first
+// smartop returning a non-zero code denies the call and its code is returned.
+func (s *Service) Run(caller smartops.EventCaller, smartOpIds []string) (uint32, error) {
+	return runAll(caller, smartOpIds, s.run)
+}
+
+// runAll runs each smartop through run, until one denies the call.
+func runAll(caller smartops.EventCaller, smartOpIds []string, run func(smartops.EventCaller, string) (uint32, error)) (uint32, error) {
+	for _, smartOpId := range smartOpIds {
+		code, err := run(caller, smartOpId)
+		if err != nil {
+			return 0, fmt.Errorf("running smartop `%s` failed with: %w", smartOpId, err)
+		}
+
+		if code != 0 {
+			return code, nil
+		}
+	}
+
+	return 0, nil
+}
+
+func (s *Service) run(caller smartops.EventCaller, smartOpId string) (uint32, error) {
+	project, application := caller.Project(), caller.Application()
+
+	smartOps, commit, branch, err := s.Tns().SmartOp().All(project, application, spec.DefaultBranches...).List()
+	if err != nil {
+		return 0, fmt.Errorf("listing smartops failed with: %w", err)
+	}
+
+	config, ok := smartOps[smartOpId]
+	if !ok || config == nil {
+		return 0, fmt.Errorf("smartop not found in project `%s`", project)
+	}
+
+	cached, ok := s.cache.Get(project, application, smartOpId, caller.Context())
+	if inst, isInstance := cached.(*instance); ok && isInstance && inst.commit == commit {
+		return inst.Run(caller)
+	}
+
+	// concurrent misses share one instance rather than each caching its own
+	v, err, _ := s.group.Do(cacheKey(project, application, smartOpId)+"@"+commit, func() (any, error) {
+		inst, err := s.instantiate(project, application, branch, commit, config)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = s.cache.Put(project, application, smartOpId, caller.Context(), inst); err != nil {
+			logger.Errorf("caching smartop `%s` failed with: %s", smartOpId, err)
+		}
+
+		return inst, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return v.(*instance).Run(caller)
+}
@@ -0,0 +1,61 @@
+package smartOps
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/taubyte/tau/core/services/substrate/smartops"
+	"gotest.tools/v3/assert"
+)
+
+type fakeCaller struct {
+	*fakeInstance
+}
+
+func (fakeCaller) Type() uint32        { return 0 }
+func (fakeCaller) Application() string { return "app" }
+func (fakeCaller) Project() string     { return "project" }
+
+func fakeRun(codes map[string]uint32, errs map[string]error, ran *[]string) func(smartops.EventCaller, string) (uint32, error) {
+	return func(_ smartops.EventCaller, smartOpId string) (uint32, error) {
+		*ran = append(*ran, smartOpId)
+		return codes[smartOpId], errs[smartOpId]
+	}
+}
+
+func TestRun(t *testing.T) {
+	caller := fakeCaller{newFakeInstance(t.Context())}
+
+	t.Run("allow", func(t *testing.T) {
+		var ran []string
+		code, err := runAll(caller, []string{"a", "b"}, fakeRun(nil, nil, &ran))
+		assert.NilError(t, err)
+		assert.Equal(t, code, uint32(0))
+		assert.DeepEqual(t, ran, []string{"a", "b"})
+	})
+
+	t.Run("deny on non-zero", func(t *testing.T) {
+		var ran []string
+		code, err := runAll(caller, []string{"a", "b", "c"}, fakeRun(map[string]uint32{"b": 3}, nil, &ran))
+		assert.NilError(t, err)
+		assert.Equal(t, code, uint32(3))
+		assert.DeepEqual(t, ran, []string{"a", "b"})
+	})
+
+	t.Run("error", func(t *testing.T) {
+		var ran []string
+		errFailed := errors.New("failed")
+		code, err := runAll(caller, []string{"a", "b"}, fakeRun(nil, map[string]error{"a": errFailed}, &ran))
+		assert.ErrorIs(t, err, errFailed)
+		assert.Equal(t, code, uint32(0))
+		assert.DeepEqual(t, ran, []string{"a"})
+	})
+
+	t.Run("none", func(t *testing.T) {
+		var ran []string
+		code, err := runAll(caller, nil, fakeRun(map[string]uint32{"a": 1}, nil, &ran))
+		assert.NilError(t, err)
+		assert.Equal(t, code, uint32(0))
+		assert.Equal(t, len(ran), 0)
+	})
+}
@@ -0,0 +1,38 @@
+package smartOps
+
+import (
+	"context"
+	"sync"
+
+	"github.com/taubyte/tau/core/services/substrate"
+	"github.com/taubyte/tau/core/services/substrate/smartops"
+	"github.com/taubyte/tau/core/vm"
+	structureSpec "github.com/taubyte/tau/pkg/specs/structure"
+	opsPlugins "github.com/taubyte/tau/pkg/vm-ops-orbit"
+	"golang.org/x/sync/singleflight"
+)
+
+var _ substrate.SmartOpsService = &Service{}
+
+type Service struct {
+	substrate.Service
+	cache smartops.SmartOpsCache
+	group singleflight.Group
+}
+
+var _ smartops.Instance = &instance{}
+
+// instance is a smartop module loaded in its own runtime. A wasm runtime is
+// single threaded, so calls are serialized on lock.
+type instance struct {
+	ctx  context.Context
+	ctxC context.CancelFunc
+
+	config *structureSpec.SmartOp
+	commit string
+
+	runtime vm.Runtime
+	api     opsPlugins.Instance
+	fx      vm.FunctionInstance
+	lock    sync.Mutex
+}
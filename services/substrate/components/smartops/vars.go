@@ -0,0 +1,17 @@
+package smartOps
+
+import (
+	"time"
+
+	"github.com/ipfs/go-log/v2"
+)
+
+var logger = log.Logger("tau.substrate.service.smartops")
+
+var (
+	// InstanceIdleTTL is how long an unused smartop instance stays cached.
+	InstanceIdleTTL = 10 * time.Minute
+
+	// DefaultTimeout bounds a smartop call when its config sets no timeout.
+	DefaultTimeout = 5 * time.Second
+)
@@ -2,8 +2,10 @@ package structure
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/pterm/pterm"
+	"github.com/taubyte/tau/core/kvdb"
 	pubsubIface "github.com/taubyte/tau/core/services/substrate/components/pubsub"
 	"github.com/taubyte/tau/core/vm"
 	"github.com/taubyte/tau/p2p/streams/command"
@@ -37,4 +39,24 @@ func (ts *TestSdk) CreateP2PEvent(cmd *command.Command, response response.Respon
 	return &event.Event{}
 }
 
+func (ts *TestSdk) CreateWebSocketEvent(connection string, kind event.WebSocketEventKind, data []byte, binary bool) *event.Event {
+	CalledTestFunctionsWS = append(CalledTestFunctionsWS, webSocketEvent{Connection: connection, Kind: kind, Data: data})
+	return &event.Event{}
+}
+
+func (ts *TestSdk) CreateCronEvent(schedule string, tick time.Time) *event.Event {
+	CalledTestFunctionsCron = append(CalledTestFunctionsCron, cronEvent{Schedule: schedule, Tick: tick})
+	return &event.Event{}
+}
+
+func (ts *TestSdk) CreateQueueEvent(queue string, message uint64, data []byte, attempt uint32) *event.Event {
+	CalledTestFunctionsQueue = append(CalledTestFunctionsQueue, queueEvent{Queue: queue, Message: message, Data: data, Attempt: attempt})
+	return &event.Event{}
+}
+
+func (ts *TestSdk) CreateDatabaseEvent(database string, change kvdb.Event) *event.Event {
+	CalledTestFunctionsDatabase = append(CalledTestFunctionsDatabase, databaseEvent{Database: database, Change: change})
+	return &event.Event{}
+}
+
 func (ts *TestSdk) AttachEvent(*event.Event) {}
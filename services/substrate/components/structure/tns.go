@@ -8,6 +8,7 @@ import (
 	functionSpec "github.com/taubyte/tau/pkg/specs/function"
 	librarySpec "github.com/taubyte/tau/pkg/specs/library"
 	messagingSpec "github.com/taubyte/tau/pkg/specs/messaging"
+	queueSpec "github.com/taubyte/tau/pkg/specs/queue"
 	serviceSpec "github.com/taubyte/tau/pkg/specs/service"
 	smartOpSpec "github.com/taubyte/tau/pkg/specs/smartops"
 	storageSpec "github.com/taubyte/tau/pkg/specs/storage"
@@ -78,6 +79,10 @@ func (c *TestClient) Messaging() tns.StructureIface[*structureSpec.Messaging] {
 	return structure.New[*structureSpec.Messaging](c, messagingSpec.PathVariable)
 }
 
+func (c *TestClient) Queue() tns.StructureIface[*structureSpec.Queue] {
+	return structure.New[*structureSpec.Queue](c, queueSpec.PathVariable)
+}
+
 func (c *TestClient) Service() tns.StructureIface[*structureSpec.Service] {
 	return structure.New[*structureSpec.Service](c, serviceSpec.PathVariable)
 }
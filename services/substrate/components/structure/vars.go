@@ -4,9 +4,12 @@ import (
 	"net/http"
 	"reflect"
 	"testing"
+	"time"
 
+	"github.com/taubyte/tau/core/kvdb"
 	pubsubIface "github.com/taubyte/tau/core/services/substrate/components/pubsub"
 	"github.com/taubyte/tau/p2p/streams/command"
+	"github.com/taubyte/tau/pkg/vm-low-orbit/event"
 )
 
 type httpEvent struct {
@@ -14,11 +17,38 @@ type httpEvent struct {
 	R *http.Request
 }
 
+type webSocketEvent struct {
+	Connection string
+	Kind       event.WebSocketEventKind
+	Data       []byte
+}
+
+type cronEvent struct {
+	Schedule string
+	Tick     time.Time
+}
+
+type queueEvent struct {
+	Queue   string
+	Message uint64
+	Data    []byte
+	Attempt uint32
+}
+
+type databaseEvent struct {
+	Database string
+	Change   kvdb.Event
+}
+
 var (
-	AttachedTestFunctions     = make(map[string]int)
-	CalledTestFunctionsPubsub = make([]pubsubIface.Message, 0)
-	CalledTestFunctionsP2P    = make([]command.Body, 0)
-	CalledTestFunctionsHttp   = make([]httpEvent, 0)
+	AttachedTestFunctions       = make(map[string]int)
+	CalledTestFunctionsPubsub   = make([]pubsubIface.Message, 0)
+	CalledTestFunctionsP2P      = make([]command.Body, 0)
+	CalledTestFunctionsHttp     = make([]httpEvent, 0)
+	CalledTestFunctionsWS       = make([]webSocketEvent, 0)
+	CalledTestFunctionsCron     = make([]cronEvent, 0)
+	CalledTestFunctionsQueue    = make([]queueEvent, 0)
+	CalledTestFunctionsDatabase = make([]databaseEvent, 0)
 )
 
 func RefreshTestVariables() {
@@ -26,6 +56,10 @@ func RefreshTestVariables() {
 	CalledTestFunctionsPubsub = make([]pubsubIface.Message, 0)
 	CalledTestFunctionsP2P = make([]command.Body, 0)
 	CalledTestFunctionsHttp = make([]httpEvent, 0)
+	CalledTestFunctionsWS = make([]webSocketEvent, 0)
+	CalledTestFunctionsCron = make([]cronEvent, 0)
+	CalledTestFunctionsQueue = make([]queueEvent, 0)
+	CalledTestFunctionsDatabase = make([]databaseEvent, 0)
 }
 
 func CheckAttached(t *testing.T, expected map[string]int) bool {
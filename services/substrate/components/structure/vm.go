@@ -53,6 +53,14 @@ func (*testRuntime) Close() error {
 	return nil
 }
 
+func (*testRuntime) Fuel() uint64 {
+	return 0
+}
+
+func (*testRuntime) Stats() vm.RuntimeStats {
+	return vm.RuntimeStats{}
+}
+
 func (*testRuntime) Module(name string) (vm.ModuleInstance, error) {
 	v, ok := AttachedTestFunctions[name]
 	if !ok {
@@ -0,0 +1,16 @@
+package common
+
+// MatchDefinition identifies a function run by a trigger.
+type MatchDefinition struct {
+	Project     string
+	Application string
+	Function    string
+}
+
+func (m *MatchDefinition) String() string {
+	return m.Project + m.Application + m.Function
+}
+
+func (m *MatchDefinition) CachePrefix() string {
+	return m.Project
+}
@@ -0,0 +1,135 @@
+package trigger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/taubyte/tau/p2p/streams/client"
+	"github.com/taubyte/tau/p2p/streams/command"
+	"github.com/taubyte/tau/pkg/raft"
+	protocolCommon "github.com/taubyte/tau/services/common"
+	"github.com/taubyte/tau/services/substrate/components/trigger/common"
+	"github.com/taubyte/tau/services/substrate/components/trigger/function"
+	counter "github.com/taubyte/tau/services/substrate/runtime/counter"
+	"github.com/taubyte/tau/services/substrate/runtime/lookup"
+)
+
+// Members returns the members of the cluster to try, in turn, to run what key
+// identifies. The order depends on key, so runs are spread across members.
+// The node is the only member while the members of the cluster are unknown.
+func (s *Service) Members(key string) []peer.ID {
+	var members []raft.Member
+	if cluster := s.Raft(); cluster != nil {
+		members, _ = cluster.Members()
+	}
+
+	peers := make([]peer.ID, 0, len(members))
+	for _, m := range members {
+		peers = append(peers, m.ID)
+	}
+
+	if len(peers) == 0 {
+		peers = append(peers, s.Node().ID())
+	}
+
+	return order(peers, key)
+}
+
+func order(peers []peer.ID, key string) []peer.ID {
+	slices.Sort(peers)
+
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	start := int(h.Sum32() % uint32(len(peers)))
+
+	return append(peers[start:], peers[:start]...)
+}
+
+// Member reports whether pid is a member of the cluster, which is where
+// functions are triggered from.
+func (s *Service) Member(pid peer.ID) bool {
+	cluster := s.Raft()
+	if cluster == nil {
+		return false
+	}
+
+	members, err := cluster.Members()
+	if err != nil {
+		return false
+	}
+
+	return slices.ContainsFunc(members, func(m raft.Member) bool { return m.ID == pid })
+}
+
+// Send sends cmd with body to pid over the substrate protocol.
+func (s *Service) Send(cmd string, body command.Body, pid peer.ID) error {
+	c, err := s.substrateClient()
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Send(cmd, body, pid)
+	return err
+}
+
+func (s *Service) substrateClient() (*client.Client, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.client == nil {
+		c, err := client.New(s.Node(), protocolCommon.SubstrateProtocol)
+		if err != nil {
+			return nil, fmt.Errorf("creating substrate client failed with: %w", err)
+		}
+		s.client = c
+	}
+
+	return s.client, nil
+}
+
+// Ambiguous reports whether err, returned by Send, leaves unknown if the
+// peer got the command.
+func Ambiguous(err error) bool {
+	var netErr net.Error
+	return errors.Is(err, os.ErrDeadlineExceeded) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		(errors.As(err, &netErr) && netErr.Timeout())
+}
+
+// Run runs the function of matcher for the event that event creates in the
+// background, once the function is found and ready. done is called with the
+// error the run ended with.
+func (s *Service) Run(matcher *common.MatchDefinition, event function.Event, done func(error)) error {
+	picks, err := lookup.Lookup(s, matcher)
+	if err != nil {
+		return fmt.Errorf("%s serviceable lookup failed with: %w", s.typ, err)
+	}
+
+	if len(picks) != 1 {
+		return fmt.Errorf("unexpected %d picks for matcher %v", len(picks), matcher)
+	}
+
+	pick, ok := picks[0].(*function.Function)
+	if !ok {
+		return fmt.Errorf("matched serviceable is not a %s function", s.typ)
+	}
+
+	if err = pick.Ready(); err != nil {
+		return fmt.Errorf("%s function is not ready with: %w", s.typ, err)
+	}
+
+	go func() {
+		start := time.Now()
+		coldStartDone, err := pick.Handle(event)
+		done(counter.ErrorWrapper(pick, start, coldStartDone, err))
+	}()
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package trigger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+func TestAmbiguous(t *testing.T) {
+	for _, err := range []error{
+		fmt.Errorf("command %q timed out: %w", "cron", os.ErrDeadlineExceeded),
+		fmt.Errorf("command %q returned error: %w", "cron", context.DeadlineExceeded),
+	} {
+		if !Ambiguous(err) {
+			t.Fatalf("%s should leave delivery unknown", err)
+		}
+	}
+
+	for _, err := range []error{
+		errors.New("peer QmPeer returned error for command"),
+		fmt.Errorf("dial failed with: %w", context.Canceled),
+	} {
+		if Ambiguous(err) {
+			t.Fatalf("%s should not leave delivery unknown", err)
+		}
+	}
+}
+
+func TestOrder(t *testing.T) {
+	peers := []peer.ID{"c", "a", "b"}
+
+	starts := make(map[peer.ID]bool)
+	for i := range 32 {
+		key := fmt.Sprintf("key-%d", i)
+
+		ordered := order(slices.Clone(peers), key)
+		if !slices.Equal(ordered, order([]peer.ID{"b", "c", "a"}, key)) {
+			t.Fatalf("order of %s depends on the order of the members", key)
+		}
+
+		// members are tried in turn from where the key starts
+		rotated := slices.Clone(ordered)
+		for rotated[0] != "a" {
+			rotated = append(rotated[1:], rotated[0])
+		}
+		if !slices.Equal(rotated, []peer.ID{"a", "b", "c"}) {
+			t.Fatalf("order of %s is %v", key, ordered)
+		}
+
+		starts[ordered[0]] = true
+	}
+
+	if len(starts) != len(peers) {
+		t.Errorf("keys start on %d of %d members", len(starts), len(peers))
+	}
+}
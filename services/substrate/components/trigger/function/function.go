@@ -0,0 +1,102 @@
+package function
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/taubyte/tau/core/services/substrate/components"
+	matcherSpec "github.com/taubyte/tau/pkg/specs/matcher"
+	structureSpec "github.com/taubyte/tau/pkg/specs/structure"
+	"github.com/taubyte/tau/services/substrate/components/trigger/common"
+)
+
+func (f *Function) Commit() string {
+	return f.commit
+}
+
+func (f *Function) Branch() string {
+	return f.branch
+}
+
+func (f *Function) Project() string {
+	return f.matcher.Project
+}
+
+func (f *Function) Application() string {
+	return f.matcher.Application
+}
+
+// Handle runs the function for the event that event creates.
+func (f *Function) Handle(event Event) (t time.Time, err error) {
+	instance, err := f.Instantiate(f.instanceCtx)
+	if err != nil {
+		return t, fmt.Errorf("instantiating function `%s` on project `%s` on application `%s` failed with: %w", f.config.Name, f.matcher.Project, f.matcher.Application, err)
+	}
+	defer instance.Free()
+
+	ev := event(instance.SDK(), &f.config)
+
+	return time.Now(), f.Call(instance, ev.Id)
+}
+
+func (f *Function) Match(matcher components.MatchDefinition) matcherSpec.Index {
+	_matcher, ok := matcher.(*common.MatchDefinition)
+	if !ok {
+		return matcherSpec.NoMatch
+	}
+
+	if f.config.Type == f.typ && _matcher.Function == f.config.Id {
+		return matcherSpec.HighMatch
+	}
+
+	return matcherSpec.NoMatch
+}
+
+func (f *Function) Validate(matcher components.MatchDefinition) error {
+	if f.Match(matcher) != matcherSpec.HighMatch {
+		return fmt.Errorf("function is not the %s function matched", f.typ)
+	}
+
+	return nil
+}
+
+func (f *Function) Matcher() components.MatchDefinition {
+	return f.matcher
+}
+
+func (f *Function) Name() string {
+	return f.config.Name
+}
+
+func (f *Function) Id() string {
+	return f.config.Id
+}
+
+func (f *Function) Ready() error {
+	if !f.readyDone {
+		<-f.readyCtx.Done()
+	}
+
+	return f.readyError
+}
+
+func (f *Function) Config() *structureSpec.Function {
+	return &f.config
+}
+
+func (f *Function) Service() components.ServiceComponent {
+	return f.srv
+}
+
+func (f *Function) AssetId() string {
+	return f.assetId
+}
+
+func (f *Function) Close() {
+	f.closeOnce.Do(func() {
+		go func() {
+			f.Shutdown()
+			f.instanceCtxC()
+		}()
+	})
+}
@@ -0,0 +1,57 @@
+package function
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/taubyte/tau/core/services/substrate/components"
+	structureSpec "github.com/taubyte/tau/pkg/specs/structure"
+	"github.com/taubyte/tau/services/substrate/components/trigger/common"
+	"github.com/taubyte/tau/services/substrate/runtime"
+	"github.com/taubyte/tau/services/substrate/runtime/cache"
+)
+
+// New returns the function of config, which srv runs for the functions of
+// type typ.
+func New(srv components.ServiceComponent, typ string, config structureSpec.Function, commit, branch string, matcher *common.MatchDefinition) (components.Serviceable, error) {
+	f := &Function{
+		srv:     srv,
+		typ:     typ,
+		config:  config,
+		matcher: matcher,
+		commit:  commit,
+		branch:  branch,
+	}
+
+	f.instanceCtx, f.instanceCtxC = context.WithCancel(srv.Context())
+	f.readyCtx, f.readyCtxC = context.WithCancel(srv.Context())
+
+	var err error
+	defer func() {
+		f.readyError = err
+		f.readyDone = true
+		f.readyCtxC()
+	}()
+
+	if err = f.Validate(matcher); err != nil {
+		f.instanceCtxC()
+		return nil, fmt.Errorf("validating function with id: `%s` failed with: %w", f.config.Id, err)
+	}
+
+	if f.Function, err = runtime.New(f.instanceCtx, f); err != nil {
+		return nil, fmt.Errorf("initializing vm module failed with: %w", err)
+	}
+
+	if f.config.Source == "." {
+		f.assetId, err = cache.ResolveAssetCid(f)
+		if err != nil {
+			return nil, fmt.Errorf("getting asset id failed with: %w", err)
+		}
+	}
+
+	if _, err = srv.Cache().Add(f); err != nil {
+		return nil, fmt.Errorf("adding %s function serviceable failed with: %w", typ, err)
+	}
+
+	return f, nil
+}
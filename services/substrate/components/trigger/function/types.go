@@ -0,0 +1,43 @@
+package function
+
+import (
+	"context"
+	"sync"
+
+	"github.com/taubyte/tau/core/services/substrate/components"
+	structureSpec "github.com/taubyte/tau/pkg/specs/structure"
+	plugins "github.com/taubyte/tau/pkg/vm-low-orbit"
+	"github.com/taubyte/tau/pkg/vm-low-orbit/event"
+	"github.com/taubyte/tau/services/substrate/components/trigger/common"
+	"github.com/taubyte/tau/services/substrate/runtime"
+)
+
+var _ components.FunctionServiceable = &Function{}
+
+// Event creates, in the sdk of an instance of the function of config, the
+// event a call of the function handles.
+type Event func(sdk plugins.Instance, config *structureSpec.Function) *event.Event
+
+type Function struct {
+	srv    components.ServiceComponent
+	typ    string
+	config structureSpec.Function
+
+	assetId string
+
+	matcher *common.MatchDefinition
+	commit  string
+	branch  string
+
+	readyCtx   context.Context
+	readyCtxC  context.CancelFunc
+	readyError error
+	readyDone  bool
+
+	instanceCtx  context.Context
+	instanceCtxC context.CancelFunc
+
+	closeOnce sync.Once
+
+	*runtime.Function
+}
@@ -0,0 +1,57 @@
+package trigger
+
+import (
+	"context"
+	"time"
+
+	"github.com/taubyte/tau/pkg/raft"
+	"github.com/taubyte/tau/services/substrate/components/trigger/common"
+)
+
+// Start joins the cluster in the background, then calls the Leader that
+// newLeader returns for it every resolution, until the service is closed.
+// A service started without Cluster does nothing.
+func (s *Service) Start(resolution time.Duration, newLeader func(raft.Cluster) Leader) {
+	if s.namespace == "" {
+		return
+	}
+
+	go func() {
+		cluster, err := raft.New(s.Node(), s.namespace, s.raftOptions...)
+		if err != nil {
+			common.Logger.Errorf("starting raft cluster `%s` failed with: %s", s.namespace, err.Error())
+			return
+		}
+
+		s.lock.Lock()
+		if s.ctx.Err() != nil {
+			s.lock.Unlock()
+			cluster.Close()
+			return
+		}
+		s.cluster = cluster
+		s.lock.Unlock()
+
+		lead(s.ctx, cluster, resolution, newLeader(cluster))
+	}()
+}
+
+func lead(ctx context.Context, cluster raft.Cluster, resolution time.Duration, l Leader) {
+	ticker := time.NewTicker(resolution)
+	defer ticker.Stop()
+	// nothing led outlives the service
+	defer l.Follow()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if cluster.IsLeader() {
+				l.Lead(ctx)
+			} else {
+				l.Follow()
+			}
+		}
+	}
+}
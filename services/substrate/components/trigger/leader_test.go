@@ -0,0 +1,85 @@
+package trigger
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/taubyte/tau/pkg/raft"
+)
+
+// follower is a cluster member that does not lead.
+type follower struct {
+	*raft.MockCluster
+}
+
+func (follower) IsLeader() bool { return false }
+
+type testLeader struct {
+	lock    sync.Mutex
+	leads   int
+	follows int
+}
+
+func (l *testLeader) Lead(context.Context) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.leads++
+}
+
+func (l *testLeader) Follow() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.follows++
+}
+
+func (l *testLeader) counts() (int, int) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.leads, l.follows
+}
+
+func runLead(t *testing.T, cluster raft.Cluster) *testLeader {
+	ctx, cancel := context.WithCancel(context.Background())
+	l := &testLeader{}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		lead(ctx, cluster, time.Millisecond, l)
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("lead did not return once the service was closed")
+	}
+
+	return l
+}
+
+func TestLeadLeads(t *testing.T) {
+	leads, follows := runLead(t, raft.NewMockCluster()).counts()
+	if leads == 0 {
+		t.Error("the leader did not lead")
+	}
+
+	if follows != 1 {
+		t.Errorf("the leader followed %d times, once when closed expected", follows)
+	}
+}
+
+func TestLeadFollows(t *testing.T) {
+	leads, follows := runLead(t, follower{raft.NewMockCluster()}).counts()
+	if leads != 0 {
+		t.Errorf("a follower led %d times", leads)
+	}
+
+	if follows < 2 {
+		t.Errorf("a follower followed %d times", follows)
+	}
+}
@@ -0,0 +1,38 @@
+package trigger
+
+import (
+	"fmt"
+
+	iface "github.com/taubyte/tau/core/services/substrate/components"
+	spec "github.com/taubyte/tau/pkg/specs/common"
+	"github.com/taubyte/tau/services/substrate/components/trigger/common"
+	"github.com/taubyte/tau/services/substrate/components/trigger/function"
+)
+
+func (s *Service) CheckTns(matcherIface iface.MatchDefinition) ([]iface.Serviceable, error) {
+	matcher, ok := matcherIface.(*common.MatchDefinition)
+	if !ok {
+		return nil, fmt.Errorf("matcher not correct type expected (%T) got (%T)", new(common.MatchDefinition), matcherIface)
+	}
+
+	functions, commit, branch, err := s.Tns().Function().All(matcher.Project, matcher.Application, spec.DefaultBranches...).List()
+	if err != nil {
+		return nil, fmt.Errorf("listing functions failed with: %w", err)
+	}
+
+	config, ok := functions[matcher.Function]
+	if !ok || config.Type != s.typ {
+		return nil, fmt.Errorf("no %s function matches `%s`", s.typ, matcher.String())
+	}
+
+	// listed functions are keyed by id, which they do not carry
+	fn := *config
+	fn.Id = matcher.Function
+
+	serv, err := function.New(s, s.typ, fn, commit, branch, matcher)
+	if err != nil {
+		return nil, fmt.Errorf("creating %s function `%s` failed with: %w", s.typ, matcher.Function, err)
+	}
+
+	return []iface.Serviceable{serv}, nil
+}
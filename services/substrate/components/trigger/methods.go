@@ -0,0 +1,42 @@
+package trigger
+
+import (
+	"context"
+
+	iface "github.com/taubyte/tau/core/services/substrate/components"
+	"github.com/taubyte/tau/pkg/raft"
+)
+
+func (s *Service) Cache() iface.Cache {
+	return s.cache
+}
+
+func (s *Service) Context() context.Context {
+	return s.Node().Context()
+}
+
+// Raft returns the cluster of the service, or nil until it is started.
+func (s *Service) Raft() raft.Cluster {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.cluster
+}
+
+func (s *Service) Close() error {
+	s.ctxC()
+	s.cache.Close()
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.client != nil {
+		s.client.Close()
+	}
+
+	if s.cluster != nil {
+		return s.cluster.Close()
+	}
+
+	return nil
+}
@@ -0,0 +1,27 @@
+package trigger
+
+import (
+	"context"
+
+	"github.com/taubyte/tau/core/services/substrate"
+	"github.com/taubyte/tau/services/substrate/runtime/cache"
+)
+
+// New returns a service running the functions of type typ.
+func New(srv substrate.Service, typ string, options ...Option) (*Service, error) {
+	s := &Service{
+		Service: srv,
+		cache:   cache.New(),
+		typ:     typ,
+	}
+
+	for _, opt := range options {
+		if err := opt(s); err != nil {
+			return nil, err
+		}
+	}
+
+	s.ctx, s.ctxC = context.WithCancel(srv.Context())
+
+	return s, nil
+}
@@ -0,0 +1,14 @@
+package trigger
+
+import "github.com/taubyte/tau/pkg/raft"
+
+// Cluster makes the node a member of the raft cluster of namespace, from
+// which the functions are triggered. The cluster is started in the
+// background, as forming it waits for peers.
+func Cluster(namespace string, options ...raft.Option) Option {
+	return func(s *Service) error {
+		s.namespace = namespace
+		s.raftOptions = options
+		return nil
+	}
+}
@@ -0,0 +1,65 @@
+package trigger
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/taubyte/tau/core/services/tns"
+	spec "github.com/taubyte/tau/pkg/specs/common"
+)
+
+// Keys returns the keys of TNS under prefix.
+func Keys(client tns.Client, prefix ...string) ([]string, error) {
+	keys, err := client.Lookup(tns.Query{Prefix: prefix})
+	if err != nil {
+		return nil, err
+	}
+
+	list, ok := keys.([]string)
+	if !ok {
+		return nil, fmt.Errorf("lookup returned %T", keys)
+	}
+
+	return list, nil
+}
+
+// Split returns the segments of a TNS key.
+func Split(key string) []string {
+	return strings.Split(strings.Trim(key, "/"), "/")
+}
+
+// ProjectIds returns the projects with a current commit among keys, which
+// are of the form /projects/<id>/branches/<branch>/current/...
+func ProjectIds(keys []string) []string {
+	ids := make([]string, 0)
+	for _, key := range keys {
+		p := Split(key)
+		if len(p) < 5 || p[0] != spec.ProjectPathVariable.String() || p[2] != spec.BranchPathVariable.String() || p[4] != spec.CurrentCommitPathVariable.String() {
+			continue
+		}
+
+		if !slices.Contains(ids, p[1]) {
+			ids = append(ids, p[1])
+		}
+	}
+
+	return ids
+}
+
+// Applications returns the applications of the project at prefix, among keys.
+func Applications(prefix []string, keys []string) []string {
+	apps := make([]string, 0)
+	for _, key := range keys {
+		p := Split(key)
+		if len(p) <= len(prefix)+1 || !slices.Equal(p[:len(prefix)], prefix) || p[len(prefix)] != spec.ApplicationPathVariable.String() {
+			continue
+		}
+
+		if app := p[len(prefix)+1]; !slices.Contains(apps, app) {
+			apps = append(apps, app)
+		}
+	}
+
+	return apps
+}
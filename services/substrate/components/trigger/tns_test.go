@@ -0,0 +1,37 @@
+package trigger
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestProjectIds(t *testing.T) {
+	ids := ProjectIds([]string{
+		"/projects/p1/branches/main/current/commit",
+		"/projects/p1/branches/dev/current/commit",
+		"/projects/p2/branches/main/current/commit",
+		"/projects/p3/branches/main",
+		"/projects/p4/other/main/current/commit",
+	})
+
+	if !slices.Equal(ids, []string{"p1", "p2"}) {
+		t.Errorf("got %v", ids)
+	}
+}
+
+func TestApplications(t *testing.T) {
+	prefix := []string{"branches", "main", "commit", "c1", "projects", "p1"}
+
+	apps := Applications(prefix, []string{
+		"/branches/main/commit/c1/projects/p1/functions/f1/type",
+		"/branches/main/commit/c1/projects/p1/applications/a1/functions/f2/type",
+		"/branches/main/commit/c1/projects/p1/applications/a1/databases/d1/match",
+		"/branches/main/commit/c1/projects/p1/applications/a2/functions/f3/type",
+		"/branches/main/commit/c2/projects/p1/applications/a3/functions/f4/type",
+		"/branches/main/commit/c1/projects/p1/applications",
+	})
+
+	if !slices.Equal(apps, []string{"a1", "a2"}) {
+		t.Errorf("got %v", apps)
+	}
+}
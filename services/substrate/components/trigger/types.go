@@ -0,0 +1,42 @@
+package trigger
+
+import (
+	"context"
+	"sync"
+
+	"github.com/taubyte/tau/core/services/substrate"
+	"github.com/taubyte/tau/p2p/streams/client"
+	"github.com/taubyte/tau/pkg/raft"
+	"github.com/taubyte/tau/services/substrate/runtime/cache"
+)
+
+// Service runs the functions of a type that are triggered from a raft
+// cluster, rather than by a request. When started with Cluster, the node
+// joins the cluster, whose leader decides when the functions run and on which
+// member.
+type Service struct {
+	substrate.Service
+	cache *cache.Cache
+	typ   string
+
+	namespace   string
+	raftOptions []raft.Option
+
+	ctx  context.Context
+	ctxC context.CancelFunc
+
+	lock    sync.Mutex
+	cluster raft.Cluster
+	client  *client.Client
+}
+
+type Option func(*Service) error
+
+// Leader runs on every member of the cluster, and is called every resolution
+// with whether the node leads it.
+type Leader interface {
+	// Lead is called while the node is the leader of the cluster.
+	Lead(ctx context.Context)
+	// Follow is called while another node is, or no node is, the leader.
+	Follow()
+}
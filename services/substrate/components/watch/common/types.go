@@ -0,0 +1,6 @@
+package common
+
+import "github.com/taubyte/tau/services/substrate/components/trigger/common"
+
+// MatchDefinition identifies a database function.
+type MatchDefinition = common.MatchDefinition
@@ -0,0 +1,5 @@
+package common
+
+import "github.com/ipfs/go-log/v2"
+
+var Logger = log.Logger("tau.substrate.service.watch")
@@ -0,0 +1,78 @@
+package watch
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/taubyte/tau/clients/p2p/substrate"
+	"github.com/taubyte/tau/core/kvdb"
+	dbIface "github.com/taubyte/tau/core/services/substrate/components/database"
+	"github.com/taubyte/tau/p2p/streams/command"
+	structureSpec "github.com/taubyte/tau/pkg/specs/structure"
+	plugins "github.com/taubyte/tau/pkg/vm-low-orbit"
+	"github.com/taubyte/tau/pkg/vm-low-orbit/event"
+	triggerComponent "github.com/taubyte/tau/services/substrate/components/trigger"
+	"github.com/taubyte/tau/services/substrate/components/watch/common"
+)
+
+// watch streams the changes to the keys of the database of t under its prefix.
+func (s *Service) watch(ctx context.Context, t *trigger) (<-chan kvdb.Event, error) {
+	db, err := s.database.Database(dbIface.Context{
+		ProjectId:     t.Project,
+		ApplicationId: t.Application,
+		Matcher:       t.Database,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("opening database `%s` failed with: %w", t.Database, err)
+	}
+
+	return db.KV().Watch(ctx, t.Prefix)
+}
+
+// dispatch runs the function of t for change on a member of the cluster.
+// Members are tried in turn until one of them takes it. A member that timed
+// out may be running the function all the same, so it counts as having taken
+// the change.
+func (s *Service) dispatch(t *trigger, change kvdb.Event) {
+	for _, pid := range s.Members(t.key() + "\x00" + change.Key) {
+		err := s.send(pid, t, change)
+		if err == nil {
+			return
+		} else if triggerComponent.Ambiguous(err) {
+			common.Logger.Warnf("running `%s` for %s of `%s` on %s timed out, it may have run: %s", t.Function, change.Type, change.Key, pid.String(), err.Error())
+			return
+		}
+
+		common.Logger.Warnf("running `%s` for %s of `%s` on %s failed with: %s", t.Function, change.Type, change.Key, pid.String(), err.Error())
+	}
+
+	common.Logger.Errorf("no node ran `%s` for %s of `%s`", t.Function, change.Type, change.Key)
+}
+
+func (s *Service) send(pid peer.ID, t *trigger, change kvdb.Event) error {
+	if pid == s.Node().ID() {
+		return s.Run(&t.MatchDefinition, change)
+	}
+
+	return s.Send(substrate.CommandDatabase, command.Body{
+		substrate.BodyProject:     t.Project,
+		substrate.BodyApplication: t.Application,
+		substrate.BodyFunction:    t.Function,
+		substrate.BodyKey:         change.Key,
+		substrate.BodyOp:          int(change.Type),
+		substrate.BodyData:        change.Value,
+	}, pid)
+}
+
+// Run runs the database function of matcher for change in the background,
+// once it is found and ready.
+func (s *Service) Run(matcher *common.MatchDefinition, change kvdb.Event) error {
+	return s.Service.Run(matcher, func(sdk plugins.Instance, config *structureSpec.Function) *event.Event {
+		return sdk.CreateDatabaseEvent(config.Database, change)
+	}, func(err error) {
+		if err != nil {
+			common.Logger.Errorf("running function `%s` for %s of `%s` failed with: %s", matcher.Function, change.Type, change.Key, err.Error())
+		}
+	})
+}
@@ -0,0 +1,25 @@
+package watch
+
+import (
+	"github.com/taubyte/tau/core/services/substrate"
+	dbIface "github.com/taubyte/tau/core/services/substrate/components/database"
+	"github.com/taubyte/tau/pkg/raft"
+	functionSpec "github.com/taubyte/tau/pkg/specs/function"
+	triggerComponent "github.com/taubyte/tau/services/substrate/components/trigger"
+)
+
+// New returns the service running database functions, watching the databases
+// of database through the node's database component.
+func New(srv substrate.Service, database dbIface.Service, options ...triggerComponent.Option) (*Service, error) {
+	t, err := triggerComponent.New(srv, functionSpec.TypeDatabase, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Service{Service: t, database: database}
+	s.Start(Resolution, func(raft.Cluster) triggerComponent.Leader {
+		return newWatcher(s.triggers, s.watch, s.dispatch)
+	})
+
+	return s, nil
+}
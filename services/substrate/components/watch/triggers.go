@@ -0,0 +1,68 @@
+package watch
+
+import (
+	"fmt"
+	"strings"
+
+	spec "github.com/taubyte/tau/pkg/specs/common"
+	functionSpec "github.com/taubyte/tau/pkg/specs/function"
+	"github.com/taubyte/tau/pkg/specs/methods"
+	triggerComponent "github.com/taubyte/tau/services/substrate/components/trigger"
+	"github.com/taubyte/tau/services/substrate/components/watch/common"
+)
+
+// trigger is a database function, and the keys of the database whose changes
+// it receives.
+type trigger struct {
+	common.MatchDefinition
+	Database string
+	Prefix   string
+}
+
+// key identifies what the trigger watches, so that a function whose database
+// or prefix changed is watched again.
+func (t *trigger) key() string {
+	return strings.Join([]string{t.Project, t.Application, t.Function, t.Database, t.Prefix}, "\x00")
+}
+
+// triggers returns the database functions of the current commit of every
+// project.
+func (s *Service) triggers() ([]*trigger, error) {
+	keys, err := triggerComponent.Keys(s.Tns(), spec.ProjectPathVariable.String())
+	if err != nil {
+		return nil, fmt.Errorf("listing projects failed with: %w", err)
+	}
+
+	triggers := make([]*trigger, 0)
+	for _, project := range triggerComponent.ProjectIds(keys) {
+		commit, branch, err := s.Tns().Simple().Commit(project, spec.DefaultBranches...)
+		if err != nil {
+			continue
+		}
+
+		prefix := methods.ProjectPrefix(project, branch, commit)
+		keys, err := triggerComponent.Keys(s.Tns(), prefix.Slice()...)
+		if err != nil {
+			common.Logger.Errorf("listing keys of project `%s` failed with: %s", project, err.Error())
+			continue
+		}
+
+		for _, app := range append([]string{""}, triggerComponent.Applications(prefix.Slice(), keys)...) {
+			// a scope without functions has nothing to list
+			functions, _, _, _ := s.Tns().Function().Relative(project, app, branch).List()
+			for id, fn := range functions {
+				if fn.Type != functionSpec.TypeDatabase || fn.Database == "" {
+					continue
+				}
+
+				triggers = append(triggers, &trigger{
+					MatchDefinition: common.MatchDefinition{Project: project, Application: app, Function: id},
+					Database:        fn.Database,
+					Prefix:          fn.Prefix,
+				})
+			}
+		}
+	}
+
+	return triggers, nil
+}
@@ -0,0 +1,13 @@
+package watch
+
+import "testing"
+
+func TestTriggerKey(t *testing.T) {
+	a := &trigger{Database: "db", Prefix: "/users"}
+	b := &trigger{Database: "db", Prefix: "/users/"}
+	a.Function, b.Function = "fn", "fn"
+
+	if a.key() == b.key() {
+		t.Error("triggers watching different prefixes share a key")
+	}
+}
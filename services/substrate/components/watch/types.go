@@ -0,0 +1,14 @@
+package watch
+
+import (
+	dbIface "github.com/taubyte/tau/core/services/substrate/components/database"
+	triggerComponent "github.com/taubyte/tau/services/substrate/components/trigger"
+)
+
+// Service runs database functions. When started with triggerComponent.Cluster, the
+// node also joins a raft cluster whose leader watches, for the whole cloud,
+// the databases of database functions, and hands each change to one member.
+type Service struct {
+	*triggerComponent.Service
+	database dbIface.Service
+}
@@ -0,0 +1,12 @@
+package watch
+
+import "time"
+
+var (
+	// Resolution is how often members check whether they lead, and the leader
+	// whether watches ended and must be started again.
+	Resolution = time.Second
+	// RefreshInterval is how often the leader refreshes the list of database
+	// functions from TNS.
+	RefreshInterval = time.Minute
+)
@@ -0,0 +1,140 @@
+package watch
+
+import (
+	"context"
+	"time"
+
+	"github.com/taubyte/tau/core/kvdb"
+	"github.com/taubyte/tau/services/substrate/components/watch/common"
+)
+
+// watching is the watch of the database of a trigger.
+type watching struct {
+	trigger *trigger
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+func (w *watching) ended() bool {
+	select {
+	case <-w.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// watcher runs on every member of the raft cluster, but only the leader
+// watches: it dispatches each change once, to a single member. Watches are
+// best effort, so changes made while the cluster has no leader, before a new
+// leader has started watching, or dropped because dispatching fell behind,
+// run no function.
+type watcher struct {
+	triggers func() ([]*trigger, error)
+	watch    func(ctx context.Context, t *trigger) (<-chan kvdb.Event, error)
+	dispatch func(t *trigger, change kvdb.Event)
+	now      func() time.Time
+
+	refreshed time.Time
+	active    map[string]*watching
+}
+
+func newWatcher(
+	triggers func() ([]*trigger, error),
+	watch func(context.Context, *trigger) (<-chan kvdb.Event, error),
+	dispatch func(*trigger, kvdb.Event),
+) *watcher {
+	return &watcher{
+		triggers: triggers,
+		watch:    watch,
+		dispatch: dispatch,
+		now:      time.Now,
+		active:   make(map[string]*watching),
+	}
+}
+
+// Follow stops watching, and makes the watcher refresh as soon as this node
+// becomes the leader.
+func (w *watcher) Follow() {
+	w.stop()
+	w.refreshed = time.Time{}
+}
+
+func (w *watcher) Lead(ctx context.Context) {
+	now := w.now()
+	if now.Sub(w.refreshed) >= RefreshInterval {
+		w.refresh(ctx, now)
+	}
+
+	for key, wt := range w.active {
+		if wt.ended() {
+			delete(w.active, key)
+			w.start(ctx, key, wt.trigger)
+		}
+	}
+}
+
+// refresh watches the triggers that are new, and stops watching the ones
+// that are gone.
+func (w *watcher) refresh(ctx context.Context, now time.Time) {
+	w.refreshed = now
+
+	triggers, err := w.triggers()
+	if err != nil {
+		common.Logger.Errorf("listing database functions failed with: %s", err.Error())
+		return
+	}
+
+	current := make(map[string]*trigger, len(triggers))
+	for _, t := range triggers {
+		current[t.key()] = t
+	}
+
+	for key, wt := range w.active {
+		if _, ok := current[key]; !ok {
+			wt.cancel()
+			delete(w.active, key)
+		}
+	}
+
+	for key, t := range current {
+		if _, ok := w.active[key]; !ok {
+			w.start(ctx, key, t)
+		}
+	}
+}
+
+// start watches the database of t. A trigger whose database can't be
+// watched is tried again on the next refresh.
+func (w *watcher) start(ctx context.Context, key string, t *trigger) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	changes, err := w.watch(ctx, t)
+	if err != nil {
+		cancel()
+		common.Logger.Errorf("watching database `%s` of function `%s` failed with: %s", t.Database, t.Function, err.Error())
+		return
+	}
+
+	wt := &watching{trigger: t, cancel: cancel, done: make(chan struct{})}
+	w.active[key] = wt
+
+	go func() {
+		defer close(wt.done)
+		for change := range changes {
+			// a stopped watch may still hold changes, which are no longer
+			// this node's to dispatch
+			if ctx.Err() != nil {
+				continue
+			}
+			w.dispatch(t, change)
+		}
+	}()
+}
+
+func (w *watcher) stop() {
+	for key, wt := range w.active {
+		wt.cancel()
+		delete(w.active, key)
+	}
+}
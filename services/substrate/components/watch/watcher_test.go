@@ -0,0 +1,205 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/taubyte/tau/core/kvdb"
+	"github.com/taubyte/tau/services/substrate/components/watch/common"
+)
+
+type dispatched struct {
+	function string
+	key      string
+}
+
+type testDatabases struct {
+	lock    sync.Mutex
+	changes map[string]chan kvdb.Event
+	fail    error
+}
+
+func (d *testDatabases) watch(ctx context.Context, t *trigger) (<-chan kvdb.Event, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if d.fail != nil {
+		return nil, d.fail
+	}
+
+	changes := make(chan kvdb.Event, 16)
+	d.changes[t.Function] = changes
+	go func() {
+		<-ctx.Done()
+		d.lock.Lock()
+		defer d.lock.Unlock()
+		if d.changes[t.Function] == changes {
+			delete(d.changes, t.Function)
+		}
+		close(changes)
+	}()
+
+	return changes, nil
+}
+
+func (d *testDatabases) put(t *testing.T, function, key string) {
+	t.Helper()
+
+	d.lock.Lock()
+	changes, ok := d.changes[function]
+	d.lock.Unlock()
+
+	if !ok {
+		t.Fatalf("database of %s is not watched", function)
+	}
+	changes <- kvdb.Event{Type: kvdb.EventPut, Key: key}
+}
+
+func (d *testDatabases) watched() int {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	return len(d.changes)
+}
+
+func newTestWatcher(t *testing.T, triggers ...*trigger) (*watcher, *testDatabases, chan dispatched, *time.Time) {
+	t.Helper()
+
+	dbs := &testDatabases{changes: make(map[string]chan kvdb.Event)}
+	changes := make(chan dispatched, 16)
+	now := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	w := newWatcher(func() ([]*trigger, error) {
+		return triggers, nil
+	}, dbs.watch, func(t *trigger, change kvdb.Event) {
+		changes <- dispatched{t.Function, change.Key}
+	})
+	w.now = func() time.Time { return now }
+
+	return w, dbs, changes, &now
+}
+
+func testTrigger(function string) *trigger {
+	return &trigger{
+		MatchDefinition: common.MatchDefinition{Project: "project", Function: function},
+		Database:        "db",
+		Prefix:          "/users",
+	}
+}
+
+func expectChanges(t *testing.T, changes chan dispatched, expected ...dispatched) {
+	t.Helper()
+
+	for _, e := range expected {
+		select {
+		case d := <-changes:
+			if d != e {
+				t.Fatalf("dispatched %s of %s, expected %s of %s", d.key, d.function, e.key, e.function)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("%s of %s was not dispatched", e.key, e.function)
+		}
+	}
+
+	select {
+	case d := <-changes:
+		t.Fatalf("unexpected dispatch of %s of %s", d.key, d.function)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func waitWatched(t *testing.T, dbs *testDatabases, count int) {
+	t.Helper()
+
+	for deadline := time.Now().Add(time.Second); dbs.watched() != count; time.Sleep(5 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatalf("%d databases watched, expected %d", dbs.watched(), count)
+		}
+	}
+}
+
+func TestWatcherDispatchesEachChangeOnce(t *testing.T) {
+	w, dbs, changes, _ := newTestWatcher(t, testTrigger("fn1"), testTrigger("fn2"))
+	defer w.stop()
+
+	w.Lead(context.Background())
+	w.Lead(context.Background())
+	waitWatched(t, dbs, 2)
+
+	dbs.put(t, "fn1", "/users/a")
+	expectChanges(t, changes, dispatched{"fn1", "/users/a"})
+
+	dbs.put(t, "fn2", "/users/b")
+	expectChanges(t, changes, dispatched{"fn2", "/users/b"})
+}
+
+func TestWatcherStopsWhenNotLeader(t *testing.T) {
+	w, dbs, _, _ := newTestWatcher(t, testTrigger("fn"))
+	w.Lead(context.Background())
+	waitWatched(t, dbs, 1)
+
+	w.Follow()
+	waitWatched(t, dbs, 0)
+
+	if !w.refreshed.IsZero() {
+		t.Error("a follower keeps its last refresh")
+	}
+}
+
+func TestWatcherStopsRemovedTriggers(t *testing.T) {
+	w, dbs, _, now := newTestWatcher(t, testTrigger("fn1"), testTrigger("fn2"))
+	defer w.stop()
+
+	w.Lead(context.Background())
+	waitWatched(t, dbs, 2)
+
+	w.triggers = func() ([]*trigger, error) { return []*trigger{testTrigger("fn2")}, nil }
+	*now = now.Add(RefreshInterval)
+	w.Lead(context.Background())
+	waitWatched(t, dbs, 1)
+
+	if _, ok := w.active[testTrigger("fn2").key()]; !ok {
+		t.Error("remaining trigger is no longer watched")
+	}
+}
+
+func TestWatcherRestartsEndedWatches(t *testing.T) {
+	w, dbs, changes, _ := newTestWatcher(t, testTrigger("fn"))
+	defer w.stop()
+
+	w.Lead(context.Background())
+	waitWatched(t, dbs, 1)
+
+	// the watch ends on its own, as when its subscription fails
+	w.active[testTrigger("fn").key()].cancel()
+	waitWatched(t, dbs, 0)
+	<-w.active[testTrigger("fn").key()].done
+
+	w.Lead(context.Background())
+	waitWatched(t, dbs, 1)
+
+	dbs.put(t, "fn", "/users/a")
+	expectChanges(t, changes, dispatched{"fn", "/users/a"})
+}
+
+func TestWatcherRetriesFailedWatchesOnRefresh(t *testing.T) {
+	w, dbs, _, now := newTestWatcher(t, testTrigger("fn"))
+	defer w.stop()
+
+	dbs.fail = errors.New("database not found")
+	w.Lead(context.Background())
+	if len(w.active) != 0 {
+		t.Fatal("a failed watch is active")
+	}
+
+	dbs.fail = nil
+	w.Lead(context.Background())
+	waitWatched(t, dbs, 0)
+
+	*now = now.Add(RefreshInterval)
+	w.Lead(context.Background())
+	waitWatched(t, dbs, 1)
+}
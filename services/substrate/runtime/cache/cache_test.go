@@ -94,6 +94,7 @@ func (m *mockTnsClient) Domain() tns.StructureIface[*structureSpec.Domain]
 func (m *mockTnsClient) Function() tns.StructureIface[*structureSpec.Function]   { return nil }
 func (m *mockTnsClient) Library() tns.StructureIface[*structureSpec.Library]     { return nil }
 func (m *mockTnsClient) Messaging() tns.StructureIface[*structureSpec.Messaging] { return nil }
+func (m *mockTnsClient) Queue() tns.StructureIface[*structureSpec.Queue]         { return nil }
 func (m *mockTnsClient) Service() tns.StructureIface[*structureSpec.Service]     { return nil }
 func (m *mockTnsClient) SmartOp() tns.StructureIface[*structureSpec.SmartOp]     { return nil }
 func (m *mockTnsClient) Storage() tns.StructureIface[*structureSpec.Storage]     { return nil }
@@ -1473,6 +1474,9 @@ func (m *mockTnsClientWithNonStringCid) Library() tns.StructureIface[*structureS
 func (m *mockTnsClientWithNonStringCid) Messaging() tns.StructureIface[*structureSpec.Messaging] {
 	return nil
 }
+func (m *mockTnsClientWithNonStringCid) Queue() tns.StructureIface[*structureSpec.Queue] {
+	return nil
+}
 func (m *mockTnsClientWithNonStringCid) Service() tns.StructureIface[*structureSpec.Service] {
 	return nil
 }
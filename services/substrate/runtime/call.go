@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/taubyte/tau/core/vm"
+	"github.com/taubyte/tau/services/substrate/runtime/counter"
 )
 
 var DebugFunctionCallsLogger vm.Logger
@@ -65,6 +66,14 @@ func (f *Function) Call(inst Instance, id uint32) (err error) {
 		if err != nil {
 			return fmt.Errorf("getting wasm function instance failed with: %w", err)
 		}
+
+		// the first call of a new instance comes right after its modules
+		// initialized: the state it resets to between calls
+		if f.config.Snapshot && ii.snapshot == nil {
+			if err = ii.takeSnapshot(); err != nil {
+				return fmt.Errorf("snapshotting instance failed with: %w", err)
+			}
+		}
 	} else {
 		module, err = inst.Module(moduleName)
 		if err != nil {
@@ -81,6 +90,9 @@ func (f *Function) Call(inst Instance, id uint32) (err error) {
 	defer ctxC()
 
 	_, err = fx.RawCall(ctx, uint64(id))
+	if internalInst, ok := inst.(*instance); ok {
+		counter.Fuel(f.serviceable, internalInst.collectStats().Fuel)
+	}
 	if f.serviceable.Service().Verbose() {
 		defer func() {
 			if internalInst, ok := inst.(*instance); ok {
@@ -83,3 +83,15 @@ func ErrorWrapper(serviceable components.Serviceable, startTime time.Time, coldS
 
 	return gerr
 }
+
+// Fuel reports the fuel a call of the serviceable burnt.
+func Fuel(serviceable components.Serviceable, used uint64) {
+	if used == 0 || !serviceable.Service().Counter().Implemented() {
+		return
+	}
+
+	serviceable.Service().Counter().Push(&counters.WrappedMetric{
+		Key:    counters.NewPath(path.Join(serviceable.Project(), serviceable.Id())).Fuel().String(),
+		Metric: metrics.NewSumMetric(used),
+	})
+}
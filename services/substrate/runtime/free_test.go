@@ -1,6 +1,7 @@
 package runtime
 
 import (
+	"sync/atomic"
 	"testing"
 
 	"github.com/taubyte/tau/core/vm"
@@ -89,6 +90,7 @@ func TestInstanceFree(t *testing.T) {
 			serviceable:        newMockServiceable(),
 			vmConfig:           &vm.Config{MemoryLimitPages: pages},
 			availableInstances: make(chan Instance, InstanceMaxRequests),
+			shutdown:           new(atomic.Bool),
 		}
 		return &instance{runtime: rt, parent: f}, f
 	}
@@ -2,6 +2,7 @@ package runtime
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"runtime"
@@ -47,6 +48,13 @@ func (i *instance) Free() error {
 		return nil
 	}
 
+	if i.snapshot != nil {
+		if err := i.restoreSnapshot(); err != nil {
+			i.Close()
+			return err
+		}
+	}
+
 	useMem, err := i.usedMemory()
 	if err != nil {
 		i.Close()
@@ -68,7 +76,10 @@ func (i *instance) Free() error {
 	}
 
 	i.pooled = true
-	i.parent.availableInstances <- i
+	i.idleSince = time.Now()
+	if !i.parent.pool(i) {
+		i.Close()
+	}
 	return nil
 }
 
@@ -116,6 +127,12 @@ func (i *instance) Ready() (Instance, error) {
 }
 
 func (i *instance) Close() error {
+	if i.closed {
+		return nil
+	}
+
+	i.closed = true
+	i.parent.live.Add(-1)
 	return i.runtime.Close()
 }
 
@@ -181,27 +198,34 @@ func (f *Function) intanceManager() {
 func (f *Function) processRequest(reqCh *instanceRequest) {
 	select {
 	case instance := <-f.availableInstances:
+		f.warmCalls.Add(1)
 		reqCh.ch <- instance
+		return
 	default:
-		// we need to instantiate a new instance
-		// hoever if that does not work we need to repush the request in a way that is it first in line
-		runtime, sdk, err := f.instantiate()
-		if err == nil {
-			reqCh.ch <- &instance{runtime: runtime, sdk: sdk, parent: f}
-		} else {
-			logger.Errorf("creating new instance failed with: %s", err.Error())
-			// we reached some sort of limit
-			// wait for an instance to be available
-			select {
-			case <-reqCh.ctx.Done():
-				reqCh.err = fmt.Errorf("instance request context done with: %w", reqCh.ctx.Err())
-				reqCh.ch <- nil
-			case instance := <-f.availableInstances:
-				reqCh.ch <- instance
-			case <-f.ctx.Done():
-				return
-			}
-		}
+	}
+
+	// we need to instantiate a new instance, unless the function runs as many
+	// as it may already
+	instance, err := f.newInstance()
+	if err == nil {
+		f.coldCalls.Add(1)
+		reqCh.ch <- instance
+		return
+	} else if !errors.Is(err, errAtCapacity) {
+		logger.Errorf("creating new instance failed with: %s", err.Error())
+	}
+
+	// we reached some sort of limit
+	// wait for an instance to be available
+	select {
+	case <-reqCh.ctx.Done():
+		reqCh.err = fmt.Errorf("instance request context done with: %w", reqCh.ctx.Err())
+		reqCh.ch <- nil
+	case instance := <-f.availableInstances:
+		f.warmCalls.Add(1)
+		reqCh.ch <- instance
+	case <-f.ctx.Done():
+		return
 	}
 }
 
@@ -317,6 +341,13 @@ func (f *Function) configureVM() error {
 			),
 		}
 
+		if f.config.Fuel > 0 {
+			f.vmConfig.Fuel = uint64(f.config.Fuel)
+		}
+
+		// snapshots reset the globals modules keep too
+		f.vmConfig.ExportGlobals = f.config.Snapshot
+
 		if f.serviceable.Service().Verbose() {
 			f.vmConfig.Output = vm.Buffer
 		}
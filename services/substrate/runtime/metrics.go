@@ -3,6 +3,8 @@ package runtime
 import (
 	"sync/atomic"
 	"time"
+
+	"github.com/taubyte/tau/core/vm"
 )
 
 func (f *Function) averageDuration(duration *atomic.Int64, count *atomic.Uint64) time.Duration {
@@ -17,10 +19,52 @@ func (f *Function) ColdStart() time.Duration {
 	return f.averageDuration(f.totalColdStart, f.coldStarts)
 }
 
+// WarmCalls returns the number of calls served by a pooled instance.
+func (f *Function) WarmCalls() uint64 {
+	return f.warmCalls.Load()
+}
+
+// ColdCalls returns the number of calls that had to wait for a new instance.
+func (f *Function) ColdCalls() uint64 {
+	return f.coldCalls.Load()
+}
+
 func (f *Function) MemoryMax() uint64 {
 	return uint64(f.maxMemory.Load())
 }
 
+// Fuel returns the fuel a call burns on average, out of fuel calls included.
+func (f *Function) Fuel() uint64 {
+	if stats := f.stats.get(); stats.Calls > 0 {
+		return stats.Fuel / stats.Calls
+	}
+
+	return 0
+}
+
+// Stats returns the stats of the function's runtimes, summed.
+func (f *Function) Stats() vm.RuntimeStats {
+	return f.stats.get()
+}
+
+// CompileTime returns the time a cold start spends compiling, on average.
+func (f *Function) CompileTime() time.Duration {
+	if n := f.coldStarts.Load(); n > 0 {
+		return f.stats.get().CompileTime / time.Duration(n)
+	}
+
+	return 0
+}
+
+// InstantiateTime returns the time a cold start spends instantiating, on average.
+func (f *Function) InstantiateTime() time.Duration {
+	if n := f.coldStarts.Load(); n > 0 {
+		return f.stats.get().InstantiateTime / time.Duration(n)
+	}
+
+	return 0
+}
+
 func (f *Function) CallTime() time.Duration {
 	return f.averageDuration(f.totalCallTime, f.calls)
 }
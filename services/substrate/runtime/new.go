@@ -24,6 +24,7 @@ func New(ctx context.Context, serviceable components.FunctionServiceable) (*Func
 			calls:          new(atomic.Uint64),
 			totalCallTime:  new(atomic.Int64),
 			maxMemory:      new(atomic.Uint64),
+			stats:          new(stats),
 
 			instanceReqs:       make(chan *instanceRequest, InstanceMaxRequests),
 			availableInstances: make(chan Instance, InstanceMaxRequests),
@@ -32,6 +33,9 @@ func New(ctx context.Context, serviceable components.FunctionServiceable) (*Func
 		}
 
 		go dFunc.intanceManager()
+		if config.MinIdle > 0 || config.IdleTimeout > 0 {
+			go dFunc.warmer()
+		}
 
 		return dFunc, nil
 	}
@@ -0,0 +1,226 @@
+package runtime
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/taubyte/tau/core/vm"
+)
+
+var errAtCapacity = errors.New("function runs as many instances as its max-concurrency allows")
+
+// newInstance creates an instance of the function. It takes its slot out of
+// max-concurrency before instantiating, and gives it back if that fails, so
+// concurrent callers cannot go past the limit.
+func (f *Function) newInstance() (*instance, error) {
+	if !f.reserve() {
+		return nil, errAtCapacity
+	}
+
+	rt, sdk, err := f.instantiate()
+	if err != nil {
+		f.live.Add(-1)
+		return nil, err
+	}
+
+	return &instance{runtime: rt, sdk: sdk, parent: f}, nil
+}
+
+// reserve counts one more live instance, unless the function already runs as
+// many as its max-concurrency allows.
+func (f *Function) reserve() bool {
+	n := f.live.Add(1)
+	if f.config.MaxConcurrency > 0 && n > int64(f.config.MaxConcurrency) {
+		f.live.Add(-1)
+		return false
+	}
+
+	return true
+}
+
+// pool returns inst to the available instances, reporting whether it did.
+func (f *Function) pool(inst *instance) bool {
+	f.shutdownMu.RLock()
+	defer f.shutdownMu.RUnlock()
+
+	if f.shutdown.Load() {
+		return false
+	}
+
+	select {
+	case f.availableInstances <- inst:
+		return true
+	default:
+		return false
+	}
+}
+
+// warmer keeps min-idle instances warm and evicts those idle past the
+// idle-timeout, until the function is done.
+func (f *Function) warmer() {
+	ticker := time.NewTicker(WarmInterval)
+	defer ticker.Stop()
+
+	for {
+		f.evictIdle()
+		f.warm()
+
+		select {
+		case <-f.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// warm pre-warms instances until min-idle of them are available.
+func (f *Function) warm() {
+	for len(f.availableInstances) < f.config.MinIdle && !f.shutdown.Load() {
+		inst, err := f.newInstance()
+		if errors.Is(err, errAtCapacity) {
+			return
+		} else if err != nil {
+			logger.Errorf("pre-warming an instance of `%s` failed with: %s", f.serviceable.Id(), err.Error())
+			return
+		}
+
+		if err = inst.prepare(); err != nil {
+			logger.Errorf("pre-warming an instance of `%s` failed with: %s", f.serviceable.Id(), err.Error())
+			inst.Close()
+			return
+		}
+
+		inst.pooled = true
+		inst.idleSince = time.Now()
+		if !f.pool(inst) {
+			inst.Close()
+			return
+		}
+	}
+}
+
+// evictIdle closes the available instances past min-idle that have been idle
+// longer than the idle-timeout.
+func (f *Function) evictIdle() {
+	if f.config.IdleTimeout == 0 {
+		return
+	}
+
+	timeout := time.Duration(f.config.IdleTimeout)
+	kept := 0
+	for n := len(f.availableInstances); n > 0; n-- {
+		var inst Instance
+		select {
+		case inst = <-f.availableInstances:
+		default:
+			return
+		}
+
+		if ii, ok := inst.(*instance); ok && kept >= f.config.MinIdle && time.Since(ii.idleSince) > timeout {
+			ii.Close()
+			continue
+		}
+
+		kept++
+		if ii, ok := inst.(*instance); !ok || !f.pool(ii) {
+			inst.Close()
+		}
+	}
+}
+
+// prepare loads the function's module into the instance, initializing it,
+// and snapshots it when the function asks for it.
+func (i *instance) prepare() error {
+	moduleName, err := i.parent.moduleName()
+	if err != nil {
+		return fmt.Errorf("getting module name failed with: %w", err)
+	}
+
+	if _, _, err = i.function(moduleName, i.parent.config.Call); err != nil {
+		return fmt.Errorf("loading module `%s` failed with: %w", moduleName, err)
+	}
+
+	if i.parent.config.Snapshot && i.snapshot == nil {
+		return i.takeSnapshot()
+	}
+
+	return nil
+}
+
+// takeSnapshot copies the linear memory and the mutable globals of the
+// instance's modules.
+func (i *instance) takeSnapshot() error {
+	snapshot := make(map[string]moduleSnapshot)
+	for _, name := range i.runtime.Modules() {
+		mod, err := i.runtime.Module(name)
+		if err != nil {
+			return fmt.Errorf("snapshotting module `%s` failed with: %w", name, err)
+		}
+
+		var snap moduleSnapshot
+		if mem := mod.Memory(); mem != nil {
+			data, ok := mem.Read(0, mem.Size())
+			if !ok {
+				return fmt.Errorf("snapshotting module `%s` failed: memory out of range", name)
+			}
+			snap.memory = append([]byte(nil), data...)
+		}
+
+		for _, global := range mod.Globals() {
+			snap.globals = append(snap.globals, global.Get())
+		}
+
+		if snap.memory != nil || snap.globals != nil {
+			snapshot[name] = snap
+		}
+	}
+
+	i.snapshot = snapshot
+
+	return nil
+}
+
+// restoreSnapshot resets the linear memory and the mutable globals of the
+// instance's modules to their snapshot; memory grown since is zeroed, as wasm
+// memory cannot shrink.
+func (i *instance) restoreSnapshot() error {
+	for name, snap := range i.snapshot {
+		mod, err := i.runtime.Module(name)
+		if err != nil {
+			return fmt.Errorf("restoring module `%s` failed with: %w", name, err)
+		}
+
+		if snap.memory != nil {
+			if err = restoreMemory(mod.Memory(), snap.memory); err != nil {
+				return fmt.Errorf("restoring module `%s` failed: %w", name, err)
+			}
+		}
+
+		globals := mod.Globals()
+		if len(globals) != len(snap.globals) {
+			return fmt.Errorf("restoring module `%s` failed: %d globals, snapshot has %d", name, len(globals), len(snap.globals))
+		}
+		for j, global := range globals {
+			global.Set(snap.globals[j])
+		}
+	}
+
+	return nil
+}
+
+func restoreMemory(mem vm.Memory, data []byte) error {
+	if mem == nil || !mem.Write(0, data) {
+		return errors.New("memory out of range")
+	}
+
+	if size := mem.Size(); size > uint32(len(data)) {
+		grown, ok := mem.Read(uint32(len(data)), size-uint32(len(data)))
+		if !ok {
+			return errors.New("memory out of range")
+		}
+		clear(grown)
+	}
+
+	return nil
+}
@@ -0,0 +1,167 @@
+package runtime
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/taubyte/tau/core/vm"
+	structureSpec "github.com/taubyte/tau/pkg/specs/structure"
+	"gotest.tools/v3/assert"
+)
+
+// bufMemory is a linear memory backed by a byte slice that grow extends.
+type bufMemory struct {
+	vm.Memory
+	data []byte
+}
+
+func (m *bufMemory) Size() uint32 { return uint32(len(m.data)) }
+
+func (m *bufMemory) Read(offset, count uint32) ([]byte, bool) {
+	if uint64(offset)+uint64(count) > uint64(len(m.data)) {
+		return nil, false
+	}
+	return m.data[offset : offset+count], true
+}
+
+func (m *bufMemory) Write(offset uint32, v []byte) bool {
+	if uint64(offset)+uint64(len(v)) > uint64(len(m.data)) {
+		return false
+	}
+	copy(m.data[offset:], v)
+	return true
+}
+
+func (m *bufMemory) grow(n int) { m.data = append(m.data, bytes.Repeat([]byte{0xff}, n)...) }
+
+type fakeGlobal struct {
+	vm.MutableGlobal
+	v uint64
+}
+
+func (g *fakeGlobal) Get() uint64  { return g.v }
+func (g *fakeGlobal) Set(v uint64) { g.v = v }
+
+type bufModule struct {
+	vm.ModuleInstance
+	mem     *bufMemory
+	globals []vm.MutableGlobal
+}
+
+func (m bufModule) Memory() vm.Memory           { return m.mem }
+func (m bufModule) Globals() []vm.MutableGlobal { return m.globals }
+
+type bufRuntime struct {
+	fakeRuntime
+	mem     *bufMemory
+	globals []vm.MutableGlobal
+}
+
+func (r *bufRuntime) Module(name string) (vm.ModuleInstance, error) {
+	return bufModule{mem: r.mem, globals: r.globals}, nil
+}
+
+func TestInstanceSnapshot(t *testing.T) {
+	mem := &bufMemory{data: []byte("initialized")}
+	stackPointer := &fakeGlobal{v: 1024}
+	i := &instance{runtime: &bufRuntime{mem: mem, globals: []vm.MutableGlobal{stackPointer}}}
+
+	assert.NilError(t, i.takeSnapshot())
+
+	// a call scribbles over memory, grows it, and moves a global
+	copy(mem.data, "scribbled")
+	mem.grow(5)
+	stackPointer.Set(512)
+
+	assert.NilError(t, i.restoreSnapshot())
+	assert.DeepEqual(t, mem.data, append([]byte("initialized"), 0, 0, 0, 0, 0))
+	assert.Equal(t, stackPointer.Get(), uint64(1024))
+}
+
+func newPoolFunction(config *structureSpec.Function) *Function {
+	return &Function{
+		ctx:                context.Background(),
+		config:             config,
+		serviceable:        newMockServiceable(),
+		vmConfig:           &vm.Config{MemoryLimitPages: 4},
+		availableInstances: make(chan Instance, InstanceMaxRequests),
+		shutdown:           new(atomic.Bool),
+	}
+}
+
+func TestEvictIdle(t *testing.T) {
+	f := newPoolFunction(&structureSpec.Function{MinIdle: 1, IdleTimeout: uint64(time.Minute)})
+
+	var runtimes []*fakeRuntime
+	for _, idle := range []time.Duration{2 * time.Minute, 2 * time.Minute, time.Second} {
+		rt := &fakeRuntime{}
+		runtimes = append(runtimes, rt)
+		f.live.Add(1)
+		f.availableInstances <- &instance{runtime: rt, parent: f, idleSince: time.Now().Add(-idle)}
+	}
+
+	f.evictIdle()
+
+	// the first instance is kept as min-idle, the second evicted, the third not idle long enough
+	assert.Equal(t, len(f.availableInstances), 2)
+	assert.Equal(t, f.live.Load(), int64(2))
+	assert.Equal(t, runtimes[0].closed, 0)
+	assert.Equal(t, runtimes[1].closed, 1)
+	assert.Equal(t, runtimes[2].closed, 0)
+}
+
+func TestMaxConcurrency(t *testing.T) {
+	f := newPoolFunction(&structureSpec.Function{MaxConcurrency: 1})
+	f.live.Add(1)
+	_, err := f.newInstance()
+	assert.ErrorIs(t, err, errAtCapacity)
+	assert.Equal(t, f.live.Load(), int64(1))
+
+	// at capacity, a request waits for an instance to free instead of creating one
+	req := &instanceRequest{ctx: context.Background(), ch: make(chan Instance, 1)}
+	done := make(chan struct{})
+	go func() {
+		f.processRequest(req)
+		close(done)
+	}()
+
+	select {
+	case <-req.ch:
+		t.Fatal("request served past max concurrency")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	freed := &instance{runtime: &fakeRuntime{}, parent: f}
+	assert.Assert(t, f.pool(freed))
+	<-done
+
+	assert.Equal(t, <-req.ch, Instance(freed))
+	assert.Equal(t, f.WarmCalls(), uint64(1))
+	assert.Equal(t, f.ColdCalls(), uint64(0))
+}
+
+func TestReserve(t *testing.T) {
+	f := newPoolFunction(&structureSpec.Function{MaxConcurrency: 3})
+
+	var (
+		wg       sync.WaitGroup
+		reserved atomic.Int64
+	)
+	for range 100 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if f.reserve() {
+				reserved.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, reserved.Load(), int64(3))
+	assert.Equal(t, f.live.Load(), int64(3))
+}
@@ -0,0 +1,86 @@
+package runtime
+
+import (
+	"sync"
+
+	"github.com/taubyte/tau/core/vm"
+)
+
+// stats sums what the runtimes of a function measured of themselves, except
+// for PeakMemory, the peak of any one of them.
+type stats struct {
+	lock  sync.Mutex
+	stats vm.RuntimeStats
+}
+
+func (s *stats) add(delta vm.RuntimeStats) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	t := &s.stats
+	t.PeakMemory = max(t.PeakMemory, delta.PeakMemory)
+	t.CompileTime += delta.CompileTime
+	t.InstantiateTime += delta.InstantiateTime
+	t.Calls += delta.Calls
+	t.WallTime += delta.WallTime
+	t.Fuel += delta.Fuel
+
+	if len(delta.HostCalls) > 0 && t.HostCalls == nil {
+		t.HostCalls = make(map[string]vm.HostCallStats, len(delta.HostCalls))
+	}
+	for name, d := range delta.HostCalls {
+		c := t.HostCalls[name]
+		c.Calls += d.Calls
+		c.Time += d.Time
+		t.HostCalls[name] = c
+	}
+}
+
+func (s *stats) get() vm.RuntimeStats {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	stats := s.stats
+	stats.HostCalls = make(map[string]vm.HostCallStats, len(s.stats.HostCalls))
+	for name, c := range s.stats.HostCalls {
+		stats.HostCalls[name] = c
+	}
+
+	return stats
+}
+
+// since returns what was measured between last and now, two stats of the same
+// runtime; PeakMemory is kept as of now.
+func since(now, last vm.RuntimeStats) vm.RuntimeStats {
+	delta := vm.RuntimeStats{
+		PeakMemory:      now.PeakMemory,
+		CompileTime:     now.CompileTime - last.CompileTime,
+		InstantiateTime: now.InstantiateTime - last.InstantiateTime,
+		Calls:           now.Calls - last.Calls,
+		WallTime:        now.WallTime - last.WallTime,
+		Fuel:            now.Fuel - last.Fuel,
+		HostCalls:       make(map[string]vm.HostCallStats, len(now.HostCalls)),
+	}
+
+	for name, c := range now.HostCalls {
+		l := last.HostCalls[name]
+		if c.Calls > l.Calls {
+			delta.HostCalls[name] = vm.HostCallStats{
+				Calls: c.Calls - l.Calls,
+				Time:  c.Time - l.Time,
+			}
+		}
+	}
+
+	return delta
+}
+
+// collectStats accounts, in the stats of the function, for what the runtime
+// of the instance measured since it was last collected, and returns it.
+func (i *instance) collectStats() vm.RuntimeStats {
+	now := i.runtime.Stats()
+	delta := since(now, i.stats)
+	i.stats = now
+	i.parent.stats.add(delta)
+	return delta
+}
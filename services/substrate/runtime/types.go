@@ -5,6 +5,7 @@ import (
 	"io"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	commonIface "github.com/taubyte/tau/core/services/substrate/components"
 	"github.com/taubyte/tau/core/vm"
@@ -38,6 +39,16 @@ type Function struct {
 	totalCallTime *atomic.Int64
 	maxMemory     *atomic.Uint64
 
+	// stats sums the stats of the function's runtimes.
+	stats *stats
+
+	// calls served by a pooled instance, and by a new one.
+	warmCalls atomic.Uint64
+	coldCalls atomic.Uint64
+
+	// live counts the instances of the function, pooled or in use.
+	live atomic.Int64
+
 	// noPoolWarned gates the once-per-function warning emitted when instances
 	// retire without ever pooling (memory config leaves no headroom).
 	noPoolWarned atomic.Bool
@@ -50,6 +61,17 @@ type instance struct {
 	sdk         plugins.Instance
 	parent      *Function
 
+	// stats are the runtime stats as of their last collection.
+	stats vm.RuntimeStats
+
+	// snapshot is the state of the instance's modules after their
+	// initialization, for functions resetting instances between calls.
+	snapshot map[string]moduleSnapshot
+
+	// idleSince is when the instance was last pooled.
+	idleSince time.Time
+	closed    bool
+
 	// failed marks an instance whose call errored or timed out; its runtime
 	// is in an unknown (possibly closed) state, so Free retires it instead of
 	// repooling.
@@ -67,6 +89,12 @@ type instance struct {
 	fx           vm.FunctionInstance
 }
 
+// moduleSnapshot is the linear memory and mutable globals of a module.
+type moduleSnapshot struct {
+	memory  []byte
+	globals []uint64
+}
+
 type instanceRequest struct {
 	ctx context.Context
 	ch  chan Instance
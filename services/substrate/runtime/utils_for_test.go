@@ -95,6 +95,10 @@ func (*mockInstance) Runtime(...func(wazy.HostModuleBuilder)) (vm.Runtime, error
 }
 func (*mockInstance) Close() error { return nil }
 func (*mockRuntime) Close() error  { return nil }
+func (*mockRuntime) Fuel() uint64  { return 0 }
+func (*mockRuntime) Stats() vm.RuntimeStats {
+	return vm.RuntimeStats{}
+}
 func (*mockRuntime) Attach(plugin vm.Plugin) (vm.PluginInstance, vm.ModuleInstance, error) {
 	return nil, nil, nil
 }
@@ -15,4 +15,8 @@ var (
 
 	MaxGlobalInstances int64  = 128 * 1024
 	MemoryThreshold    uint64 = 80
+
+	// WarmInterval is how often functions keep their min-idle instances warm
+	// and evict idle ones.
+	WarmInterval time.Duration = 10 * time.Second
 )
@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strconv"
 	"time"
 
 	"github.com/taubyte/tau/clients/p2p/substrate"
+	"github.com/taubyte/tau/core/kvdb"
 	compIface "github.com/taubyte/tau/core/services/substrate/components"
 	con "github.com/taubyte/tau/p2p/streams"
 	"github.com/taubyte/tau/p2p/streams/command"
@@ -16,9 +18,12 @@ import (
 	functionSpec "github.com/taubyte/tau/pkg/specs/function"
 	websiteSpec "github.com/taubyte/tau/pkg/specs/website"
 	protocolCommon "github.com/taubyte/tau/services/common"
+	cron "github.com/taubyte/tau/services/substrate/components/cron/common"
 	http "github.com/taubyte/tau/services/substrate/components/http/common"
 	"github.com/taubyte/tau/services/substrate/components/http/function"
 	"github.com/taubyte/tau/services/substrate/components/http/website"
+	queue "github.com/taubyte/tau/services/substrate/components/queue/common"
+	watch "github.com/taubyte/tau/services/substrate/components/watch/common"
 	"github.com/taubyte/tau/utils/maps"
 )
 
@@ -35,6 +40,10 @@ func (s *Service) startStream() (err error) {
 		return fmt.Errorf("defining command `%s` failed with: %w", substrate.CommandHTTP, err)
 	}
 
+	s.stream.Define(substrate.CommandCron, s.runCron)
+	s.stream.Define(substrate.CommandQueue, s.runQueue)
+	s.stream.Define(substrate.CommandDatabase, s.runDatabase)
+
 	s.stream.Start()
 
 	return
@@ -111,5 +120,143 @@ func (s *Service) proxyHttp(ctx context.Context, con con.Connection, body comman
 		return nil, fmt.Errorf("getting serviceable metrics failed with: %w", err)
 	}
 
+	// lets the gateway tie what it caches to the project serving it
+	response[substrate.BodyProject] = pick.Project()
+
 	return response, nil
 }
+
+func (s *Service) runCron(ctx context.Context, con con.Connection, body command.Body) (response.Response, error) {
+	var (
+		matcher cron.MatchDefinition
+		err     error
+	)
+
+	// ticks are only dispatched by the cron cluster
+	if s.components.cron == nil || !s.components.cron.Member(con.RemotePeer()) {
+		return nil, fmt.Errorf("peer %s is not a member of the cron cluster", con.RemotePeer())
+	}
+
+	if matcher.Project, err = maps.String(body, substrate.BodyProject); err != nil {
+		return nil, err
+	}
+
+	// global functions have no application
+	matcher.Application, _ = maps.String(body, substrate.BodyApplication)
+
+	if matcher.Function, err = maps.String(body, substrate.BodyFunction); err != nil {
+		return nil, err
+	}
+
+	tick, err := maps.Int(body, substrate.BodyTick)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.components.cron.Run(&matcher, time.Unix(int64(tick), 0)); err != nil {
+		return nil, fmt.Errorf("running cron function failed with: %w", err)
+	}
+
+	return response.Response{}, nil
+}
+
+func (s *Service) runQueue(ctx context.Context, con con.Connection, body command.Body) (response.Response, error) {
+	var (
+		matcher  queue.MatchDefinition
+		delivery queue.Delivery
+		err      error
+	)
+
+	// messages are only delivered by the queue cluster
+	if s.components.queue == nil || !s.components.queue.Member(con.RemotePeer()) {
+		return nil, fmt.Errorf("peer %s is not a member of the queue cluster", con.RemotePeer())
+	}
+
+	if matcher.Project, err = maps.String(body, substrate.BodyProject); err != nil {
+		return nil, err
+	}
+	delivery.Project = matcher.Project
+
+	// global functions have no application
+	matcher.Application, _ = maps.String(body, substrate.BodyApplication)
+
+	if matcher.Function, err = maps.String(body, substrate.BodyFunction); err != nil {
+		return nil, err
+	}
+
+	if delivery.QueueId, err = maps.String(body, substrate.BodyQueue); err != nil {
+		return nil, err
+	}
+
+	if delivery.Queue, err = maps.String(body, substrate.BodyQueueName); err != nil {
+		return nil, err
+	}
+
+	message, err := maps.String(body, substrate.BodyMessage)
+	if err != nil {
+		return nil, err
+	}
+
+	if delivery.Message, err = strconv.ParseUint(message, 16, 64); err != nil {
+		return nil, fmt.Errorf("parsing message id failed with: %w", err)
+	}
+
+	if delivery.Data, err = maps.ByteArray(body, substrate.BodyData); err != nil {
+		return nil, err
+	}
+
+	attempt, err := maps.Int(body, substrate.BodyAttempt)
+	if err != nil {
+		return nil, err
+	}
+	delivery.Attempt = uint32(attempt)
+
+	if err = s.components.queue.Run(&matcher, &delivery); err != nil {
+		return nil, fmt.Errorf("running queue function failed with: %w", err)
+	}
+
+	return response.Response{}, nil
+}
+
+func (s *Service) runDatabase(ctx context.Context, con con.Connection, body command.Body) (response.Response, error) {
+	var (
+		matcher watch.MatchDefinition
+		change  kvdb.Event
+		err     error
+	)
+
+	// changes are only dispatched by the watch cluster
+	if s.components.watch == nil || !s.components.watch.Member(con.RemotePeer()) {
+		return nil, fmt.Errorf("peer %s is not a member of the watch cluster", con.RemotePeer())
+	}
+
+	if matcher.Project, err = maps.String(body, substrate.BodyProject); err != nil {
+		return nil, err
+	}
+
+	// global functions have no application
+	matcher.Application, _ = maps.String(body, substrate.BodyApplication)
+
+	if matcher.Function, err = maps.String(body, substrate.BodyFunction); err != nil {
+		return nil, err
+	}
+
+	if change.Key, err = maps.String(body, substrate.BodyKey); err != nil {
+		return nil, err
+	}
+
+	op, err := maps.Int(body, substrate.BodyOp)
+	if err != nil {
+		return nil, err
+	}
+	change.Type = kvdb.EventType(op)
+
+	// deletes carry no value
+	change.Value, _ = maps.ByteArray(body, substrate.BodyData)
+
+	if err = s.components.watch.Run(&matcher, change); err != nil {
+		return nil, fmt.Errorf("running database function failed with: %w", err)
+	}
+
+	return response.Response{}, nil
+}
@@ -18,6 +18,10 @@ const (
 	FunctionTypeHttps          = "https"
 	FunctionTypeP2P            = "p2p"
 	FunctionTypePubSub         = "pubsub"
+	FunctionTypeWebSocket      = "websocket"
+	FunctionTypeCron           = "cron"
+	FunctionTypeQueue          = "queue"
+	FunctionTypeDatabase       = "database"
 	DefaultGeneratedDomainName = "generated"
 	DefaultNewProjectBranch    = "main"
 
@@ -38,6 +42,6 @@ const (
 )
 
 var (
-	FunctionTypes = []string{FunctionTypeHttp, FunctionTypeHttps, FunctionTypeP2P, FunctionTypePubSub}
+	FunctionTypes = []string{FunctionTypeHttp, FunctionTypeHttps, FunctionTypeP2P, FunctionTypePubSub, FunctionTypeWebSocket, FunctionTypeCron, FunctionTypeQueue, FunctionTypeDatabase}
 	BucketTypes   = []string{"Object", "Streaming"}
 )
@@ -18,7 +18,7 @@ func (pi *minimalPluginInstance) LoadFactory(factory vm.Factory, hm vm.HostModul
 	if !ok {
 		return fmt.Errorf("factory %q (%T) does not provide host functions", factory.Name(), factory)
 	}
-	provider.RegisterHostFunctions(hm.Builder())
+	provider.RegisterHostFunctions(hm.Group(factory.Name()))
 	return nil
 }
 
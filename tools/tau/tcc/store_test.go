@@ -81,7 +81,7 @@ func TestStoreValidateAndComplete(t *testing.T) {
 	// completion: enum members, and a reference field lists in-scope resources
 	got := st.Complete("functions", res, []string{"trigger", "type"})
 	sort.Strings(got)
-	assert.DeepEqual(t, got, []string{"http", "https", "p2p", "pubsub"})
+	assert.DeepEqual(t, got, []string{"cron", "database", "http", "https", "p2p", "pubsub", "queue", "websocket"})
 
 	domains := st.Complete("functions", res, []string{"trigger", "domains"})
 	assert.Assert(t, contains(domains, "test_domain1"))
@@ -34,6 +34,26 @@ var patrickTree = &tctree{
 			},
 			handler: getJobs,
 		},
+		{
+			validator: stringValidator("dead"),
+			ret: []goPrompt.Suggest{
+				{
+					Text:        "dead",
+					Description: "show dead-lettered jobs",
+				},
+			},
+			handler: listDeadLetters,
+		},
+		{
+			validator: stringValidator("requeue"),
+			ret: []goPrompt.Suggest{
+				{
+					Text:        "requeue",
+					Description: "requeue given or all dead-lettered jobs",
+				},
+			},
+			handler: requeueJobs,
+		},
 		{
 			validator: stringValidator("status"),
 			ret: []goPrompt.Suggest{
@@ -68,6 +88,32 @@ func listJobs(p Prompt, args []string) error {
 	return nil
 }
 
+func listDeadLetters(p Prompt, args []string) error {
+	ids, err := p.PatrickClient().DeadLetters()
+	if err != nil {
+		return fmt.Errorf("failed listing dead letters with error: %w", err)
+	}
+
+	if len(ids) == 0 {
+		fmt.Println("No jobs are dead-lettered")
+		return nil
+	}
+	list.CreateTableIds(ids, "Job Id's")
+
+	return nil
+}
+
+func requeueJobs(p Prompt, args []string) error {
+	ids, err := p.PatrickClient().Requeue(args[1:]...)
+	if err != nil {
+		return fmt.Errorf("failed requeueing jobs with error: %w", err)
+	}
+
+	list.CreateTableIds(ids, "Requeued Job Id's")
+
+	return nil
+}
+
 func getJobs(p Prompt, args []string) error {
 	t := table.NewWriter()
 	t.SetStyle(table.StyleLight)
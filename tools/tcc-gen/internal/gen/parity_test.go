@@ -17,6 +17,7 @@ var specTypes = map[string]reflect.Type{
 	"Domain":      reflect.TypeFor[structureSpec.Domain](),
 	"Library":     reflect.TypeFor[structureSpec.Library](),
 	"Messaging":   reflect.TypeFor[structureSpec.Messaging](),
+	"Queue":       reflect.TypeFor[structureSpec.Queue](),
 	"Service":     reflect.TypeFor[structureSpec.Service](),
 	"SmartOp":     reflect.TypeFor[structureSpec.SmartOp](),
 	"Storage":     reflect.TypeFor[structureSpec.Storage](),
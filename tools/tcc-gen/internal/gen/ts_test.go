@@ -62,25 +62,25 @@ func TestGenerateTSAccessors(t *testing.T) {
 	ts := string(out)
 
 	for _, want := range []string{
-		`export type FunctionType = "http" | "https" | "pubsub" | "p2p";`,
+		`export type FunctionType = "http" | "https" | "pubsub" | "p2p" | "websocket" | "cron" | "queue" | "database";`,
 		`function(name: string, app?: string): FunctionConfig {`,                          // Session factory (app-scoped)
 		`super(s, app ? ["applications", app, "functions", name] : ["functions", name]);`, // resource address
 		`functionNames(app?: string): Promise<string[]> {`,                                // list
-		`delete(): Promise<void> {`,                                                       // delete
-		`applications(): Promise<string[]> {`,                                             // app list
-		"async type(): Promise<FunctionType | undefined> {",                               // async + union
-		`this.s.binding.get(this.s.handle, this.res, ["trigger", "type"])`,                // field path
-		`this.s.binding.set(this.s.handle, this.res, ["execution", "memory"]`,             // setter path
-		"async memory(): Promise<string | undefined> {",                                   // Bytes -> string (source form)
-		`(await this.s.binding.get(this.s.handle, this.res, ["trigger", "service"]))`,     // compat fallback
-		`application(name: string): ApplicationConfig {`,                                  // the container is addressed like any resource
-		`super(s, ["config"]);`,                                                           // the project root's own document
-		`resourceAt(path: string): Promise<string[] | null> {`,                            // path -> address
-		`serialize(): Promise<SerializedResource> {`,                                      // one resource's file + YAML
-		`setDoc(doc: Record<string, unknown>): Promise<void> {`,                           // whole-document diff
-		`generate(field: string[]): Promise<string> {`,                                    // DSL-minted values
-		`address(kind: string, name: string, app?: string): Promise<string[]> {`,          // kind -> address
-		`names(kind: string, app?: string): Promise<string[]> {`,                          // kind -> instances
+		`delete(): Promise<void> {`,                                                   // delete
+		`applications(): Promise<string[]> {`,                                         // app list
+		"async type(): Promise<FunctionType | undefined> {",                           // async + union
+		`this.s.binding.get(this.s.handle, this.res, ["trigger", "type"])`,            // field path
+		`this.s.binding.set(this.s.handle, this.res, ["execution", "memory"]`,         // setter path
+		"async memory(): Promise<string | undefined> {",                               // Bytes -> string (source form)
+		`(await this.s.binding.get(this.s.handle, this.res, ["trigger", "service"]))`, // compat fallback
+		`application(name: string): ApplicationConfig {`,                              // the container is addressed like any resource
+		`super(s, ["config"]);`,                                                       // the project root's own document
+		`resourceAt(path: string): Promise<string[] | null> {`,                        // path -> address
+		`serialize(): Promise<SerializedResource> {`,                                  // one resource's file + YAML
+		`setDoc(doc: Record<string, unknown>): Promise<void> {`,                       // whole-document diff
+		`generate(field: string[]): Promise<string> {`,                                // DSL-minted values
+		`address(kind: string, name: string, app?: string): Promise<string[]> {`,      // kind -> address
+		`names(kind: string, app?: string): Promise<string[]> {`,                      // kind -> instances
 		`async resource(kind: string, name: string, app?: string): Promise<ResourceConfig> {`,
 	} {
 		if !strings.Contains(ts, want) {
@@ -0,0 +1,100 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func mustTime(t *testing.T, s string) time.Time {
+	t.Helper()
+	v, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestNext(t *testing.T) {
+	for _, tc := range []struct {
+		spec, from, next string
+	}{
+		{"* * * * *", "2026-01-01T10:00:30Z", "2026-01-01T10:01:00Z"},
+		{"*/15 * * * *", "2026-01-01T10:01:00Z", "2026-01-01T10:15:00Z"},
+		{"*/15 * * * *", "2026-01-01T10:45:00Z", "2026-01-01T11:00:00Z"},
+		{"5/20 * * * *", "2026-01-01T10:30:00Z", "2026-01-01T10:45:00Z"},
+		{"0 9-17/4 * * *", "2026-01-01T10:00:00Z", "2026-01-01T13:00:00Z"},
+		{"30 2 * * mon-fri", "2026-01-02T03:00:00Z", "2026-01-05T02:30:00Z"},
+		{"0 0 1,15 * *", "2026-01-02T00:00:00Z", "2026-01-15T00:00:00Z"},
+		{"0 0 * feb *", "2026-03-01T00:00:00Z", "2027-02-01T00:00:00Z"},
+		{"0 0 29 2 *", "2026-01-01T00:00:00Z", "2028-02-29T00:00:00Z"},
+		{"0 0 * * 7", "2026-01-01T00:00:00Z", "2026-01-04T00:00:00Z"},
+		// day of month or day of week
+		{"0 0 13 * fri", "2026-01-01T00:00:00Z", "2026-01-02T00:00:00Z"},
+		{"0 0 13 * fri", "2026-01-09T00:00:00Z", "2026-01-13T00:00:00Z"},
+		{"@hourly", "2026-01-01T10:00:00Z", "2026-01-01T11:00:00Z"},
+		{"@daily", "2026-01-01T10:00:00Z", "2026-01-02T00:00:00Z"},
+		{"@weekly", "2026-01-01T10:00:00Z", "2026-01-04T00:00:00Z"},
+		{"@monthly", "2026-01-01T10:00:00Z", "2026-02-01T00:00:00Z"},
+		{"@yearly", "2026-01-01T10:00:00Z", "2027-01-01T00:00:00Z"},
+		{"@every 90m", "2026-01-01T10:00:00Z", "2026-01-01T10:30:00Z"},
+		{"@every 1h", "2026-01-01T10:00:00Z", "2026-01-01T11:00:00Z"},
+	} {
+		s, err := Parse(tc.spec)
+		if err != nil {
+			t.Errorf("parsing `%s` failed with: %s", tc.spec, err)
+			continue
+		}
+
+		if next := s.Next(mustTime(t, tc.from)); !next.Equal(mustTime(t, tc.next)) {
+			t.Errorf("`%s` after %s: got %s, expected %s", tc.spec, tc.from, next.Format(time.RFC3339), tc.next)
+		}
+	}
+}
+
+func TestNextNever(t *testing.T) {
+	s, err := Parse("0 0 30 2 *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if next := s.Next(mustTime(t, "2026-01-01T00:00:00Z")); !next.IsZero() {
+		t.Errorf("expected no tick, got %s", next)
+	}
+}
+
+func TestNextIsUTC(t *testing.T) {
+	s, err := Parse("0 12 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	next := s.Next(time.Date(2026, 1, 1, 15, 0, 0, 0, loc))
+	if !next.Equal(mustTime(t, "2026-01-02T12:00:00Z")) {
+		t.Errorf("got %s", next)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"10-5 * * * *",
+		"a * * * *",
+		"* * * jan-foo *",
+		"@often",
+		"@every 10s",
+		"@every soon",
+	} {
+		if _, err := Parse(spec); err == nil {
+			t.Errorf("expected `%s` to fail", spec)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package records
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+// DefaultTTL is the ttl, in seconds, of a record that does not give one.
+const DefaultTTL = 300
+
+// Types are the record types a domain may publish. Address records are left
+// out, as they are answered with the nodes serving the domain.
+var Types = map[uint16]bool{
+	dns.TypeMX:    true,
+	dns.TypeTXT:   true,
+	dns.TypeCNAME: true,
+	dns.TypeCAA:   true,
+}
+
+// Parse parses a record of the domain fqdn, written as a line of a zone file
+// whose origin is fqdn: `<name> [ttl] <type> <data>`, like
+// `@ MX 10 mail.example.com.`, `_dmarc TXT "v=DMARC1; p=none"` or
+// `www 60 CNAME example.net.`.
+//
+// The name is relative to fqdn, `@` being fqdn itself, unless it ends with a
+// dot; either way, it has to be fqdn or one of its subdomains.
+func Parse(fqdn, record string) (dns.RR, error) {
+	origin := dns.Fqdn(strings.ToLower(fqdn))
+	if _, ok := dns.IsDomainName(origin); !ok || strings.ContainsAny(origin, " \t") {
+		return nil, fmt.Errorf("invalid domain `%s`", fqdn)
+	}
+
+	rr, err := parse(origin, record)
+	if err != nil {
+		return nil, err
+	}
+
+	hdr := rr.Header()
+	if !dns.IsSubDomain(origin, hdr.Name) {
+		return nil, fmt.Errorf("record name `%s` is outside of `%s`", hdr.Name, origin)
+	}
+
+	// the domain itself answers with the nodes serving it
+	if hdr.Rrtype == dns.TypeCNAME && hdr.Name == origin {
+		return nil, errors.New("a CNAME record can't be set on the domain itself")
+	}
+
+	return rr, nil
+}
+
+// Validate checks the syntax and type of a record, whatever domain it is for.
+func Validate(record string) error {
+	_, err := parse(".", record)
+	return err
+}
+
+func parse(origin, record string) (dns.RR, error) {
+	record = strings.TrimSpace(record)
+	if record == "" || strings.ContainsAny(record, "\n\r") || strings.HasPrefix(record, "$") {
+		return nil, errors.New("a record is a single line of a zone file")
+	}
+
+	zp := dns.NewZoneParser(strings.NewReader(record), origin, "")
+	zp.SetDefaultTTL(DefaultTTL)
+
+	rr, ok := zp.Next()
+	if err := zp.Err(); err != nil {
+		return nil, fmt.Errorf("parsing record failed with: %w", err)
+	}
+	if !ok {
+		return nil, errors.New("empty record")
+	}
+
+	hdr := rr.Header()
+	if !Types[hdr.Rrtype] {
+		return nil, fmt.Errorf("record type %s is not supported", dns.TypeToString[hdr.Rrtype])
+	}
+
+	if hdr.Class != dns.ClassINET {
+		return nil, fmt.Errorf("record class %s is not supported", dns.ClassToString[hdr.Class])
+	}
+
+	hdr.Name = strings.ToLower(hdr.Name)
+
+	return rr, nil
+}
@@ -0,0 +1,74 @@
+package records
+
+import (
+	"testing"
+
+	"github.com/miekg/dns"
+)
+
+func TestParse(t *testing.T) {
+	for _, tc := range []struct {
+		record string
+		name   string
+		rrtype uint16
+		ttl    uint32
+	}{
+		{"@ MX 10 mail.example.com.", "example.com.", dns.TypeMX, DefaultTTL},
+		{"@ 60 TXT \"v=spf1 include:_spf.example.net ~all\"", "example.com.", dns.TypeTXT, 60},
+		{"_dmarc TXT \"v=DMARC1; p=none\"", "_dmarc.example.com.", dns.TypeTXT, DefaultTTL},
+		{"www CNAME example.net.", "www.example.com.", dns.TypeCNAME, DefaultTTL},
+		{"Mail.Example.Com. 3600 IN MX 5 mx.example.net.", "mail.example.com.", dns.TypeMX, 3600},
+		{"@ CAA 0 issue \"letsencrypt.org\"", "example.com.", dns.TypeCAA, DefaultTTL},
+	} {
+		rr, err := Parse("Example.com", tc.record)
+		if err != nil {
+			t.Fatalf("parsing `%s` failed with: %s", tc.record, err)
+		}
+
+		hdr := rr.Header()
+		if hdr.Name != tc.name || hdr.Rrtype != tc.rrtype || hdr.Ttl != tc.ttl {
+			t.Errorf("parsing `%s` got %s", tc.record, rr)
+		}
+	}
+
+	mx, err := Parse("example.com", "@ MX 10 mail")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if target := mx.(*dns.MX).Mx; target != "mail.example.com." {
+		t.Errorf("relative target got `%s`", target)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, record := range []string{
+		"",
+		"@ A 1.2.3.4",
+		"@ AAAA ::1",
+		"@ NS ns.example.net.",
+		"@ CNAME example.net.",
+		"other.net. TXT \"hello\"",
+		"@ MX mail.example.com.",
+		"@ CH TXT \"hello\"",
+		"$TTL 60",
+		"@ TXT \"a\"\nwww CNAME example.net.",
+	} {
+		if _, err := Parse("example.com", record); err == nil {
+			t.Errorf("parsing `%s` should fail", record)
+		}
+	}
+
+	if _, err := Parse("not a domain", "@ TXT \"hello\""); err == nil {
+		t.Error("parsing for an invalid domain should fail")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := Validate("mail.example.net. MX 10 mx.example.net."); err != nil {
+		t.Errorf("validating failed with: %s", err)
+	}
+
+	if err := Validate("@ A 1.2.3.4"); err == nil {
+		t.Error("validating an A record should fail")
+	}
+}